@@ -456,6 +456,23 @@ func (err ErrMergeConflicts) Error() string {
 	return fmt.Sprintf("Merge Conflict Error: %v: %s\n%s", err.Err, err.StdErr, err.StdOut)
 }
 
+// ErrCherryPickConflict represents an error if a commit cannot be cherry-picked onto a branch because of conflicts
+type ErrCherryPickConflict struct {
+	CommitID string
+	Branch   string
+	Files    []string
+}
+
+// IsErrCherryPickConflict checks if an error is a ErrCherryPickConflict.
+func IsErrCherryPickConflict(err error) bool {
+	_, ok := err.(ErrCherryPickConflict)
+	return ok
+}
+
+func (err ErrCherryPickConflict) Error() string {
+	return fmt.Sprintf("failed to merge due to conflicts [commit_id: %s, branch: %s, files: %v]", err.CommitID, err.Branch, err.Files)
+}
+
 // ErrMergeUnrelatedHistories represents an error if merging fails due to unrelated histories
 type ErrMergeUnrelatedHistories struct {
 	Style  repo_model.MergeStyle
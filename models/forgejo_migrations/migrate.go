@@ -94,6 +94,42 @@ var migrations = []*Migration{
 	NewMigration("Add `created_unix` column to `user_redirect` table", AddCreatedUnixToRedirect),
 	// v27 -> v28
 	NewMigration("Add pronoun privacy settings to user", AddHidePronounsOptionToUser),
+	// v28 -> v29
+	NewMigration("Create the `org_ruleset` and `org_ruleset_violation` tables", CreateOrgRulesetTables),
+	// v29 -> v30
+	NewMigration("Add `max_pushed_blob_size` column to `repository` table", AddMaxPushedBlobSizeToRepository),
+	// v30 -> v31
+	NewMigration("Add `object_pool_id` column to `repository` table", AddObjectPoolIDToRepository),
+	// v31 -> v32
+	NewMigration("Create the `repo_maintenance` table", CreateRepoMaintenanceTable),
+	// v32 -> v33
+	NewMigration("Create the `replica_repository` table", CreateReplicaRepositoryTable),
+	// v33 -> v34
+	NewMigration("Create the `project_field` and `project_field_value` tables", CreateProjectFieldTables),
+	// v34 -> v35
+	NewMigration("Create the `sub_issue` table", CreateSubIssueTable),
+	// v35 -> v36
+	NewMigration("Create the `abuse_report` table and hide moderated issues and comments", CreateAbuseReportTable),
+	// v36 -> v37
+	NewMigration("Create the `saved_search`, `saved_search_subscription` and `saved_search_match` tables", CreateSavedSearchTables),
+	// v37 -> v38
+	NewMigration("Create the `triage_rule` and `triage_log` tables", CreateTriageRuleTables),
+	// v38 -> v39
+	NewMigration("Create the `recurring_issue` and `recurring_issue_run` tables", CreateRecurringIssueTables),
+	// v39 -> v40
+	NewMigration("Add `require_code_owner_approval` to the `protected_branch` table", AddRequireCodeOwnerApprovalToProtectedBranch),
+	// v40 -> v41
+	NewMigration("Create the `applied_suggestion` table", CreateAppliedSuggestionTable),
+	// v41 -> v42
+	NewMigration("Add `start_line` to the `comment` table", AddStartLineToComment),
+	// v42 -> v43
+	NewMigration("Add the review assignment columns to the `team` table and create the `team_review_manager` table", AddReviewAssignmentToTeam),
+	// v43 -> v44
+	NewMigration("Create the `pull_backport` table", CreatePullBackportTable),
+	// v44 -> v45
+	NewMigration("Add the sources of the status checks to `commit_status` and `protected_branch`", AddStatusCheckSources),
+	// v45 -> v46
+	NewMigration("Add `checklist` to `review` and `require_resolved_conversations` to `protected_branch`", AddReviewChecklistAndResolvedConversations),
 }
 
 // GetCurrentDBVersion returns the current Forgejo database version.
@@ -0,0 +1,54 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateOrgRulesetTables(x *xorm.Engine) error {
+	type OrgRuleset struct {
+		ID          int64  `xorm:"pk autoincr"`
+		OrgID       int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Name        string `xorm:"UNIQUE(s) NOT NULL"`
+		Target      int    `xorm:"NOT NULL DEFAULT 0"`
+		Enforcement int    `xorm:"NOT NULL DEFAULT 0"`
+
+		RepoNamePatterns string   `xorm:"TEXT"`
+		RepoTopics       []string `xorm:"JSON TEXT"`
+		RefPatterns      string   `xorm:"TEXT"`
+
+		CanPush                bool     `xorm:"NOT NULL DEFAULT false"`
+		EnableWhitelist        bool     `xorm:"NOT NULL DEFAULT false"`
+		WhitelistUserIDs       []int64  `xorm:"JSON TEXT"`
+		WhitelistTeamIDs       []int64  `xorm:"JSON TEXT"`
+		WhitelistDeployKeys    bool     `xorm:"NOT NULL DEFAULT false"`
+		EnableMergeWhitelist   bool     `xorm:"NOT NULL DEFAULT false"`
+		MergeWhitelistUserIDs  []int64  `xorm:"JSON TEXT"`
+		MergeWhitelistTeamIDs  []int64  `xorm:"JSON TEXT"`
+		EnableStatusCheck      bool     `xorm:"NOT NULL DEFAULT false"`
+		StatusCheckContexts    []string `xorm:"JSON TEXT"`
+		RequiredApprovals      int64    `xorm:"NOT NULL DEFAULT 0"`
+		BlockOnRejectedReviews bool     `xorm:"NOT NULL DEFAULT false"`
+		RequireSignedCommits   bool     `xorm:"NOT NULL DEFAULT false"`
+		ApplyToAdmins          bool     `xorm:"NOT NULL DEFAULT false"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type OrgRulesetViolation struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RulesetID   int64  `xorm:"INDEX NOT NULL"`
+		RepoID      int64  `xorm:"INDEX NOT NULL"`
+		RefName     string `xorm:"TEXT"`
+		DoerID      int64
+		Reason      string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+
+	return x.Sync(new(OrgRuleset), new(OrgRulesetViolation))
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddMaxPushedBlobSizeToRepository(x *xorm.Engine) error {
+	type Repository struct {
+		ID                int64 `xorm:"pk autoincr"`
+		MaxPushedBlobSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(&Repository{})
+}
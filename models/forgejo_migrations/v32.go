@@ -0,0 +1,24 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateRepoMaintenanceTable(x *xorm.Engine) error {
+	type RepoMaintenance struct {
+		ID             int64              `xorm:"pk autoincr"`
+		RepoID         int64              `xorm:"UNIQUE NOT NULL"`
+		PushesSinceRun int64              `xorm:"NOT NULL DEFAULT 0"`
+		LastRunUnix    timeutil.TimeStamp `xorm:"INDEX"`
+		LastDuration   int64              `xorm:"NOT NULL DEFAULT 0"`
+		LastTasks      string
+		LastError      string `xorm:"TEXT"`
+	}
+
+	return x.Sync(new(RepoMaintenance))
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateReplicaRepositoryTable(x *xorm.Engine) error {
+	type ReplicaRepository struct {
+		ID            int64              `xorm:"pk autoincr"`
+		RepoID        int64              `xorm:"UNIQUE NOT NULL"`
+		PrimaryRepoID int64              `xorm:"UNIQUE NOT NULL"`
+		LastEventUnix timeutil.TimeStamp `xorm:"INDEX"`
+		LastSyncUnix  timeutil.TimeStamp
+	}
+
+	return x.Sync(new(ReplicaRepository))
+}
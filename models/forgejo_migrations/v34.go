@@ -0,0 +1,45 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateProjectFieldTables(x *xorm.Engine) error {
+	type ProjectFieldOption struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+
+	type ProjectFieldIteration struct {
+		Title     string `json:"title"`
+		StartDate string `json:"start_date"`
+		Duration  int    `json:"duration"`
+	}
+
+	type ProjectField struct {
+		ID         int64                    `xorm:"pk autoincr"`
+		OwnerID    int64                    `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ProjectID  int64                    `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name       string                   `xorm:"NOT NULL"`
+		Type       uint8                    `xorm:"NOT NULL"`
+		Options    []*ProjectFieldOption    `xorm:"JSON TEXT"`
+		Iterations []*ProjectFieldIteration `xorm:"JSON TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ProjectFieldValue struct {
+		ID      int64  `xorm:"pk autoincr"`
+		FieldID int64  `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Value   string `xorm:"TEXT"`
+	}
+
+	return x.Sync(new(ProjectField), new(ProjectFieldValue))
+}
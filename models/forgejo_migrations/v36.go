@@ -0,0 +1,37 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateAbuseReportTable(x *xorm.Engine) error {
+	type AbuseReport struct {
+		ID               int64              `xorm:"pk autoincr"`
+		Status           int                `xorm:"INDEX NOT NULL DEFAULT 1"`
+		ReporterID       int64              `xorm:"INDEX NOT NULL"`
+		ContentType      int                `xorm:"INDEX(s) NOT NULL"`
+		ContentID        int64              `xorm:"INDEX(s) NOT NULL"`
+		ContentReference string             `xorm:"VARCHAR(255)"`
+		ContentLink      string             `xorm:"TEXT"`
+		Category         int                `xorm:"NOT NULL"`
+		Remarks          string             `xorm:"TEXT"`
+		ResolverID       int64              `xorm:"NOT NULL DEFAULT 0"`
+		Action           int                `xorm:"NOT NULL DEFAULT 0"`
+		ActionReason     string             `xorm:"TEXT"`
+		CreatedUnix      timeutil.TimeStamp `xorm:"INDEX created"`
+		ResolvedUnix     timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+	type Issue struct {
+		IsHidden bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	type Comment struct {
+		IsHidden bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync(new(AbuseReport), new(Issue), new(Comment))
+}
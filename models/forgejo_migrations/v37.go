@@ -0,0 +1,39 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateSavedSearchTables(x *xorm.Engine) error {
+	type SavedSearch struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Name        string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		Query       string             `xorm:"TEXT"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type SavedSearchSubscription struct {
+		ID              int64              `xorm:"pk autoincr"`
+		SearchID        int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID          int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		OnDashboard     bool               `xorm:"NOT NULL DEFAULT false"`
+		Notify          bool               `xorm:"NOT NULL DEFAULT false"`
+		LastCheckedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type SavedSearchMatch struct {
+		ID             int64 `xorm:"pk autoincr"`
+		SubscriptionID int64 `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID        int64 `xorm:"UNIQUE(s) NOT NULL"`
+	}
+
+	return x.Sync(new(SavedSearch), new(SavedSearchSubscription), new(SavedSearchMatch))
+}
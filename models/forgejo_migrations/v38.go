@@ -0,0 +1,42 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateTriageRuleTables(x *xorm.Engine) error {
+	type TriageRule struct {
+		ID           int64              `xorm:"pk autoincr"`
+		OwnerID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name         string             `xorm:"VARCHAR(255) NOT NULL"`
+		Event        int                `xorm:"NOT NULL"`
+		StaleDays    int                `xorm:"NOT NULL DEFAULT 0"`
+		Conditions   string             `xorm:"TEXT"`
+		Actions      string             `xorm:"TEXT"`
+		IsActive     bool               `xorm:"NOT NULL DEFAULT true"`
+		DryRun       bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatorID    int64              `xorm:"NOT NULL"`
+		NextAssignee int                `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type TriageLog struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RuleID      int64              `xorm:"INDEX NOT NULL"`
+		IssueID     int64              `xorm:"INDEX NOT NULL"`
+		Event       int                `xorm:"NOT NULL"`
+		DryRun      bool               `xorm:"NOT NULL DEFAULT false"`
+		Actions     string             `xorm:"TEXT"`
+		Error       string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+
+	return x.Sync(new(TriageRule), new(TriageLog))
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateRecurringIssueTables(x *xorm.Engine) error {
+	type RecurringIssue struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		Title       string             `xorm:"VARCHAR(255) NOT NULL"`
+		Content     string             `xorm:"LONGTEXT"`
+		Labels      []string           `xorm:"JSON TEXT"`
+		Assignees   []string           `xorm:"JSON TEXT"`
+		Schedule    string             `xorm:"VARCHAR(255) NOT NULL"`
+		SkipIfOpen  bool               `xorm:"NOT NULL DEFAULT false"`
+		IsActive    bool               `xorm:"NOT NULL DEFAULT true"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		LastIssueID int64              `xorm:"NOT NULL DEFAULT 0"`
+		LastRunUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		NextRunUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type RecurringIssueRun struct {
+		ID               int64              `xorm:"pk autoincr"`
+		RecurringIssueID int64              `xorm:"INDEX NOT NULL"`
+		IssueID          int64              `xorm:"NOT NULL DEFAULT 0"`
+		Skipped          bool               `xorm:"NOT NULL DEFAULT false"`
+		Error            string             `xorm:"TEXT"`
+		CreatedUnix      timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+
+	return x.Sync(new(RecurringIssue), new(RecurringIssueRun))
+}
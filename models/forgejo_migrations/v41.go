@@ -0,0 +1,24 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateAppliedSuggestionTable(x *xorm.Engine) error {
+	type AppliedSuggestion struct {
+		ID          int64              `xorm:"pk autoincr"`
+		IssueID     int64              `xorm:"INDEX NOT NULL"`
+		CommentID   int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Index       int                `xorm:"UNIQUE(s) NOT NULL"`
+		DoerID      int64              `xorm:"NOT NULL"`
+		CommitSHA   string             `xorm:"VARCHAR(64) NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(AppliedSuggestion))
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddStartLineToComment(x *xorm.Engine) error {
+	type Comment struct {
+		StartLine int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(Comment))
+}
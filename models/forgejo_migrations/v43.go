@@ -0,0 +1,28 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddReviewAssignmentToTeam(x *xorm.Engine) error {
+	type Team struct {
+		ReviewAssignment                string  `xorm:"NOT NULL DEFAULT ''"`
+		ReviewAssignmentCount           int     `xorm:"NOT NULL DEFAULT 1"`
+		ReviewAssignmentMaxPending      int     `xorm:"NOT NULL DEFAULT 0"`
+		ReviewAssignmentExcludedUserIDs []int64 `xorm:"JSON TEXT"`
+		ReviewAssignmentLastUserID      int64   `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	if err := x.Sync(new(Team)); err != nil {
+		return err
+	}
+
+	type TeamReviewManager struct {
+		ID        int64 `xorm:"pk autoincr"`
+		TeamID    int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		UserID    int64 `xorm:"UNIQUE(s) NOT NULL"`
+		ManagerID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	}
+	return x.Sync(new(TeamReviewManager))
+}
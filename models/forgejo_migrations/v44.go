@@ -0,0 +1,26 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePullBackportTable(x *xorm.Engine) error {
+	type PullBackport struct {
+		ID             int64              `xorm:"pk autoincr"`
+		PullID         int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		TargetBranch   string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		DoerID         int64              `xorm:"NOT NULL"`
+		Status         string             `xorm:"VARCHAR(20) NOT NULL DEFAULT 'pending'"`
+		BackportPullID int64              `xorm:"NOT NULL DEFAULT 0"`
+		Error          string             `xorm:"TEXT"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PullBackport))
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddStatusCheckSources(x *xorm.Engine) error {
+	type CommitStatus struct {
+		ActionsWorkflowID   string `xorm:"VARCHAR(255)"`
+		ActionsWorkflowRef  string `xorm:"VARCHAR(255)"`
+		OAuth2ApplicationID int64  `xorm:"NOT NULL DEFAULT 0"`
+	}
+	if err := x.Sync(new(CommitStatus)); err != nil {
+		return err
+	}
+
+	type StatusCheckSource struct {
+		Context       string `json:"context"`
+		Type          string `json:"type"`
+		Workflow      string `json:"workflow,omitempty"`
+		ApplicationID int64  `json:"application_id,omitempty"`
+		UserID        int64  `json:"user_id,omitempty"`
+	}
+	type ProtectedBranch struct {
+		StatusCheckSources []*StatusCheckSource `xorm:"JSON TEXT"`
+	}
+	return x.Sync(new(ProtectedBranch))
+}
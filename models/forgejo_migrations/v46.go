@@ -0,0 +1,20 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddReviewChecklistAndResolvedConversations(x *xorm.Engine) error {
+	type Review struct {
+		Checklist []string `xorm:"JSON TEXT"`
+	}
+	if err := x.Sync(new(Review)); err != nil {
+		return err
+	}
+
+	type ProtectedBranch struct {
+		RequireResolvedConversations bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	return x.Sync(new(ProtectedBranch))
+}
@@ -42,6 +42,12 @@ type CommitStatus struct {
 	Context     string                 `xorm:"TEXT"`
 	Creator     *user_model.User       `xorm:"-"`
 	CreatorID   int64
+	// ActionsWorkflowID is the workflow file of the Actions run the status was created by
+	ActionsWorkflowID string `xorm:"VARCHAR(255)"`
+	// ActionsWorkflowRef is the ref the workflow file of the Actions run was read from
+	ActionsWorkflowRef string `xorm:"VARCHAR(255)"`
+	// OAuth2ApplicationID is the OAuth2 application the status was created through
+	OAuth2ApplicationID int64 `xorm:"NOT NULL DEFAULT 0"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
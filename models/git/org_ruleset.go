@@ -0,0 +1,407 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/gobwas/glob"
+	"xorm.io/builder"
+)
+
+// RulesetTarget is the kind of git reference an organization ruleset applies to
+type RulesetTarget int
+
+const (
+	// RulesetTargetBranch applies the ruleset to branches
+	RulesetTargetBranch RulesetTarget = iota
+	// RulesetTargetTag applies the ruleset to tags
+	RulesetTargetTag
+)
+
+// String returns the name of the ruleset target
+func (t RulesetTarget) String() string {
+	if t == RulesetTargetTag {
+		return "tag"
+	}
+	return "branch"
+}
+
+// RulesetTargetFromString returns the ruleset target matching the given name
+func RulesetTargetFromString(s string) (RulesetTarget, bool) {
+	switch s {
+	case "", "branch":
+		return RulesetTargetBranch, true
+	case "tag":
+		return RulesetTargetTag, true
+	}
+	return RulesetTargetBranch, false
+}
+
+// RulesetEnforcement describes what happens when a push or a merge violates an organization ruleset
+type RulesetEnforcement int
+
+const (
+	// RulesetEnforcementActive rejects pushes and merges violating the ruleset
+	RulesetEnforcementActive RulesetEnforcement = iota
+	// RulesetEnforcementEvaluate only records violations without blocking anything
+	RulesetEnforcementEvaluate
+)
+
+// String returns the name of the ruleset enforcement mode
+func (e RulesetEnforcement) String() string {
+	if e == RulesetEnforcementEvaluate {
+		return "evaluate"
+	}
+	return "active"
+}
+
+// RulesetEnforcementFromString returns the ruleset enforcement mode matching the given name
+func RulesetEnforcementFromString(s string) (RulesetEnforcement, bool) {
+	switch s {
+	case "", "active":
+		return RulesetEnforcementActive, true
+	case "evaluate":
+		return RulesetEnforcementEvaluate, true
+	}
+	return RulesetEnforcementActive, false
+}
+
+// OrgRuleset is a set of branch or tag protections defined once by an organization
+// and applied to every repository of the organization it targets.
+type OrgRuleset struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OrgID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name        string             `xorm:"UNIQUE(s) NOT NULL"`
+	Target      RulesetTarget      `xorm:"NOT NULL DEFAULT 0"`
+	Enforcement RulesetEnforcement `xorm:"NOT NULL DEFAULT 0"`
+
+	// RepoNamePatterns is a semicolon separated list of globs matched against repository names
+	RepoNamePatterns string `xorm:"TEXT"`
+	// RepoTopics targets repositories having at least one of these topics
+	RepoTopics []string `xorm:"JSON TEXT"`
+	// RefPatterns is a semicolon separated list of globs matched against branch or tag names
+	RefPatterns string `xorm:"TEXT"`
+
+	CanPush                bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableWhitelist        bool     `xorm:"NOT NULL DEFAULT false"`
+	WhitelistUserIDs       []int64  `xorm:"JSON TEXT"`
+	WhitelistTeamIDs       []int64  `xorm:"JSON TEXT"`
+	WhitelistDeployKeys    bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableMergeWhitelist   bool     `xorm:"NOT NULL DEFAULT false"`
+	MergeWhitelistUserIDs  []int64  `xorm:"JSON TEXT"`
+	MergeWhitelistTeamIDs  []int64  `xorm:"JSON TEXT"`
+	EnableStatusCheck      bool     `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts    []string `xorm:"JSON TEXT"`
+	RequiredApprovals      int64    `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits   bool     `xorm:"NOT NULL DEFAULT false"`
+	ApplyToAdmins          bool     `xorm:"NOT NULL DEFAULT false"`
+
+	repoGlobs []glob.Glob `xorm:"-"`
+	refGlobs  []glob.Glob `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// OrgRulesetViolation records a push or a merge that did not comply with an
+// organization ruleset running in evaluate-only mode.
+type OrgRulesetViolation struct {
+	ID          int64  `xorm:"pk autoincr"`
+	RulesetID   int64  `xorm:"INDEX NOT NULL"`
+	RepoID      int64  `xorm:"INDEX NOT NULL"`
+	RefName     string `xorm:"TEXT"`
+	DoerID      int64
+	Reason      string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgRuleset))
+	db.RegisterModel(new(OrgRulesetViolation))
+}
+
+// ErrOrgRulesetNotExist represents a "OrgRulesetNotExist" kind of error.
+type ErrOrgRulesetNotExist struct {
+	ID int64
+}
+
+// IsErrOrgRulesetNotExist checks if an error is a ErrOrgRulesetNotExist.
+func IsErrOrgRulesetNotExist(err error) bool {
+	_, ok := err.(ErrOrgRulesetNotExist)
+	return ok
+}
+
+func (err ErrOrgRulesetNotExist) Error() string {
+	return fmt.Sprintf("organization ruleset does not exist [id: %d]", err.ID)
+}
+
+func (err ErrOrgRulesetNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+func compileRulesetPatterns(patterns string, separators ...rune) []glob.Glob {
+	globs := make([]glob.Glob, 0, 4)
+	for _, expr := range strings.Split(patterns, ";") {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		g, err := glob.Compile(expr, separators...)
+		if err != nil {
+			log.Warn("Invalid glob expression '%s' in organization ruleset (skipped): %v", expr, err)
+			continue
+		}
+		globs = append(globs, g)
+	}
+	return globs
+}
+
+// ValidateRulesetPatterns checks that every semicolon separated glob of patterns compiles
+func ValidateRulesetPatterns(patterns string) error {
+	for _, expr := range strings.Split(patterns, ";") {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		if _, err := glob.Compile(expr, '/'); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", expr, err)
+		}
+	}
+	return nil
+}
+
+func (rs *OrgRuleset) loadGlobs() {
+	if rs.repoGlobs == nil {
+		rs.repoGlobs = compileRulesetPatterns(strings.ToLower(rs.RepoNamePatterns))
+	}
+	if rs.refGlobs == nil {
+		rs.refGlobs = compileRulesetPatterns(rs.RefPatterns, '/')
+	}
+}
+
+// MatchRepo tests if the repository is targeted by the ruleset. A ruleset without
+// any repository name pattern nor topic targets every repository of the organization.
+func (rs *OrgRuleset) MatchRepo(repo *repo_model.Repository) bool {
+	if repo.OwnerID != rs.OrgID {
+		return false
+	}
+	rs.loadGlobs()
+	if len(rs.repoGlobs) == 0 && len(rs.RepoTopics) == 0 {
+		return true
+	}
+
+	name := strings.ToLower(repo.Name)
+	for _, g := range rs.repoGlobs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	for _, topic := range rs.RepoTopics {
+		if slices.Contains(repo.Topics, strings.ToLower(topic)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchRef tests if the branch or tag name is targeted by the ruleset
+func (rs *OrgRuleset) MatchRef(refName string) bool {
+	rs.loadGlobs()
+	for _, g := range rs.refGlobs {
+		if g.Match(refName) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEvaluateOnly returns true if violations of the ruleset are only recorded
+func (rs *OrgRuleset) IsEvaluateOnly() bool {
+	return rs.Enforcement == RulesetEnforcementEvaluate
+}
+
+// ToProtectedBranch returns a branch protection rule for repo carrying the
+// settings of the ruleset, so it can go through the regular branch protection checks.
+// The branches are matched by the ruleset, the rule is only named after it.
+func (rs *OrgRuleset) ToProtectedBranch(repo *repo_model.Repository) *ProtectedBranch {
+	return &ProtectedBranch{
+		RepoID:                 repo.ID,
+		Repo:                   repo,
+		RuleName:               rs.Name,
+		CanPush:                rs.CanPush,
+		EnableWhitelist:        rs.EnableWhitelist,
+		WhitelistUserIDs:       rs.WhitelistUserIDs,
+		WhitelistTeamIDs:       rs.WhitelistTeamIDs,
+		WhitelistDeployKeys:    rs.WhitelistDeployKeys,
+		EnableMergeWhitelist:   rs.EnableMergeWhitelist,
+		MergeWhitelistUserIDs:  rs.MergeWhitelistUserIDs,
+		MergeWhitelistTeamIDs:  rs.MergeWhitelistTeamIDs,
+		EnableStatusCheck:      rs.EnableStatusCheck,
+		StatusCheckContexts:    rs.StatusCheckContexts,
+		RequiredApprovals:      rs.RequiredApprovals,
+		BlockOnRejectedReviews: rs.BlockOnRejectedReviews,
+		RequireSignedCommits:   rs.RequireSignedCommits,
+		ApplyToAdmins:          rs.ApplyToAdmins,
+		CreatedUnix:            rs.CreatedUnix,
+		UpdatedUnix:            rs.UpdatedUnix,
+	}
+}
+
+// ToProtectedTag returns a tag protection rule for repo carrying the push allowlist of the ruleset,
+// which is empty unless the allowlist is enabled
+func (rs *OrgRuleset) ToProtectedTag(repo *repo_model.Repository) *ProtectedTag {
+	pt := &ProtectedTag{
+		RepoID:      repo.ID,
+		NamePattern: rs.RefPatterns,
+		CreatedUnix: rs.CreatedUnix,
+		UpdatedUnix: rs.UpdatedUnix,
+	}
+	if rs.EnableWhitelist {
+		pt.AllowlistUserIDs = rs.WhitelistUserIDs
+		pt.AllowlistTeamIDs = rs.WhitelistTeamIDs
+	}
+	return pt
+}
+
+// IsUserAllowedToModifyTag tests if the user can push the tags targeted by the ruleset. As for
+// branches, nobody can push unless pushing is enabled, and only the allowlist if it is enabled.
+func (rs *OrgRuleset) IsUserAllowedToModifyTag(ctx context.Context, repo *repo_model.Repository, userID int64) (bool, error) {
+	if !rs.CanPush {
+		return false, nil
+	}
+	if !rs.EnableWhitelist {
+		return true, nil
+	}
+	return IsUserAllowedModifyTag(ctx, rs.ToProtectedTag(repo), userID)
+}
+
+// InsertOrgRuleset inserts an organization ruleset
+func InsertOrgRuleset(ctx context.Context, rs *OrgRuleset) error {
+	_, err := db.GetEngine(ctx).Insert(rs)
+	return err
+}
+
+// UpdateOrgRuleset updates all the columns of an organization ruleset
+func UpdateOrgRuleset(ctx context.Context, rs *OrgRuleset) error {
+	rs.repoGlobs, rs.refGlobs = nil, nil
+	_, err := db.GetEngine(ctx).ID(rs.ID).AllCols().Update(rs)
+	return err
+}
+
+// DeleteOrgRuleset deletes an organization ruleset and its recorded violations
+func DeleteOrgRuleset(ctx context.Context, orgID, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("org_id = ?", orgID).ID(id).Delete(new(OrgRuleset))
+		if err != nil {
+			return err
+		} else if affected == 0 {
+			return ErrOrgRulesetNotExist{ID: id}
+		}
+		_, err = db.GetEngine(ctx).Where("ruleset_id = ?", id).Delete(new(OrgRulesetViolation))
+		return err
+	})
+}
+
+// DeleteOrgRulesets deletes all the rulesets of an organization
+func DeleteOrgRulesets(ctx context.Context, orgID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.In("ruleset_id", builder.Select("id").From("org_ruleset").Where(builder.Eq{"org_id": orgID}))).
+			Delete(new(OrgRulesetViolation)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Delete(new(OrgRuleset))
+		return err
+	})
+}
+
+// GetOrgRulesetByID returns the ruleset of the organization with the given id
+func GetOrgRulesetByID(ctx context.Context, orgID, id int64) (*OrgRuleset, error) {
+	rs, exist, err := db.Get[OrgRuleset](ctx, builder.Eq{"org_id": orgID, "id": id})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, ErrOrgRulesetNotExist{ID: id}
+	}
+	return rs, nil
+}
+
+// GetOrgRulesetByName returns the ruleset of the organization with the given name
+func GetOrgRulesetByName(ctx context.Context, orgID int64, name string) (*OrgRuleset, error) {
+	rs, exist, err := db.Get[OrgRuleset](ctx, builder.Eq{"org_id": orgID, "name": name})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, nil
+	}
+	return rs, nil
+}
+
+// FindOrgRulesets returns all the rulesets of an organization
+func FindOrgRulesets(ctx context.Context, orgID int64) ([]*OrgRuleset, error) {
+	rulesets := make([]*OrgRuleset, 0, 5)
+	return rulesets, db.GetEngine(ctx).Where("org_id = ?", orgID).Asc("id").Find(&rulesets)
+}
+
+// FindMatchedOrgRulesets returns the rulesets of the repository owner targeting the repository and the reference
+func FindMatchedOrgRulesets(ctx context.Context, repo *repo_model.Repository, target RulesetTarget, refName string) ([]*OrgRuleset, error) {
+	rulesets := make([]*OrgRuleset, 0, 5)
+	if err := db.GetEngine(ctx).Where("org_id = ? AND target = ?", repo.OwnerID, target).Asc("id").Find(&rulesets); err != nil {
+		return nil, err
+	}
+	matched := rulesets[:0]
+	for _, rs := range rulesets {
+		if rs.MatchRepo(repo) && rs.MatchRef(refName) {
+			matched = append(matched, rs)
+		}
+	}
+	return matched, nil
+}
+
+// GetEnforcedRulesetBranchRules returns the branch protection rules of every active
+// organization ruleset applying to the branch of the repository.
+func GetEnforcedRulesetBranchRules(ctx context.Context, repoID int64, branchName string) (ProtectedBranchRules, error) {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	rulesets, err := FindMatchedOrgRulesets(ctx, repo, RulesetTargetBranch, branchName)
+	if err != nil {
+		return nil, err
+	}
+	rules := make(ProtectedBranchRules, 0, len(rulesets))
+	for _, rs := range rulesets {
+		if !rs.IsEvaluateOnly() {
+			rules = append(rules, rs.ToProtectedBranch(repo))
+		}
+	}
+	return rules, nil
+}
+
+// InsertOrgRulesetViolation records a violation of an evaluate-only ruleset
+func InsertOrgRulesetViolation(ctx context.Context, v *OrgRulesetViolation) error {
+	_, err := db.GetEngine(ctx).Insert(v)
+	return err
+}
+
+// FindOrgRulesetViolations returns the recorded violations of a ruleset, most recent first
+func FindOrgRulesetViolations(ctx context.Context, rulesetID int64, opts db.ListOptions) ([]*OrgRulesetViolation, int64, error) {
+	sess := db.GetEngine(ctx).Where("ruleset_id = ?", rulesetID).Desc("created_unix", "id")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	violations := make([]*OrgRulesetViolation, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&violations)
+	return violations, count, err
+}
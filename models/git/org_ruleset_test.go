@@ -0,0 +1,137 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRulesetMatch(t *testing.T) {
+	repo := &repo_model.Repository{ID: 3, OwnerID: 3, Name: "Repo3", Topics: []string{"game-assets"}}
+
+	rs := &git_model.OrgRuleset{OrgID: 3, RefPatterns: "main; release/*"}
+	assert.True(t, rs.MatchRepo(repo))
+	assert.False(t, rs.MatchRepo(&repo_model.Repository{ID: 1, OwnerID: 2, Name: "repo1"}))
+
+	assert.True(t, rs.MatchRef("main"))
+	assert.True(t, rs.MatchRef("release/v1"))
+	assert.False(t, rs.MatchRef("release/v1/hotfix"))
+	assert.False(t, rs.MatchRef("feature"))
+
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoNamePatterns: "repo*", RefPatterns: "*"}
+	assert.True(t, rs.MatchRepo(repo))
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoNamePatterns: "infra-*", RefPatterns: "*"}
+	assert.False(t, rs.MatchRepo(repo))
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoNamePatterns: "infra-*", RepoTopics: []string{"game-assets"}, RefPatterns: "*"}
+	assert.True(t, rs.MatchRepo(repo))
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoTopics: []string{"docs"}, RefPatterns: "*"}
+	assert.False(t, rs.MatchRepo(repo))
+}
+
+func TestOrgRulesets(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	active := &git_model.OrgRuleset{
+		OrgID:                3,
+		Name:                 "protect main",
+		RefPatterns:          "main;master",
+		RequiredApprovals:    2,
+		RequireSignedCommits: true,
+	}
+	require.NoError(t, git_model.InsertOrgRuleset(db.DefaultContext, active))
+	evaluate := &git_model.OrgRuleset{
+		OrgID:            3,
+		Name:             "dry run",
+		Enforcement:      git_model.RulesetEnforcementEvaluate,
+		RepoNamePatterns: "repo3",
+		RefPatterns:      "*",
+	}
+	require.NoError(t, git_model.InsertOrgRuleset(db.DefaultContext, evaluate))
+	tags := &git_model.OrgRuleset{
+		OrgID:            3,
+		Name:             "release tags",
+		Target:           git_model.RulesetTargetTag,
+		RefPatterns:      "v*",
+		CanPush:          true,
+		EnableWhitelist:  true,
+		WhitelistTeamIDs: []int64{1},
+	}
+	require.NoError(t, git_model.InsertOrgRuleset(db.DefaultContext, tags))
+
+	rulesets, err := git_model.FindOrgRulesets(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Len(t, rulesets, 3)
+
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	repo5 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 5})
+
+	matched, err := git_model.FindMatchedOrgRulesets(db.DefaultContext, repo3, git_model.RulesetTargetBranch, "master")
+	require.NoError(t, err)
+	if assert.Len(t, matched, 2) {
+		assert.Equal(t, active.ID, matched[0].ID)
+		assert.Equal(t, evaluate.ID, matched[1].ID)
+	}
+	matched, err = git_model.FindMatchedOrgRulesets(db.DefaultContext, repo5, git_model.RulesetTargetBranch, "feature")
+	require.NoError(t, err)
+	assert.Empty(t, matched)
+	matched, err = git_model.FindMatchedOrgRulesets(db.DefaultContext, repo5, git_model.RulesetTargetTag, "v1.0")
+	require.NoError(t, err)
+	if assert.Len(t, matched, 1) {
+		allowed, err := matched[0].IsUserAllowedToModifyTag(db.DefaultContext, repo5, 2)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, err = matched[0].IsUserAllowedToModifyTag(db.DefaultContext, repo5, 1)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+
+		// the allowlist is ignored once disabled, like for branches
+		matched[0].EnableWhitelist = false
+		assert.Empty(t, matched[0].ToProtectedTag(repo5).AllowlistTeamIDs)
+		allowed, err = matched[0].IsUserAllowedToModifyTag(db.DefaultContext, repo5, 1)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		matched[0].CanPush = false
+		allowed, err = matched[0].IsUserAllowedToModifyTag(db.DefaultContext, repo5, 2)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	}
+
+	rules, err := git_model.GetEnforcedRulesetBranchRules(db.DefaultContext, repo3.ID, "master")
+	require.NoError(t, err)
+	if assert.Len(t, rules, 1) {
+		assert.Equal(t, repo3.ID, rules[0].RepoID)
+		assert.Equal(t, "protect main", rules[0].RuleName)
+		assert.EqualValues(t, 2, rules[0].RequiredApprovals)
+		assert.True(t, rules[0].RequireSignedCommits)
+	}
+
+	require.NoError(t, git_model.InsertOrgRulesetViolation(db.DefaultContext, &git_model.OrgRulesetViolation{
+		RulesetID: evaluate.ID,
+		RepoID:    repo3.ID,
+		RefName:   "refs/heads/master",
+		DoerID:    2,
+		Reason:    "branch master is protected from force push",
+	}))
+	violations, count, err := git_model.FindOrgRulesetViolations(db.DefaultContext, evaluate.ID, db.ListOptions{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	assert.Len(t, violations, 1)
+
+	require.NoError(t, git_model.DeleteOrgRuleset(db.DefaultContext, 3, evaluate.ID))
+	unittest.AssertNotExistsBean(t, &git_model.OrgRulesetViolation{RulesetID: evaluate.ID})
+	_, err = git_model.GetOrgRulesetByID(db.DefaultContext, 3, evaluate.ID)
+	assert.True(t, git_model.IsErrOrgRulesetNotExist(err))
+	assert.True(t, git_model.IsErrOrgRulesetNotExist(git_model.DeleteOrgRuleset(db.DefaultContext, 3, evaluate.ID)))
+
+	require.NoError(t, git_model.DeleteOrgRulesets(db.DefaultContext, 3))
+	unittest.AssertCount(t, &git_model.OrgRuleset{OrgID: 3}, 0)
+}
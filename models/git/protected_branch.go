@@ -38,27 +38,30 @@ type ProtectedBranch struct {
 	isPlainName                   bool                   `xorm:"-"`
 	CanPush                       bool                   `xorm:"NOT NULL DEFAULT false"`
 	EnableWhitelist               bool
-	WhitelistUserIDs              []int64  `xorm:"JSON TEXT"`
-	WhitelistTeamIDs              []int64  `xorm:"JSON TEXT"`
-	EnableMergeWhitelist          bool     `xorm:"NOT NULL DEFAULT false"`
-	WhitelistDeployKeys           bool     `xorm:"NOT NULL DEFAULT false"`
-	MergeWhitelistUserIDs         []int64  `xorm:"JSON TEXT"`
-	MergeWhitelistTeamIDs         []int64  `xorm:"JSON TEXT"`
-	EnableStatusCheck             bool     `xorm:"NOT NULL DEFAULT false"`
-	StatusCheckContexts           []string `xorm:"JSON TEXT"`
-	EnableApprovalsWhitelist      bool     `xorm:"NOT NULL DEFAULT false"`
-	ApprovalsWhitelistUserIDs     []int64  `xorm:"JSON TEXT"`
-	ApprovalsWhitelistTeamIDs     []int64  `xorm:"JSON TEXT"`
-	RequiredApprovals             int64    `xorm:"NOT NULL DEFAULT 0"`
-	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
-	DismissStaleApprovals         bool     `xorm:"NOT NULL DEFAULT false"`
-	IgnoreStaleApprovals          bool     `xorm:"NOT NULL DEFAULT false"`
-	RequireSignedCommits          bool     `xorm:"NOT NULL DEFAULT false"`
-	ProtectedFilePatterns         string   `xorm:"TEXT"`
-	UnprotectedFilePatterns       string   `xorm:"TEXT"`
-	ApplyToAdmins                 bool     `xorm:"NOT NULL DEFAULT false"`
+	WhitelistUserIDs              []int64              `xorm:"JSON TEXT"`
+	WhitelistTeamIDs              []int64              `xorm:"JSON TEXT"`
+	EnableMergeWhitelist          bool                 `xorm:"NOT NULL DEFAULT false"`
+	WhitelistDeployKeys           bool                 `xorm:"NOT NULL DEFAULT false"`
+	MergeWhitelistUserIDs         []int64              `xorm:"JSON TEXT"`
+	MergeWhitelistTeamIDs         []int64              `xorm:"JSON TEXT"`
+	EnableStatusCheck             bool                 `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts           []string             `xorm:"JSON TEXT"`
+	StatusCheckSources            []*StatusCheckSource `xorm:"JSON TEXT"` // the sources trusted to create some of the status checks
+	EnableApprovalsWhitelist      bool                 `xorm:"NOT NULL DEFAULT false"`
+	ApprovalsWhitelistUserIDs     []int64              `xorm:"JSON TEXT"`
+	ApprovalsWhitelistTeamIDs     []int64              `xorm:"JSON TEXT"`
+	RequiredApprovals             int64                `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews        bool                 `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOfficialReviewRequests bool                 `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOutdatedBranch         bool                 `xorm:"NOT NULL DEFAULT false"`
+	DismissStaleApprovals         bool                 `xorm:"NOT NULL DEFAULT false"`
+	IgnoreStaleApprovals          bool                 `xorm:"NOT NULL DEFAULT false"`
+	RequireCodeOwnerApproval      bool                 `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits          bool                 `xorm:"NOT NULL DEFAULT false"`
+	RequireResolvedConversations  bool                 `xorm:"NOT NULL DEFAULT false"`
+	ProtectedFilePatterns         string               `xorm:"TEXT"`
+	UnprotectedFilePatterns       string               `xorm:"TEXT"`
+	ApplyToAdmins                 bool                 `xorm:"NOT NULL DEFAULT false"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -0,0 +1,167 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+
+	"github.com/gobwas/glob"
+)
+
+// StatusCheckSourceType is the kind of source a required status check is bound to
+type StatusCheckSourceType string
+
+const (
+	// StatusCheckSourceActions binds the status check to a workflow of the Actions of the repository, read from
+	// a branch protected by the rule
+	StatusCheckSourceActions StatusCheckSourceType = "actions"
+	// StatusCheckSourceOAuth2 binds the status check to an OAuth2 application
+	StatusCheckSourceOAuth2 StatusCheckSourceType = "oauth2"
+	// StatusCheckSourceUser binds the status check to a user, usually a bot
+	StatusCheckSourceUser StatusCheckSourceType = "user"
+)
+
+// StatusCheckSource binds the status checks whose context matches a pattern to the source which is trusted
+// to create them. The statuses of these contexts created by other sources are ignored by the branch protection.
+type StatusCheckSource struct {
+	Context       string                `json:"context"`
+	Type          StatusCheckSourceType `json:"type"`
+	Workflow      string                `json:"workflow,omitempty"`
+	ApplicationID int64                 `json:"application_id,omitempty"`
+	UserID        int64                 `json:"user_id,omitempty"`
+}
+
+// MatchContext returns true if the source is bound to the context of a status
+func (s *StatusCheckSource) MatchContext(context string) bool {
+	if s.Context == context {
+		return true
+	}
+	gp, err := glob.Compile(s.Context)
+	if err != nil {
+		log.Error("compile glob %q: %v", s.Context, err)
+		return false
+	}
+	return gp.Match(context)
+}
+
+// IsCreatorOf returns true if the status was created by the source. The workflow of an Actions source must
+// have been read from a branch protected by the rule, otherwise anyone able to push a branch could change it.
+func (s *StatusCheckSource) IsCreatorOf(protectBranch *ProtectedBranch, status *CommitStatus) bool {
+	switch s.Type {
+	case StatusCheckSourceActions:
+		if status.CreatorID != user_model.ActionsUserID || status.ActionsWorkflowID != s.Workflow {
+			return false
+		}
+		refName := git.RefName(status.ActionsWorkflowRef)
+		return refName.IsBranch() && protectBranch.Match(refName.BranchName())
+	case StatusCheckSourceOAuth2:
+		return status.OAuth2ApplicationID == s.ApplicationID
+	case StatusCheckSourceUser:
+		return status.CreatorID == s.UserID
+	}
+	return false
+}
+
+// ParseStatusCheckSource parses a binding of the form "<source> <context pattern>", where the source is
+// "actions:<workflow file>", "oauth2:<client id>" or "user:<user name>"
+func ParseStatusCheckSource(ctx context.Context, line string) (*StatusCheckSource, error) {
+	source, pattern, _ := strings.Cut(strings.TrimSpace(line), " ")
+	pattern = strings.TrimSpace(pattern)
+	typ, name, _ := strings.Cut(source, ":")
+	if pattern == "" || name == "" {
+		return nil, util.NewInvalidArgumentErrorf("status check source %q is not of the form \"<source> <context pattern>\"", line)
+	}
+	if _, err := glob.Compile(pattern); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid status check pattern %q: %v", pattern, err)
+	}
+
+	s := &StatusCheckSource{Context: pattern, Type: StatusCheckSourceType(typ)}
+	switch s.Type {
+	case StatusCheckSourceActions:
+		s.Workflow = name
+	case StatusCheckSourceOAuth2:
+		app, err := auth_model.GetOAuth2ApplicationByClientID(ctx, name)
+		if err != nil {
+			if auth_model.IsErrOauthClientIDInvalid(err) {
+				return nil, util.NewNotExistErrorf("OAuth2 application %q does not exist", name)
+			}
+			return nil, err
+		}
+		// anyone can get a token of a public client on behalf of themselves
+		if !app.ConfidentialClient {
+			return nil, util.NewInvalidArgumentErrorf("OAuth2 application %q is not a confidential client", name)
+		}
+		s.ApplicationID = app.ID
+	case StatusCheckSourceUser:
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, util.NewNotExistErrorf("user %q does not exist", name)
+			}
+			return nil, err
+		}
+		s.UserID = u.ID
+	default:
+		return nil, util.NewInvalidArgumentErrorf("unknown status check source %q", source)
+	}
+	return s, nil
+}
+
+// FormatStatusCheckSource formats a binding as parsed by ParseStatusCheckSource
+func FormatStatusCheckSource(ctx context.Context, s *StatusCheckSource) string {
+	name := s.Workflow
+	switch s.Type {
+	case StatusCheckSourceOAuth2:
+		name = fmt.Sprintf("%d", s.ApplicationID)
+		if app, err := auth_model.GetOAuth2ApplicationByID(ctx, s.ApplicationID); err == nil {
+			name = app.ClientID
+		}
+	case StatusCheckSourceUser:
+		name = user_model.NewGhostUser().Name
+		if u, err := user_model.GetPossibleUserByID(ctx, s.UserID); err == nil {
+			name = u.Name
+		}
+	}
+	return fmt.Sprintf("%s:%s %s", s.Type, name, s.Context)
+}
+
+// TrustedCommitStatuses returns the latest status of each context among the statuses which can count towards
+// the required status checks, given all the statuses of a commit from the newest. When a context is bound to
+// sources, only the statuses created by one of them are trusted.
+func (protectBranch *ProtectedBranch) TrustedCommitStatuses(statuses []*CommitStatus) []*CommitStatus {
+	trusted := make([]*CommitStatus, 0, len(statuses))
+	seen := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		if seen[status.Context] || !protectBranch.IsTrustedCommitStatus(status) {
+			continue
+		}
+		seen[status.Context] = true
+		trusted = append(trusted, status)
+	}
+	return trusted
+}
+
+// IsTrustedCommitStatus returns true if the status was created by a source bound to its context, or if its
+// context is not bound to any source
+func (protectBranch *ProtectedBranch) IsTrustedCommitStatus(status *CommitStatus) bool {
+	bound := false
+	for _, source := range protectBranch.StatusCheckSources {
+		if !source.MatchContext(status.Context) {
+			continue
+		}
+		if source.IsCreatorOf(protectBranch, status) {
+			return true
+		}
+		bound = true
+	}
+	return !bound
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusCheckSource(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	source, err := ParseStatusCheckSource(db.DefaultContext, "actions:build.yml ci / build (push)")
+	require.NoError(t, err)
+	assert.Equal(t, &StatusCheckSource{Context: "ci / build (push)", Type: StatusCheckSourceActions, Workflow: "build.yml"}, source)
+	assert.Equal(t, "actions:build.yml ci / build (push)", FormatStatusCheckSource(db.DefaultContext, source))
+
+	source, err = ParseStatusCheckSource(db.DefaultContext, " user:user2  deploy/* ")
+	require.NoError(t, err)
+	assert.Equal(t, &StatusCheckSource{Context: "deploy/*", Type: StatusCheckSourceUser, UserID: 2}, source)
+	assert.Equal(t, "user:user2 deploy/*", FormatStatusCheckSource(db.DefaultContext, source))
+
+	_, err = ParseStatusCheckSource(db.DefaultContext, "user:user2")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ParseStatusCheckSource(db.DefaultContext, "bot:user2 ci/*")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ParseStatusCheckSource(db.DefaultContext, "user:nobody ci/*")
+	require.ErrorIs(t, err, util.ErrNotExist)
+	_, err = ParseStatusCheckSource(db.DefaultContext, "oauth2:unknown ci/*")
+	require.ErrorIs(t, err, util.ErrNotExist)
+
+	source, err = ParseStatusCheckSource(db.DefaultContext, "oauth2:da7da3ba-9a13-4167-856f-3899de0b0138 deploy")
+	require.NoError(t, err)
+	assert.Equal(t, &StatusCheckSource{Context: "deploy", Type: StatusCheckSourceOAuth2, ApplicationID: 1}, source)
+	// a public client
+	_, err = ParseStatusCheckSource(db.DefaultContext, "oauth2:ce5a1322-42a7-11ed-b878-0242ac120002 deploy")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestTrustedCommitStatuses(t *testing.T) {
+	pb := &ProtectedBranch{
+		RuleName: "main",
+		StatusCheckSources: []*StatusCheckSource{
+			{Context: "ci/*", Type: StatusCheckSourceActions, Workflow: "build.yml"},
+			{Context: "deploy", Type: StatusCheckSourceOAuth2, ApplicationID: 1},
+			{Context: "deploy", Type: StatusCheckSourceUser, UserID: 5},
+		},
+	}
+
+	// from the newest
+	statuses := []*CommitStatus{
+		{ID: 9, Context: "ci/build", CreatorID: 2},
+		{ID: 8, Context: "ci/build", CreatorID: user_model.ActionsUserID, ActionsWorkflowID: "other.yml", ActionsWorkflowRef: "refs/heads/main"},
+		// the workflow was read from a branch anyone with write access could push
+		{ID: 7, Context: "ci/build", CreatorID: user_model.ActionsUserID, ActionsWorkflowID: "build.yml", ActionsWorkflowRef: "refs/heads/feature"},
+		{ID: 6, Context: "ci/build", CreatorID: user_model.ActionsUserID, ActionsWorkflowID: "build.yml", ActionsWorkflowRef: "refs/pull/1/head"},
+		{ID: 5, Context: "ci/build", CreatorID: user_model.ActionsUserID, ActionsWorkflowID: "build.yml", ActionsWorkflowRef: "refs/heads/main"},
+		{ID: 4, Context: "ci/build", CreatorID: user_model.ActionsUserID, ActionsWorkflowID: "build.yml", ActionsWorkflowRef: "refs/heads/main"},
+		{ID: 3, Context: "deploy", CreatorID: 2, OAuth2ApplicationID: 1},
+		{ID: 2, Context: "deploy", CreatorID: 5},
+		{ID: 1, Context: "lint", CreatorID: 2},
+	}
+	trusted := pb.TrustedCommitStatuses(statuses)
+	ids := make([]int64, 0, len(trusted))
+	for _, status := range trusted {
+		ids = append(ids, status.ID)
+	}
+	assert.Equal(t, []int64{5, 3, 1}, ids)
+}
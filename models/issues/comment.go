@@ -116,6 +116,11 @@ const (
 	CommentTypeUnpin // 37 unpin Issue
 
 	CommentTypeAggregator // 38 Aggregator of comments
+
+	CommentTypeAddSubIssue       // 39 Sub-issue added
+	CommentTypeRemoveSubIssue    // 40 Sub-issue removed
+	CommentTypeAddParentIssue    // 41 Parent issue added
+	CommentTypeRemoveParentIssue // 42 Parent issue removed
 )
 
 var commentStrings = []string{
@@ -158,6 +163,10 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"action_aggregator",
+	"add_sub_issue",
+	"remove_sub_issue",
+	"add_parent_issue",
+	"remove_parent_issue",
 }
 
 func (t CommentType) String() string {
@@ -282,10 +291,13 @@ type Comment struct {
 
 	CommitID        int64
 	Line            int64 // - previous line / + proposed line
+	StartLine       int64 `xorm:"NOT NULL DEFAULT 0"` // first line of a range ending at Line, or 0
 	TreePath        string
 	Content         string        `xorm:"LONGTEXT"`
 	ContentVersion  int           `xorm:"NOT NULL DEFAULT 0"`
 	RenderedContent template.HTML `xorm:"-"`
+	// IsHidden is set when a moderator hid the content of the comment
+	IsHidden bool `xorm:"NOT NULL DEFAULT false"`
 
 	// Path represents the 4 lines of code cemented by this comment
 	Patch       string `xorm:"-"`
@@ -300,6 +312,9 @@ type Comment struct {
 	Attachments []*repo_model.Attachment `xorm:"-"`
 	Reactions   ReactionList             `xorm:"-"`
 
+	// CodeSuggestions are the suggestions of a code comment on proposed lines
+	CodeSuggestions []*CodeSuggestion `xorm:"-"`
+
 	// For view issue page.
 	ShowRole RoleDescriptor `xorm:"-"`
 
@@ -745,6 +760,14 @@ func (c *Comment) DiffSide() string {
 	return "proposed"
 }
 
+// UnsignedStartLine returns the first LOC of a code comment on a range of lines without + or -, or 0
+func (c *Comment) UnsignedStartLine() uint64 {
+	if c.StartLine < 0 {
+		return uint64(c.StartLine * -1)
+	}
+	return uint64(c.StartLine)
+}
+
 // UnsignedLine returns the LOC of the code comment without + or -
 func (c *Comment) UnsignedLine() uint64 {
 	if c.Line < 0 {
@@ -834,6 +857,7 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 		CommitID:         opts.CommitID,
 		CommitSHA:        opts.CommitSHA,
 		Line:             opts.LineNum,
+		StartLine:        opts.StartLineNum,
 		Content:          opts.Content,
 		OldTitle:         opts.OldTitle,
 		NewTitle:         opts.NewTitle,
@@ -1027,6 +1051,7 @@ type CreateCommentOptions struct {
 	CommitSHA        string
 	Patch            string
 	LineNum          int64
+	StartLineNum     int64
 	TreePath         string
 	ReviewID         int64
 	Content          string
@@ -1141,6 +1166,12 @@ func UpdateCommentInvalidate(ctx context.Context, c *Comment) error {
 	return err
 }
 
+// UpdateCommentHidden updates whether the content of a comment is hidden by a moderator
+func UpdateCommentHidden(ctx context.Context, c *Comment) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("is_hidden").NoAutoTime().Update(c)
+	return err
+}
+
 // UpdateComment updates information of comment.
 func UpdateComment(ctx context.Context, c *Comment, contentVersion int, doer *user_model.User) error {
 	ctx, committer, err := db.TxContext(ctx)
@@ -1194,6 +1225,12 @@ func DeleteComment(ctx context.Context, comment *Comment) error {
 		return err
 	}
 
+	if _, err := db.DeleteByBean(ctx, &AppliedSuggestion{
+		CommentID: comment.ID,
+	}); err != nil {
+		return err
+	}
+
 	if comment.Type.CountedAsConversation() {
 		if err := UpdateIssueNumComments(ctx, comment.IssueID); err != nil {
 			return err
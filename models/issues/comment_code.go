@@ -121,6 +121,10 @@ func findCodeComments(ctx context.Context, opts FindCommentsOptions, issue *Issu
 		return nil, err
 	}
 
+	if err := comments.LoadCodeSuggestions(ctx); err != nil {
+		return nil, err
+	}
+
 	// Find all reviews by ReviewID
 	reviews := make(map[int64]*Review)
 	ids := make([]int64, 0, len(comments))
@@ -154,6 +158,10 @@ func findCodeComments(ctx context.Context, opts FindCommentsOptions, issue *Issu
 			return nil, err
 		}
 
+		if comment.IsHidden {
+			comment.Content = ""
+		}
+
 		var err error
 		if comment.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 			Ctx: ctx,
@@ -161,7 +169,7 @@ func findCodeComments(ctx context.Context, opts FindCommentsOptions, issue *Issu
 				Base: issue.Repo.Link(),
 			},
 			Metas: issue.Repo.ComposeMetas(ctx),
-		}, comment.Content); err != nil {
+		}, RenderCodeSuggestions(comment.Content, comment.CommentedLines())); err != nil {
 			return nil, err
 		}
 	}
@@ -179,3 +187,30 @@ func FetchCodeConversation(ctx context.Context, comment *Comment, doer *user_mod
 	}
 	return findCodeComments(ctx, opts, comment.Issue, doer, nil, true)
 }
+
+// CountUnresolvedCodeConversations returns the number of submitted code conversations of an issue,
+// outdated ones included, which have not been marked as resolved
+func CountUnresolvedCodeConversations(ctx context.Context, issueID int64) (int, error) {
+	comments := make(CommentList, 0, 10)
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"comment.issue_id": issueID, "comment.type": CommentTypeCode}).
+		And(builder.NotIn("comment.review_id", builder.Select("id").From("review").Where(builder.Eq{"type": ReviewTypePending}))).
+		Asc("comment.created_unix").
+		Asc("comment.id").
+		Find(&comments); err != nil {
+		return 0, err
+	}
+
+	tree := newCodeConversationsAtLineAndTreePath(comments)
+	unresolved := 0
+	for _, lines := range tree {
+		for _, conversations := range lines {
+			for _, conversation := range conversations {
+				if !conversation[0].IsResolved() {
+					unresolved++
+				}
+			}
+		}
+	}
+	return unresolved, nil
+}
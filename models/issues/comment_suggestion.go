@@ -0,0 +1,216 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"strings"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// AppliedSuggestion records that a suggestion of a code comment was committed to the head branch of
+// its pull request
+type AppliedSuggestion struct {
+	ID        int64 `xorm:"pk autoincr"`
+	IssueID   int64 `xorm:"INDEX NOT NULL"`
+	CommentID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// Index is the position of the suggestion in the content of the comment
+	Index       int                `xorm:"UNIQUE(s) NOT NULL"`
+	DoerID      int64              `xorm:"NOT NULL"`
+	Doer        *user_model.User   `xorm:"-"`
+	CommitSHA   string             `xorm:"VARCHAR(64) NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(AppliedSuggestion))
+}
+
+// CodeSuggestion is a ```suggestion block of a code comment, which replaces the commented lines
+type CodeSuggestion struct {
+	Index int
+	// Lines are the suggested lines, none to remove the commented lines
+	Lines   []string
+	Applied *AppliedSuggestion
+}
+
+type codeSuggestionBlock struct {
+	fence      string
+	begin, end int // lines of the opening and of the closing fences
+	lines      []string
+}
+
+// parseCodeSuggestionBlocks returns the ```suggestion blocks of a content split in lines. A block which is
+// not closed is not a suggestion, like it is not a code block for the markdown renderer.
+func parseCodeSuggestionBlocks(lines []string) []*codeSuggestionBlock {
+	var blocks []*codeSuggestionBlock
+	var block *codeSuggestionBlock
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if block == nil {
+			fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, "`"))]
+			if len(fence) >= 3 && strings.TrimSpace(trimmed[len(fence):]) == "suggestion" {
+				block = &codeSuggestionBlock{fence: fence, begin: i}
+			}
+			continue
+		}
+		if len(trimmed) >= len(block.fence) && strings.Trim(trimmed, "`") == "" {
+			block.end = i
+			blocks = append(blocks, block)
+			block = nil
+			continue
+		}
+		block.lines = append(block.lines, strings.TrimSuffix(line, "\r"))
+	}
+	return blocks
+}
+
+// ParseCodeSuggestions returns the suggestions of the content of a code comment
+func ParseCodeSuggestions(content string) []*CodeSuggestion {
+	blocks := parseCodeSuggestionBlocks(strings.Split(content, "\n"))
+	suggestions := make([]*CodeSuggestion, 0, len(blocks))
+	for i, block := range blocks {
+		suggestions = append(suggestions, &CodeSuggestion{
+			Index: i,
+			Lines: block.lines,
+		})
+	}
+	return suggestions
+}
+
+// RenderCodeSuggestions replaces the ```suggestion blocks of a content by ```diff blocks from the
+// commented lines to the suggested ones, for the markdown renderer
+func RenderCodeSuggestions(content string, commentedLines []string) string {
+	lines := strings.Split(content, "\n")
+	blocks := parseCodeSuggestionBlocks(lines)
+	if len(blocks) == 0 {
+		return content
+	}
+
+	rendered := make([]string, 0, len(lines))
+	last := 0
+	for _, block := range blocks {
+		rendered = append(rendered, lines[last:block.begin]...)
+		rendered = append(rendered, block.fence+"diff")
+		for _, line := range commentedLines {
+			rendered = append(rendered, "-"+line)
+		}
+		for _, line := range block.lines {
+			rendered = append(rendered, "+"+line)
+		}
+		rendered = append(rendered, block.fence)
+		last = block.end + 1
+	}
+	rendered = append(rendered, lines[last:]...)
+	return strings.Join(rendered, "\n")
+}
+
+// CommentedLines returns the lines of code a code comment is about, as they were when it was made. They
+// are taken from the end of its patch, and only the proposed lines can be commented with suggestions.
+func (c *Comment) CommentedLines() []string {
+	if c.Type != CommentTypeCode || c.Line <= 0 {
+		return nil
+	}
+	count := 1
+	if c.StartLine > 0 && c.StartLine < c.Line {
+		count = int(c.Line-c.StartLine) + 1
+	}
+
+	patchLines := strings.Split(strings.TrimRight(c.Patch, "\n"), "\n")
+	lines := make([]string, count)
+	for i := len(patchLines) - 1; i >= 0 && count > 0; i-- {
+		line := patchLines[i]
+		if line == "" {
+			return nil
+		}
+		switch line[0] {
+		case '+', ' ':
+			count--
+			lines[count] = line[1:]
+		case '-', '\\':
+		default:
+			// the hunk header, the patch does not contain all the lines
+			return nil
+		}
+	}
+	if count > 0 {
+		return nil
+	}
+	return lines
+}
+
+// LoadCodeSuggestions loads the suggestions of code comments, with who applied them
+func (comments CommentList) LoadCodeSuggestions(ctx context.Context) error {
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		comment.CodeSuggestions = nil
+		if comment.Type != CommentTypeCode || comment.Line <= 0 {
+			continue
+		}
+		comment.CodeSuggestions = ParseCodeSuggestions(comment.Content)
+		if len(comment.CodeSuggestions) > 0 {
+			commentIDs = append(commentIDs, comment.ID)
+		}
+	}
+	if len(commentIDs) == 0 {
+		return nil
+	}
+
+	applied, err := GetAppliedSuggestions(ctx, commentIDs)
+	if err != nil {
+		return err
+	}
+	type key struct {
+		commentID int64
+		index     int
+	}
+	appliedMap := make(map[key]*AppliedSuggestion, len(applied))
+	for _, a := range applied {
+		appliedMap[key{a.CommentID, a.Index}] = a
+	}
+	for _, comment := range comments {
+		for _, suggestion := range comment.CodeSuggestions {
+			suggestion.Applied = appliedMap[key{comment.ID, suggestion.Index}]
+		}
+	}
+	return nil
+}
+
+// GetAppliedSuggestions returns the applied suggestions of comments, with who applied them
+func GetAppliedSuggestions(ctx context.Context, commentIDs []int64) ([]*AppliedSuggestion, error) {
+	applied := make([]*AppliedSuggestion, 0, len(commentIDs))
+	if err := db.GetEngine(ctx).Where(builder.In("comment_id", commentIDs)).Find(&applied); err != nil {
+		return nil, err
+	}
+
+	doerIDs := make(container.Set[int64], len(applied))
+	for _, a := range applied {
+		doerIDs.Add(a.DoerID)
+	}
+	doers, err := user_model.GetUserByIDs(ctx, doerIDs.Values())
+	if err != nil {
+		return nil, err
+	}
+	doerMap := make(map[int64]*user_model.User, len(doers))
+	for _, doer := range doers {
+		doerMap[doer.ID] = doer
+	}
+	for _, a := range applied {
+		if a.Doer = doerMap[a.DoerID]; a.Doer == nil {
+			a.Doer = user_model.NewGhostUser()
+		}
+	}
+	return applied, nil
+}
+
+// CreateAppliedSuggestions records the suggestions applied by a commit
+func CreateAppliedSuggestions(ctx context.Context, applied []*AppliedSuggestion) error {
+	return db.Insert(ctx, applied)
+}
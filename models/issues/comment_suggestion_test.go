@@ -0,0 +1,49 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "forgejo.org/models/issues"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeSuggestions(t *testing.T) {
+	content := "Maybe:\n```suggestion\nfoo := 1\r\nbar := 2\n```\nor removing it:\n````suggestion\n````\n```go\nnot a suggestion\n```\n```suggestion\nnot closed"
+	suggestions := issues_model.ParseCodeSuggestions(content)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, 0, suggestions[0].Index)
+	assert.Equal(t, []string{"foo := 1", "bar := 2"}, suggestions[0].Lines)
+	assert.Equal(t, 1, suggestions[1].Index)
+	assert.Empty(t, suggestions[1].Lines)
+}
+
+func TestRenderCodeSuggestions(t *testing.T) {
+	assert.Equal(t, "no suggestion", issues_model.RenderCodeSuggestions("no suggestion", []string{"old"}))
+	assert.Equal(t,
+		"Maybe:\n```diff\n-old := 0\n+foo := 1\n+bar := 2\n```\nthanks",
+		issues_model.RenderCodeSuggestions("Maybe:\n```suggestion\nfoo := 1\nbar := 2\n```\nthanks", []string{"old := 0"}))
+}
+
+func TestCommentCommentedLines(t *testing.T) {
+	patch := "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,3 @@\n package main\n+\n+var old = 0\n"
+	comment := &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: 3, Patch: patch}
+	assert.Equal(t, []string{"var old = 0"}, comment.CommentedLines())
+
+	comment.StartLine = 1
+	assert.Equal(t, []string{"package main", "", "var old = 0"}, comment.CommentedLines())
+
+	// the patch does not contain all the lines of the range
+	comment.Patch = "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1,1 +2,2 @@\n+\n+var old = 0\n"
+	assert.Empty(t, comment.CommentedLines())
+
+	comment.Line = -2
+	assert.Empty(t, comment.CommentedLines())
+
+	comment = &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: 3}
+	assert.Empty(t, comment.CommentedLines())
+}
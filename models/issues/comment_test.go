@@ -65,6 +65,23 @@ func TestFetchCodeConversations(t *testing.T) {
 	assert.Len(t, res, 1)
 }
 
+func TestCountUnresolvedCodeConversations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// the comments of the pending review 4 do not count
+	count, err := issues_model.CountUnresolvedCodeConversations(db.DefaultContext, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 5})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	require.NoError(t, issues_model.MarkConversation(db.DefaultContext, comment, user, true))
+
+	count, err = issues_model.CountUnresolvedCodeConversations(db.DefaultContext, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
 func TestAsCommentType(t *testing.T) {
 	assert.Equal(t, issues_model.CommentTypeComment, issues_model.CommentType(0))
 	assert.Equal(t, issues_model.CommentTypeUndefined, issues_model.AsCommentType(""))
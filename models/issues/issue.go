@@ -133,6 +133,9 @@ type Issue struct {
 	// with write access
 	IsLocked bool `xorm:"NOT NULL DEFAULT false"`
 
+	// IsHidden is set when a moderator hid the content of the issue
+	IsHidden bool `xorm:"NOT NULL DEFAULT false"`
+
 	// For view issue page.
 	ShowRole RoleDescriptor `xorm:"-"`
 }
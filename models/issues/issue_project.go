@@ -30,7 +30,8 @@ func (issue *Issue) LoadProject(ctx context.Context) (err error) {
 	return err
 }
 
-func (issue *Issue) projectID(ctx context.Context) int64 {
+// ProjectID return the id of the project the issue was assigned to, if any
+func (issue *Issue) ProjectID(ctx context.Context) int64 {
 	var ip project_model.ProjectIssue
 	has, err := db.GetEngine(ctx).Where("issue_id=?", issue.ID).Get(&ip)
 	if err != nil || !has {
@@ -102,7 +103,7 @@ func LoadIssuesFromColumnList(ctx context.Context, bs project_model.ColumnList,
 // If newProjectID is 0, the issue is removed from the project
 func IssueAssignOrRemoveProject(ctx context.Context, issue *Issue, doer *user_model.User, newProjectID, newColumnID int64) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
-		oldProjectID := issue.projectID(ctx)
+		oldProjectID := issue.ProjectID(ctx)
 
 		if err := issue.LoadRepo(ctx); err != nil {
 			return err
@@ -36,6 +36,8 @@ type IssuesOptions struct { //nolint
 	MilestoneIDs       []int64
 	ProjectID          int64
 	ProjectColumnID    int64
+	ProjectFieldValues map[int64]string // values of the custom fields of projects by field id
+	ParentIssueID      int64
 	IsClosed           optional.Option[bool]
 	IsPull             optional.Option[bool]
 	LabelIDs           []int64
@@ -194,6 +196,22 @@ func applyProjectColumnCondition(sess *xorm.Session, opts *IssuesOptions) {
 	}
 }
 
+func applyProjectFieldConditions(sess *xorm.Session, opts *IssuesOptions) {
+	for fieldID, value := range opts.ProjectFieldValues {
+		sess.In("issue.id", builder.Select("issue_id").From("project_field_value").Where(builder.Eq{"field_id": fieldID, "value": value}))
+	}
+}
+
+func applyParentIssueCondition(sess *xorm.Session, opts *IssuesOptions) {
+	// opts.ParentIssueID == 0 means any parent or none,
+	// do not need to apply any condition
+	if opts.ParentIssueID > 0 {
+		sess.In("issue.id", builder.Select("issue_id").From("sub_issue").Where(builder.Eq{"parent_id": opts.ParentIssueID}))
+	} else if opts.ParentIssueID == db.NoConditionID {
+		sess.NotIn("issue.id", builder.Select("issue_id").From("sub_issue"))
+	}
+}
+
 func applyRepoConditions(sess *xorm.Session, opts *IssuesOptions) {
 	if len(opts.RepoIDs) == 1 {
 		opts.RepoCond = builder.Eq{"issue.repo_id": opts.RepoIDs[0]}
@@ -262,6 +280,10 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyProjectColumnCondition(sess, opts)
 
+	applyProjectFieldConditions(sess, opts)
+
+	applyParentIssueCondition(sess, opts)
+
 	if opts.IsPull.Has() {
 		sess.And("issue.is_pull=?", opts.IsPull.Value())
 	}
@@ -697,6 +697,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&AppliedSuggestion{})
+		if err != nil {
+			return nil, err
+		}
+
 		// Dependencies for issues in this repository
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueDependency{})
 		if err != nil {
@@ -709,6 +714,17 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		// Sub-issues in this repository, and in other repositories
+		_, err = sess.In("issue_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("parent_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueUser{})
 		if err != nil {
 			return nil, err
@@ -739,6 +755,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&project_model.FieldValue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("dependent_issue_id", issueIDs).Delete(&Comment{})
 		if err != nil {
 			return nil, err
@@ -965,6 +965,11 @@ type CodeOwnerRule struct {
 	Teams    []*org_model.Team
 }
 
+// Match returns true if the rule applies to a path
+func (rule *CodeOwnerRule) Match(path string) bool {
+	return rule.Rule.MatchString(path) != rule.Negative
+}
+
 func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule, []string) {
 	var err error
 	rule := &CodeOwnerRule{
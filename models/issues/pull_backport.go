@@ -0,0 +1,113 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ErrPullBackportNotExist represents a "PullBackportNotExist" kind of error.
+type ErrPullBackportNotExist struct {
+	ID int64
+}
+
+// IsErrPullBackportNotExist checks if an error is a ErrPullBackportNotExist.
+func IsErrPullBackportNotExist(err error) bool {
+	_, ok := err.(ErrPullBackportNotExist)
+	return ok
+}
+
+func (err ErrPullBackportNotExist) Error() string {
+	return fmt.Sprintf("pull request backport does not exist [id: %d]", err.ID)
+}
+
+func (err ErrPullBackportNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// PullBackportStatus is the status of the backport of a pull request
+type PullBackportStatus string
+
+const (
+	// PullBackportPending backports wait for the pull request to be merged or for the worker
+	PullBackportPending PullBackportStatus = "pending"
+	// PullBackportDone backports have their pull request opened against the target branch
+	PullBackportDone PullBackportStatus = "done"
+	// PullBackportFailed backports could not be cherry-picked, usually because of conflicts
+	PullBackportFailed PullBackportStatus = "failed"
+)
+
+// PullBackport is a request to backport the commits of a pull request to another branch once it is merged
+type PullBackport struct {
+	ID           int64              `xorm:"pk autoincr"`
+	PullID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	TargetBranch string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	DoerID       int64              `xorm:"NOT NULL"`
+	Status       PullBackportStatus `xorm:"VARCHAR(20) NOT NULL DEFAULT 'pending'"`
+	// BackportPullID is the pull request opened against the target branch
+	BackportPullID int64  `xorm:"NOT NULL DEFAULT 0"`
+	Error          string `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(PullBackport))
+}
+
+// RequestPullBackport requests the backport of a pull request to a branch. Requesting again a failed backport
+// makes it pending again, the other backports already requested are returned as they are.
+func RequestPullBackport(ctx context.Context, pullID int64, targetBranch string, doerID int64) (*PullBackport, error) {
+	backport := &PullBackport{PullID: pullID, TargetBranch: targetBranch}
+	return backport, db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Get(backport)
+		if err != nil {
+			return err
+		}
+		if !has {
+			backport.DoerID = doerID
+			backport.Status = PullBackportPending
+			_, err := db.GetEngine(ctx).Insert(backport)
+			return err
+		}
+		if backport.Status != PullBackportFailed {
+			return nil
+		}
+		backport.DoerID = doerID
+		backport.Status = PullBackportPending
+		backport.Error = ""
+		_, err = db.GetEngine(ctx).ID(backport.ID).Cols("doer_id", "status", "error").Update(backport)
+		return err
+	})
+}
+
+// GetPullBackportByID returns the backport with the given ID
+func GetPullBackportByID(ctx context.Context, id int64) (*PullBackport, error) {
+	backport := new(PullBackport)
+	has, err := db.GetEngine(ctx).ID(id).Get(backport)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrPullBackportNotExist{ID: id}
+	}
+	return backport, nil
+}
+
+// GetPullBackports returns the backports requested for a pull request
+func GetPullBackports(ctx context.Context, pullID int64) ([]*PullBackport, error) {
+	backports := make([]*PullBackport, 0, 2)
+	return backports, db.GetEngine(ctx).Where("pull_id = ?", pullID).Asc("id").Find(&backports)
+}
+
+// UpdatePullBackportResult records the result of a backport
+func UpdatePullBackportResult(ctx context.Context, backport *PullBackport) error {
+	_, err := db.GetEngine(ctx).ID(backport.ID).Cols("status", "backport_pull_id", "error").Update(backport)
+	return err
+}
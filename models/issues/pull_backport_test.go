@@ -0,0 +1,52 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestPullBackport(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	backport, err := issues_model.RequestPullBackport(db.DefaultContext, 2, "release/1.0", 1)
+	require.NoError(t, err)
+	assert.Equal(t, issues_model.PullBackportPending, backport.Status)
+
+	// requesting it again returns the same backport
+	again, err := issues_model.RequestPullBackport(db.DefaultContext, 2, "release/1.0", 2)
+	require.NoError(t, err)
+	assert.Equal(t, backport.ID, again.ID)
+	assert.EqualValues(t, 1, again.DoerID)
+
+	backport.Status = issues_model.PullBackportFailed
+	backport.Error = "conflict"
+	require.NoError(t, issues_model.UpdatePullBackportResult(db.DefaultContext, backport))
+
+	// a failed backport is pending again when requested again
+	again, err = issues_model.RequestPullBackport(db.DefaultContext, 2, "release/1.0", 2)
+	require.NoError(t, err)
+	assert.Equal(t, backport.ID, again.ID)
+	assert.Equal(t, issues_model.PullBackportPending, again.Status)
+	assert.EqualValues(t, 2, again.DoerID)
+	assert.Empty(t, again.Error)
+
+	_, err = issues_model.RequestPullBackport(db.DefaultContext, 2, "release/2.0", 1)
+	require.NoError(t, err)
+	backports, err := issues_model.GetPullBackports(db.DefaultContext, 2)
+	require.NoError(t, err)
+	require.Len(t, backports, 2)
+	assert.Equal(t, "release/1.0", backports[0].TargetBranch)
+	assert.Equal(t, "release/2.0", backports[1].TargetBranch)
+
+	_, err = issues_model.GetPullBackportByID(db.DefaultContext, 1000)
+	assert.True(t, issues_model.IsErrPullBackportNotExist(err))
+}
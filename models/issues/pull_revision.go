@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/timeutil"
+)
+
+// PullRequestRevision is a head commit a pull request had, from its creation or after a push to its head branch
+type PullRequestRevision struct {
+	// Index is the 1-based position of the revision in the history of the pull request
+	Index        int
+	HeadCommitID string
+	IsForcePush  bool
+	// Pusher, CommentID and CreatedUnix are not set for a revision which is only known as the head which
+	// was replaced by a force push
+	Pusher      *user_model.User
+	CommentID   int64
+	CreatedUnix timeutil.TimeStamp
+}
+
+// GetPullRequestRevisions returns the revisions of a pull request, oldest first. They are taken from the
+// push comments: the one made when the pull request was created lists all its commits, the ones of later
+// pushes list the pushed commits or, for force pushes, the old and the new head commits.
+func GetPullRequestRevisions(ctx context.Context, pr *PullRequest) ([]*PullRequestRevision, error) {
+	var comments CommentList
+	if err := db.GetEngine(ctx).
+		Where("issue_id = ? AND type = ?", pr.IssueID, CommentTypePullRequestPush).
+		Asc("created_unix", "id").
+		Find(&comments); err != nil {
+		return nil, err
+	}
+	if err := comments.LoadPosters(ctx); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*PullRequestRevision, 0, len(comments))
+	for _, comment := range comments {
+		var data PushActionContent
+		if err := json.Unmarshal([]byte(comment.Content), &data); err != nil || len(data.CommitIDs) == 0 {
+			continue
+		}
+		revision := &PullRequestRevision{
+			HeadCommitID: data.CommitIDs[len(data.CommitIDs)-1],
+			IsForcePush:  data.IsForcePush,
+			Pusher:       comment.Poster,
+			CommentID:    comment.ID,
+			CreatedUnix:  comment.CreatedUnix,
+		}
+		if data.IsForcePush && len(data.CommitIDs) == 2 &&
+			(len(revisions) == 0 || revisions[len(revisions)-1].HeadCommitID != data.CommitIDs[0]) {
+			// the head before the force push is not known from an earlier comment
+			revisions = append(revisions, &PullRequestRevision{HeadCommitID: data.CommitIDs[0]})
+		}
+		revisions = append(revisions, revision)
+	}
+	for i, revision := range revisions {
+		revision.Index = i + 1
+	}
+	return revisions, nil
+}
+
+// GetPullRequestRevision returns the revision of a pull request with the given head commit, or nil
+func GetPullRequestRevision(revisions []*PullRequestRevision, headCommitID string) *PullRequestRevision {
+	for _, revision := range revisions {
+		if revision.HeadCommitID == headCommitID {
+			return revision
+		}
+	}
+	return nil
+}
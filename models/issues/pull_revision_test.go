@@ -0,0 +1,62 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPullRequestRevisions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	require.NoError(t, pr.LoadIssue(db.DefaultContext))
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pr.BaseRepoID})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	revisions, err := issues_model.GetPullRequestRevisions(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	for _, content := range []string{
+		`{"is_force_push":false,"commit_ids":["aaa","bbb"]}`,
+		`{"is_force_push":false,"commit_ids":["ccc"]}`,
+		`{"is_force_push":true,"commit_ids":["ccc","ddd"]}`,
+		`{"is_force_push":true,"commit_ids":["eee","fff"]}`,
+	} {
+		_, err := issues_model.CreateComment(db.DefaultContext, &issues_model.CreateCommentOptions{
+			Type:    issues_model.CommentTypePullRequestPush,
+			Doer:    doer,
+			Repo:    repo,
+			Issue:   pr.Issue,
+			Content: content,
+		})
+		require.NoError(t, err)
+	}
+
+	revisions, err = issues_model.GetPullRequestRevisions(db.DefaultContext, pr)
+	require.NoError(t, err)
+	heads := make([]string, 0, len(revisions))
+	for i, revision := range revisions {
+		assert.Equal(t, i+1, revision.Index)
+		heads = append(heads, revision.HeadCommitID)
+	}
+	// eee is only known as the head replaced by the last force push
+	assert.Equal(t, []string{"bbb", "ccc", "ddd", "eee", "fff"}, heads)
+	assert.True(t, revisions[2].IsForcePush)
+	assert.Nil(t, revisions[3].Pusher)
+	assert.Equal(t, doer.ID, revisions[4].Pusher.ID)
+
+	assert.Equal(t, revisions[1], issues_model.GetPullRequestRevision(revisions, "ccc"))
+	assert.Nil(t, issues_model.GetPullRequestRevision(revisions, "zzz"))
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"slices"
+
+	"forgejo.org/modules/container"
+)
+
+// getStackParent returns the open pull request a pull request is stacked on, the one whose head branch is
+// its base branch in the same repository, or nil
+func getStackParent(ctx context.Context, pr *PullRequest) (*PullRequest, error) {
+	prs, err := GetUnmergedPullRequestsByHeadInfo(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(parent *PullRequest) bool {
+		return parent.BaseRepoID != pr.BaseRepoID || parent.ID == pr.ID
+	})
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return slices.MinFunc(prs, func(a, b *PullRequest) int { return int(a.Index - b.Index) }), nil
+}
+
+// getStackChildren returns the open pull requests stacked on a pull request, ordered by index
+func getStackChildren(ctx context.Context, pr *PullRequest) ([]*PullRequest, error) {
+	if pr.HeadRepoID != pr.BaseRepoID || pr.Flow != PullRequestFlowGithub {
+		return nil, nil
+	}
+	prs, err := GetUnmergedPullRequestsByBaseInfo(ctx, pr.HeadRepoID, pr.HeadBranch)
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(child *PullRequest) bool { return child.ID == pr.ID })
+	slices.SortFunc(prs, func(a, b *PullRequest) int { return int(a.Index - b.Index) })
+	return prs, nil
+}
+
+// GetPullRequestStack returns the stack of open pull requests a pull request belongs to, from the bottom of
+// the stack to its top: the pull requests it is stacked on, itself, then the pull requests stacked on it,
+// depth first. A pull request is stacked on another one when its base branch is the head branch of the
+// other one in the same repository. The stack is only the pull request itself when it is not stacked.
+func GetPullRequestStack(ctx context.Context, pr *PullRequest) (PullRequestList, error) {
+	seen := make(container.Set[int64])
+	seen.Add(pr.ID)
+
+	var ancestors PullRequestList
+	for current := pr; ; {
+		parent, err := getStackParent(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || !seen.Add(parent.ID) {
+			break
+		}
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+	slices.Reverse(ancestors)
+
+	stack := append(ancestors, pr)
+	var addDescendants func(parent *PullRequest) error
+	addDescendants = func(parent *PullRequest) error {
+		children, err := getStackChildren(ctx, parent)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if !seen.Add(child.ID) {
+				continue
+			}
+			stack = append(stack, child)
+			if err := addDescendants(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := addDescendants(pr); err != nil {
+		return nil, err
+	}
+
+	if err := stack.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
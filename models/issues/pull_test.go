@@ -423,6 +423,19 @@ func TestParseCodeOwnersLine(t *testing.T) {
 	}
 }
 
+func TestCodeOwnerRuleMatch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	rules, _ := issues_model.GetCodeOwnersFromContent(db.DefaultContext, "docs/.* @user2\n!.*\\\\.go @user5\n")
+	require.Len(t, rules, 2)
+
+	assert.True(t, rules[0].Match("docs/index.md"))
+	assert.False(t, rules[0].Match("README.md"))
+	assert.True(t, rules[1].Match("README.md"))
+	assert.True(t, rules[1].Match("main_go"))
+	assert.False(t, rules[1].Match("main.go"))
+}
+
 func TestGetApprovers(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
@@ -473,3 +486,24 @@ func TestMigrate_InsertPullRequests(t *testing.T) {
 
 	unittest.CheckConsistencyFor(t, &issues_model.Issue{}, &issues_model.PullRequest{})
 }
+
+func TestGetPullRequestStack(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	stackIDs := func(pr *issues_model.PullRequest) []int64 {
+		stack, err := issues_model.GetPullRequestStack(db.DefaultContext, pr)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(stack))
+		for _, pr := range stack {
+			assert.NotNil(t, pr.Issue)
+			ids = append(ids, pr.ID)
+		}
+		return ids
+	}
+
+	// pull request 5 is based on branch2, the head branch of pull request 2
+	assert.Equal(t, []int64{2, 5}, stackIDs(unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})))
+	assert.Equal(t, []int64{2, 5}, stackIDs(unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})))
+
+	assert.Equal(t, []int64{6}, stackIDs(unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 6})))
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/robfig/cron/v3"
+	"xorm.io/builder"
+)
+
+// ErrRecurringIssueNotExist represents a "RecurringIssueNotExist" kind of error.
+type ErrRecurringIssueNotExist struct {
+	ID int64
+}
+
+// IsErrRecurringIssueNotExist checks if an error is a ErrRecurringIssueNotExist.
+func IsErrRecurringIssueNotExist(err error) bool {
+	_, ok := err.(ErrRecurringIssueNotExist)
+	return ok
+}
+
+func (err ErrRecurringIssueNotExist) Error() string {
+	return fmt.Sprintf("recurring issue does not exist [id: %d]", err.ID)
+}
+
+func (err ErrRecurringIssueNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// RecurringIssue is the definition of an issue created in a repository on a schedule
+type RecurringIssue struct {
+	ID      int64  `xorm:"pk autoincr"`
+	RepoID  int64  `xorm:"INDEX NOT NULL"`
+	Title   string `xorm:"VARCHAR(255) NOT NULL"`
+	Content string `xorm:"LONGTEXT"`
+	// Labels and Assignees are the names of the labels and of the users of the created issues
+	Labels    []string `xorm:"JSON TEXT"`
+	Assignees []string `xorm:"JSON TEXT"`
+	// Schedule is a cron expression, in UTC unless it starts with CRON_TZ=
+	Schedule string `xorm:"VARCHAR(255) NOT NULL"`
+	// SkipIfOpen skips the creation of an issue while the last created issue is still open
+	SkipIfOpen bool  `xorm:"NOT NULL DEFAULT false"`
+	IsActive   bool  `xorm:"NOT NULL DEFAULT true"`
+	CreatorID  int64 `xorm:"NOT NULL"`
+	// LastIssueID is the ID of the last created issue
+	LastIssueID int64              `xorm:"NOT NULL DEFAULT 0"`
+	LastRunUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// NextRunUnix is when the next issue is due, 0 for inactive definitions
+	NextRunUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// RecurringIssueRun records a run of a recurring issue, with the issue it created or why it did not
+type RecurringIssueRun struct {
+	ID               int64  `xorm:"pk autoincr"`
+	RecurringIssueID int64  `xorm:"INDEX NOT NULL"`
+	IssueID          int64  `xorm:"NOT NULL DEFAULT 0"`
+	Issue            *Issue `xorm:"-"`
+	// Skipped runs did not create an issue because the last one was still open
+	Skipped     bool               `xorm:"NOT NULL DEFAULT false"`
+	Error       string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(RecurringIssue))
+	db.RegisterModel(new(RecurringIssueRun))
+}
+
+// ParseRecurringIssueSchedule parses the schedule of a recurring issue, which is a cron expression of
+// five fields or a descriptor such as @weekly. Like the schedules of the actions, it is in UTC unless a
+// time zone is specified.
+func ParseRecurringIssueSchedule(spec string) (cron.Schedule, error) {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	schedule, err := parser.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return schedule, nil
+	}
+	if specSchedule, ok := schedule.(*cron.SpecSchedule); ok {
+		specSchedule.Location = time.UTC
+	}
+	return schedule, nil
+}
+
+// NextRun returns when the next issue is due after a time, or 0 if the recurring issue is inactive
+func (r *RecurringIssue) NextRun(after time.Time) (timeutil.TimeStamp, error) {
+	if !r.IsActive {
+		return 0, nil
+	}
+	schedule, err := ParseRecurringIssueSchedule(r.Schedule)
+	if err != nil {
+		return 0, err
+	}
+	next := schedule.Next(after)
+	if next.IsZero() {
+		return 0, nil
+	}
+	return timeutil.TimeStamp(next.Unix()), nil
+}
+
+// CreateRecurringIssue creates a recurring issue
+func CreateRecurringIssue(ctx context.Context, r *RecurringIssue) error {
+	return db.Insert(ctx, r)
+}
+
+// UpdateRecurringIssue updates the settings of a recurring issue
+func UpdateRecurringIssue(ctx context.Context, r *RecurringIssue) error {
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("title", "content", "labels", "assignees", "schedule", "skip_if_open", "is_active", "next_run_unix").Update(r)
+	return err
+}
+
+// GetRecurringIssueByID returns the recurring issue with an ID
+func GetRecurringIssueByID(ctx context.Context, id int64) (*RecurringIssue, error) {
+	r, exist, err := db.GetByID[RecurringIssue](ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, ErrRecurringIssueNotExist{id}
+	}
+	return r, nil
+}
+
+// GetRecurringIssueByRepoID returns the recurring issue with an ID in a repository
+func GetRecurringIssueByRepoID(ctx context.Context, repoID, id int64) (*RecurringIssue, error) {
+	r, err := GetRecurringIssueByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.RepoID != repoID {
+		return nil, ErrRecurringIssueNotExist{id}
+	}
+	return r, nil
+}
+
+// DeleteRecurringIssue deletes a recurring issue and its history, but not the issues it created
+func DeleteRecurringIssue(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&RecurringIssueRun{RecurringIssueID: id}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[RecurringIssue](ctx, id)
+		return err
+	})
+}
+
+// DeleteRecurringIssuesByRepoID deletes the recurring issues of a repository and their history
+func DeleteRecurringIssuesByRepoID(ctx context.Context, repoID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		ids := builder.Select("id").From("recurring_issue").Where(builder.Eq{"repo_id": repoID})
+		if _, err := db.GetEngine(ctx).Where(builder.In("recurring_issue_id", ids)).Delete(new(RecurringIssueRun)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}).Delete(new(RecurringIssue))
+		return err
+	})
+}
+
+// FindRecurringIssuesOptions represents the options to find the recurring issues of a repository
+type FindRecurringIssuesOptions struct {
+	db.ListOptions
+	RepoID int64
+}
+
+func (opts FindRecurringIssuesOptions) ToConds() builder.Cond {
+	return builder.Eq{"repo_id": opts.RepoID}
+}
+
+func (opts FindRecurringIssuesOptions) ToOrders() string {
+	return "title ASC, id ASC"
+}
+
+// GetDueRecurringIssues returns the active recurring issues which are due at a time, by ID after an ID
+func GetDueRecurringIssues(ctx context.Context, now timeutil.TimeStamp, afterID int64, limit int) ([]*RecurringIssue, error) {
+	rs := make([]*RecurringIssue, 0, limit)
+	return rs, db.GetEngine(ctx).
+		Where(builder.Eq{"is_active": true}.And(builder.Gt{"next_run_unix": 0}, builder.Lte{"next_run_unix": now}, builder.Gt{"id": afterID})).
+		OrderBy("id ASC").
+		Limit(limit).
+		Find(&rs)
+}
+
+// UpdateRecurringIssueRun saves the last run of a recurring issue and when the next issue is due
+func UpdateRecurringIssueRun(ctx context.Context, r *RecurringIssue) error {
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("last_issue_id", "last_run_unix", "next_run_unix").NoAutoTime().Update(r)
+	return err
+}
+
+// CreateRecurringIssueRun records a run of a recurring issue
+func CreateRecurringIssueRun(ctx context.Context, run *RecurringIssueRun) error {
+	return db.Insert(ctx, run)
+}
+
+// FindRecurringIssueRunsOptions represents the options to find the history of a recurring issue
+type FindRecurringIssueRunsOptions struct {
+	db.ListOptions
+	RecurringIssueID int64
+}
+
+func (opts FindRecurringIssueRunsOptions) ToConds() builder.Cond {
+	return builder.Eq{"recurring_issue_id": opts.RecurringIssueID}
+}
+
+func (opts FindRecurringIssueRunsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
+
+// LoadRecurringIssueRunIssues loads the issues created by runs of recurring issues, the runs which did
+// not create an issue or whose issue was deleted are left without issue
+func LoadRecurringIssueRunIssues(ctx context.Context, runs []*RecurringIssueRun) error {
+	issueIDs := make([]int64, 0, len(runs))
+	for _, run := range runs {
+		if run.IssueID > 0 {
+			issueIDs = append(issueIDs, run.IssueID)
+		}
+	}
+	issues, err := GetIssuesByIDs(ctx, issueIDs)
+	if err != nil {
+		return err
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return err
+	}
+	issueMap := make(map[int64]*Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	for _, run := range runs {
+		run.Issue = issueMap[run.IssueID]
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurringIssueSchedule(t *testing.T) {
+	from := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC) // a Thursday
+
+	schedule, err := issues_model.ParseRecurringIssueSchedule("0 9 * * 1")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC), schedule.Next(from))
+
+	schedule, err = issues_model.ParseRecurringIssueSchedule("@daily")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC), schedule.Next(from).UTC())
+
+	_, err = issues_model.ParseRecurringIssueSchedule("0 0 9 * * 1")
+	require.Error(t, err)
+	_, err = issues_model.ParseRecurringIssueSchedule("")
+	require.Error(t, err)
+}
+
+func TestGetDueRecurringIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	due := &issues_model.RecurringIssue{RepoID: 1, Title: "Due", Schedule: "@daily", IsActive: true, CreatorID: 2, NextRunUnix: now - 60}
+	notDue := &issues_model.RecurringIssue{RepoID: 1, Title: "Not due", Schedule: "@daily", IsActive: true, CreatorID: 2, NextRunUnix: now + 60}
+	inactive := &issues_model.RecurringIssue{RepoID: 1, Title: "Inactive", Schedule: "@daily", CreatorID: 2}
+	for _, r := range []*issues_model.RecurringIssue{due, notDue, inactive} {
+		require.NoError(t, issues_model.CreateRecurringIssue(db.DefaultContext, r))
+	}
+
+	rs, err := issues_model.GetDueRecurringIssues(db.DefaultContext, now, 0, 10)
+	require.NoError(t, err)
+	if assert.Len(t, rs, 1) {
+		assert.Equal(t, due.ID, rs[0].ID)
+	}
+	rs, err = issues_model.GetDueRecurringIssues(db.DefaultContext, now, due.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, rs)
+
+	_, err = issues_model.GetRecurringIssueByRepoID(db.DefaultContext, 2, due.ID)
+	assert.True(t, issues_model.IsErrRecurringIssueNotExist(err))
+
+	require.NoError(t, issues_model.CreateRecurringIssueRun(db.DefaultContext, &issues_model.RecurringIssueRun{RecurringIssueID: due.ID, Skipped: true}))
+	require.NoError(t, issues_model.DeleteRecurringIssue(db.DefaultContext, due.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.RecurringIssue{ID: due.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.RecurringIssueRun{RecurringIssueID: due.ID})
+}
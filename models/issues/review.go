@@ -136,6 +136,8 @@ type Review struct {
 	CommitID  string `xorm:"VARCHAR(64)"`
 	Stale     bool   `xorm:"NOT NULL DEFAULT false"`
 	Dismissed bool   `xorm:"NOT NULL DEFAULT false"`
+	// Checklist are the items of the review checklist of the repository ticked by the reviewer
+	Checklist []string `xorm:"JSON TEXT"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
@@ -252,6 +254,7 @@ type CreateReviewOptions struct {
 	Official     bool
 	CommitID     string
 	Stale        bool
+	Checklist    []string
 }
 
 // IsOfficialReviewer check if at least one of the provided reviewers can make official reviews in issue (counts towards required approvals)
@@ -324,6 +327,7 @@ func CreateReview(ctx context.Context, opts CreateReviewOptions) (*Review, error
 		Official:     opts.Official,
 		CommitID:     opts.CommitID,
 		Stale:        opts.Stale,
+		Checklist:    opts.Checklist,
 	}
 
 	if opts.Reviewer != nil {
@@ -398,7 +402,7 @@ func IsContentEmptyErr(err error) bool {
 }
 
 // SubmitReview creates a review out of the existing pending review or creates a new one if no pending review exist
-func SubmitReview(ctx context.Context, doer *user_model.User, issue *Issue, reviewType ReviewType, content, commitID string, stale bool, attachmentUUIDs []string) (*Review, *Comment, error) {
+func SubmitReview(ctx context.Context, doer *user_model.User, issue *Issue, reviewType ReviewType, content, commitID string, stale bool, checklist []string, attachmentUUIDs []string) (*Review, *Comment, error) {
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -430,13 +434,14 @@ func SubmitReview(ctx context.Context, doer *user_model.User, issue *Issue, revi
 
 		// No current review. Create a new one!
 		if review, err = CreateReview(ctx, CreateReviewOptions{
-			Type:     reviewType,
-			Issue:    issue,
-			Reviewer: doer,
-			Content:  content,
-			Official: official,
-			CommitID: commitID,
-			Stale:    stale,
+			Type:      reviewType,
+			Issue:     issue,
+			Reviewer:  doer,
+			Content:   content,
+			Official:  official,
+			CommitID:  commitID,
+			Stale:     stale,
+			Checklist: checklist,
 		}); err != nil {
 			return nil, nil, err
 		}
@@ -464,8 +469,9 @@ func SubmitReview(ctx context.Context, doer *user_model.User, issue *Issue, revi
 		review.Type = reviewType
 		review.CommitID = commitID
 		review.Stale = stale
+		review.Checklist = checklist
 
-		if _, err := sess.ID(review.ID).Cols("content, type, official, commit_id, stale").Update(review); err != nil {
+		if _, err := sess.ID(review.ID).Cols("content, type, official, commit_id, stale, checklist").Update(review); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -759,6 +765,31 @@ func restoreLatestOfficialReview(ctx context.Context, issueID, reviewerID int64)
 	return nil
 }
 
+// CountPendingReviewRequests returns, for each of the users, the number of open pull requests they are
+// requested to review and did not review yet
+func CountPendingReviewRequests(ctx context.Context, userIDs []int64) (map[int64]int, error) {
+	type pendingCount struct {
+		ReviewerID int64
+		Count      int
+	}
+	pendingCounts := make([]*pendingCount, 0, len(userIDs))
+	if err := db.GetEngine(ctx).Table("review").
+		Select("review.reviewer_id, count(review.id) as `count`").
+		Join("INNER", "issue", "issue.id = review.issue_id").
+		In("review.reviewer_id", userIDs).
+		And("review.type = ? AND issue.is_closed = ?", ReviewTypeRequest, false).
+		GroupBy("review.reviewer_id").
+		Find(&pendingCounts); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int, len(pendingCounts))
+	for _, c := range pendingCounts {
+		counts[c.ReviewerID] = c.Count
+	}
+	return counts, nil
+}
+
 // AddTeamReviewRequest add a review request from one team
 func AddTeamReviewRequest(ctx context.Context, issue *Issue, reviewer *organization.Team, doer *user_model.User) (*Comment, error) {
 	ctx, committer, err := db.TxContext(ctx)
@@ -0,0 +1,412 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSavedSearchNotExist represents a "SavedSearchNotExist" kind of error.
+type ErrSavedSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedSearchNotExist checks if an error is a ErrSavedSearchNotExist.
+func IsErrSavedSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedSearchNotExist) Error() string {
+	return fmt.Sprintf("saved search does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedSearchNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrSavedSearchAlreadyExists represents a "SavedSearchAlreadyExists" kind of error.
+type ErrSavedSearchAlreadyExists struct {
+	OwnerID int64
+	Name    string
+}
+
+// IsErrSavedSearchAlreadyExists checks if an error is a ErrSavedSearchAlreadyExists.
+func IsErrSavedSearchAlreadyExists(err error) bool {
+	_, ok := err.(ErrSavedSearchAlreadyExists)
+	return ok
+}
+
+func (err ErrSavedSearchAlreadyExists) Error() string {
+	return fmt.Sprintf("saved search already exists [owner id: %d, name: %s]", err.OwnerID, err.Name)
+}
+
+func (err ErrSavedSearchAlreadyExists) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// SavedSearchFilter holds the filters of a saved search of issues and pull requests
+type SavedSearchFilter struct {
+	Keyword string
+	// Type is "issues", "pulls" or empty for both
+	Type string
+	// State is "open", "closed" or "all"
+	State      string
+	Labels     []string
+	Milestones []string
+	// Repos are full names of repositories (owner/name), all the accessible repositories if empty
+	Repos []string
+	// Assignee, Poster and Mentioned are user names
+	Assignee  string
+	Poster    string
+	Mentioned string
+	ProjectID int64
+	Sort      string
+}
+
+func splitFilterList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// ParseSavedSearchFilter reads the filters of a saved search from query parameters, using the same
+// names as the issue search of the API
+func ParseSavedSearchFilter(values url.Values) SavedSearchFilter {
+	f := SavedSearchFilter{
+		Keyword:    strings.TrimSpace(values.Get("q")),
+		Type:       values.Get("type"),
+		State:      values.Get("state"),
+		Labels:     splitFilterList(values.Get("labels")),
+		Milestones: splitFilterList(values.Get("milestones")),
+		Repos:      splitFilterList(values.Get("repos")),
+		Assignee:   strings.TrimSpace(values.Get("assignee")),
+		Poster:     strings.TrimSpace(values.Get("poster")),
+		Mentioned:  strings.TrimSpace(values.Get("mentioned")),
+		Sort:       values.Get("sort"),
+	}
+	f.ProjectID, _ = strconv.ParseInt(values.Get("project"), 10, 64)
+	if f.Type != "issues" && f.Type != "pulls" {
+		f.Type = ""
+	}
+	if f.State != "closed" && f.State != "all" {
+		f.State = "open"
+	}
+	return f
+}
+
+// Values returns the filters as query parameters
+func (f SavedSearchFilter) Values() url.Values {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("q", f.Keyword)
+	set("type", f.Type)
+	set("state", f.State)
+	set("labels", strings.Join(f.Labels, ","))
+	set("milestones", strings.Join(f.Milestones, ","))
+	set("repos", strings.Join(f.Repos, ","))
+	set("assignee", f.Assignee)
+	set("poster", f.Poster)
+	set("mentioned", f.Mentioned)
+	if f.ProjectID > 0 {
+		values.Set("project", strconv.FormatInt(f.ProjectID, 10))
+	}
+	set("sort", f.Sort)
+	return values
+}
+
+// SavedSearch is a named search of issues and pull requests across repositories which belongs
+// to a user or an organization
+type SavedSearch struct {
+	ID      int64            `xorm:"pk autoincr"`
+	OwnerID int64            `xorm:"UNIQUE(s) NOT NULL"`
+	Owner   *user_model.User `xorm:"-"`
+	Name    string           `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	// Query holds the filters encoded as query parameters
+	Query       string             `xorm:"TEXT"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SavedSearchSubscription records how a user follows a saved search: as a widget of their
+// dashboard and/or as a source of notifications for new matches
+type SavedSearchSubscription struct {
+	ID          int64 `xorm:"pk autoincr"`
+	SearchID    int64 `xorm:"UNIQUE(s) NOT NULL"`
+	UserID      int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	OnDashboard bool  `xorm:"NOT NULL DEFAULT false"`
+	Notify      bool  `xorm:"NOT NULL DEFAULT false"`
+	// LastCheckedUnix is the last time the search was run for notifications
+	LastCheckedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// SavedSearchMatch records an issue a user has already been notified about for a saved search
+type SavedSearchMatch struct {
+	ID             int64 `xorm:"pk autoincr"`
+	SubscriptionID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID        int64 `xorm:"UNIQUE(s) NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(SavedSearch))
+	db.RegisterModel(new(SavedSearchSubscription))
+	db.RegisterModel(new(SavedSearchMatch))
+}
+
+// Filter returns the decoded filters of the search
+func (s *SavedSearch) Filter() SavedSearchFilter {
+	values, _ := url.ParseQuery(s.Query)
+	return ParseSavedSearchFilter(values)
+}
+
+// SetFilter encodes the filters of the search
+func (s *SavedSearch) SetFilter(f SavedSearchFilter) {
+	s.Query = f.Values().Encode()
+}
+
+// LoadOwner loads the user or organization the search belongs to
+func (s *SavedSearch) LoadOwner(ctx context.Context) (err error) {
+	if s.Owner != nil {
+		return nil
+	}
+	s.Owner, err = user_model.GetUserByID(ctx, s.OwnerID)
+	return err
+}
+
+// CreateSavedSearch saves a new search with a name which is unique for its owner
+func CreateSavedSearch(ctx context.Context, s *SavedSearch) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Exist(&SavedSearch{OwnerID: s.OwnerID, Name: s.Name})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrSavedSearchAlreadyExists{s.OwnerID, s.Name}
+		}
+		return db.Insert(ctx, s)
+	})
+}
+
+// UpdateSavedSearch updates the name and the filters of a saved search
+func UpdateSavedSearch(ctx context.Context, s *SavedSearch) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("owner_id=? AND name=? AND id<>?", s.OwnerID, s.Name, s.ID).Exist(new(SavedSearch))
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrSavedSearchAlreadyExists{s.OwnerID, s.Name}
+		}
+		_, err = db.GetEngine(ctx).ID(s.ID).Cols("name", "query").Update(s)
+		return err
+	})
+}
+
+// DeleteSavedSearch deletes a saved search with its subscriptions
+func DeleteSavedSearch(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		subQuery := builder.Select("id").From("saved_search_subscription").Where(builder.Eq{"search_id": id})
+		if _, err := db.GetEngine(ctx).Where(builder.In("subscription_id", subQuery)).Delete(new(SavedSearchMatch)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Delete(&SavedSearchSubscription{SearchID: id}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[SavedSearch](ctx, id)
+		return err
+	})
+}
+
+// DeleteSavedSearchesByOwner deletes the saved searches of a user or organization
+func DeleteSavedSearchesByOwner(ctx context.Context, ownerID int64) error {
+	var ids []int64
+	if err := db.GetEngine(ctx).Table("saved_search").Where("owner_id=?", ownerID).Cols("id").Find(&ids); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := DeleteSavedSearch(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSavedSearchByID returns the saved search with an ID
+func GetSavedSearchByID(ctx context.Context, id int64) (*SavedSearch, error) {
+	s, exist, err := db.GetByID[SavedSearch](ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, ErrSavedSearchNotExist{id}
+	}
+	return s, nil
+}
+
+// FindSavedSearchesOptions represents the options to find saved searches
+type FindSavedSearchesOptions struct {
+	db.ListOptions
+	OwnerIDs []int64
+}
+
+func (opts FindSavedSearchesOptions) ToConds() builder.Cond {
+	return builder.In("owner_id", opts.OwnerIDs)
+}
+
+func (opts FindSavedSearchesOptions) ToOrders() string {
+	return "name ASC, id ASC"
+}
+
+// SavedSearchList is a list of saved searches
+type SavedSearchList []*SavedSearch
+
+// LoadOwners loads the owners of the saved searches
+func (list SavedSearchList) LoadOwners(ctx context.Context) error {
+	ownerIDs := make([]int64, 0, len(list))
+	for _, s := range list {
+		ownerIDs = append(ownerIDs, s.OwnerID)
+	}
+	owners, err := user_model.GetUsersByIDs(ctx, ownerIDs)
+	if err != nil {
+		return err
+	}
+	ownerMap := make(map[int64]*user_model.User, len(owners))
+	for _, owner := range owners {
+		ownerMap[owner.ID] = owner
+	}
+	for _, s := range list {
+		if s.Owner = ownerMap[s.OwnerID]; s.Owner == nil {
+			s.Owner = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
+
+// GetSavedSearchSubscription returns the subscription of a user to a saved search, which is empty
+// if the user does not follow it
+func GetSavedSearchSubscription(ctx context.Context, searchID, userID int64) (*SavedSearchSubscription, error) {
+	sub := &SavedSearchSubscription{SearchID: searchID, UserID: userID}
+	if _, err := db.GetEngine(ctx).Get(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// GetSavedSearchSubscriptionsByUser returns the subscriptions of a user to saved searches by search ID
+func GetSavedSearchSubscriptionsByUser(ctx context.Context, userID int64) (map[int64]*SavedSearchSubscription, error) {
+	subs := make(map[int64]*SavedSearchSubscription)
+	return subs, db.GetEngine(ctx).Where("user_id = ?", userID).Find(&subs)
+}
+
+// SetSavedSearchSubscription changes whether a user shows a saved search on their dashboard and
+// is notified about its new matches
+func SetSavedSearchSubscription(ctx context.Context, searchID, userID int64, onDashboard, notify bool) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		sub, err := GetSavedSearchSubscription(ctx, searchID, userID)
+		if err != nil {
+			return err
+		}
+		if !onDashboard && !notify {
+			if sub.ID == 0 {
+				return nil
+			}
+			if _, err := db.GetEngine(ctx).Delete(&SavedSearchMatch{SubscriptionID: sub.ID}); err != nil {
+				return err
+			}
+			_, err := db.DeleteByID[SavedSearchSubscription](ctx, sub.ID)
+			return err
+		}
+
+		// only issues which match after subscribing are notified
+		if notify && !sub.Notify {
+			sub.LastCheckedUnix = timeutil.TimeStampNow()
+		}
+		sub.OnDashboard = onDashboard
+		sub.Notify = notify
+		if sub.ID == 0 {
+			return db.Insert(ctx, sub)
+		}
+		_, err = db.GetEngine(ctx).ID(sub.ID).Cols("on_dashboard", "notify", "last_checked_unix").Update(sub)
+		return err
+	})
+}
+
+// DeleteSavedSearchSubscriptionsByUser deletes the subscriptions of a user to saved searches
+func DeleteSavedSearchSubscriptionsByUser(ctx context.Context, userID int64) error {
+	subQuery := builder.Select("id").From("saved_search_subscription").Where(builder.Eq{"user_id": userID})
+	if _, err := db.GetEngine(ctx).Where(builder.In("subscription_id", subQuery)).Delete(new(SavedSearchMatch)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Delete(&SavedSearchSubscription{UserID: userID})
+	return err
+}
+
+// GetDashboardSavedSearches returns the saved searches a user shows on their dashboard
+func GetDashboardSavedSearches(ctx context.Context, userID int64) (SavedSearchList, error) {
+	searches := make(SavedSearchList, 0, 5)
+	return searches, db.GetEngine(ctx).
+		Join("INNER", "saved_search_subscription", "saved_search_subscription.search_id = saved_search.id").
+		Where("saved_search_subscription.user_id = ? AND saved_search_subscription.on_dashboard = ?", userID, true).
+		OrderBy("saved_search.name ASC, saved_search.id ASC").
+		Find(&searches)
+}
+
+// GetNotifySavedSearchSubscriptions returns all the subscriptions to saved searches with notifications
+func GetNotifySavedSearchSubscriptions(ctx context.Context) ([]*SavedSearchSubscription, error) {
+	subs := make([]*SavedSearchSubscription, 0, 10)
+	return subs, db.GetEngine(ctx).Where("notify = ?", true).OrderBy("id ASC").Find(&subs)
+}
+
+// UpdateSavedSearchLastChecked sets the last time a subscription was checked for new matches
+func UpdateSavedSearchLastChecked(ctx context.Context, sub *SavedSearchSubscription) error {
+	_, err := db.GetEngine(ctx).ID(sub.ID).Cols("last_checked_unix").Update(sub)
+	return err
+}
+
+// AddSavedSearchMatches records issues as matches of a subscription and returns the IDs of those
+// which were not recorded before
+func AddSavedSearchMatches(ctx context.Context, subscriptionID int64, issueIDs []int64) ([]int64, error) {
+	if len(issueIDs) == 0 {
+		return nil, nil
+	}
+	var known []int64
+	if err := db.GetEngine(ctx).Table("saved_search_match").
+		Where("subscription_id = ?", subscriptionID).In("issue_id", issueIDs).
+		Cols("issue_id").Find(&known); err != nil {
+		return nil, err
+	}
+
+	newIDs := make([]int64, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		if slices.Contains(known, id) || slices.Contains(newIDs, id) {
+			continue
+		}
+		if err := db.Insert(ctx, &SavedSearchMatch{SubscriptionID: subscriptionID, IssueID: id}); err != nil {
+			return nil, err
+		}
+		newIDs = append(newIDs, id)
+	}
+	return newIDs, nil
+}
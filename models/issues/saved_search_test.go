@@ -0,0 +1,82 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"net/url"
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchFilter(t *testing.T) {
+	values, err := url.ParseQuery("q=crash&type=bogus&labels=bug,+ui,&repos=user2/repo1&assignee=user2&project=3&sort=oldest")
+	require.NoError(t, err)
+
+	filter := issues_model.ParseSavedSearchFilter(values)
+	assert.Equal(t, issues_model.SavedSearchFilter{
+		Keyword:   "crash",
+		State:     "open",
+		Labels:    []string{"bug", "ui"},
+		Repos:     []string{"user2/repo1"},
+		Assignee:  "user2",
+		ProjectID: 3,
+		Sort:      "oldest",
+	}, filter)
+	assert.Equal(t, filter, issues_model.ParseSavedSearchFilter(filter.Values()))
+}
+
+func TestSavedSearches(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	search := &issues_model.SavedSearch{OwnerID: 3, CreatorID: 2, Name: "Open bugs"}
+	search.SetFilter(issues_model.SavedSearchFilter{Labels: []string{"bug"}, State: "open"})
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, search))
+	err := issues_model.CreateSavedSearch(db.DefaultContext, &issues_model.SavedSearch{OwnerID: 3, CreatorID: 2, Name: "Open bugs"})
+	assert.True(t, issues_model.IsErrSavedSearchAlreadyExists(err))
+
+	other := &issues_model.SavedSearch{OwnerID: 3, CreatorID: 2, Name: "Other"}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, other))
+	other.Name = "Open bugs"
+	assert.True(t, issues_model.IsErrSavedSearchAlreadyExists(issues_model.UpdateSavedSearch(db.DefaultContext, other)))
+
+	searches, err := db.Find[issues_model.SavedSearch](db.DefaultContext, issues_model.FindSavedSearchesOptions{OwnerIDs: []int64{2, 3}})
+	require.NoError(t, err)
+	require.Len(t, searches, 2)
+	assert.Equal(t, "Open bugs", searches[0].Name)
+	assert.Equal(t, []string{"bug"}, searches[0].Filter().Labels)
+
+	// subscriptions
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, search.ID, 2, true, true))
+	dashboard, err := issues_model.GetDashboardSavedSearches(db.DefaultContext, 2)
+	require.NoError(t, err)
+	require.Len(t, dashboard, 1)
+	assert.Equal(t, search.ID, dashboard[0].ID)
+
+	subs, err := issues_model.GetNotifySavedSearchSubscriptions(db.DefaultContext)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.NotZero(t, subs[0].LastCheckedUnix)
+
+	newIDs, err := issues_model.AddSavedSearchMatches(db.DefaultContext, subs[0].ID, []int64{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, newIDs)
+	newIDs, err = issues_model.AddSavedSearchMatches(db.DefaultContext, subs[0].ID, []int64{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, newIDs)
+
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, search.ID, 2, false, false))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearchSubscription{SearchID: search.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearchMatch{SubscriptionID: subs[0].ID})
+
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, search.ID, 2, true, false))
+	require.NoError(t, issues_model.DeleteSavedSearchesByOwner(db.DefaultContext, 3))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{ID: search.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearchSubscription{SearchID: search.ID})
+}
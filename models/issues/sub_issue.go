@@ -0,0 +1,410 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// MaxSubIssueDepth is the maximum number of levels of sub-issues below an issue without parent
+const MaxSubIssueDepth = 8
+
+// ErrSubIssueHasParent represents a "SubIssueHasParent" kind of error.
+type ErrSubIssueHasParent struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueHasParent checks if an error is a ErrSubIssueHasParent.
+func IsErrSubIssueHasParent(err error) bool {
+	_, ok := err.(ErrSubIssueHasParent)
+	return ok
+}
+
+func (err ErrSubIssueHasParent) Error() string {
+	return fmt.Sprintf("issue already has a parent issue [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueHasParent) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrSubIssueNotExist represents a "SubIssueNotExist" kind of error.
+type ErrSubIssueNotExist struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueNotExist checks if an error is a ErrSubIssueNotExist.
+func IsErrSubIssueNotExist(err error) bool {
+	_, ok := err.(ErrSubIssueNotExist)
+	return ok
+}
+
+func (err ErrSubIssueNotExist) Error() string {
+	return fmt.Sprintf("issue is not a sub-issue of the parent issue [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrCircularSubIssue represents a "CircularSubIssue" kind of error.
+type ErrCircularSubIssue struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("issue is an ancestor of its parent issue [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrSubIssueOtherOwner represents a "SubIssueOtherOwner" kind of error.
+type ErrSubIssueOtherOwner struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueOtherOwner checks if an error is a ErrSubIssueOtherOwner.
+func IsErrSubIssueOtherOwner(err error) bool {
+	_, ok := err.(ErrSubIssueOtherOwner)
+	return ok
+}
+
+func (err ErrSubIssueOtherOwner) Error() string {
+	return fmt.Sprintf("issue and parent issue are in repositories of different owners [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueOtherOwner) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrSubIssueTooDeep represents a "SubIssueTooDeep" kind of error.
+type ErrSubIssueTooDeep struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueTooDeep checks if an error is a ErrSubIssueTooDeep.
+func IsErrSubIssueTooDeep(err error) bool {
+	_, ok := err.(ErrSubIssueTooDeep)
+	return ok
+}
+
+func (err ErrSubIssueTooDeep) Error() string {
+	return fmt.Sprintf("sub-issues would be nested more than %d levels deep [issue id: %d, parent id: %d]", MaxSubIssueDepth, err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueTooDeep) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// SubIssue represents an issue which is a child of another issue. An issue has at most one parent.
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	UserID      int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// AddSubIssue makes an issue the last child of a parent issue in a repository of the same owner
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	if parent.ID == issue.ID {
+		return ErrCircularSubIssue{issue.ID, parent.ID}
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if parent.Repo.OwnerID != issue.Repo.OwnerID {
+		return ErrSubIssueOtherOwner{issue.ID, parent.ID}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		parentID, err := GetParentIssueID(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if parentID != 0 {
+			return ErrSubIssueHasParent{issue.ID, parentID}
+		}
+
+		ancestorIDs, err := getAncestorIssueIDs(ctx, parent.ID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ancestorIDs, issue.ID) {
+			return ErrCircularSubIssue{issue.ID, parent.ID}
+		}
+		height, err := getSubIssueTreeHeight(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if len(ancestorIDs)+1+height > MaxSubIssueDepth {
+			return ErrSubIssueTooDeep{issue.ID, parent.ID}
+		}
+
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("sub_issue").Select("COALESCE(MAX(sorting), -1)").Where("parent_id=?", parent.ID).Get(&maxSorting); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, &SubIssue{
+			ParentID: parent.ID,
+			IssueID:  issue.ID,
+			Sorting:  maxSorting + 1,
+			UserID:   doer.ID,
+		}); err != nil {
+			return err
+		}
+		return createSubIssueComments(ctx, doer, parent, issue, true)
+	})
+}
+
+// RemoveSubIssue removes an issue from the children of its parent issue
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Delete(&SubIssue{ParentID: parent.ID, IssueID: issue.ID})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrSubIssueNotExist{issue.ID, parent.ID}
+		}
+		return createSubIssueComments(ctx, doer, parent, issue, false)
+	})
+}
+
+// MoveSubIssue moves a child of a parent issue to the given position among its children.
+// A negative position moves it to the end.
+func MoveSubIssue(ctx context.Context, parent, issue *Issue, position int) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		subIssues := make([]*SubIssue, 0, 10)
+		if err := db.GetEngine(ctx).Where("parent_id=?", parent.ID).OrderBy("sorting, id").Find(&subIssues); err != nil {
+			return err
+		}
+
+		issueIDs := make([]int64, 0, len(subIssues))
+		found := false
+		for _, subIssue := range subIssues {
+			if subIssue.IssueID == issue.ID {
+				found = true
+			} else {
+				issueIDs = append(issueIDs, subIssue.IssueID)
+			}
+		}
+		if !found {
+			return ErrSubIssueNotExist{issue.ID, parent.ID}
+		}
+		if position < 0 || position > len(issueIDs) {
+			position = len(issueIDs)
+		}
+		issueIDs = slices.Insert(issueIDs, position, issue.ID)
+
+		for sorting, issueID := range issueIDs {
+			if _, err := db.GetEngine(ctx).Where("parent_id=? AND issue_id=?", parent.ID, issueID).
+				Cols("sorting").Update(&SubIssue{Sorting: int64(sorting)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetParentIssueID returns the id of the parent issue of an issue, or 0 if it has none
+func GetParentIssueID(ctx context.Context, issueID int64) (int64, error) {
+	var parentID int64
+	_, err := db.GetEngine(ctx).Table("sub_issue").Select("parent_id").Where("issue_id=?", issueID).Get(&parentID)
+	return parentID, err
+}
+
+// GetSubIssues returns the ordered children of an issue
+func GetSubIssues(ctx context.Context, parentID int64) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id = ?", parentID).
+		OrderBy("sub_issue.sorting, sub_issue.id").
+		Find(&issues)
+}
+
+// getAncestorIssueIDs returns the ids of the parent, the grand parent, ... of an issue
+func getAncestorIssueIDs(ctx context.Context, issueID int64) ([]int64, error) {
+	var ancestorIDs []int64
+	for {
+		parentID, err := GetParentIssueID(ctx, issueID)
+		if err != nil {
+			return nil, err
+		}
+		// the guard only protects against corrupted data, cycles are never created
+		if parentID == 0 || slices.Contains(ancestorIDs, parentID) {
+			return ancestorIDs, nil
+		}
+		ancestorIDs = append(ancestorIDs, parentID)
+		issueID = parentID
+	}
+}
+
+// getSubIssueTreeHeight returns the number of levels of sub-issues below an issue
+func getSubIssueTreeHeight(ctx context.Context, issueID int64) (int, error) {
+	height := 0
+	levelIDs := []int64{issueID}
+	for height <= MaxSubIssueDepth {
+		var childIDs []int64
+		if err := db.GetEngine(ctx).Table("sub_issue").Cols("issue_id").In("parent_id", levelIDs).Find(&childIDs); err != nil {
+			return 0, err
+		}
+		if len(childIDs) == 0 {
+			break
+		}
+		height++
+		levelIDs = childIDs
+	}
+	return height, nil
+}
+
+// SubIssueNode is an issue of a tree of sub-issues, with its own children
+type SubIssueNode struct {
+	Issue    *Issue
+	Children SubIssueTree
+}
+
+// SubIssueTree is an ordered list of sub-issues with their own children
+type SubIssueTree []*SubIssueNode
+
+// SubIssueProgress counts the closed issues of a tree of sub-issues
+type SubIssueProgress struct {
+	Closed int
+	Total  int
+}
+
+// Percent returns the percentage of closed issues, rounded down
+func (p SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Closed * 100 / p.Total
+}
+
+// Progress rolls up the closed issues of all the levels of the tree
+func (tree SubIssueTree) Progress() SubIssueProgress {
+	var progress SubIssueProgress
+	for _, node := range tree {
+		progress.Total++
+		if node.Issue.IsClosed {
+			progress.Closed++
+		}
+		childProgress := node.Children.Progress()
+		progress.Total += childProgress.Total
+		progress.Closed += childProgress.Closed
+	}
+	return progress
+}
+
+// Filter returns the tree without the nodes, and their children, which are not accepted
+func (tree SubIssueTree) Filter(accept func(*Issue) bool) SubIssueTree {
+	filtered := make(SubIssueTree, 0, len(tree))
+	for _, node := range tree {
+		if accept(node.Issue) {
+			filtered = append(filtered, &SubIssueNode{Issue: node.Issue, Children: node.Children.Filter(accept)})
+		}
+	}
+	return filtered
+}
+
+// GetSubIssueTree returns all the levels of sub-issues below an issue, with their repositories loaded
+func GetSubIssueTree(ctx context.Context, issueID int64) (SubIssueTree, error) {
+	nodes := map[int64]*SubIssueNode{issueID: {}}
+	levelIDs := []int64{issueID}
+	for depth := 0; depth < MaxSubIssueDepth && len(levelIDs) > 0; depth++ {
+		subIssues := make([]*SubIssue, 0, len(levelIDs))
+		if err := db.GetEngine(ctx).In("parent_id", levelIDs).OrderBy("sorting, id").Find(&subIssues); err != nil {
+			return nil, err
+		}
+		childIDs := make([]int64, 0, len(subIssues))
+		for _, subIssue := range subIssues {
+			childIDs = append(childIDs, subIssue.IssueID)
+		}
+		issues, err := GetIssuesByIDs(ctx, childIDs)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := issues.LoadRepositories(ctx); err != nil {
+			return nil, err
+		}
+		issuesByID := make(map[int64]*Issue, len(issues))
+		for _, issue := range issues {
+			issuesByID[issue.ID] = issue
+		}
+
+		levelIDs = levelIDs[:0]
+		for _, subIssue := range subIssues {
+			issue, ok := issuesByID[subIssue.IssueID]
+			if !ok {
+				continue
+			}
+			node := &SubIssueNode{Issue: issue}
+			nodes[issue.ID] = node
+			nodes[subIssue.ParentID].Children = append(nodes[subIssue.ParentID].Children, node)
+			levelIDs = append(levelIDs, issue.ID)
+		}
+	}
+	return nodes[issueID].Children, nil
+}
+
+func createSubIssueComments(ctx context.Context, doer *user_model.User, parent, issue *Issue, add bool) error {
+	parentType, issueType := CommentTypeAddSubIssue, CommentTypeAddParentIssue
+	if !add {
+		parentType, issueType = CommentTypeRemoveSubIssue, CommentTypeRemoveParentIssue
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	// Make two comments, one in each issue
+	if _, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             parentType,
+		Doer:             doer,
+		Repo:             parent.Repo,
+		Issue:            parent,
+		DependentIssueID: issue.ID,
+	}); err != nil {
+		return err
+	}
+	_, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             issueType,
+		Doer:             doer,
+		Repo:             issue.Repo,
+		Issue:            issue,
+		DependentIssueID: parent.ID,
+	})
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue4 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	issue6 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+	issue7 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+
+	// issue 7 is in another repository of the same owner
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue1, issue5))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue1, issue7))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue7, issue4))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{Type: issues_model.CommentTypeAddSubIssue, IssueID: issue1.ID, DependentIssueID: issue5.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{Type: issues_model.CommentTypeAddParentIssue, IssueID: issue5.ID, DependentIssueID: issue1.ID})
+
+	err := issues_model.AddSubIssue(db.DefaultContext, doer, issue7, issue5)
+	assert.True(t, issues_model.IsErrSubIssueHasParent(err))
+	err = issues_model.AddSubIssue(db.DefaultContext, doer, issue4, issue1)
+	assert.True(t, issues_model.IsErrCircularSubIssue(err))
+	err = issues_model.AddSubIssue(db.DefaultContext, doer, issue1, issue1)
+	assert.True(t, issues_model.IsErrCircularSubIssue(err))
+	err = issues_model.AddSubIssue(db.DefaultContext, doer, issue1, issue6)
+	assert.True(t, issues_model.IsErrSubIssueOtherOwner(err))
+
+	parentID, err := issues_model.GetParentIssueID(db.DefaultContext, issue4.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, issue7.ID, parentID)
+
+	// issues 4 and 5 are closed
+	tree, err := issues_model.GetSubIssueTree(db.DefaultContext, issue1.ID)
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	assert.EqualValues(t, issue5.ID, tree[0].Issue.ID)
+	assert.EqualValues(t, issue7.ID, tree[1].Issue.ID)
+	require.Len(t, tree[1].Children, 1)
+	assert.EqualValues(t, issue4.ID, tree[1].Children[0].Issue.ID)
+	assert.Equal(t, issues_model.SubIssueProgress{Closed: 2, Total: 3}, tree.Progress())
+	assert.Equal(t, 66, tree.Progress().Percent())
+
+	filtered := tree.Filter(func(issue *issues_model.Issue) bool { return issue.RepoID == issue1.RepoID })
+	assert.Equal(t, issues_model.SubIssueProgress{Closed: 1, Total: 1}, filtered.Progress())
+
+	require.NoError(t, issues_model.MoveSubIssue(db.DefaultContext, issue1, issue7, 0))
+	subIssues, err := issues_model.GetSubIssues(db.DefaultContext, issue1.ID)
+	require.NoError(t, err)
+	require.Len(t, subIssues, 2)
+	assert.EqualValues(t, issue7.ID, subIssues[0].ID)
+	assert.EqualValues(t, issue5.ID, subIssues[1].ID)
+
+	require.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, doer, issue1, issue5))
+	unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: issue5.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{Type: issues_model.CommentTypeRemoveParentIssue, IssueID: issue5.ID, DependentIssueID: issue1.ID})
+	err = issues_model.RemoveSubIssue(db.DefaultContext, doer, issue1, issue5)
+	assert.True(t, issues_model.IsErrSubIssueNotExist(err))
+}
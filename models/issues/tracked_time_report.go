@@ -0,0 +1,173 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+
+	"xorm.io/builder"
+)
+
+// TimesheetGroup is how the tracked times of a timesheet are aggregated
+type TimesheetGroup string
+
+const (
+	TimesheetGroupUser      TimesheetGroup = "user"
+	TimesheetGroupRepo      TimesheetGroup = "repo"
+	TimesheetGroupMilestone TimesheetGroup = "milestone"
+	TimesheetGroupLabel     TimesheetGroup = "label"
+	TimesheetGroupDate      TimesheetGroup = "date"
+)
+
+// TimesheetGroups are the ways the tracked times of a timesheet can be aggregated
+var TimesheetGroups = []TimesheetGroup{
+	TimesheetGroupUser,
+	TimesheetGroupRepo,
+	TimesheetGroupMilestone,
+	TimesheetGroupLabel,
+	TimesheetGroupDate,
+}
+
+// IsValid checks if the group is known
+func (g TimesheetGroup) IsValid() bool {
+	return slices.Contains(TimesheetGroups, g)
+}
+
+// TimesheetOptions represent the filters of a timesheet. If an ID is 0 it will be ignored.
+type TimesheetOptions struct {
+	OwnerID int64
+	// AccessCond restricts the tracked times to the ones a user can read, it is a condition on the tracked_time
+	// and repository tables
+	AccessCond builder.Cond
+	UserID     int64
+	RepoID     int64
+	// Milestone and Label are the names of a milestone and a label, which may belong to several repositories
+	Milestone         string
+	Label             string
+	CreatedAfterUnix  int64
+	CreatedBeforeUnix int64
+}
+
+func (opts *TimesheetOptions) toConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"tracked_time.deleted": false})
+	if opts.OwnerID != 0 {
+		cond = cond.And(builder.Eq{"`repository`.owner_id": opts.OwnerID})
+	}
+	if opts.AccessCond != nil {
+		cond = cond.And(opts.AccessCond)
+	}
+	if opts.UserID != 0 {
+		cond = cond.And(builder.Eq{"tracked_time.user_id": opts.UserID})
+	}
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"issue.repo_id": opts.RepoID})
+	}
+	if opts.Milestone != "" {
+		cond = cond.And(builder.In("issue.milestone_id", builder.Select("id").From("milestone").Where(builder.Eq{"name": opts.Milestone})))
+	}
+	if opts.Label != "" {
+		cond = cond.And(builder.In("issue.id", builder.Select("issue_label.issue_id").From("issue_label").
+			Join("INNER", "label", "label.id = issue_label.label_id").
+			Where(builder.Eq{"label.name": opts.Label})))
+	}
+	if opts.CreatedAfterUnix != 0 {
+		cond = cond.And(builder.Gte{"tracked_time.created_unix": opts.CreatedAfterUnix})
+	}
+	if opts.CreatedBeforeUnix != 0 {
+		cond = cond.And(builder.Lte{"tracked_time.created_unix": opts.CreatedBeforeUnix})
+	}
+	return cond
+}
+
+// TimesheetRow is the total tracked time of a group of a timesheet, which is identified by its ID for users and
+// repositories, and by its Name for milestones, labels and dates. The tracked times of the issues without
+// milestone or label are in a group with an empty name.
+type TimesheetRow struct {
+	ID      int64
+	Name    string
+	Seconds int64
+}
+
+// GetTimesheet returns the total tracked times matching the options by group, sorted by decreasing time, or
+// chronologically for dates. The tracked times of an issue with several labels are counted for each label.
+func GetTimesheet(ctx context.Context, opts *TimesheetOptions, group TimesheetGroup) ([]*TimesheetRow, error) {
+	sess := db.GetEngine(ctx).Table("tracked_time").
+		Join("INNER", "issue", "issue.id = tracked_time.issue_id").
+		Join("INNER", "repository", "`repository`.id = issue.repo_id")
+
+	rows := make([]*TimesheetRow, 0, 10)
+	switch group {
+	case TimesheetGroupUser:
+		sess = sess.Select("tracked_time.user_id AS id, SUM(tracked_time.time) AS seconds").GroupBy("tracked_time.user_id")
+	case TimesheetGroupRepo:
+		sess = sess.Select("issue.repo_id AS id, SUM(tracked_time.time) AS seconds").GroupBy("issue.repo_id")
+	case TimesheetGroupMilestone:
+		sess = sess.Join("LEFT", "milestone", "milestone.id = issue.milestone_id").
+			Select("COALESCE(milestone.name, '') AS name, SUM(tracked_time.time) AS seconds").
+			GroupBy("COALESCE(milestone.name, '')")
+	case TimesheetGroupLabel:
+		sess = sess.Join("LEFT", "issue_label", "issue_label.issue_id = issue.id").
+			Join("LEFT", "label", "label.id = issue_label.label_id").
+			Select("COALESCE(label.name, '') AS name, SUM(tracked_time.time) AS seconds").
+			GroupBy("COALESCE(label.name, '')")
+	case TimesheetGroupDate:
+		return getTimesheetByDate(ctx, opts)
+	default:
+		return nil, fmt.Errorf("unknown timesheet group %q", group)
+	}
+	if err := sess.Where(opts.toConds()).Find(&rows); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Seconds > rows[j].Seconds
+	})
+	return rows, nil
+}
+
+// getTimesheetByDate returns the total tracked times by day, in the default time zone of the UI, as the
+// databases do not share a function to get the date of a timestamp
+func getTimesheetByDate(ctx context.Context, opts *TimesheetOptions) ([]*TimesheetRow, error) {
+	var times []*TrackedTime
+	if err := db.GetEngine(ctx).Table("tracked_time").
+		Join("INNER", "issue", "issue.id = tracked_time.issue_id").
+		Join("INNER", "repository", "`repository`.id = issue.repo_id").
+		Cols("tracked_time.created_unix", "tracked_time.time").
+		Where(opts.toConds()).
+		Find(&times); err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]*TimesheetRow)
+	rows := make([]*TimesheetRow, 0, 10)
+	for _, t := range times {
+		date := time.Unix(t.CreatedUnix, 0).In(setting.DefaultUILocation).Format("2006-01-02")
+		row, ok := byDate[date]
+		if !ok {
+			row = &TimesheetRow{Name: date}
+			byDate[date] = row
+			rows = append(rows, row)
+		}
+		row.Seconds += t.Time
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Name < rows[j].Name
+	})
+	return rows, nil
+}
+
+// GetTrackedTimeUserIDs returns the IDs of the users who tracked time since a time
+func GetTrackedTimeUserIDs(ctx context.Context, sinceUnix int64) ([]int64, error) {
+	userIDs := make([]int64, 0, 10)
+	return userIDs, db.GetEngine(ctx).Table("tracked_time").
+		Where(builder.Eq{"deleted": false}.And(builder.Gte{"created_unix": sinceUnix}).And(builder.Gt{"user_id": 0})).
+		Distinct("user_id").
+		Find(&userIDs)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTimesheet(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	rows, err := issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{OwnerID: 2}, issues_model.TimesheetGroupUser)
+	require.NoError(t, err)
+	assert.Equal(t, []*issues_model.TimesheetRow{
+		{ID: 2, Seconds: 3666},
+		{ID: 1, Seconds: 491},
+		{ID: -1, Seconds: 1},
+	}, rows)
+
+	rows, err = issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{OwnerID: 2}, issues_model.TimesheetGroupRepo)
+	require.NoError(t, err)
+	assert.Equal(t, []*issues_model.TimesheetRow{
+		{ID: 1, Seconds: 4083},
+		{ID: 2, Seconds: 75},
+	}, rows)
+
+	rows, err = issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{OwnerID: 2, UserID: 1, RepoID: 1}, issues_model.TimesheetGroupRepo)
+	require.NoError(t, err)
+	assert.Equal(t, []*issues_model.TimesheetRow{{ID: 1, Seconds: 420}}, rows)
+
+	rows, err = issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{OwnerID: 2}, issues_model.TimesheetGroupDate)
+	require.NoError(t, err)
+	if assert.Len(t, rows, 2) {
+		assert.EqualValues(t, 4087, rows[0].Seconds)
+		assert.EqualValues(t, 71, rows[1].Seconds)
+	}
+
+	rows, err = issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{OwnerID: 2, CreatedBeforeUnix: 946684803}, issues_model.TimesheetGroupUser)
+	require.NoError(t, err)
+	assert.Equal(t, []*issues_model.TimesheetRow{
+		{ID: 2, Seconds: 3662},
+		{ID: 1, Seconds: 400},
+		{ID: -1, Seconds: 1},
+	}, rows)
+
+	rows, err = issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{OwnerID: 3}, issues_model.TimesheetGroupUser)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	_, err = issues_model.GetTimesheet(db.DefaultContext, &issues_model.TimesheetOptions{}, "unknown")
+	require.Error(t, err)
+}
+
+func TestGetTrackedTimeUserIDs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	userIDs, err := issues_model.GetTrackedTimeUserIDs(db.DefaultContext, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2}, userIDs)
+
+	userIDs, err = issues_model.GetTrackedTimeUserIDs(db.DefaultContext, 947000000)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, userIDs)
+}
@@ -0,0 +1,309 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// TriageEvent is the event which makes a triage rule run
+type TriageEvent int
+
+const (
+	TriageEventOpened    TriageEvent = iota + 1 // an issue or a pull request is opened
+	TriageEventLabeled                          // labels are added to an issue or a pull request
+	TriageEventCommented                        // an issue or a pull request is commented
+	TriageEventStale                            // an issue or a pull request has not been updated for some days
+)
+
+var triageEventNames = map[TriageEvent]string{
+	TriageEventOpened:    "opened",
+	TriageEventLabeled:   "labeled",
+	TriageEventCommented: "commented",
+	TriageEventStale:     "stale",
+}
+
+func (e TriageEvent) String() string {
+	return triageEventNames[e]
+}
+
+// TriageEventFromString returns the triage event with a name, or 0 if there is none
+func TriageEventFromString(name string) TriageEvent {
+	for e, n := range triageEventNames {
+		if n == name {
+			return e
+		}
+	}
+	return 0
+}
+
+// TriageEvents returns all the triage events
+func TriageEvents() []TriageEvent {
+	return []TriageEvent{TriageEventOpened, TriageEventLabeled, TriageEventCommented, TriageEventStale}
+}
+
+// TriageConditions are the conditions an issue or a pull request must meet for the actions of a
+// triage rule to be taken. Empty conditions are ignored.
+type TriageConditions struct {
+	// Type is "issues", "pulls" or empty for both
+	Type string `json:"type,omitempty"`
+	// Labels must all be set
+	Labels []string `json:"labels,omitempty"`
+	// ExcludedLabels must not be set
+	ExcludedLabels []string `json:"excluded_labels,omitempty"`
+	// Authors are the user names of which one must be the poster
+	Authors    []string `json:"authors,omitempty"`
+	TitleRegex string   `json:"title_regex,omitempty"`
+	// BodyRegex must match the body of the issue, or of the comment for the commented event
+	BodyRegex string `json:"body_regex,omitempty"`
+	// Fields are values of custom project fields by field name
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// TriageActions are the actions a triage rule takes on the issues and pull requests meeting its conditions
+type TriageActions struct {
+	AddLabels    []string `json:"add_labels,omitempty"`
+	RemoveLabels []string `json:"remove_labels,omitempty"`
+	// AssignTeam is the name of a team of the organization whose members are assigned in turn
+	AssignTeam string `json:"assign_team,omitempty"`
+	// Comment is a template of the comment to post
+	Comment         string `json:"comment,omitempty"`
+	Close           bool   `json:"close,omitempty"`
+	ProjectColumnID int64  `json:"project_column_id,omitempty"`
+}
+
+// IsEmpty returns true if the actions do nothing
+func (a *TriageActions) IsEmpty() bool {
+	return len(a.AddLabels) == 0 && len(a.RemoveLabels) == 0 && a.AssignTeam == "" && a.Comment == "" && !a.Close && a.ProjectColumnID == 0
+}
+
+// ErrTriageRuleNotExist represents a "TriageRuleNotExist" kind of error.
+type ErrTriageRuleNotExist struct {
+	ID int64
+}
+
+// IsErrTriageRuleNotExist checks if an error is a ErrTriageRuleNotExist.
+func IsErrTriageRuleNotExist(err error) bool {
+	_, ok := err.(ErrTriageRuleNotExist)
+	return ok
+}
+
+func (err ErrTriageRuleNotExist) Error() string {
+	return fmt.Sprintf("triage rule does not exist [id: %d]", err.ID)
+}
+
+func (err ErrTriageRuleNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// TriageRule takes actions on the issues and pull requests of a repository, or of all the
+// repositories of an organization, which meet its conditions when an event happens
+type TriageRule struct {
+	ID      int64       `xorm:"pk autoincr"`
+	OwnerID int64       `xorm:"INDEX NOT NULL DEFAULT 0"` // the organization of a rule for all its repositories
+	RepoID  int64       `xorm:"INDEX NOT NULL DEFAULT 0"` // the repository of a rule for a single repository
+	Name    string      `xorm:"VARCHAR(255) NOT NULL"`
+	Event   TriageEvent `xorm:"NOT NULL"`
+	// StaleDays is the number of days without update after which the stale event happens
+	StaleDays  int              `xorm:"NOT NULL DEFAULT 0"`
+	Conditions TriageConditions `xorm:"JSON TEXT"`
+	Actions    TriageActions    `xorm:"JSON TEXT"`
+	IsActive   bool             `xorm:"NOT NULL DEFAULT true"`
+	// DryRun rules only log the actions they would take
+	DryRun    bool  `xorm:"NOT NULL DEFAULT false"`
+	CreatorID int64 `xorm:"NOT NULL"`
+	// NextAssignee is the position in the team of the next member to assign
+	NextAssignee int `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TriageLog records the issues and pull requests which met the conditions of a triage rule, and
+// the actions which were taken or would have been taken in dry-run
+type TriageLog struct {
+	ID      int64       `xorm:"pk autoincr"`
+	RuleID  int64       `xorm:"INDEX NOT NULL"`
+	IssueID int64       `xorm:"INDEX NOT NULL"`
+	Issue   *Issue      `xorm:"-"`
+	Event   TriageEvent `xorm:"NOT NULL"`
+	DryRun  bool        `xorm:"NOT NULL DEFAULT false"`
+	// Actions describes the actions, one per line
+	Actions     string             `xorm:"TEXT"`
+	Error       string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(TriageRule))
+	db.RegisterModel(new(TriageLog))
+}
+
+// IsOrganizationRule returns true if the rule applies to all the repositories of an organization
+func (r *TriageRule) IsOrganizationRule() bool {
+	return r.RepoID == 0
+}
+
+// CreateTriageRule creates a triage rule
+func CreateTriageRule(ctx context.Context, r *TriageRule) error {
+	return db.Insert(ctx, r)
+}
+
+// UpdateTriageRule updates the settings of a triage rule
+func UpdateTriageRule(ctx context.Context, r *TriageRule) error {
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("name", "event", "stale_days", "conditions", "actions", "is_active", "dry_run").Update(r)
+	return err
+}
+
+// UpdateTriageRuleNextAssignee saves the position of the next member of the team to assign
+func UpdateTriageRuleNextAssignee(ctx context.Context, r *TriageRule) error {
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("next_assignee").NoAutoTime().Update(r)
+	return err
+}
+
+// GetTriageRuleByID returns the triage rule with an ID
+func GetTriageRuleByID(ctx context.Context, id int64) (*TriageRule, error) {
+	r, exist, err := db.GetByID[TriageRule](ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, ErrTriageRuleNotExist{id}
+	}
+	return r, nil
+}
+
+// DeleteTriageRule deletes a triage rule and its log
+func DeleteTriageRule(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&TriageLog{RuleID: id}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[TriageRule](ctx, id)
+		return err
+	})
+}
+
+func deleteTriageRulesByCond(ctx context.Context, cond builder.Cond) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		ruleIDs := builder.Select("id").From("triage_rule").Where(cond)
+		if _, err := db.GetEngine(ctx).Where(builder.In("rule_id", ruleIDs)).Delete(new(TriageLog)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where(cond).Delete(new(TriageRule))
+		return err
+	})
+}
+
+// DeleteTriageRulesByRepoID deletes the triage rules of a repository
+func DeleteTriageRulesByRepoID(ctx context.Context, repoID int64) error {
+	return deleteTriageRulesByCond(ctx, builder.Eq{"repo_id": repoID})
+}
+
+// DeleteTriageRulesByOwnerID deletes the triage rules of an organization
+func DeleteTriageRulesByOwnerID(ctx context.Context, ownerID int64) error {
+	return deleteTriageRulesByCond(ctx, builder.Eq{"owner_id": ownerID, "repo_id": 0})
+}
+
+// FindTriageRulesOptions represents the options to find triage rules
+type FindTriageRulesOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+}
+
+func (opts FindTriageRulesOptions) ToConds() builder.Cond {
+	if opts.RepoID > 0 {
+		return builder.Eq{"repo_id": opts.RepoID}
+	}
+	return builder.Eq{"owner_id": opts.OwnerID, "repo_id": 0}
+}
+
+func (opts FindTriageRulesOptions) ToOrders() string {
+	return "name ASC, id ASC"
+}
+
+// GetActiveTriageRules returns the active rules for an event which apply to a repository: the
+// rules of the repository and those of its owner
+func GetActiveTriageRules(ctx context.Context, repo *repo_model.Repository, event TriageEvent) ([]*TriageRule, error) {
+	rules := make([]*TriageRule, 0, 5)
+	return rules, db.GetEngine(ctx).
+		Where(builder.Eq{"is_active": true, "event": event}).
+		And(builder.Eq{"repo_id": repo.ID}.Or(builder.Eq{"owner_id": repo.OwnerID, "repo_id": 0})).
+		OrderBy("id ASC").
+		Find(&rules)
+}
+
+// GetActiveStaleTriageRules returns all the active rules for the stale event
+func GetActiveStaleTriageRules(ctx context.Context) ([]*TriageRule, error) {
+	rules := make([]*TriageRule, 0, 5)
+	return rules, db.GetEngine(ctx).Where(builder.Eq{"is_active": true, "event": TriageEventStale}).OrderBy("id ASC").Find(&rules)
+}
+
+// CreateTriageLog records a run of a triage rule
+func CreateTriageLog(ctx context.Context, l *TriageLog) error {
+	return db.Insert(ctx, l)
+}
+
+// GetTriageStaleIssues returns open issues and pull requests to which a rule for the stale event
+// applies, which were not updated since a time and on which the rule did not run since their last
+// update, by ID after an ID
+func GetTriageStaleIssues(ctx context.Context, r *TriageRule, updatedBefore timeutil.TimeStamp, afterID int64, limit int) (IssueList, error) {
+	cond := builder.Eq{"issue.is_closed": false}.And(builder.Lt{"issue.updated_unix": updatedBefore}, builder.Gt{"issue.id": afterID})
+	if r.RepoID > 0 {
+		cond = cond.And(builder.Eq{"issue.repo_id": r.RepoID})
+	} else {
+		cond = cond.And(builder.In("issue.repo_id", builder.Select("id").From("repository").Where(builder.Eq{"owner_id": r.OwnerID})))
+	}
+	cond = cond.And(builder.NotExists(builder.Select("id").From("triage_log").
+		Where(builder.Eq{"triage_log.rule_id": r.ID}.And(builder.Expr("triage_log.issue_id = issue.id AND triage_log.created_unix >= issue.updated_unix")))))
+
+	issues := make(IssueList, 0, limit)
+	return issues, db.GetEngine(ctx).Where(cond).OrderBy("issue.id ASC").Limit(limit).Find(&issues)
+}
+
+// FindTriageLogsOptions represents the options to find the log of a triage rule
+type FindTriageLogsOptions struct {
+	db.ListOptions
+	RuleID int64
+}
+
+func (opts FindTriageLogsOptions) ToConds() builder.Cond {
+	return builder.Eq{"rule_id": opts.RuleID}
+}
+
+func (opts FindTriageLogsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
+
+// LoadTriageLogIssues loads the issues of triage logs, the logs of deleted issues are left without issue
+func LoadTriageLogIssues(ctx context.Context, logs []*TriageLog) error {
+	issueIDs := make([]int64, 0, len(logs))
+	for _, l := range logs {
+		issueIDs = append(issueIDs, l.IssueID)
+	}
+	issues, err := GetIssuesByIDs(ctx, issueIDs)
+	if err != nil {
+		return err
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return err
+	}
+	issueMap := make(map[int64]*Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	for _, l := range logs {
+		l.Issue = issueMap[l.IssueID]
+	}
+	return nil
+}
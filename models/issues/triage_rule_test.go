@@ -0,0 +1,114 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriageRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	orgRule := &issues_model.TriageRule{
+		OwnerID:    3,
+		Name:       "Label bugs",
+		Event:      issues_model.TriageEventOpened,
+		Conditions: issues_model.TriageConditions{TitleRegex: "(?i)crash"},
+		Actions:    issues_model.TriageActions{AddLabels: []string{"bug"}},
+		IsActive:   true,
+		CreatorID:  2,
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, orgRule))
+	repoRule := &issues_model.TriageRule{
+		RepoID:    3,
+		Name:      "Greet",
+		Event:     issues_model.TriageEventOpened,
+		Actions:   issues_model.TriageActions{Comment: "Thanks {{.Poster}}"},
+		IsActive:  true,
+		CreatorID: 2,
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, repoRule))
+	inactiveRule := &issues_model.TriageRule{
+		RepoID:    3,
+		Name:      "Inactive",
+		Event:     issues_model.TriageEventOpened,
+		Actions:   issues_model.TriageActions{Close: true},
+		CreatorID: 2,
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, inactiveRule))
+
+	rule, err := issues_model.GetTriageRuleByID(db.DefaultContext, orgRule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "(?i)crash", rule.Conditions.TitleRegex)
+	assert.Equal(t, []string{"bug"}, rule.Actions.AddLabels)
+
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	rules, err := issues_model.GetActiveTriageRules(db.DefaultContext, repo3, issues_model.TriageEventOpened)
+	require.NoError(t, err)
+	if assert.Len(t, rules, 2) {
+		assert.Equal(t, orgRule.ID, rules[0].ID)
+		assert.Equal(t, repoRule.ID, rules[1].ID)
+	}
+	rules, err = issues_model.GetActiveTriageRules(db.DefaultContext, repo3, issues_model.TriageEventCommented)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	orgRules, err := db.Find[issues_model.TriageRule](db.DefaultContext, issues_model.FindTriageRulesOptions{OwnerID: 3})
+	require.NoError(t, err)
+	assert.Len(t, orgRules, 1)
+
+	require.NoError(t, issues_model.DeleteTriageRulesByRepoID(db.DefaultContext, 3))
+	unittest.AssertNotExistsBean(t, &issues_model.TriageRule{ID: repoRule.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.TriageRule{ID: orgRule.ID})
+
+	require.NoError(t, issues_model.DeleteTriageRule(db.DefaultContext, orgRule.ID))
+	_, err = issues_model.GetTriageRuleByID(db.DefaultContext, orgRule.ID)
+	assert.True(t, issues_model.IsErrTriageRuleNotExist(err))
+}
+
+func TestGetTriageStaleIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	rule := &issues_model.TriageRule{
+		RepoID:    1,
+		Name:      "Stale",
+		Event:     issues_model.TriageEventStale,
+		StaleDays: 30,
+		Actions:   issues_model.TriageActions{AddLabels: []string{"stale"}},
+		IsActive:  true,
+		CreatorID: 2,
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, rule))
+
+	issueIDs := func(issues issues_model.IssueList) []int64 {
+		ids := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	issues, err := issues_model.GetTriageStaleIssues(db.DefaultContext, rule, 1000000000, 0, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, issueIDs(issues))
+
+	issues, err = issues_model.GetTriageStaleIssues(db.DefaultContext, rule, 1000000000, 1, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, issueIDs(issues))
+
+	// the rule does not run again on an issue which was not updated since it ran
+	require.NoError(t, issues_model.CreateTriageLog(db.DefaultContext, &issues_model.TriageLog{RuleID: rule.ID, IssueID: 2, Event: issues_model.TriageEventStale}))
+	issues, err = issues_model.GetTriageStaleIssues(db.DefaultContext, rule, timeutil.TimeStampNow()+1, 0, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 3, 11}, issueIDs(issues))
+}
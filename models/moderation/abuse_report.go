@@ -0,0 +1,444 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ReportStatus is the status of an abuse report
+type ReportStatus int
+
+const (
+	// ReportStatusOpen is the status of a report waiting for a moderator
+	ReportStatusOpen ReportStatus = iota + 1
+
+	// ReportStatusHandled is the status of a report on which a moderator took an action
+	ReportStatusHandled
+
+	// ReportStatusIgnored is the status of a report dismissed by a moderator
+	ReportStatusIgnored
+)
+
+var reportStatusNames = map[ReportStatus]string{
+	ReportStatusOpen:    "open",
+	ReportStatusHandled: "handled",
+	ReportStatusIgnored: "ignored",
+}
+
+// String returns the name of the report status
+func (s ReportStatus) String() string {
+	return reportStatusNames[s]
+}
+
+// ReportStatusFromString returns the report status of a name, or 0 if the name is unknown
+func ReportStatusFromString(name string) ReportStatus {
+	for s, n := range reportStatusNames {
+		if n == name {
+			return s
+		}
+	}
+	return 0
+}
+
+// ContentType is the type of the reported content
+type ContentType int
+
+const (
+	// ContentTypeUser is the type of reported user accounts and organizations
+	ContentTypeUser ContentType = iota + 1
+
+	// ContentTypeRepository is the type of reported repositories
+	ContentTypeRepository
+
+	// ContentTypeIssue is the type of reported issues and pull requests
+	ContentTypeIssue
+
+	// ContentTypeComment is the type of reported comments
+	ContentTypeComment
+)
+
+var contentTypeNames = map[ContentType]string{
+	ContentTypeUser:       "user",
+	ContentTypeRepository: "repository",
+	ContentTypeIssue:      "issue",
+	ContentTypeComment:    "comment",
+}
+
+// String returns the name of the content type
+func (t ContentType) String() string {
+	return contentTypeNames[t]
+}
+
+// ContentTypeFromString returns the content type of a name, or 0 if the name is unknown
+func ContentTypeFromString(name string) ContentType {
+	for t, n := range contentTypeNames {
+		if n == name {
+			return t
+		}
+	}
+	return 0
+}
+
+// Category is the reason why content is reported
+type Category int
+
+const (
+	// CategorySpam is the category of unsolicited advertising
+	CategorySpam Category = iota + 1
+
+	// CategoryMalware is the category of content distributing malicious software
+	CategoryMalware
+
+	// CategoryIllegalContent is the category of content that is illegal
+	CategoryIllegalContent
+
+	// CategoryHarassment is the category of abusive behavior towards other users
+	CategoryHarassment
+
+	// CategoryOther is the category of the reports that do not fit in any other category
+	CategoryOther
+)
+
+var categoryNames = map[Category]string{
+	CategorySpam:           "spam",
+	CategoryMalware:        "malware",
+	CategoryIllegalContent: "illegal_content",
+	CategoryHarassment:     "harassment",
+	CategoryOther:          "other",
+}
+
+// Categories returns all the report categories in the order they are shown to reporters
+func Categories() []Category {
+	return []Category{CategorySpam, CategoryMalware, CategoryIllegalContent, CategoryHarassment, CategoryOther}
+}
+
+// String returns the name of the category
+func (c Category) String() string {
+	return categoryNames[c]
+}
+
+// CategoryFromString returns the category of a name, or 0 if the name is unknown
+func CategoryFromString(name string) Category {
+	for c, n := range categoryNames {
+		if n == name {
+			return c
+		}
+	}
+	return 0
+}
+
+// Action is what a moderator did to resolve a report
+type Action int
+
+const (
+	// ActionNone is the action of the reports dismissed without changing the content
+	ActionNone Action = iota
+
+	// ActionHideContent hides the content of an issue or a comment
+	ActionHideContent
+
+	// ActionSuspendUser prohibits a user, or the poster of an issue or a comment, to sign in
+	ActionSuspendUser
+
+	// ActionDeleteRepository deletes a repository
+	ActionDeleteRepository
+)
+
+var actionNames = map[Action]string{
+	ActionNone:             "none",
+	ActionHideContent:      "hide_content",
+	ActionSuspendUser:      "suspend_user",
+	ActionDeleteRepository: "delete_repository",
+}
+
+// String returns the name of the action
+func (a Action) String() string {
+	return actionNames[a]
+}
+
+// ActionFromString returns the action of a name, and false if the name is unknown
+func ActionFromString(name string) (Action, bool) {
+	for a, n := range actionNames {
+		if n == name {
+			return a, true
+		}
+	}
+	return ActionNone, false
+}
+
+// Actions returns the actions a moderator can take on a type of content, besides dismissing its reports
+func (t ContentType) Actions() []Action {
+	switch t {
+	case ContentTypeUser:
+		return []Action{ActionSuspendUser}
+	case ContentTypeRepository:
+		return []Action{ActionDeleteRepository}
+	case ContentTypeIssue, ContentTypeComment:
+		return []Action{ActionHideContent, ActionSuspendUser}
+	}
+	return nil
+}
+
+// ErrAbuseReportNotExist represents a "report does not exist" error
+type ErrAbuseReportNotExist struct {
+	ID int64
+}
+
+// IsErrAbuseReportNotExist checks if an error is a ErrAbuseReportNotExist
+func IsErrAbuseReportNotExist(err error) bool {
+	_, ok := err.(ErrAbuseReportNotExist)
+	return ok
+}
+
+func (err ErrAbuseReportNotExist) Error() string {
+	return fmt.Sprintf("abuse report does not exist [id: %d]", err.ID)
+}
+
+func (err ErrAbuseReportNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrAbuseReportAlreadyExists represents a "user already reported this content" error
+type ErrAbuseReportAlreadyExists struct {
+	ReporterID  int64
+	ContentType ContentType
+	ContentID   int64
+}
+
+// IsErrAbuseReportAlreadyExists checks if an error is a ErrAbuseReportAlreadyExists
+func IsErrAbuseReportAlreadyExists(err error) bool {
+	_, ok := err.(ErrAbuseReportAlreadyExists)
+	return ok
+}
+
+func (err ErrAbuseReportAlreadyExists) Error() string {
+	return fmt.Sprintf("abuse report already exists [reporter_id: %d, content_type: %s, content_id: %d]", err.ReporterID, err.ContentType, err.ContentID)
+}
+
+func (err ErrAbuseReportAlreadyExists) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrAbuseReportResolved represents a "report is already resolved" error
+type ErrAbuseReportResolved struct {
+	ID int64
+}
+
+// IsErrAbuseReportResolved checks if an error is a ErrAbuseReportResolved
+func IsErrAbuseReportResolved(err error) bool {
+	_, ok := err.(ErrAbuseReportResolved)
+	return ok
+}
+
+func (err ErrAbuseReportResolved) Error() string {
+	return fmt.Sprintf("abuse report is already resolved [id: %d]", err.ID)
+}
+
+func (err ErrAbuseReportResolved) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// AbuseReport is a report of a user about content that breaks the rules of the instance
+type AbuseReport struct {
+	ID          int64            `xorm:"pk autoincr"`
+	Status      ReportStatus     `xorm:"INDEX NOT NULL DEFAULT 1"`
+	ReporterID  int64            `xorm:"INDEX NOT NULL"`
+	Reporter    *user_model.User `xorm:"-"`
+	ContentType ContentType      `xorm:"INDEX(s) NOT NULL"`
+	ContentID   int64            `xorm:"INDEX(s) NOT NULL"`
+	// ContentReference names the content when it was reported, e.g. owner/repo#12, so that
+	// moderators know what it was after it is deleted
+	ContentReference string `xorm:"VARCHAR(255)"`
+	// ContentLink is the relative link to the content when it was reported
+	ContentLink string   `xorm:"TEXT"`
+	Category    Category `xorm:"NOT NULL"`
+	Remarks     string   `xorm:"TEXT"`
+
+	ResolverID   int64            `xorm:"NOT NULL DEFAULT 0"`
+	Resolver     *user_model.User `xorm:"-"`
+	Action       Action           `xorm:"NOT NULL DEFAULT 0"`
+	ActionReason string           `xorm:"TEXT"`
+
+	CreatedUnix  timeutil.TimeStamp `xorm:"INDEX created"`
+	ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(AbuseReport))
+}
+
+// IsOpen returns true if no moderator resolved the report yet
+func (r *AbuseReport) IsOpen() bool {
+	return r.Status == ReportStatusOpen
+}
+
+// LoadAttributes loads the reporter and the resolver of the report
+func (r *AbuseReport) LoadAttributes(ctx context.Context) error {
+	return AbuseReportList{r}.LoadAttributes(ctx)
+}
+
+// AbuseReportList is a list of abuse reports
+type AbuseReportList []*AbuseReport
+
+// LoadAttributes loads the reporters and the resolvers of the reports
+func (reports AbuseReportList) LoadAttributes(ctx context.Context) error {
+	userIDs := make(container.Set[int64], len(reports))
+	for _, r := range reports {
+		userIDs.Add(r.ReporterID)
+		if r.ResolverID > 0 {
+			userIDs.Add(r.ResolverID)
+		}
+	}
+	users, err := user_model.GetPossibleUserByIDs(ctx, userIDs.Values())
+	if err != nil {
+		return err
+	}
+	userMap := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		userMap[u.ID] = u
+	}
+	for _, r := range reports {
+		r.Reporter = userMap[r.ReporterID]
+		if r.Reporter == nil {
+			r.Reporter = user_model.NewGhostUser()
+		}
+		if r.ResolverID > 0 {
+			r.Resolver = userMap[r.ResolverID]
+			if r.Resolver == nil {
+				r.Resolver = user_model.NewGhostUser()
+			}
+		}
+	}
+	return nil
+}
+
+// CreateAbuseReport saves a new report, unless the reporter already has an open report about the same content
+func CreateAbuseReport(ctx context.Context, report *AbuseReport) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Exist(&AbuseReport{
+			ReporterID:  report.ReporterID,
+			ContentType: report.ContentType,
+			ContentID:   report.ContentID,
+			Status:      ReportStatusOpen,
+		})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrAbuseReportAlreadyExists{ReporterID: report.ReporterID, ContentType: report.ContentType, ContentID: report.ContentID}
+		}
+
+		report.Status = ReportStatusOpen
+		return db.Insert(ctx, report)
+	})
+}
+
+// GetAbuseReportByID returns the abuse report with the given ID
+func GetAbuseReportByID(ctx context.Context, id int64) (*AbuseReport, error) {
+	report := new(AbuseReport)
+	has, err := db.GetEngine(ctx).ID(id).Get(report)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAbuseReportNotExist{ID: id}
+	}
+	return report, nil
+}
+
+// FindReportsOptions are the options to search the abuse reports
+type FindReportsOptions struct {
+	db.ListOptions
+	Status      ReportStatus
+	ContentType ContentType
+	ContentID   int64
+	Category    Category
+	ReporterID  int64
+}
+
+// ToConds implements db.FindOptions
+func (opts FindReportsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.Status > 0 {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	if opts.ContentType > 0 {
+		cond = cond.And(builder.Eq{"content_type": opts.ContentType})
+	}
+	if opts.ContentID > 0 {
+		cond = cond.And(builder.Eq{"content_id": opts.ContentID})
+	}
+	if opts.Category > 0 {
+		cond = cond.And(builder.Eq{"category": opts.Category})
+	}
+	if opts.ReporterID > 0 {
+		cond = cond.And(builder.Eq{"reporter_id": opts.ReporterID})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder, the oldest reports are handled first
+func (opts FindReportsOptions) ToOrders() string {
+	return "created_unix ASC, id ASC"
+}
+
+// ResolveReportsOptions describes how a moderator resolves the open reports about some content
+type ResolveReportsOptions struct {
+	ContentType ContentType
+	ContentID   int64
+	Resolver    *user_model.User
+	Action      Action
+	Reason      string
+}
+
+// ResolveReports resolves all the open reports about some content at once with the action of a moderator,
+// and returns the resolved reports
+func ResolveReports(ctx context.Context, opts ResolveReportsOptions) (AbuseReportList, error) {
+	var reports AbuseReportList
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.GetEngine(ctx).Where(builder.Eq{
+			"content_type": opts.ContentType,
+			"content_id":   opts.ContentID,
+			"status":       ReportStatusOpen,
+		}).Find(&reports); err != nil {
+			return err
+		}
+		if len(reports) == 0 {
+			return nil
+		}
+
+		status := ReportStatusHandled
+		if opts.Action == ActionNone {
+			status = ReportStatusIgnored
+		}
+		now := timeutil.TimeStampNow()
+		for _, r := range reports {
+			r.Status = status
+			r.ResolverID = opts.Resolver.ID
+			r.Resolver = opts.Resolver
+			r.Action = opts.Action
+			r.ActionReason = opts.Reason
+			r.ResolvedUnix = now
+			if _, err := db.GetEngine(ctx).ID(r.ID).Cols("status", "resolver_id", "action", "action_reason", "resolved_unix").Update(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return reports, err
+}
+
+// CountOpenReports returns the number of reports waiting for a moderator
+func CountOpenReports(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Where("status = ?", ReportStatusOpen).Count(new(AbuseReport))
+}
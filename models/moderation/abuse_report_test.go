@@ -0,0 +1,106 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbuseReports(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	newReport := func(reporterID int64) *AbuseReport {
+		return &AbuseReport{
+			ReporterID:       reporterID,
+			ContentType:      ContentTypeIssue,
+			ContentID:        1,
+			ContentReference: "user2/repo1#1",
+			Category:         CategorySpam,
+		}
+	}
+
+	report := newReport(4)
+	require.NoError(t, CreateAbuseReport(db.DefaultContext, report))
+	assert.True(t, report.IsOpen())
+
+	err := CreateAbuseReport(db.DefaultContext, newReport(4))
+	assert.True(t, IsErrAbuseReportAlreadyExists(err))
+
+	require.NoError(t, CreateAbuseReport(db.DefaultContext, newReport(5)))
+	count, err := CountOpenReports(db.DefaultContext)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	t.Run("Find", func(t *testing.T) {
+		reports, err := db.Find[AbuseReport](db.DefaultContext, FindReportsOptions{ReporterID: 5})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.NoError(t, AbuseReportList(reports).LoadAttributes(db.DefaultContext))
+		assert.EqualValues(t, 5, reports[0].Reporter.ID)
+
+		reports, err = db.Find[AbuseReport](db.DefaultContext, FindReportsOptions{Category: CategoryMalware})
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		resolved, err := ResolveReports(db.DefaultContext, ResolveReportsOptions{
+			ContentType: ContentTypeIssue,
+			ContentID:   1,
+			Resolver:    admin,
+			Action:      ActionHideContent,
+			Reason:      "advertising",
+		})
+		require.NoError(t, err)
+		assert.Len(t, resolved, 2)
+
+		report, err := GetAbuseReportByID(db.DefaultContext, report.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ReportStatusHandled, report.Status)
+		assert.Equal(t, ActionHideContent, report.Action)
+		assert.Equal(t, "advertising", report.ActionReason)
+		assert.EqualValues(t, 1, report.ResolverID)
+		assert.NotZero(t, report.ResolvedUnix)
+
+		count, err := CountOpenReports(db.DefaultContext)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		// the reporter can report the content again once the moderators resolved their report
+		require.NoError(t, CreateAbuseReport(db.DefaultContext, newReport(4)))
+		resolved, err = ResolveReports(db.DefaultContext, ResolveReportsOptions{
+			ContentType: ContentTypeIssue,
+			ContentID:   1,
+			Resolver:    admin,
+			Action:      ActionNone,
+		})
+		require.NoError(t, err)
+		require.Len(t, resolved, 1)
+		assert.Equal(t, ReportStatusIgnored, resolved[0].Status)
+	})
+
+	_, err = GetAbuseReportByID(db.DefaultContext, 1000)
+	assert.True(t, IsErrAbuseReportNotExist(err))
+}
+
+func TestContentTypeActions(t *testing.T) {
+	assert.Equal(t, []Action{ActionSuspendUser}, ContentTypeUser.Actions())
+	assert.Equal(t, []Action{ActionDeleteRepository}, ContentTypeRepository.Actions())
+	assert.Equal(t, []Action{ActionHideContent, ActionSuspendUser}, ContentTypeComment.Actions())
+	assert.Empty(t, ContentType(0).Actions())
+
+	action, ok := ActionFromString("hide_content")
+	assert.True(t, ok)
+	assert.Equal(t, ActionHideContent, action)
+	_, ok = ActionFromString("ban")
+	assert.False(t, ok)
+}
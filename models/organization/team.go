@@ -86,6 +86,12 @@ type Team struct {
 	Units                   []*TeamUnit `xorm:"-"`
 	IncludesAllRepositories bool        `xorm:"NOT NULL DEFAULT false"`
 	CanCreateOrgRepo        bool        `xorm:"NOT NULL DEFAULT false"`
+
+	ReviewAssignment                ReviewAssignmentAlgorithm `xorm:"NOT NULL DEFAULT ''"`
+	ReviewAssignmentCount           int                       `xorm:"NOT NULL DEFAULT 1"`
+	ReviewAssignmentMaxPending      int                       `xorm:"NOT NULL DEFAULT 0"` // members with this many pending review requests are skipped, 0 for no limit
+	ReviewAssignmentExcludedUserIDs []int64                   `xorm:"JSON TEXT"`          // members who are never assigned
+	ReviewAssignmentLastUserID      int64                     `xorm:"NOT NULL DEFAULT 0"` // member assigned last by round robin
 }
 
 func init() {
@@ -94,6 +100,7 @@ func init() {
 	db.RegisterModel(new(TeamRepo))
 	db.RegisterModel(new(TeamUnit))
 	db.RegisterModel(new(TeamInvite))
+	db.RegisterModel(new(TeamReviewManager))
 }
 
 func (t *Team) LogString() string {
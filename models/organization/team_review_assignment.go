@@ -0,0 +1,91 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization
+
+import (
+	"context"
+	"slices"
+
+	"forgejo.org/models/db"
+)
+
+// ReviewAssignmentAlgorithm is how the members of a team are chosen when the team is requested to review a
+// pull request
+type ReviewAssignmentAlgorithm string
+
+const (
+	// ReviewAssignmentNone requests the review from the team as a whole
+	ReviewAssignmentNone ReviewAssignmentAlgorithm = ""
+	// ReviewAssignmentRoundRobin chooses the members in turn
+	ReviewAssignmentRoundRobin ReviewAssignmentAlgorithm = "round_robin"
+	// ReviewAssignmentLoadBalance chooses the members with the fewest pending review requests
+	ReviewAssignmentLoadBalance ReviewAssignmentAlgorithm = "load_balance"
+)
+
+// IsValid returns true if the algorithm is a known one
+func (a ReviewAssignmentAlgorithm) IsValid() bool {
+	return a == ReviewAssignmentNone || a == ReviewAssignmentRoundRobin || a == ReviewAssignmentLoadBalance
+}
+
+// HasReviewAssignment returns true if review requests for the team are replaced by requests for some of its members
+func (t *Team) HasReviewAssignment() bool {
+	return t.ReviewAssignment != ReviewAssignmentNone
+}
+
+// IsReviewAssignmentExcluded returns true if the member is never assigned to review for the team
+func (t *Team) IsReviewAssignmentExcluded(userID int64) bool {
+	return slices.Contains(t.ReviewAssignmentExcludedUserIDs, userID)
+}
+
+// TeamReviewManager is a member of a team managing a user, who is never assigned to review the pull requests
+// authored by the user when the team is requested to review them
+type TeamReviewManager struct {
+	ID        int64 `xorm:"pk autoincr"`
+	TeamID    int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	UserID    int64 `xorm:"UNIQUE(s) NOT NULL"`
+	ManagerID int64 `xorm:"UNIQUE(s) NOT NULL"`
+}
+
+// GetTeamReviewManagers returns the members of a team managing users
+func GetTeamReviewManagers(ctx context.Context, teamID int64) ([]*TeamReviewManager, error) {
+	managers := make([]*TeamReviewManager, 0, 5)
+	return managers, db.GetEngine(ctx).Where("team_id = ?", teamID).Asc("user_id", "manager_id").Find(&managers)
+}
+
+// GetTeamReviewManagerIDs returns the IDs of the members of a team managing a user
+func GetTeamReviewManagerIDs(ctx context.Context, teamID, userID int64) ([]int64, error) {
+	managerIDs := make([]int64, 0, 2)
+	return managerIDs, db.GetEngine(ctx).Table("team_review_manager").
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		Cols("manager_id").
+		Find(&managerIDs)
+}
+
+// UpdateTeamReviewAssignment updates the review assignment policy of a team and replaces its managers
+func UpdateTeamReviewAssignment(ctx context.Context, t *Team, managers []*TeamReviewManager) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(t.ID).
+			Cols("review_assignment", "review_assignment_count", "review_assignment_max_pending", "review_assignment_excluded_user_ids").
+			Update(t); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("team_id = ?", t.ID).Delete(new(TeamReviewManager)); err != nil {
+			return err
+		}
+		if len(managers) == 0 {
+			return nil
+		}
+		for _, manager := range managers {
+			manager.TeamID = t.ID
+		}
+		return db.Insert(ctx, managers)
+	})
+}
+
+// UpdateTeamReviewAssignmentLastUser records the member last assigned to review for a team by round robin
+func UpdateTeamReviewAssignmentLastUser(ctx context.Context, t *Team, userID int64) error {
+	t.ReviewAssignmentLastUserID = userID
+	_, err := db.GetEngine(ctx).ID(t.ID).Cols("review_assignment_last_user_id").Update(t)
+	return err
+}
@@ -0,0 +1,430 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// FieldType is the type of the values of a custom field
+type FieldType uint8
+
+const (
+	// FieldTypeText is a field whose values are free text
+	FieldTypeText FieldType = iota + 1
+
+	// FieldTypeNumber is a field whose values are numbers
+	FieldTypeNumber
+
+	// FieldTypeDate is a field whose values are dates
+	FieldTypeDate
+
+	// FieldTypeSingleSelect is a field whose values are one of its options
+	FieldTypeSingleSelect
+
+	// FieldTypeIteration is a field whose values are one of its iterations
+	FieldTypeIteration
+)
+
+// FieldDateFormat is the format of the values of the date fields
+const FieldDateFormat = "2006-01-02"
+
+var fieldTypeNames = map[FieldType]string{
+	FieldTypeText:         "text",
+	FieldTypeNumber:       "number",
+	FieldTypeDate:         "date",
+	FieldTypeSingleSelect: "single_select",
+	FieldTypeIteration:    "iteration",
+}
+
+// String returns the name of the field type
+func (t FieldType) String() string {
+	return fieldTypeNames[t]
+}
+
+// FieldTypeFromString returns the field type of a name, or 0 if the name is unknown
+func FieldTypeFromString(name string) FieldType {
+	for t, n := range fieldTypeNames {
+		if n == name {
+			return t
+		}
+	}
+	return 0
+}
+
+// FieldOption is an option of a single select field
+type FieldOption struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// FieldIteration is an iteration of an iteration field
+type FieldIteration struct {
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	Duration  int    `json:"duration"` // in days
+}
+
+// EndDate returns the last day of the iteration
+func (it *FieldIteration) EndDate() string {
+	start, err := time.Parse(FieldDateFormat, it.StartDate)
+	if err != nil {
+		return ""
+	}
+	return start.AddDate(0, 0, max(it.Duration, 1)-1).Format(FieldDateFormat)
+}
+
+// ErrProjectFieldNotExist represents a "ProjectFieldNotExist" kind of error.
+type ErrProjectFieldNotExist struct {
+	ID int64
+}
+
+// IsErrProjectFieldNotExist checks if an error is a ErrProjectFieldNotExist
+func IsErrProjectFieldNotExist(err error) bool {
+	_, ok := err.(ErrProjectFieldNotExist)
+	return ok
+}
+
+func (err ErrProjectFieldNotExist) Error() string {
+	return fmt.Sprintf("project field does not exist [id: %d]", err.ID)
+}
+
+func (err ErrProjectFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// Field is a custom field of the issues of a project, or of all the projects of an organization
+type Field struct {
+	ID         int64             `xorm:"pk autoincr"`
+	OwnerID    int64             `xorm:"INDEX NOT NULL DEFAULT 0"` // the organization of a field shared by its projects
+	ProjectID  int64             `xorm:"INDEX NOT NULL DEFAULT 0"` // the project of a field of a single project
+	Name       string            `xorm:"NOT NULL"`
+	Type       FieldType         `xorm:"NOT NULL"`
+	Options    []*FieldOption    `xorm:"JSON TEXT"`
+	Iterations []*FieldIteration `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (Field) TableName() string {
+	return "project_field"
+}
+
+// FieldValue is the value of a custom field for an issue
+type FieldValue struct {
+	ID      int64  `xorm:"pk autoincr"`
+	FieldID int64  `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value   string `xorm:"TEXT"`
+}
+
+// TableName return the real table name
+func (FieldValue) TableName() string {
+	return "project_field_value"
+}
+
+func init() {
+	db.RegisterModel(new(Field))
+	db.RegisterModel(new(FieldValue))
+}
+
+// IsOrganizationField returns true if the field is shared by all the projects of an organization
+func (f *Field) IsOrganizationField() bool {
+	return f.ProjectID == 0
+}
+
+// Validate checks the name, the type, the options and the iterations of the field
+func (f *Field) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of the field is empty")
+	}
+	if _, ok := fieldTypeNames[f.Type]; !ok {
+		return util.NewInvalidArgumentErrorf("unknown field type %d", f.Type)
+	}
+
+	names := make([]string, 0, len(f.Options)+len(f.Iterations))
+	switch f.Type {
+	case FieldTypeSingleSelect:
+		if len(f.Options) == 0 {
+			return util.NewInvalidArgumentErrorf("a single select field needs options")
+		}
+		for _, option := range f.Options {
+			option.Name = strings.TrimSpace(option.Name)
+			if option.Color != "" && !ColumnColorPattern.MatchString(option.Color) {
+				return util.NewInvalidArgumentErrorf("bad color code: %s", option.Color)
+			}
+			names = append(names, option.Name)
+		}
+		f.Iterations = nil
+	case FieldTypeIteration:
+		if len(f.Iterations) == 0 {
+			return util.NewInvalidArgumentErrorf("an iteration field needs iterations")
+		}
+		for _, iteration := range f.Iterations {
+			iteration.Title = strings.TrimSpace(iteration.Title)
+			if _, err := time.Parse(FieldDateFormat, iteration.StartDate); err != nil {
+				return util.NewInvalidArgumentErrorf("bad start date of iteration %q: %s", iteration.Title, iteration.StartDate)
+			}
+			if iteration.Duration <= 0 {
+				return util.NewInvalidArgumentErrorf("the duration of iteration %q is not positive", iteration.Title)
+			}
+			names = append(names, iteration.Title)
+		}
+		f.Options = nil
+	default:
+		f.Options = nil
+		f.Iterations = nil
+	}
+
+	for i, name := range names {
+		if name == "" {
+			return util.NewInvalidArgumentErrorf("an option of the field has no name")
+		}
+		if slices.Contains(names[:i], name) {
+			return util.NewInvalidArgumentErrorf("the option %q of the field is duplicated", name)
+		}
+	}
+	return nil
+}
+
+// NormalizeValue checks a value of the field and returns its canonical form. An empty value unsets the field.
+func (f *Field) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	switch f.Type {
+	case FieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("%q is not a number", value)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case FieldTypeDate:
+		date, err := time.Parse(FieldDateFormat, value)
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("%q is not a date formatted as YYYY-MM-DD", value)
+		}
+		return date.Format(FieldDateFormat), nil
+	case FieldTypeSingleSelect:
+		for _, option := range f.Options {
+			if option.Name == value {
+				return value, nil
+			}
+		}
+		return "", util.NewInvalidArgumentErrorf("%q is not an option of the field %q", value, f.Name)
+	case FieldTypeIteration:
+		for _, iteration := range f.Iterations {
+			if iteration.Title == value {
+				return value, nil
+			}
+		}
+		return "", util.NewInvalidArgumentErrorf("%q is not an iteration of the field %q", value, f.Name)
+	}
+	return value, nil
+}
+
+// Option returns the option of a single select field with the given name
+func (f *Field) Option(name string) *FieldOption {
+	for _, option := range f.Options {
+		if option.Name == name {
+			return option
+		}
+	}
+	return nil
+}
+
+// Iteration returns the iteration of an iteration field with the given title
+func (f *Field) Iteration(title string) *FieldIteration {
+	for _, iteration := range f.Iterations {
+		if iteration.Title == title {
+			return iteration
+		}
+	}
+	return nil
+}
+
+// NewField creates a custom field of a project, or of an organization if its project is 0
+func NewField(ctx context.Context, f *Field) error {
+	if (f.ProjectID == 0) == (f.OwnerID == 0) {
+		return util.NewInvalidArgumentErrorf("a field belongs either to a project or to an organization")
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, f)
+}
+
+// GetFieldByID returns a custom field by its id
+func GetFieldByID(ctx context.Context, id int64) (*Field, error) {
+	f := new(Field)
+	has, err := db.GetEngine(ctx).ID(id).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectFieldNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// GetOrgFields returns the custom fields shared by all the projects of an organization
+func GetOrgFields(ctx context.Context, ownerID int64) ([]*Field, error) {
+	fields := make([]*Field, 0, 5)
+	return fields, db.GetEngine(ctx).Where("owner_id=? AND project_id=0", ownerID).OrderBy("id").Find(&fields)
+}
+
+// GetFields returns the custom fields of the project, starting with the fields of its organization
+func (p *Project) GetFields(ctx context.Context) ([]*Field, error) {
+	ownerID := p.OwnerID
+	if p.RepoID > 0 {
+		if err := p.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+		ownerID = p.Repo.OwnerID
+	}
+
+	cond := builder.Eq{"project_id": p.ID}.Or(builder.Eq{"project_id": 0, "owner_id": ownerID})
+	fields := make([]*Field, 0, 5)
+	return fields, db.GetEngine(ctx).Where(cond).OrderBy("project_id, id").Find(&fields)
+}
+
+// HasField returns true if the field is a field of the project
+func (p *Project) HasField(ctx context.Context, f *Field) (bool, error) {
+	if !f.IsOrganizationField() {
+		return f.ProjectID == p.ID, nil
+	}
+	if p.RepoID > 0 {
+		if err := p.LoadRepo(ctx); err != nil {
+			return false, err
+		}
+		return f.OwnerID == p.Repo.OwnerID, nil
+	}
+	return f.OwnerID == p.OwnerID, nil
+}
+
+// UpdateField updates the name, the options and the iterations of a custom field. The values of the
+// issues which are not an option or an iteration of the field anymore are removed.
+func UpdateField(ctx context.Context, f *Field) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "options", "iterations").Update(f); err != nil {
+			return err
+		}
+
+		var names []string
+		switch f.Type {
+		case FieldTypeSingleSelect:
+			for _, option := range f.Options {
+				names = append(names, option.Name)
+			}
+		case FieldTypeIteration:
+			for _, iteration := range f.Iterations {
+				names = append(names, iteration.Title)
+			}
+		default:
+			return nil
+		}
+		_, err := db.GetEngine(ctx).Where("field_id=?", f.ID).NotIn("value", names).Delete(new(FieldValue))
+		return err
+	})
+}
+
+// DeleteFieldByID deletes a custom field and its values
+func DeleteFieldByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("field_id=?", id).Delete(new(FieldValue)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(new(Field))
+		return err
+	})
+}
+
+func deleteFieldsByCond(ctx context.Context, cond builder.Cond) error {
+	if _, err := db.GetEngine(ctx).In("field_id", builder.Select("id").From("project_field").Where(cond)).Delete(new(FieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(cond).Delete(new(Field))
+	return err
+}
+
+// DeleteFieldsByOwnerID deletes the custom fields of the organization and their values
+func DeleteFieldsByOwnerID(ctx context.Context, ownerID int64) error {
+	return deleteFieldsByCond(ctx, builder.Eq{"owner_id": ownerID, "project_id": 0})
+}
+
+// SetIssueFieldValue sets the value of a custom field for an issue, or removes it if the value is empty.
+// It returns the previous value.
+func SetIssueFieldValue(ctx context.Context, f *Field, issueID int64, value string) (oldValue string, err error) {
+	value, err = f.NormalizeValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		fieldValue := &FieldValue{FieldID: f.ID, IssueID: issueID}
+		has, err := db.GetEngine(ctx).Get(fieldValue)
+		if err != nil {
+			return err
+		}
+		oldValue = fieldValue.Value
+
+		switch {
+		case value == "" && has:
+			_, err = db.GetEngine(ctx).ID(fieldValue.ID).Delete(new(FieldValue))
+		case value == "" || value == oldValue:
+		case has:
+			fieldValue.Value = value
+			_, err = db.GetEngine(ctx).ID(fieldValue.ID).Cols("value").Update(fieldValue)
+		default:
+			fieldValue.Value = value
+			err = db.Insert(ctx, fieldValue)
+		}
+		return err
+	})
+	return oldValue, err
+}
+
+// GetIssueFieldValues returns the values of the custom fields of an issue by field id
+func GetIssueFieldValues(ctx context.Context, issueID int64) (map[int64]string, error) {
+	values, err := GetFieldValuesByIssueIDs(ctx, []int64{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return values[issueID], nil
+}
+
+// GetFieldValuesByIssueIDs returns the values of the custom fields of issues by issue id and field id
+func GetFieldValuesByIssueIDs(ctx context.Context, issueIDs []int64) (map[int64]map[int64]string, error) {
+	fieldValues := make([]*FieldValue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).In("issue_id", issueIDs).Find(&fieldValues); err != nil {
+		return nil, err
+	}
+
+	values := make(map[int64]map[int64]string, len(issueIDs))
+	for _, fieldValue := range fieldValues {
+		if values[fieldValue.IssueID] == nil {
+			values[fieldValue.IssueID] = make(map[int64]string)
+		}
+		values[fieldValue.IssueID][fieldValue.FieldID] = fieldValue.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldNormalizeValue(t *testing.T) {
+	cases := []struct {
+		field    *Field
+		value    string
+		expected string
+		valid    bool
+	}{
+		{&Field{Type: FieldTypeText}, " some text ", "some text", true},
+		{&Field{Type: FieldTypeNumber}, "1.50", "1.5", true},
+		{&Field{Type: FieldTypeNumber}, "one", "", false},
+		{&Field{Type: FieldTypeDate}, "2026-01-31", "2026-01-31", true},
+		{&Field{Type: FieldTypeDate}, "31/01/2026", "", false},
+		{&Field{Type: FieldTypeSingleSelect, Options: []*FieldOption{{Name: "high"}}}, "high", "high", true},
+		{&Field{Type: FieldTypeSingleSelect, Options: []*FieldOption{{Name: "high"}}}, "low", "", false},
+		{&Field{Type: FieldTypeIteration, Iterations: []*FieldIteration{{Title: "Sprint 1"}}}, "Sprint 1", "Sprint 1", true},
+		{&Field{Type: FieldTypeNumber}, "", "", true},
+	}
+
+	for _, c := range cases {
+		value, err := c.field.NormalizeValue(c.value)
+		if c.valid {
+			require.NoError(t, err, c.value)
+			assert.Equal(t, c.expected, value)
+		} else {
+			require.ErrorIs(t, err, util.ErrInvalidArgument, c.value)
+		}
+	}
+}
+
+func TestFieldValidate(t *testing.T) {
+	require.Error(t, (&Field{Name: " ", Type: FieldTypeText}).Validate())
+	require.Error(t, (&Field{Name: "Priority", Type: FieldTypeSingleSelect}).Validate())
+	require.Error(t, (&Field{Name: "Priority", Type: FieldTypeSingleSelect, Options: []*FieldOption{{Name: "high"}, {Name: "high"}}}).Validate())
+	require.Error(t, (&Field{Name: "Sprint", Type: FieldTypeIteration, Iterations: []*FieldIteration{{Title: "Sprint 1", StartDate: "2026-01-01"}}}).Validate())
+
+	iteration := &FieldIteration{Title: "Sprint 1", StartDate: "2026-01-01", Duration: 14}
+	require.NoError(t, (&Field{Name: "Sprint", Type: FieldTypeIteration, Iterations: []*FieldIteration{iteration}}).Validate())
+	assert.Equal(t, "2026-01-14", iteration.EndDate())
+}
+
+func TestIssueFieldValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	project := unittest.AssertExistsAndLoadBean(t, &Project{ID: 1})
+	field := &Field{
+		ProjectID: project.ID,
+		Name:      "Priority",
+		Type:      FieldTypeSingleSelect,
+		Options:   []*FieldOption{{Name: "high"}, {Name: "low"}},
+	}
+	require.NoError(t, NewField(db.DefaultContext, field))
+
+	fields, err := project.GetFields(db.DefaultContext)
+	require.NoError(t, err)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "Priority", fields[0].Name)
+
+	oldValue, err := SetIssueFieldValue(db.DefaultContext, field, 1, "high")
+	require.NoError(t, err)
+	assert.Empty(t, oldValue)
+	_, err = SetIssueFieldValue(db.DefaultContext, field, 1, "medium")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	values, err := GetIssueFieldValues(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]string{field.ID: "high"}, values)
+
+	// removing an option removes the values using it
+	field.Options = []*FieldOption{{Name: "low"}}
+	require.NoError(t, UpdateField(db.DefaultContext, field))
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: field.ID, IssueID: 1})
+
+	oldValue, err = SetIssueFieldValue(db.DefaultContext, field, 1, "low")
+	require.NoError(t, err)
+	assert.Empty(t, oldValue)
+	oldValue, err = SetIssueFieldValue(db.DefaultContext, field, 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, "low", oldValue)
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: field.ID, IssueID: 1})
+
+	require.NoError(t, DeleteFieldByID(db.DefaultContext, field.ID))
+	unittest.AssertNotExistsBean(t, &Field{ID: field.ID})
+}
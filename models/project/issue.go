@@ -62,6 +62,22 @@ func (p *Project) NumOpenIssues(ctx context.Context) int {
 	return int(c)
 }
 
+// GetProjectIssuesByIssueIDs returns the placement of the given issues in the project, by issue ID
+func GetProjectIssuesByIssueIDs(ctx context.Context, projectID int64, issueIDs []int64) (map[int64]*ProjectIssue, error) {
+	projectIssues := make(map[int64]*ProjectIssue, len(issueIDs))
+	if len(issueIDs) == 0 {
+		return projectIssues, nil
+	}
+	list := make([]*ProjectIssue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).Where("project_id=?", projectID).In("issue_id", issueIDs).Find(&list); err != nil {
+		return nil, err
+	}
+	for _, projectIssue := range list {
+		projectIssues[projectIssue.IssueID] = projectIssue
+	}
+	return projectIssues, nil
+}
+
 // MoveIssuesOnProjectColumn moves or keeps issues in a column and sorts them inside that column
 func MoveIssuesOnProjectColumn(ctx context.Context, column *Column, sortedIssueIDs map[int64]int64) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
@@ -0,0 +1,49 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+)
+
+// ObjectPoolsDir is the directory, relative to the repository root, where object pools are stored.
+// It starts with a dot so that it can never clash with a user name.
+const ObjectPoolsDir = ".object-pools"
+
+// ObjectPoolPath returns the path of the bare repository which holds the objects
+// shared through git alternates by the repositories of a fork network.
+func ObjectPoolPath(poolID int64) string {
+	return filepath.Join(setting.RepoRootPath, ObjectPoolsDir, strconv.FormatInt(poolID, 10)+".git")
+}
+
+// ObjectPoolPath returns the path of the object pool the repository borrows objects from
+func (repo *Repository) ObjectPoolPath() string {
+	return ObjectPoolPath(repo.ObjectPoolID)
+}
+
+// CanShareObjects returns true if the objects of the repository may be shared with other repositories,
+// only repositories visible to everyone are allowed to do so.
+func (repo *Repository) CanShareObjects(ctx context.Context) (bool, error) {
+	if repo.IsPrivate {
+		return false, nil
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return false, err
+	}
+	return repo.Owner.Visibility.IsPublic(), nil
+}
+
+// GetObjectPoolMembers returns the repositories which borrow objects from the given pool
+func GetObjectPoolMembers(ctx context.Context, poolID int64) ([]*Repository, error) {
+	repos := make([]*Repository, 0, 10)
+	return repos, db.GetEngine(ctx).
+		Where("object_pool_id=?", poolID).
+		OrderBy("id").
+		Find(&repos)
+}
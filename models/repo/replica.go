@@ -0,0 +1,94 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ReplicaRepository links a pull mirror of a replica instance to the repository it follows on the primary instance
+type ReplicaRepository struct {
+	ID            int64 `xorm:"pk autoincr"`
+	RepoID        int64 `xorm:"UNIQUE NOT NULL"`
+	PrimaryRepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// LastEventUnix is when the last push event was received from the primary
+	LastEventUnix timeutil.TimeStamp `xorm:"INDEX"`
+	// LastSyncUnix is when the last successful sync of the mirror started
+	LastSyncUnix timeutil.TimeStamp
+	Repo         *Repository `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(ReplicaRepository))
+}
+
+// IsLagging returns true if a push event was received after the last successful sync started
+func (r *ReplicaRepository) IsLagging() bool {
+	return r.LastEventUnix > r.LastSyncUnix
+}
+
+// Lag returns for how long the replica has been missing a push of the primary
+func (r *ReplicaRepository) Lag(now time.Time) time.Duration {
+	if !r.IsLagging() {
+		return 0
+	}
+	return now.Sub(r.LastEventUnix.AsTime()).Truncate(time.Second)
+}
+
+// GetReplicaRepositoryByPrimaryRepoID returns the replica of a repository of the primary instance
+func GetReplicaRepositoryByPrimaryRepoID(ctx context.Context, primaryRepoID int64) (*ReplicaRepository, bool, error) {
+	return db.Get[ReplicaRepository](ctx, builder.Eq{"primary_repo_id": primaryRepoID})
+}
+
+// InsertReplicaRepository records that a repository follows a repository of the primary instance
+func InsertReplicaRepository(ctx context.Context, r *ReplicaRepository) error {
+	return db.Insert(ctx, r)
+}
+
+// UpdateReplicaRepositoryEvent records that a push event was received for a replica repository
+func UpdateReplicaRepositoryEvent(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Cols("last_event_unix").
+		Update(&ReplicaRepository{LastEventUnix: timeutil.TimeStampNow()})
+	return err
+}
+
+// UpdateReplicaRepositorySync records that the sync of a replica repository which started at startUnix succeeded
+func UpdateReplicaRepositorySync(ctx context.Context, repoID int64, startUnix timeutil.TimeStamp) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Cols("last_sync_unix").
+		Update(&ReplicaRepository{LastSyncUnix: startUnix})
+	return err
+}
+
+// CountReplicaRepositories returns the number of repositories following the primary instance
+func CountReplicaRepositories(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(new(ReplicaRepository))
+}
+
+// FindLaggingReplicaRepositories returns the replica repositories which missed a push of the primary, the most lagging first
+func FindLaggingReplicaRepositories(ctx context.Context, limit int) ([]*ReplicaRepository, error) {
+	replicas := make([]*ReplicaRepository, 0, limit)
+	if err := db.GetEngine(ctx).Where("last_event_unix > last_sync_unix").
+		OrderBy("last_event_unix ASC").Limit(limit).Find(&replicas); err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]int64, 0, len(replicas))
+	for _, r := range replicas {
+		repoIDs = append(repoIDs, r.RepoID)
+	}
+	repos := make(map[int64]*Repository, len(repoIDs))
+	if err := db.GetEngine(ctx).In("id", repoIDs).Find(&repos); err != nil {
+		return nil, err
+	}
+	for _, r := range replicas {
+		r.Repo = repos[r.RepoID]
+	}
+	return replicas, nil
+}
@@ -184,6 +184,8 @@ type Repository struct {
 	CloseIssuesViaCommitInAnyBranch bool               `xorm:"NOT NULL DEFAULT false"`
 	Topics                          []string           `xorm:"TEXT JSON"`
 	ObjectFormatName                string             `xorm:"VARCHAR(6) NOT NULL DEFAULT 'sha1'"`
+	MaxPushedBlobSize               int64              `xorm:"NOT NULL DEFAULT 0"` // in bytes, 0 means unlimited
+	ObjectPoolID                    int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
 
 	TrustModel TrustModelType
 
@@ -291,6 +291,26 @@ func userOrgTeamUnitRepoCond(idStr string, userID int64, unitType unit.Type) bui
 	return builder.In(idStr, userOrgTeamUnitRepoBuilder(userID, unitType))
 }
 
+// UnitWriterRepositoryCondition returns a condition to select the repositories in which the user can write the unit,
+// as their owner, a collaborator with write access or a member of a team with write access to the unit
+func UnitWriterRepositoryCondition(user *user_model.User, unitType unit.Type) builder.Cond {
+	return builder.Or(
+		builder.Eq{"`repository`.owner_id": user.ID},
+		builder.In("`repository`.id", builder.Select("repo_id").
+			From("`collaboration`").
+			Where(builder.And(
+				builder.Eq{"`collaboration`.user_id": user.ID},
+				builder.Gte{"`collaboration`.mode": int(perm.AccessModeWrite)},
+			)),
+		),
+		builder.In("`repository`.id", userOrgTeamRepoBuilder(user.ID).
+			Join("INNER", "team_unit", "`team_unit`.team_id = `team_repo`.team_id").
+			Where(builder.Eq{"`team_unit`.`type`": unitType}).
+			And(builder.Gte{"`team_unit`.`access_mode`": int(perm.AccessModeWrite)}),
+		),
+	)
+}
+
 // UserOrgUnitRepoCond selects repos that the given user has access to through org and the special unit
 func UserOrgUnitRepoCond(idStr string, userID, orgID int64, unitType unit.Type) builder.Cond {
 	return builder.In(idStr,
@@ -0,0 +1,86 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+)
+
+// RepoMaintenance keeps track of the incremental git maintenance of a repository
+type RepoMaintenance struct { //revive:disable-line:exported
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// PushesSinceRun is the number of pushes received since the last maintenance started
+	PushesSinceRun int64              `xorm:"NOT NULL DEFAULT 0"`
+	LastRunUnix    timeutil.TimeStamp `xorm:"INDEX"`
+	LastDuration   int64              `xorm:"NOT NULL DEFAULT 0"` // in milliseconds
+	LastTasks      string
+	LastError      string `xorm:"TEXT"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoMaintenance))
+}
+
+// HasRun returns true if the repository has been maintained at least once
+func (m *RepoMaintenance) HasRun() bool {
+	return m.LastRunUnix > 0
+}
+
+// HasFailed returns true if the last maintenance of the repository failed
+func (m *RepoMaintenance) HasFailed() bool {
+	return m.LastError != ""
+}
+
+// GetRepoMaintenance returns the maintenance status of a repository, which is empty if it has never been recorded
+func GetRepoMaintenance(ctx context.Context, repoID int64) (*RepoMaintenance, error) {
+	m := &RepoMaintenance{RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FindRepoMaintenancesByRepoIDs returns the recorded maintenance status of the given repositories, by repository ID
+func FindRepoMaintenancesByRepoIDs(ctx context.Context, repoIDs []int64) (map[int64]*RepoMaintenance, error) {
+	maintenances := make(map[int64]*RepoMaintenance, len(repoIDs))
+	if len(repoIDs) == 0 {
+		return maintenances, nil
+	}
+	list := make([]*RepoMaintenance, 0, len(repoIDs))
+	if err := db.GetEngine(ctx).In("repo_id", repoIDs).Find(&list); err != nil {
+		return nil, err
+	}
+	for _, m := range list {
+		maintenances[m.RepoID] = m
+	}
+	return maintenances, nil
+}
+
+// IncreaseRepoMaintenancePushes records that a repository received a push since its last maintenance
+func IncreaseRepoMaintenancePushes(ctx context.Context, repoID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Incr("pushes_since_run").Update(new(RepoMaintenance))
+		if err != nil || affected > 0 {
+			return err
+		}
+		return db.Insert(ctx, &RepoMaintenance{RepoID: repoID, PushesSinceRun: 1})
+	})
+}
+
+// UpdateRepoMaintenanceRun records the outcome of a maintenance. pushesSeen is the number of pushes
+// which were known when the maintenance started, the ones received meanwhile are kept for the next one.
+func UpdateRepoMaintenanceRun(ctx context.Context, m *RepoMaintenance, pushesSeen int64) error {
+	if m.ID == 0 {
+		return db.Insert(ctx, m)
+	}
+	_, err := db.GetEngine(ctx).ID(m.ID).
+		Cols("last_run_unix", "last_duration", "last_tasks", "last_error").
+		Decr("pushes_since_run", pushesSeen).
+		Update(m)
+	return err
+}
@@ -161,6 +161,7 @@ type PullRequestsConfig struct {
 	DefaultMergeStyle             MergeStyle
 	DefaultUpdateStyle            UpdateStyle
 	DefaultAllowMaintainerEdit    bool
+	ReviewChecklist               []string
 }
 
 // FromDB fills up a PullRequestsConfig from serialized format.
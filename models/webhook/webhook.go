@@ -209,6 +209,12 @@ func (w *Webhook) HasIssuesMilestoneEvent() bool {
 		(w.ChooseEvents && w.HookEvents.IssueMilestone)
 }
 
+// HasIssuesProjectEvent returns true if hook enabled issues project event.
+func (w *Webhook) HasIssuesProjectEvent() bool {
+	return w.SendEverything ||
+		(w.ChooseEvents && w.HookEvents.IssueProject)
+}
+
 // HasIssueCommentEvent returns true if hook enabled issue_comment event.
 func (w *Webhook) HasIssueCommentEvent() bool {
 	return w.SendEverything ||
@@ -322,6 +328,7 @@ func (w *Webhook) EventCheckers() []struct {
 		{w.HasIssuesAssignEvent, webhook_module.HookEventIssueAssign},
 		{w.HasIssuesLabelEvent, webhook_module.HookEventIssueLabel},
 		{w.HasIssuesMilestoneEvent, webhook_module.HookEventIssueMilestone},
+		{w.HasIssuesProjectEvent, webhook_module.HookEventIssueProject},
 		{w.HasIssueCommentEvent, webhook_module.HookEventIssueComment},
 		{w.HasPullRequestEvent, webhook_module.HookEventPullRequest},
 		{w.HasPullRequestAssignEvent, webhook_module.HookEventPullRequestAssign},
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"regexp"
 	"strconv"
@@ -276,6 +277,53 @@ func CutDiffAroundLine(originalDiff io.Reader, line int64, old bool, numbersOfLi
 	return strings.Join(newHunk, "\n"), nil
 }
 
+// CutDiffAroundLines cuts a diff of a file like CutDiffAroundLine, but keeps all the lines from startLine to
+// line, plus the numberOfLine - 1 lines above startLine. The lines are on the same side, given by old.
+// Warning: Only one-file diffs are allowed.
+func CutDiffAroundLines(originalDiff io.Reader, startLine, line int64, old bool, numbersOfLine int) (string, error) {
+	if startLine == 0 || startLine >= line {
+		return CutDiffAroundLine(originalDiff, line, old, numbersOfLine)
+	}
+	diff, err := io.ReadAll(originalDiff)
+	if err != nil {
+		return "", err
+	}
+	hunk, err := CutDiffAroundLine(bytes.NewReader(diff), line, old, math.MaxInt32)
+	if err != nil || hunk == "" {
+		return hunk, err
+	}
+
+	// count the lines of the diff which are needed to show the lines of the range
+	hunkLines := strings.Split(hunk, "\n")
+	remaining := line - startLine + 1
+	needed := 0
+	for i := len(hunkLines) - 1; i >= 0 && remaining > 0; i-- {
+		lof := hunkLines[i]
+		if strings.HasPrefix(lof, "@@") {
+			// the range starts before the hunk, keep all of it
+			return hunk, nil
+		}
+		needed++
+		if lof == "" {
+			continue
+		}
+		switch lof[0] {
+		case '+':
+			if !old {
+				remaining--
+			}
+		case '-':
+			if old {
+				remaining--
+			}
+		case '\\':
+		default:
+			remaining--
+		}
+	}
+	return CutDiffAroundLine(bytes.NewReader(diff), line, old, needed+numbersOfLine-1)
+}
+
 // GetAffectedFiles returns the affected files between two commits
 func GetAffectedFiles(repo *Repository, oldCommitID, newCommitID string, env []string) ([]string, error) {
 	objectFormat, err := repo.GetObjectFormat()
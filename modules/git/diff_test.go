@@ -93,6 +93,32 @@ func TestCutDiffAroundLineIssue17875(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestCutDiffAroundLines(t *testing.T) {
+	result, err := CutDiffAroundLines(strings.NewReader(exampleDiff), 2, 4, false, 1)
+	require.NoError(t, err)
+	expected := `diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -2,2 +2,3 @@
++
++ Build Status
+- Latest Release
+ Docker Pulls`
+	assert.Equal(t, expected, result)
+
+	// Same result as for a single line when there is no range
+	single, err := CutDiffAroundLine(strings.NewReader(exampleDiff), 4, false, 3)
+	require.NoError(t, err)
+	result, err = CutDiffAroundLines(strings.NewReader(exampleDiff), 0, 4, false, 3)
+	require.NoError(t, err)
+	assert.Equal(t, single, result)
+
+	// The range covers the whole hunk
+	result, err = CutDiffAroundLines(strings.NewReader(exampleDiff), 1, 6, false, 1)
+	require.NoError(t, err)
+	assert.Equal(t, exampleDiff, result)
+}
+
 func TestCutDiffAroundLine(t *testing.T) {
 	result, err := CutDiffAroundLine(strings.NewReader(exampleDiff), 4, false, 3)
 	require.NoError(t, err)
@@ -13,6 +13,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -290,8 +292,49 @@ func syncGitConfig() (err error) {
 		}
 		err = configUnsetAll("uploadpack.allowAnySHA1InWant", "true")
 	}
+	if err != nil {
+		return err
+	}
 
-	return err
+	return syncPartialCloneFilterConfig()
+}
+
+// partialCloneFilters are the object filters which can be allowed or denied individually by git-upload-pack
+var partialCloneFilters = []string{"blob:none", "blob:limit", "tree", "sparse:oid", "object:type", "combine"}
+
+// syncPartialCloneFilterConfig restricts the object filters clients may request for partial clones, from git v2.28
+func syncPartialCloneFilterConfig() error {
+	enabled := !setting.Git.DisablePartialClone && CheckGitVersionAtLeast("2.28") == nil
+
+	if enabled && len(setting.Git.PartialCloneFilters) > 0 {
+		for _, filter := range setting.Git.PartialCloneFilters {
+			if !slices.Contains(partialCloneFilters, filter) {
+				log.Warn("Unknown partial clone filter %q in [git] PARTIAL_CLONE_FILTERS, possible values are: %s", filter, strings.Join(partialCloneFilters, ", "))
+			}
+		}
+		if err := configSet("uploadpackfilter.allow", "false"); err != nil {
+			return err
+		}
+		for _, filter := range partialCloneFilters {
+			if err := configSet("uploadpackfilter."+filter+".allow", strconv.FormatBool(slices.Contains(setting.Git.PartialCloneFilters, filter))); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := configUnset("uploadpackfilter.allow"); err != nil {
+			return err
+		}
+		for _, filter := range partialCloneFilters {
+			if err := configUnset("uploadpackfilter." + filter + ".allow"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if enabled && setting.Git.PartialCloneMaxTreeDepth > 0 {
+		return configSet("uploadpackfilter.tree.maxDepth", strconv.Itoa(setting.Git.PartialCloneMaxTreeDepth))
+	}
+	return configUnset("uploadpackfilter.tree.maxDepth")
 }
 
 // CheckGitVersionAtLeast check git version is at least the constraint version
@@ -395,6 +438,16 @@ func configUnsetAll(key, value string) error {
 	return fmt.Errorf("failed to get git config %s, err: %w", key, err)
 }
 
+// configUnset removes all values of a key from the global git config, whatever they are
+func configUnset(key string) error {
+	_, _, err := NewCommand(DefaultContext, "config", "--global", "--unset-all").AddDynamicArguments(key).RunStdString(nil)
+	if err == nil || IsErrorExitCode(err, 5) {
+		// removed, or the key did not exist
+		return nil
+	}
+	return fmt.Errorf("failed to unset git global config %s, err: %w", key, err)
+}
+
 // Fsck verifies the connectivity and validity of the objects in the database
 func Fsck(ctx context.Context, repoPath string, timeout time.Duration, args TrustedCmdArgs) error {
 	return NewCommand(ctx, "fsck").AddArguments(args...).Run(&RunOpts{Timeout: timeout, Dir: repoPath})
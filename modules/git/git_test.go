@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
 	"forgejo.org/modules/util"
 
 	"github.com/stretchr/testify/assert"
@@ -81,6 +82,12 @@ func TestGitConfig(t *testing.T) {
 	require.NoError(t, configSetNonExist("test.key-x", "*"))
 	require.NoError(t, configUnsetAll("test.key-x", "*"))
 	assert.False(t, gitConfigContains("key-x = *"))
+
+	require.NoError(t, configAddNonExist("test.key-y", "val-y"))
+	require.NoError(t, configAddNonExist("test.key-y", "val-2y"))
+	require.NoError(t, configUnset("test.key-y"))
+	assert.False(t, gitConfigContains("key-y"))
+	require.NoError(t, configUnset("test.key-y"))
 }
 
 func TestSyncConfig(t *testing.T) {
@@ -94,3 +101,24 @@ func TestSyncConfig(t *testing.T) {
 	assert.True(t, gitConfigContains("[sync-test]"))
 	assert.True(t, gitConfigContains("cfg-key-a = CfgValA"))
 }
+
+func TestSyncPartialCloneFilterConfig(t *testing.T) {
+	if CheckGitVersionAtLeast("2.28") != nil {
+		t.Skip("uploadpackfilter requires git v2.28")
+	}
+	defer test.MockVariableValue(&setting.Git.PartialCloneFilters, []string{"blob:none", "blob:limit", "tree"})()
+	defer test.MockVariableValue(&setting.Git.PartialCloneMaxTreeDepth, 2)()
+
+	require.NoError(t, syncGitConfig())
+	assert.True(t, gitConfigContains("[uploadpackfilter]"))
+	assert.True(t, gitConfigContains("allow = false"))
+	assert.True(t, gitConfigContains(`[uploadpackfilter "blob:none"]`))
+	assert.True(t, gitConfigContains(`[uploadpackfilter "tree"]`))
+	assert.True(t, gitConfigContains("maxDepth = 2"))
+
+	setting.Git.PartialCloneFilters = nil
+	setting.Git.PartialCloneMaxTreeDepth = 0
+	require.NoError(t, syncGitConfig())
+	assert.False(t, gitConfigContains("allow = "))
+	assert.False(t, gitConfigContains("maxDepth"))
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RangeDiffStatus tells how a commit of a range compares with its counterpart in the other range
+type RangeDiffStatus string
+
+const (
+	RangeDiffStatusEqual    RangeDiffStatus = "=" // the patches are the same
+	RangeDiffStatusModified RangeDiffStatus = "!" // the patches differ
+	RangeDiffStatusRemoved  RangeDiffStatus = "<" // the commit is only in the old range
+	RangeDiffStatusAdded    RangeDiffStatus = ">" // the commit is only in the new range
+)
+
+// RangeDiffEntry is a pair of matching commits of two ranges compared by git range-diff, or a commit
+// which is only in one of them
+type RangeDiffEntry struct {
+	// OldIndex and NewIndex are the 1-based positions of the commits in their ranges, 0 when absent
+	OldIndex, NewIndex int
+	// OldCommitID and NewCommitID are the abbreviated commit IDs, empty when absent
+	OldCommitID, NewCommitID string
+	Status                   RangeDiffStatus
+	Subject                  string
+	// Interdiff is the diff between the patches of the two commits, when they differ
+	Interdiff []string
+}
+
+// example: "1:  2a3b4c5 ! 1:  6d7e8f9 Subject", "-:  ------- > 2:  0a1b2c3 Subject"
+var rangeDiffPairRegex = regexp.MustCompile(`^\s*(-|\d+):\s+([0-9a-f]+|-+) ([=!<>])\s+(-|\d+):\s+([0-9a-f]+|-+) ?(.*)$`)
+
+// RangeDiff compares the commits of the range oldBase..oldHead with the ones of the range newBase..newHead,
+// like git range-diff. It needs git 2.19 or above.
+func (repo *Repository) RangeDiff(oldBase, oldHead, newBase, newHead string) ([]*RangeDiffEntry, error) {
+	if err := CheckGitVersionAtLeast("2.19"); err != nil {
+		return nil, err
+	}
+	stdout, _, err := NewCommand(repo.Ctx, "range-diff", "--no-color").
+		AddDynamicArguments(oldBase+".."+oldHead, newBase+".."+newHead).
+		RunStdString(&RunOpts{Dir: repo.Path})
+	if err != nil {
+		return nil, err
+	}
+	return parseRangeDiff(strings.NewReader(stdout))
+}
+
+func parseRangeDiff(r io.Reader) ([]*RangeDiffEntry, error) {
+	var entries []*RangeDiffEntry
+	var entry *RangeDiffEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := rangeDiffPairRegex.FindStringSubmatch(line); m != nil {
+			entry = &RangeDiffEntry{
+				Status:  RangeDiffStatus(m[3]),
+				Subject: m[6],
+			}
+			if m[1] != "-" {
+				entry.OldIndex, _ = strconv.Atoi(m[1])
+				entry.OldCommitID = m[2]
+			}
+			if m[4] != "-" {
+				entry.NewIndex, _ = strconv.Atoi(m[4])
+				entry.NewCommitID = m[5]
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		if entry != nil {
+			// the lines of the interdiff are indented by 4 spaces
+			entry.Interdiff = append(entry.Interdiff, strings.TrimPrefix(line, "    "))
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRangeDiff(t *testing.T) {
+	output := `1:  2a3b4c5 = 1:  6d7e8f9 Add the README
+2:  0a1b2c3 ! 2:  4d5e6f7 Fix the build
+    @@ Metadata
+      ## Commit message ##
+         Fix the build
+
+    @@ main.go
+     -	old()
+    -+	fixed()
+    ++	fixedBetter()
+3:  8a9b0c1 < -:  ------- Remove a test
+-:  ------- > 3:  2d3e4f5 Add a feature
+`
+	entries, err := parseRangeDiff(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, &RangeDiffEntry{
+		OldIndex: 1, NewIndex: 1,
+		OldCommitID: "2a3b4c5", NewCommitID: "6d7e8f9",
+		Status:  RangeDiffStatusEqual,
+		Subject: "Add the README",
+	}, entries[0])
+
+	assert.Equal(t, RangeDiffStatusModified, entries[1].Status)
+	assert.Equal(t, "Fix the build", entries[1].Subject)
+	assert.Equal(t, []string{
+		"@@ Metadata",
+		"  ## Commit message ##",
+		"     Fix the build",
+		"",
+		"@@ main.go",
+		" -\told()",
+		"-+\tfixed()",
+		"++\tfixedBetter()",
+	}, entries[1].Interdiff)
+
+	assert.Equal(t, &RangeDiffEntry{
+		OldIndex: 3, OldCommitID: "8a9b0c1",
+		Status:  RangeDiffStatusRemoved,
+		Subject: "Remove a test",
+	}, entries[2])
+	assert.Equal(t, &RangeDiffEntry{
+		NewIndex: 3, NewCommitID: "2d3e4f5",
+		Status:  RangeDiffStatusAdded,
+		Subject: "Add a feature",
+	}, entries[3])
+}
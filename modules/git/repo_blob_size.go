@@ -0,0 +1,97 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"forgejo.org/modules/log"
+)
+
+// LargeBlob is a blob which exceeds a size limit
+type LargeBlob struct {
+	ID   string
+	Path string
+	Size int64
+}
+
+// FindLargeBlobs returns the blobs bigger than limit bytes which are introduced by newCommitID,
+// that is which are reachable from it but not from any existing reference of the repository.
+// env is passed to git, so that objects still in the quarantine area of a push are found too.
+func FindLargeBlobs(ctx context.Context, repoPath, newCommitID string, limit int64, env []string) ([]*LargeBlob, error) {
+	revListReader, revListWriter := io.Pipe()
+	defer revListReader.Close()
+
+	go func() {
+		stderr := &strings.Builder{}
+		err := NewCommand(ctx, "rev-list", "--objects").AddDynamicArguments(newCommitID).AddArguments("--not", "--all").
+			Run(&RunOpts{
+				Env:    env,
+				Dir:    repoPath,
+				Stdout: revListWriter,
+				Stderr: stderr,
+			})
+		if err != nil {
+			_ = revListWriter.CloseWithError(ConcatenateError(err, stderr.String()))
+			return
+		}
+		_ = revListWriter.Close()
+	}()
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		log.Error("Unable to create os.Pipe for %s", repoPath)
+		return nil, err
+	}
+	defer func() {
+		_ = stdoutReader.Close()
+		_ = stdoutWriter.Close()
+	}()
+
+	var blobs []*LargeBlob
+	stderr := &strings.Builder{}
+	err = NewCommand(ctx, "cat-file", "--batch-check=%(objecttype) %(objectname) %(objectsize) %(rest)").
+		Run(&RunOpts{
+			Env:    env,
+			Dir:    repoPath,
+			Stdin:  revListReader,
+			Stdout: stdoutWriter,
+			Stderr: stderr,
+			PipelineFunc: func(ctx context.Context, cancel context.CancelFunc) error {
+				_ = stdoutWriter.Close()
+				defer stdoutReader.Close()
+
+				scanner := bufio.NewScanner(stdoutReader)
+				for scanner.Scan() {
+					fields := strings.SplitN(scanner.Text(), " ", 4)
+					if len(fields) < 3 || fields[0] != "blob" {
+						continue
+					}
+					size, err := strconv.ParseInt(fields[2], 10, 64)
+					if err != nil {
+						cancel()
+						return err
+					}
+					if size <= limit {
+						continue
+					}
+					blob := &LargeBlob{ID: fields[1], Size: size}
+					if len(fields) == 4 {
+						blob.Path = fields[3]
+					}
+					blobs = append(blobs, blob)
+				}
+				return scanner.Err()
+			},
+		})
+	if err != nil {
+		return nil, ConcatenateError(err, stderr.String())
+	}
+	return blobs, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindLargeBlobs(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, InitRepository(DefaultContext, tmpDir, false, Sha1ObjectFormat.Name()))
+
+	run := func(cmd *Command, stdin string) string {
+		stdout := &strings.Builder{}
+		require.NoError(t, cmd.Run(&RunOpts{Dir: tmpDir, Stdin: strings.NewReader(stdin), Stdout: stdout}))
+		return strings.TrimSpace(stdout.String())
+	}
+
+	smallID := run(NewCommand(DefaultContext, "hash-object", "-w", "--stdin"), "small\n")
+	largeID := run(NewCommand(DefaultContext, "hash-object", "-w", "--stdin"), strings.Repeat("x", 2048))
+	subTreeID := run(NewCommand(DefaultContext, "mktree"), "100644 blob "+largeID+"\tlarge.bin\n")
+	treeID := run(NewCommand(DefaultContext, "mktree"), "040000 tree "+subTreeID+"\tassets\n100644 blob "+smallID+"\tsmall.txt\n")
+	commitID := run(NewCommand(DefaultContext, "commit-tree", "-m", "large blob").AddDynamicArguments(treeID), "")
+
+	blobs, err := FindLargeBlobs(DefaultContext, tmpDir, commitID, 1024, nil)
+	require.NoError(t, err)
+	if assert.Len(t, blobs, 1) {
+		assert.Equal(t, largeID, blobs[0].ID)
+		assert.Equal(t, "assets/large.bin", blobs[0].Path)
+		assert.EqualValues(t, 2048, blobs[0].Size)
+	}
+
+	blobs, err = FindLargeBlobs(DefaultContext, tmpDir, commitID, 4096, nil)
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+
+	// once the commit is referenced, its blobs are not new anymore
+	run(NewCommand(DefaultContext, "update-ref", "refs/heads/main").AddDynamicArguments(commitID), "")
+	blobs, err = FindLargeBlobs(DefaultContext, tmpDir, commitID, 1024, nil)
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+}
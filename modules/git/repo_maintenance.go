@@ -0,0 +1,81 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// MaintenanceTask is an incremental maintenance task which can be run on a repository
+type MaintenanceTask string
+
+const (
+	// MaintenanceCommitGraph writes the commit-graph incrementally
+	MaintenanceCommitGraph MaintenanceTask = "commit-graph"
+	// MaintenanceLooseObjects packs the loose objects, and removes the ones which are already packed
+	MaintenanceLooseObjects MaintenanceTask = "loose-objects"
+	// MaintenanceIncrementalRepack repacks small pack-files together, using a multi-pack-index
+	MaintenanceIncrementalRepack MaintenanceTask = "incremental-repack"
+	// MaintenancePackBitmap writes a reachability bitmap for the multi-pack-index,
+	// it is not a task of git maintenance but speeds up clones and fetches of large repositories
+	MaintenancePackBitmap MaintenanceTask = "pack-bitmap"
+)
+
+// MaintenanceTasks are all the known maintenance tasks, in the order they are run
+var MaintenanceTasks = []MaintenanceTask{
+	MaintenanceCommitGraph,
+	MaintenanceLooseObjects,
+	MaintenanceIncrementalRepack,
+	MaintenancePackBitmap,
+}
+
+// IsValid returns true if the task is known
+func (t MaintenanceTask) IsValid() bool {
+	return slices.Contains(MaintenanceTasks, t)
+}
+
+// RunMaintenance runs the given maintenance tasks on a repository. git maintenance requires git v2.30,
+// with older versions only the commit-graph is written. Pack bitmaps require git v2.34.
+func RunMaintenance(ctx context.Context, repoPath string, tasks []MaintenanceTask, timeout time.Duration) error {
+	if CheckGitVersionAtLeast("2.30") != nil {
+		if slices.Contains(tasks, MaintenanceCommitGraph) {
+			return WriteCommitGraph(ctx, repoPath)
+		}
+		return nil
+	}
+
+	// the tasks are run one by one, so that each of them sees the packs written by the previous ones
+	for _, task := range MaintenanceTasks {
+		if !slices.Contains(tasks, task) {
+			continue
+		}
+		if (task == MaintenanceIncrementalRepack || task == MaintenancePackBitmap) && !hasPackFiles(repoPath) {
+			continue
+		}
+
+		var cmd *Command
+		if task == MaintenancePackBitmap {
+			if CheckGitVersionAtLeast("2.34") != nil {
+				continue
+			}
+			cmd = NewCommand(ctx, "multi-pack-index", "write", "--bitmap")
+		} else {
+			cmd = NewCommand(ctx, "maintenance", "run", "--quiet").AddOptionFormat("--task=%s", string(task))
+		}
+		if _, _, err := cmd.RunStdString(&RunOpts{Dir: repoPath, Timeout: timeout}); err != nil {
+			return fmt.Errorf("unable to run maintenance task %s for '%s': %w", task, repoPath, err)
+		}
+	}
+	return nil
+}
+
+// hasPackFiles returns true if the repository has at least one pack-file, which the multi-pack-index requires
+func hasPackFiles(repoPath string) bool {
+	packs, _ := filepath.Glob(filepath.Join(repoPath, "objects", "pack", "*.pack"))
+	return len(packs) > 0
+}
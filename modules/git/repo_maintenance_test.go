@@ -0,0 +1,46 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMaintenance(t *testing.T) {
+	if CheckGitVersionAtLeast("2.34") != nil {
+		t.Skip("git maintenance with pack bitmaps requires git v2.34")
+	}
+
+	repoPath := filepath.Join(t.TempDir(), "repo1.git")
+	require.NoError(t, Clone(DefaultContext, filepath.Join(testReposDir, "repo1_bare"), repoPath, CloneRepoOptions{Bare: true}))
+
+	require.NoError(t, RunMaintenance(DefaultContext, repoPath, MaintenanceTasks, 0))
+
+	for _, name := range []string{"objects/info/commit-graphs", "objects/pack/multi-pack-index"} {
+		exist, err := util.IsExist(filepath.Join(repoPath, name))
+		require.NoError(t, err)
+		assert.True(t, exist, name)
+	}
+	bitmaps, err := filepath.Glob(filepath.Join(repoPath, "objects", "pack", "multi-pack-index-*.bitmap"))
+	require.NoError(t, err)
+	assert.Len(t, bitmaps, 1)
+
+	_, _, err = NewCommand(DefaultContext, "fsck", "--connectivity-only").RunStdString(&RunOpts{Dir: repoPath})
+	require.NoError(t, err)
+
+	// running it again when there is nothing new to do is fine
+	require.NoError(t, RunMaintenance(DefaultContext, repoPath, MaintenanceTasks, 0))
+}
+
+func TestMaintenanceTaskIsValid(t *testing.T) {
+	assert.True(t, MaintenanceCommitGraph.IsValid())
+	assert.True(t, MaintenancePackBitmap.IsValid())
+	assert.False(t, MaintenanceTask("gc").IsValid())
+}
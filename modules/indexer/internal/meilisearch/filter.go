@@ -84,6 +84,13 @@ func (f FilterEq) Statement() string {
 	return string(f)
 }
 
+var filterStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// NewFilterEqString creates a new FilterEq comparing a field to a string, which is quoted and escaped.
+func NewFilterEqString(field, value string) FilterEq {
+	return FilterEq(fmt.Sprintf(`%s = "%s"`, field, filterStringEscaper.Replace(value)))
+}
+
 type FilterNot string
 
 func NewFilterNot(filter Filter) FilterNot {
@@ -62,6 +62,8 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 		SubscriberID:       convertID(options.SubscriberID),
 		ProjectID:          convertID(options.ProjectID),
 		ProjectColumnID:    convertID(options.ProjectColumnID),
+		ProjectFieldValues: options.ProjectFieldValues,
+		ParentIssueID:      convertID(options.ParentIssueID),
 		IsClosed:           options.IsClosed,
 		IsPull:             options.IsPull,
 		IncludedLabelNames: nil,
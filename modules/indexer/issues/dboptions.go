@@ -62,6 +62,8 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 	}
 
 	searchOpt.ProjectColumnID = convertID(opts.ProjectColumnID)
+	searchOpt.ProjectFieldValues = opts.ProjectFieldValues
+	searchOpt.ParentIssueID = convertID(opts.ParentIssueID)
 	searchOpt.PosterID = convertID(opts.PosterID)
 	searchOpt.MentionID = convertID(opts.MentionedID)
 	searchOpt.ReviewedID = convertID(opts.ReviewedID)
@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 1
+	issueIndexerLatestVersion = 3
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -73,6 +73,8 @@ const (
 			"reviewed_ids": { "type": "long", "index": true },
 			"review_requested_ids": { "type": "long", "index": true },
 			"subscriber_ids": { "type": "long", "index": true },
+			"project_field_values": { "type": "keyword", "index": true },
+			"parent_issue_id": { "type": "long", "index": true },
 			"updated_unix": { "type": "long", "index": true },
 
 			"created_unix": { "type": "long", "index": true },
@@ -222,6 +224,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	if options.ProjectColumnID.Has() {
 		query.Must(elastic.NewTermQuery("project_board_id", options.ProjectColumnID.Value()))
 	}
+	for fieldID, value := range options.ProjectFieldValues {
+		query.Must(elastic.NewTermQuery("project_field_values", internal.ProjectFieldValueToken(fieldID, value)))
+	}
+
+	if options.ParentIssueID.Has() {
+		query.Must(elastic.NewTermQuery("parent_issue_id", options.ParentIssueID.Value()))
+	}
 
 	if options.PosterID.Has() {
 		query.Must(elastic.NewTermQuery("poster_id", options.PosterID.Value()))
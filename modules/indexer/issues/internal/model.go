@@ -4,6 +4,8 @@
 package internal
 
 import (
+	"strconv"
+
 	"forgejo.org/models/db"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/timeutil"
@@ -34,6 +36,8 @@ type IndexerData struct {
 	ReviewedIDs        []int64            `json:"reviewed_ids"`
 	ReviewRequestedIDs []int64            `json:"review_requested_ids"`
 	SubscriberIDs      []int64            `json:"subscriber_ids"`
+	ProjectFieldValues []string           `json:"project_field_values"` // see ProjectFieldValueToken
+	ParentIssueID      int64              `json:"parent_issue_id"`
 	UpdatedUnix        timeutil.TimeStamp `json:"updated_unix"`
 
 	// Fields used for sorting
@@ -44,6 +48,11 @@ type IndexerData struct {
 	CommentCount int64              `json:"comment_count"`
 }
 
+// ProjectFieldValueToken returns the token indexing the value of a custom field of projects for an issue
+func ProjectFieldValueToken(fieldID int64, value string) string {
+	return strconv.FormatInt(fieldID, 10) + ":" + value
+}
+
 // Match represents on search result
 type Match struct {
 	ID    int64   `json:"id"`
@@ -90,6 +99,10 @@ type SearchOptions struct {
 	ProjectID       optional.Option[int64] // project the issues belong to
 	ProjectColumnID optional.Option[int64] // project column the issues belong to
 
+	ProjectFieldValues map[int64]string // values of the custom fields of projects the issues have, by field id
+
+	ParentIssueID optional.Option[int64] // parent issue of the issues, zero means no parent
+
 	PosterID optional.Option[int64] // poster of the issues
 
 	AssigneeID optional.Option[int64] // assignee of the issues, zero means no assignee
@@ -404,6 +404,43 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "ProjectFieldValues",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			ProjectFieldValues: map[int64]string{1: "high", 2: "sprint 1"},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, data[v.ID].ProjectFieldValues, "1:high")
+				assert.Contains(t, data[v.ID].ProjectFieldValues, "2:sprint 1")
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return slices.Contains(v.ProjectFieldValues, "1:high") && slices.Contains(v.ProjectFieldValues, "2:sprint 1")
+			}), result.Total)
+		},
+	},
+	{
+		Name: "ParentIssueID",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			ParentIssueID: optional.Some(int64(1)),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Equal(t, int64(1), data[v.ID].ParentIssueID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.ParentIssueID == 1
+			}), result.Total)
+		},
+	},
 	{
 		Name: "PosterID",
 		SearchOptions: &internal.SearchOptions{
@@ -739,6 +776,10 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 			for i := range subscriberIDs {
 				subscriberIDs[i] = int64(i) + 1 // SubscriberID should not be 0
 			}
+			projectFieldValues := []string{internal.ProjectFieldValueToken(1, []string{"high", "low"}[id%2])}
+			if id%3 == 0 {
+				projectFieldValues = append(projectFieldValues, internal.ProjectFieldValueToken(2, "sprint 1"))
+			}
 
 			data = append(data, &internal.IndexerData{
 				ID:                 id,
@@ -760,6 +801,8 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				ReviewedIDs:        reviewedIDs,
 				ReviewRequestedIDs: reviewRequestedIDs,
 				SubscriberIDs:      subscriberIDs,
+				ProjectFieldValues: projectFieldValues,
+				ParentIssueID:      issueIndex % 7,
 				UpdatedUnix:        timeutil.TimeStamp(id + issueIndex),
 				CreatedUnix:        timeutil.TimeStamp(id),
 				DeadlineUnix:       timeutil.TimeStamp(id + issueIndex + repoID),
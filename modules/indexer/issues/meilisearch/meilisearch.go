@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 3
+	issueIndexerLatestVersion = 5
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -72,6 +72,8 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"reviewed_ids",
 			"review_requested_ids",
 			"subscriber_ids",
+			"project_field_values",
+			"parent_issue_id",
 			"updated_unix",
 		},
 		SortableAttributes: []string{
@@ -177,6 +179,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	if options.ProjectColumnID.Has() {
 		query.And(inner_meilisearch.NewFilterEq("project_board_id", options.ProjectColumnID.Value()))
 	}
+	for fieldID, value := range options.ProjectFieldValues {
+		query.And(inner_meilisearch.NewFilterEqString("project_field_values", internal.ProjectFieldValueToken(fieldID, value)))
+	}
+
+	if options.ParentIssueID.Has() {
+		query.And(inner_meilisearch.NewFilterEq("parent_issue_id", options.ParentIssueID.Value()))
+	}
 
 	if options.PosterID.Has() {
 		query.And(inner_meilisearch.NewFilterEq("poster_id", options.PosterID.Value()))
@@ -10,6 +10,7 @@ import (
 
 	"forgejo.org/models/db"
 	issue_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
 	"forgejo.org/modules/container"
 	"forgejo.org/modules/indexer/issues/internal"
 	"forgejo.org/modules/log"
@@ -35,7 +36,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 
 	comments := make([]string, 0, len(issue.Comments))
 	for _, comment := range issue.Comments {
-		if comment.Content != "" {
+		if comment.Content != "" && !comment.IsHidden {
 			// what ever the comment type is, index the content if it is not empty.
 			comments = append(comments, comment.Content)
 		}
@@ -92,12 +93,32 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		projectID = issue.Project.ID
 	}
 
+	fieldValues, err := project_model.GetIssueFieldValues(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	projectFieldValues := make([]string, 0, len(fieldValues))
+	for fieldID, value := range fieldValues {
+		projectFieldValues = append(projectFieldValues, internal.ProjectFieldValueToken(fieldID, value))
+	}
+
+	parentIssueID, err := issue_model.GetParentIssueID(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the content hidden by moderators must not be found by searching it
+	content := issue.Content
+	if issue.IsHidden {
+		content = ""
+	}
+
 	return &internal.IndexerData{
 		ID:                 issue.ID,
 		RepoID:             issue.RepoID,
 		IsPublic:           !issue.Repo.IsPrivate,
 		Title:              issue.Title,
-		Content:            issue.Content,
+		Content:            content,
 		Comments:           comments,
 		IsPull:             issue.IsPull,
 		IsClosed:           issue.IsClosed,
@@ -112,6 +133,8 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		ProjectFieldValues: projectFieldValues,
+		ParentIssueID:      parentIssueID,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
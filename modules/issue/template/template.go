@@ -9,11 +9,13 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"forgejo.org/modules/container"
 	api "forgejo.org/modules/structs"
 
 	"code.forgejo.org/go-chi/binding"
+	"github.com/gobwas/glob"
 )
 
 // Validate checks whether an IssueTemplate is considered valid, and returns the first error
@@ -36,9 +38,25 @@ func validateMetadata(template *api.IssueTemplate) error {
 	if strings.TrimSpace(template.About) == "" {
 		return fmt.Errorf("'about' is required")
 	}
+	for _, branch := range template.Branches {
+		if _, err := glob.Compile(branch, '/'); err != nil {
+			return fmt.Errorf("'branches': invalid pattern %q: %w", branch, err)
+		}
+	}
 	return nil
 }
 
+// MatchBranch returns true if the template is chosen for pull requests targeting the branch, from its
+// branch patterns. A template without branch patterns matches no branch.
+func MatchBranch(template *api.IssueTemplate, branch string) bool {
+	for _, pattern := range template.Branches {
+		if g, err := glob.Compile(pattern, '/'); err == nil && g.Match(branch) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateYaml(template *api.IssueTemplate) error {
 	if len(template.Fields) == 0 {
 		return fmt.Errorf("'body' is required")
@@ -67,6 +85,12 @@ func validateYaml(template *api.IssueTemplate) error {
 			); err != nil {
 				return err
 			}
+			if err := validateRegexItem(position, field.Validations); err != nil {
+				return err
+			}
+			if err := validateNumberItem(position, field.Validations, "min", "max"); err != nil {
+				return err
+			}
 		case api.IssueFormFieldTypeInput:
 			if err := validateStringItem(position, field.Attributes, false,
 				"description",
@@ -78,7 +102,59 @@ func validateYaml(template *api.IssueTemplate) error {
 			if err := validateBoolItem(position, field.Validations, "is_number"); err != nil {
 				return err
 			}
-			if err := validateStringItem(position, field.Validations, false, "regex"); err != nil {
+			if err := validateRegexItem(position, field.Validations); err != nil {
+				return err
+			}
+			if err := validateNumberItem(position, field.Validations, "min", "max"); err != nil {
+				return err
+			}
+		case api.IssueFormFieldTypeDate:
+			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
+				return err
+			}
+			if err := validateDateItem(position, field.Attributes, "value"); err != nil {
+				return err
+			}
+			if err := validateDateItem(position, field.Validations, "min", "max"); err != nil {
+				return err
+			}
+		case api.IssueFormFieldTypeNumber:
+			if err := validateStringItem(position, field.Attributes, false, "description", "placeholder"); err != nil {
+				return err
+			}
+			if err := validateNumberItem(position, field.Attributes, "value"); err != nil {
+				return err
+			}
+			if err := validateNumberItem(position, field.Validations, "min", "max", "step"); err != nil {
+				return err
+			}
+		case api.IssueFormFieldTypeUser:
+			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
+				return err
+			}
+			if err := validateBoolItem(position, field.Attributes, "multiple"); err != nil {
+				return err
+			}
+		case api.IssueFormFieldTypeLabel:
+			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
+				return err
+			}
+			if err := validateBoolItem(position, field.Attributes, "multiple"); err != nil {
+				return err
+			}
+			if options, ok := field.Attributes["options"]; ok {
+				if _, ok := options.([]any); !ok {
+					return position.Errorf("'options' should be a array")
+				}
+				if err := validateOptions(field, idx); err != nil {
+					return err
+				}
+			}
+		case api.IssueFormFieldTypeFile:
+			if err := validateStringItem(position, field.Attributes, false, "description", "accept"); err != nil {
+				return err
+			}
+			if err := validateBoolItem(position, field.Attributes, "multiple"); err != nil {
 				return err
 			}
 		case api.IssueFormFieldTypeDropdown:
@@ -111,10 +187,47 @@ func validateYaml(template *api.IssueTemplate) error {
 		if err := validateRequired(field, idx); err != nil {
 			return err
 		}
+		if err := validateMapTo(field, idx); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// validateMapTo checks that the values of a field can be mapped to what its 'map_to' attribute is
+func validateMapTo(field *api.IssueFormField, idx int) error {
+	position := newErrorPosition(idx, field.Type)
+	if err := validateStringItem(position, field.Attributes, false, "map_to"); err != nil {
+		return err
+	}
+	mapTo := field.MapTo()
+	if mapTo == "" {
+		return nil
+	}
+	multiple, _ := field.Attributes["multiple"].(bool)
+	switch field.Type {
+	case api.IssueFormFieldTypeDropdown:
+		if mapTo == api.IssueFormFieldMapToLabels {
+			return nil
+		}
+		if mapTo == api.IssueFormFieldMapToMilestone || mapTo == api.IssueFormFieldMapToProject {
+			if multiple {
+				return position.Errorf("can not map a multiple dropdown to a %s", mapTo)
+			}
+			return nil
+		}
+	case api.IssueFormFieldTypeCheckboxes, api.IssueFormFieldTypeLabel:
+		if mapTo == api.IssueFormFieldMapToLabels {
+			return nil
+		}
+	case api.IssueFormFieldTypeUser:
+		if mapTo == api.IssueFormFieldMapToAssignees {
+			return nil
+		}
+	}
+	return position.Errorf("can not map to '%s'", mapTo)
+}
+
 func validateLabel(field *api.IssueFormField, idx int) error {
 	if field.Type == api.IssueFormFieldTypeMarkdown {
 		// The label is not required for a markdown field
@@ -158,7 +271,7 @@ func validateID(field *api.IssueFormField, idx int, ids container.Set[string]) e
 }
 
 func validateOptions(field *api.IssueFormField, idx int) error {
-	if field.Type != api.IssueFormFieldTypeDropdown && field.Type != api.IssueFormFieldTypeCheckboxes {
+	if field.Type != api.IssueFormFieldTypeDropdown && field.Type != api.IssueFormFieldTypeCheckboxes && field.Type != api.IssueFormFieldTypeLabel {
 		return nil
 	}
 	position := newErrorPosition(idx, field.Type)
@@ -171,7 +284,7 @@ func validateOptions(field *api.IssueFormField, idx int) error {
 	for optIdx, option := range options {
 		position := newErrorPosition(idx, field.Type, optIdx)
 		switch field.Type {
-		case api.IssueFormFieldTypeDropdown:
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeLabel:
 			if _, ok := option.(string); !ok {
 				return position.Errorf("should be a string")
 			}
@@ -255,6 +368,48 @@ func validateBoolItem(position errorPosition, m map[string]any, names ...string)
 	return nil
 }
 
+func validateNumberItem(position errorPosition, m map[string]any, names ...string) error {
+	for _, name := range names {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+		if _, ok := toFloat(v); !ok {
+			return position.Errorf("'%s' should be a number", name)
+		}
+	}
+	return nil
+}
+
+func validateDateItem(position errorPosition, m map[string]any, names ...string) error {
+	for _, name := range names {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return position.Errorf("'%s' should be a date string", name)
+		}
+		if _, err := time.Parse(dateLayout, s); err != nil {
+			return position.Errorf("'%s' should be a date in the format YYYY-MM-DD", name)
+		}
+	}
+	return nil
+}
+
+func validateRegexItem(position errorPosition, m map[string]any) error {
+	if err := validateStringItem(position, m, false, "regex"); err != nil {
+		return err
+	}
+	if regex, ok := m["regex"].(string); ok {
+		if _, err := regexp.Compile(regex); err != nil {
+			return position.Errorf("'regex' is invalid: %v", err)
+		}
+	}
+	return nil
+}
+
 func validateDropdownDefault(position errorPosition, attributes map[string]any) error {
 	v, ok := attributes["default"]
 	if !ok {
@@ -293,12 +448,19 @@ func newErrorPosition(fieldIdx int, fieldType api.IssueFormFieldType, optionInde
 
 // RenderToMarkdown renders template to markdown with specified values
 func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
+	return RenderToMarkdownWithFiles(template, values, nil)
+}
+
+// RenderToMarkdownWithFiles renders template to markdown with specified values, the attachments of the file
+// fields are linked with their names in fileNames by UUID
+func RenderToMarkdownWithFiles(template *api.IssueTemplate, values url.Values, fileNames map[string]string) string {
 	builder := &strings.Builder{}
 
 	for _, field := range template.Fields {
 		f := &valuedField{
 			IssueFormField: field,
 			Values:         values,
+			fileNames:      fileNames,
 		}
 		if f.ID == "" || !f.VisibleInContent() {
 			continue
@@ -312,6 +474,7 @@ func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
 type valuedField struct {
 	*api.IssueFormField
 	url.Values
+	fileNames map[string]string
 }
 
 func (f *valuedField) WriteTo(builder *strings.Builder) {
@@ -353,12 +516,36 @@ func (f *valuedField) WriteTo(builder *strings.Builder) {
 		} else {
 			_, _ = fmt.Fprint(builder, blankPlaceholder)
 		}
-	case api.IssueFormFieldTypeInput:
+	case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeDate, api.IssueFormFieldTypeNumber:
 		if value := f.Value(); value == "" {
 			_, _ = fmt.Fprint(builder, blankPlaceholder)
 		} else {
 			_, _ = fmt.Fprintf(builder, "%s\n", value)
 		}
+	case api.IssueFormFieldTypeUser:
+		if names := f.List(); len(names) == 0 {
+			_, _ = fmt.Fprint(builder, blankPlaceholder)
+		} else {
+			_, _ = fmt.Fprintf(builder, "@%s\n", strings.Join(names, ", @"))
+		}
+	case api.IssueFormFieldTypeLabel:
+		if names := f.List(); len(names) == 0 {
+			_, _ = fmt.Fprint(builder, blankPlaceholder)
+		} else {
+			_, _ = fmt.Fprintf(builder, "%s\n", strings.Join(names, ", "))
+		}
+	case api.IssueFormFieldTypeFile:
+		uuids := f.List()
+		if len(uuids) == 0 {
+			_, _ = fmt.Fprint(builder, blankPlaceholder)
+		}
+		for _, uuid := range uuids {
+			name := f.fileNames[uuid]
+			if name == "" {
+				name = uuid
+			}
+			_, _ = fmt.Fprintf(builder, "- [%s](/attachments/%s)\n", name, uuid)
+		}
 	case api.IssueFormFieldTypeTextarea:
 		if value := f.Value(); value == "" {
 			_, _ = fmt.Fprint(builder, blankPlaceholder)
@@ -404,6 +591,19 @@ func (f *valuedField) Value() string {
 	return strings.TrimSpace(f.Get("form-field-" + f.ID))
 }
 
+// List returns the comma separated values of the field, which may also be submitted several times
+func (f *valuedField) List() []string {
+	var list []string
+	for _, value := range f.Values["form-field-"+f.ID] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				list = append(list, v)
+			}
+		}
+	}
+	return list
+}
+
 func (f *valuedField) Options() []*valuedOption {
 	if options, ok := f.Attributes["options"].([]any); ok {
 		ret := make([]*valuedOption, 0, len(options))
@@ -427,7 +627,7 @@ type valuedOption struct {
 
 func (o *valuedOption) Label() string {
 	switch o.field.Type {
-	case api.IssueFormFieldTypeDropdown:
+	case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeLabel:
 		if label, ok := o.data.(string); ok {
 			return label
 		}
@@ -654,6 +654,96 @@ body:
 			},
 			wantErr: "",
 		},
+		{
+			name: "number invalid min",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "number"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      min: "one"
+`,
+			wantErr: "body[0](number): 'min' should be a number",
+		},
+		{
+			name: "date invalid value",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "date"
+    id: "1"
+    attributes:
+      label: "a"
+      value: "2026/01/01"
+`,
+			wantErr: "body[0](date): 'value' should be a date in the format YYYY-MM-DD",
+		},
+		{
+			name: "input invalid regex",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "input"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      regex: "("
+`,
+			wantErr: "body[0](input): 'regex' is invalid: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "user invalid map_to",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "user"
+    id: "1"
+    attributes:
+      label: "a"
+      map_to: "labels"
+`,
+			wantErr: "body[0](user): can not map to 'labels'",
+		},
+		{
+			name: "multiple dropdown mapped to a milestone",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "dropdown"
+    id: "1"
+    attributes:
+      label: "a"
+      multiple: true
+      map_to: "milestone"
+      options:
+        - "v1"
+        - "v2"
+`,
+			wantErr: "body[0](dropdown): can not map a multiple dropdown to a milestone",
+		},
+		{
+			name: "label invalid options",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "label"
+    id: "1"
+    attributes:
+      label: "a"
+      options: "bug"
+`,
+			wantErr: "body[0](label): 'options' should be a array",
+		},
 		{
 			name: "comma-delimited labels",
 			content: `
@@ -746,6 +836,27 @@ Content
 			},
 			wantErr: "",
 		},
+		{
+			name:     "branches in markdown",
+			filename: "test.md",
+			content: `---
+name: Name
+about: About
+branches:
+  - release/*
+  - main
+---
+Content
+`,
+			want: &api.IssueTemplate{
+				Name:     "Name",
+				About:    "About",
+				Branches: []string{"release/*", "main"},
+				Content:  "Content\n",
+				FileName: "test.md",
+			},
+			wantErr: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -767,6 +878,19 @@ Content
 	}
 }
 
+func TestMatchBranch(t *testing.T) {
+	tmpl := &api.IssueTemplate{Name: "Name", About: "About", Branches: []string{"release/*", "main"}}
+	require.NoError(t, Validate(tmpl))
+	assert.True(t, MatchBranch(tmpl, "main"))
+	assert.True(t, MatchBranch(tmpl, "release/1.0"))
+	assert.False(t, MatchBranch(tmpl, "release/1.0/fix"))
+	assert.False(t, MatchBranch(tmpl, "develop"))
+	assert.False(t, MatchBranch(&api.IssueTemplate{}, "main"))
+
+	tmpl.Branches = []string{"release/["}
+	require.ErrorContains(t, Validate(tmpl), `'branches': invalid pattern "release/["`)
+}
+
 func TestRenderToMarkdown(t *testing.T) {
 	type args struct {
 		template string
@@ -0,0 +1,306 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package template
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+)
+
+// dateLayout is the format of the values of date fields
+const dateLayout = "2006-01-02"
+
+// ErrInvalidValue represents an invalid value submitted to a field of an issue form
+type ErrInvalidValue struct {
+	Field   string
+	Message string
+}
+
+// IsErrInvalidValue checks if an error is a ErrInvalidValue.
+func IsErrInvalidValue(err error) bool {
+	_, ok := err.(ErrInvalidValue)
+	return ok
+}
+
+func (err ErrInvalidValue) Error() string {
+	return fmt.Sprintf("%s: %s", err.Field, err.Message)
+}
+
+func (err ErrInvalidValue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// FormMetas are the values of an issue form which are mapped to the metadata of the issue
+type FormMetas struct {
+	// Labels and Assignees are names of labels and users
+	Labels    []string
+	Assignees []string
+	// Milestone and Project are the name of a milestone and the title of a project
+	Milestone string
+	Project   string
+	// Files are the UUIDs of the attachments of the file fields
+	Files []string
+}
+
+// ValidateValues checks the values submitted to an issue form against the validations of its fields, and
+// returns the first invalid value as an ErrInvalidValue. The fields which are not visible on the form are
+// not validated.
+func ValidateValues(template *api.IssueTemplate, values url.Values) error {
+	for _, field := range template.Fields {
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		if f.ID == "" || f.Type == api.IssueFormFieldTypeMarkdown || !f.VisibleOnForm() {
+			continue
+		}
+		if msg := f.validate(); msg != "" {
+			name := f.Label()
+			if name == "" {
+				name = f.ID
+			}
+			return ErrInvalidValue{Field: name, Message: msg}
+		}
+	}
+	return nil
+}
+
+// validate returns why the value of the field is invalid, or an empty string
+func (f *valuedField) validate() string {
+	required, _ := f.Validations["required"].(bool)
+	multiple, _ := f.Attributes["multiple"].(bool)
+
+	switch f.Type {
+	case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+		value := f.Value()
+		if value == "" {
+			if required {
+				return "is required"
+			}
+			return ""
+		}
+		if regex, ok := f.Validations["regex"].(string); ok && regex != "" {
+			// like the pattern attribute of inputs, the whole value has to match
+			re, err := regexp.Compile("^(?:" + regex + ")$")
+			if err != nil || !re.MatchString(value) {
+				return fmt.Sprintf("does not match %q", regex)
+			}
+		}
+		if isNumber, _ := f.Validations["is_number"].(bool); isNumber && f.Type == api.IssueFormFieldTypeInput {
+			return f.validateNumber(value)
+		}
+		length := float64(utf8.RuneCountInString(value))
+		if min, ok := toFloat(f.Validations["min"]); ok && length < min {
+			return fmt.Sprintf("should be at least %v characters long", min)
+		}
+		if max, ok := toFloat(f.Validations["max"]); ok && length > max {
+			return fmt.Sprintf("should be at most %v characters long", max)
+		}
+	case api.IssueFormFieldTypeNumber:
+		value := f.Value()
+		if value == "" {
+			if required {
+				return "is required"
+			}
+			return ""
+		}
+		return f.validateNumber(value)
+	case api.IssueFormFieldTypeDate:
+		value := f.Value()
+		if value == "" {
+			if required {
+				return "is required"
+			}
+			return ""
+		}
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return "should be a date in the format YYYY-MM-DD"
+		}
+		if min, ok := f.Validations["min"].(string); ok {
+			if minDate, err := time.Parse(dateLayout, min); err == nil && date.Before(minDate) {
+				return fmt.Sprintf("should not be before %s", min)
+			}
+		}
+		if max, ok := f.Validations["max"].(string); ok {
+			if maxDate, err := time.Parse(dateLayout, max); err == nil && date.After(maxDate) {
+				return fmt.Sprintf("should not be after %s", max)
+			}
+		}
+	case api.IssueFormFieldTypeDropdown:
+		checked := 0
+		options := f.Options()
+		for _, v := range strings.Split(f.Value(), ",") {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			}
+			if idx, err := strconv.Atoi(v); err != nil || idx < 0 || idx >= len(options) {
+				return fmt.Sprintf("has no option %q", v)
+			}
+			checked++
+		}
+		if checked == 0 && required {
+			return "is required"
+		}
+		if checked > 1 && !multiple {
+			return "only accepts one option"
+		}
+	case api.IssueFormFieldTypeCheckboxes:
+		for _, option := range f.Options() {
+			if vs, ok := option.data.(map[string]any); ok {
+				if required, _ := vs["required"].(bool); required && !option.IsChecked() {
+					return fmt.Sprintf("the option %q is required", option.Label())
+				}
+			}
+		}
+	case api.IssueFormFieldTypeUser, api.IssueFormFieldTypeLabel, api.IssueFormFieldTypeFile:
+		list := f.List()
+		if len(list) == 0 && required {
+			return "is required"
+		}
+		if len(list) > 1 && !multiple {
+			return "only accepts one value"
+		}
+		if f.Type == api.IssueFormFieldTypeLabel {
+			if options := f.Options(); len(options) > 0 {
+				for _, name := range list {
+					found := false
+					for _, option := range options {
+						if strings.EqualFold(option.Label(), name) {
+							found = true
+							break
+						}
+					}
+					if !found {
+						return fmt.Sprintf("has no option %q", name)
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (f *valuedField) validateNumber(value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "should be a number"
+	}
+	if min, ok := toFloat(f.Validations["min"]); ok && number < min {
+		return fmt.Sprintf("should be at least %v", min)
+	}
+	if max, ok := toFloat(f.Validations["max"]); ok && number > max {
+		return fmt.Sprintf("should be at most %v", max)
+	}
+	return ""
+}
+
+// MapValues returns the values of an issue form which are mapped to the metadata of the issue by the
+// 'map_to' attributes of its fields, and the attachments of its file fields
+func MapValues(template *api.IssueTemplate, values url.Values) *FormMetas {
+	metas := &FormMetas{}
+	for _, field := range template.Fields {
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		if f.ID == "" || !f.VisibleOnForm() {
+			continue
+		}
+		if f.Type == api.IssueFormFieldTypeFile {
+			metas.Files = append(metas.Files, f.List()...)
+			continue
+		}
+
+		var names []string
+		switch f.Type {
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			for _, option := range f.Options() {
+				if option.IsChecked() {
+					names = append(names, option.Label())
+				}
+			}
+		case api.IssueFormFieldTypeUser, api.IssueFormFieldTypeLabel:
+			names = f.List()
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		switch f.MapTo() {
+		case api.IssueFormFieldMapToLabels:
+			metas.Labels = append(metas.Labels, names...)
+		case api.IssueFormFieldMapToAssignees:
+			metas.Assignees = append(metas.Assignees, names...)
+		case api.IssueFormFieldMapToMilestone:
+			metas.Milestone = names[0]
+		case api.IssueFormFieldMapToProject:
+			metas.Project = names[0]
+		}
+	}
+	return metas
+}
+
+// DeclaredLabels returns the names of the labels declared by an issue form, the labels of the template and the
+// options of the fields mapped to labels
+func DeclaredLabels(template *api.IssueTemplate) []string {
+	labels := slices.Clone([]string(template.Labels))
+	for _, field := range template.Fields {
+		if field.MapTo() != api.IssueFormFieldMapToLabels {
+			continue
+		}
+		f := &valuedField{IssueFormField: field}
+		for _, option := range f.Options() {
+			if label := option.Label(); label != "" {
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+// ValuesFromMap converts values of an issue form by field id to the values submitted by the form. The checked
+// options of checkboxes are given as comma separated indexes.
+func ValuesFromMap(template *api.IssueTemplate, m map[string]string) url.Values {
+	values := make(url.Values, len(m))
+	for _, field := range template.Fields {
+		value, ok := m[field.ID]
+		if !ok {
+			continue
+		}
+		if field.Type != api.IssueFormFieldTypeCheckboxes {
+			values.Set("form-field-"+field.ID, value)
+			continue
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values.Set(fmt.Sprintf("form-field-%s-%s", field.ID, v), "on")
+			}
+		}
+	}
+	return values
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
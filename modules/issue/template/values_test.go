@@ -0,0 +1,166 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package template
+
+import (
+	"net/url"
+	"testing"
+
+	api "forgejo.org/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const valuesTemplate = `
+name: Name
+about: About
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "v[0-9]+"
+  - type: number
+    id: count
+    attributes:
+      label: Count
+    validations:
+      min: 1
+      max: 10
+  - type: date
+    id: since
+    attributes:
+      label: Since
+    validations:
+      min: "2020-01-01"
+  - type: dropdown
+    id: area
+    attributes:
+      label: Area
+      map_to: labels
+      options:
+        - ui
+        - api
+  - type: dropdown
+    id: release
+    attributes:
+      label: Release
+      map_to: milestone
+      options:
+        - v1.0
+        - v2.0
+  - type: user
+    id: owner
+    attributes:
+      label: Owner
+      map_to: assignees
+  - type: label
+    id: kind
+    attributes:
+      label: Kind
+      multiple: true
+      map_to: labels
+      options:
+        - bug
+        - feature
+  - type: file
+    id: logs
+    attributes:
+      label: Logs
+      multiple: true
+`
+
+func TestValidateValues(t *testing.T) {
+	tmpl, err := Unmarshal("test.yaml", []byte(valuesTemplate))
+	require.NoError(t, err)
+
+	valid := url.Values{
+		"form-field-version": {"v12"},
+		"form-field-count":   {"3"},
+		"form-field-since":   {"2024-05-01"},
+		"form-field-area":    {"1"},
+		"form-field-owner":   {"user2"},
+		"form-field-kind":    {"bug,Feature"},
+	}
+	require.NoError(t, ValidateValues(tmpl, valid))
+
+	tests := []struct {
+		field   string
+		value   string
+		wantErr string
+	}{
+		{"version", "", "Version: is required"},
+		{"version", "12", "Version: does not match \"v[0-9]+\""},
+		{"version", "v12 and more", "Version: does not match \"v[0-9]+\""},
+		{"count", "ten", "Count: should be a number"},
+		{"count", "11", "Count: should be at most 10"},
+		{"count", "0", "Count: should be at least 1"},
+		{"since", "yesterday", "Since: should be a date in the format YYYY-MM-DD"},
+		{"since", "2019-12-31", "Since: should not be before 2020-01-01"},
+		{"area", "2", "Area: has no option \"2\""},
+		{"area", "0,1", "Area: only accepts one option"},
+		{"owner", "user2,user3", "Owner: only accepts one value"},
+		{"kind", "question", "Kind: has no option \"question\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.value, func(t *testing.T) {
+			values := url.Values{}
+			for k, v := range valid {
+				values[k] = v
+			}
+			values.Set("form-field-"+tt.field, tt.value)
+			err := ValidateValues(tmpl, values)
+			require.EqualError(t, err, tt.wantErr)
+			assert.True(t, IsErrInvalidValue(err))
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	tmpl, err := Unmarshal("test.yaml", []byte(valuesTemplate))
+	require.NoError(t, err)
+
+	values := ValuesFromMap(tmpl, map[string]string{
+		"version": "v1",
+		"area":    "0",
+		"release": "1",
+		"owner":   "user2",
+		"kind":    "bug, feature",
+		"logs":    "uuid1,uuid2",
+		"unknown": "value",
+	})
+	require.NoError(t, ValidateValues(tmpl, values))
+	assert.Equal(t, &FormMetas{
+		Labels:    []string{"ui", "bug", "feature"},
+		Assignees: []string{"user2"},
+		Milestone: "v2.0",
+		Files:     []string{"uuid1", "uuid2"},
+	}, MapValues(tmpl, values))
+
+	content := RenderToMarkdownWithFiles(tmpl, values, map[string]string{"uuid1": "log.txt"})
+	assert.Contains(t, content, "### Owner\n\n@user2\n")
+	assert.Contains(t, content, "### Kind\n\nbug, feature\n")
+	assert.Contains(t, content, "### Logs\n\n- [log.txt](/attachments/uuid1)\n- [uuid2](/attachments/uuid2)\n")
+}
+
+func TestDeclaredLabels(t *testing.T) {
+	tmpl, err := Unmarshal("test.yaml", []byte(valuesTemplate))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ui", "api", "bug", "feature"}, DeclaredLabels(tmpl))
+}
+
+func TestValuesFromMapCheckboxes(t *testing.T) {
+	tmpl := &api.IssueTemplate{
+		Fields: []*api.IssueFormField{
+			{Type: api.IssueFormFieldTypeCheckboxes, ID: "terms"},
+		},
+	}
+	assert.Equal(t, url.Values{
+		"form-field-terms-0": {"on"},
+		"form-field-terms-2": {"on"},
+	}, ValuesFromMap(tmpl, map[string]string{"terms": "0, 2"}))
+}
@@ -59,6 +59,7 @@ type ReviewComment struct {
 	DiffHunk  string `yaml:"diff_hunk"`
 	Position  int
 	Line      int
+	StartLine int    `yaml:"start_line"` // first line of a range ending at Line, or 0
 	CommitID  string `yaml:"commit_id"`
 	PosterID  int64  `yaml:"poster_id"`
 	Reactions []*Reaction
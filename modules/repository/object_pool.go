@@ -0,0 +1,311 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/sync"
+	"forgejo.org/modules/util"
+)
+
+// The repositories of a fork network can share their objects through a pool repository: every member
+// borrows objects from the pool with git alternates, and the pool keeps the references of all the
+// members under refs/remotes/<repo id>/ so that the objects they rely on are never garbage collected.
+// Only repositories visible to everyone take part in a pool, as any object of the pool can be fetched
+// from any of its members.
+
+var objectPoolWorkingPool = sync.NewExclusivePool()
+
+func alternatesPath(repoPath string) string {
+	return filepath.Join(repoPath, "objects", "info", "alternates")
+}
+
+// ReadAlternates returns the absolute paths of the object directories a repository borrows objects from
+func ReadAlternates(repoPath string) ([]string, error) {
+	content, err := os.ReadFile(alternatesPath(repoPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(repoPath, "objects", line)
+		}
+		dirs = append(dirs, filepath.Clean(line))
+	}
+	return dirs, nil
+}
+
+// WriteAlternates makes a repository borrow objects from an object pool. The path is stored relative
+// to the repository, so that it keeps working if the repository root is moved.
+func WriteAlternates(repoPath, poolPath string) error {
+	rel, err := filepath.Rel(filepath.Join(repoPath, "objects"), filepath.Join(poolPath, "objects"))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(alternatesPath(repoPath)), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(alternatesPath(repoPath), []byte(filepath.ToSlash(rel)+"\n"), 0o644)
+}
+
+func objectPoolLockKey(poolID int64) string {
+	return "object_pool_" + strconv.FormatInt(poolID, 10)
+}
+
+func objectPoolNamespace(repoID int64) string {
+	return "refs/remotes/" + strconv.FormatInt(repoID, 10) + "/"
+}
+
+// fetchIntoObjectPool copies the references and objects of a member into the pool
+func fetchIntoObjectPool(ctx context.Context, poolPath string, repo *repo_model.Repository) error {
+	_, _, err := git.NewCommand(ctx, "fetch", "--quiet", "--prune", "--no-tags").
+		AddDynamicArguments(repo.RepoPath(), "+refs/*:"+objectPoolNamespace(repo.ID)+"*").
+		SetDescription(fmt.Sprintf("fetchIntoObjectPool: %s", repo.FullName())).
+		RunStdString(&git.RunOpts{Dir: poolPath})
+	return err
+}
+
+// deleteObjectPoolNamespaces removes from the pool the references of all the repositories for which keep returns false
+func deleteObjectPoolNamespaces(ctx context.Context, poolPath string, keep func(repoID int64) bool) error {
+	stdout, _, err := git.NewCommand(ctx, "for-each-ref", "--format=%(refname)", "refs/remotes/").RunStdString(&git.RunOpts{Dir: poolPath})
+	if err != nil {
+		return err
+	}
+
+	var deletes strings.Builder
+	for _, ref := range strings.Split(stdout, "\n") {
+		if ref == "" {
+			continue
+		}
+		repoID, _, _ := strings.Cut(strings.TrimPrefix(ref, "refs/remotes/"), "/")
+		if id, err := strconv.ParseInt(repoID, 10, 64); err == nil && keep(id) {
+			continue
+		}
+		deletes.WriteString("delete " + ref + "\n")
+	}
+	if deletes.Len() == 0 {
+		return nil
+	}
+
+	return git.NewCommand(ctx, "update-ref", "--stdin").Run(&git.RunOpts{
+		Dir:   poolPath,
+		Stdin: strings.NewReader(deletes.String()),
+	})
+}
+
+// CreateObjectPool moves the objects of a repository to a new object pool, so that they can be shared with its forks.
+// Nothing is done if the repository already takes part in a pool. It repacks the repository and may take a while
+// for large repositories, so it is meant to be run in the background.
+func CreateObjectPool(ctx context.Context, repo *repo_model.Repository) error {
+	poolID := repo.ID
+	objectPoolWorkingPool.CheckIn(objectPoolLockKey(poolID))
+	defer objectPoolWorkingPool.CheckOut(objectPoolLockKey(poolID))
+
+	// another fork may have created the pool in the meantime
+	current, err := repo_model.GetRepositoryByID(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	if current.ObjectPoolID != 0 {
+		repo.ObjectPoolID = current.ObjectPoolID
+		return nil
+	}
+
+	if err := git.InitRepository(ctx, repo_model.ObjectPoolPath(poolID), true, repo.ObjectFormatName); err != nil {
+		return fmt.Errorf("InitRepository: %w", err)
+	}
+	if err := joinObjectPool(ctx, repo, poolID); err != nil {
+		return err
+	}
+
+	log.Info("Created object pool %d for %-v", poolID, repo)
+	return nil
+}
+
+// JoinObjectPool makes a repository borrow objects from a pool and drops its own copies of them.
+// The references of the repository are only refreshed in the pool if it is already a member.
+func JoinObjectPool(ctx context.Context, repo *repo_model.Repository, poolID int64) error {
+	objectPoolWorkingPool.CheckIn(objectPoolLockKey(poolID))
+	defer objectPoolWorkingPool.CheckOut(objectPoolLockKey(poolID))
+
+	switch repo.ObjectPoolID {
+	case poolID:
+		return fetchIntoObjectPool(ctx, repo.ObjectPoolPath(), repo)
+	case 0:
+	default:
+		return fmt.Errorf("%-v already borrows objects from object pool %d", repo, repo.ObjectPoolID)
+	}
+	if err := joinObjectPool(ctx, repo, poolID); err != nil {
+		return err
+	}
+
+	log.Info("Added %-v to object pool %d", repo, poolID)
+	return nil
+}
+
+func joinObjectPool(ctx context.Context, repo *repo_model.Repository, poolID int64) error {
+	poolPath := repo_model.ObjectPoolPath(poolID)
+	if err := fetchIntoObjectPool(ctx, poolPath, repo); err != nil {
+		return fmt.Errorf("fetchIntoObjectPool: %w", err)
+	}
+
+	// the repository is recorded as a member before it borrows any object, so that the pool is never removed under its feet
+	repo.ObjectPoolID = poolID
+	if err := repo_model.UpdateRepositoryCols(ctx, repo, "object_pool_id"); err != nil {
+		repo.ObjectPoolID = 0
+		return err
+	}
+
+	if err := WriteAlternates(repo.RepoPath(), poolPath); err != nil {
+		return fmt.Errorf("WriteAlternates: %w", err)
+	}
+	// drop the local copies of the objects which are now in the pool: everything is packed first,
+	// as loose objects which are also found in the alternates would otherwise be kept
+	if _, _, err := git.NewCommand(ctx, "repack", "-a", "-d", "-q").RunStdString(&git.RunOpts{Dir: repo.RepoPath()}); err != nil {
+		return fmt.Errorf("git repack: %w", err)
+	}
+	if _, _, err := git.NewCommand(ctx, "repack", "-a", "-d", "-l", "-q").RunStdString(&git.RunOpts{Dir: repo.RepoPath()}); err != nil {
+		return fmt.Errorf("git repack --local: %w", err)
+	}
+	return nil
+}
+
+// DetachFromObjectPool copies all the objects a repository borrows from its pool back into it,
+// and removes its references from the pool.
+func DetachFromObjectPool(ctx context.Context, repo *repo_model.Repository) error {
+	if repo.ObjectPoolID == 0 {
+		return nil
+	}
+	objectPoolWorkingPool.CheckIn(objectPoolLockKey(repo.ObjectPoolID))
+	defer objectPoolWorkingPool.CheckOut(objectPoolLockKey(repo.ObjectPoolID))
+
+	return detachFromObjectPool(ctx, repo)
+}
+
+func detachFromObjectPool(ctx context.Context, repo *repo_model.Repository) error {
+	repoPath := repo.RepoPath()
+	if _, _, err := git.NewCommand(ctx, "repack", "-a", "-d", "-q").RunStdString(&git.RunOpts{Dir: repoPath}); err != nil {
+		return fmt.Errorf("git repack: %w", err)
+	}
+	if err := util.Remove(alternatesPath(repoPath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := deleteObjectPoolNamespaces(ctx, repo.ObjectPoolPath(), func(repoID int64) bool { return repoID != repo.ID }); err != nil {
+		return fmt.Errorf("deleteObjectPoolNamespaces: %w", err)
+	}
+
+	log.Info("Detached %-v from object pool %d", repo, repo.ObjectPoolID)
+	repo.ObjectPoolID = 0
+	return repo_model.UpdateRepositoryCols(ctx, repo, "object_pool_id")
+}
+
+// DetachPrivateObjectPoolMembers detaches the members of a pool which are not visible to everyone anymore
+func DetachPrivateObjectPoolMembers(ctx context.Context, poolID int64) error {
+	objectPoolWorkingPool.CheckIn(objectPoolLockKey(poolID))
+	defer objectPoolWorkingPool.CheckOut(objectPoolLockKey(poolID))
+
+	_, err := detachPrivateObjectPoolMembers(ctx, poolID)
+	return err
+}
+
+// detachPrivateObjectPoolMembers returns the remaining members of the pool
+func detachPrivateObjectPoolMembers(ctx context.Context, poolID int64) ([]*repo_model.Repository, error) {
+	members, err := repo_model.GetObjectPoolMembers(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]*repo_model.Repository, 0, len(members))
+	for _, member := range members {
+		canShare, err := member.CanShareObjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if canShare {
+			remaining = append(remaining, member)
+			continue
+		}
+		if err := detachFromObjectPool(ctx, member); err != nil {
+			return nil, fmt.Errorf("detachFromObjectPool[%d]: %w", member.ID, err)
+		}
+	}
+	return remaining, nil
+}
+
+// GarbageCollectObjectPool updates the references a pool keeps for its members, drops the ones of
+// former members and runs git gc on it. The pool is removed once it has no members left.
+func GarbageCollectObjectPool(ctx context.Context, poolID int64, timeout time.Duration, args git.TrustedCmdArgs) error {
+	objectPoolWorkingPool.CheckIn(objectPoolLockKey(poolID))
+	defer objectPoolWorkingPool.CheckOut(objectPoolLockKey(poolID))
+
+	poolPath := repo_model.ObjectPoolPath(poolID)
+	members, err := detachPrivateObjectPoolMembers(ctx, poolID)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		log.Info("Removing object pool %d which has no members left", poolID)
+		return util.RemoveAll(poolPath)
+	}
+
+	// the references of every member must be up to date before the ones of former members are dropped,
+	// otherwise objects which are still borrowed could be garbage collected
+	isMember := make(map[int64]bool, len(members))
+	for _, member := range members {
+		if err := fetchIntoObjectPool(ctx, poolPath, member); err != nil {
+			return fmt.Errorf("fetchIntoObjectPool[%d]: %w", member.ID, err)
+		}
+		isMember[member.ID] = true
+	}
+	if err := deleteObjectPoolNamespaces(ctx, poolPath, func(repoID int64) bool { return isMember[repoID] }); err != nil {
+		return fmt.Errorf("deleteObjectPoolNamespaces: %w", err)
+	}
+
+	_, _, err = git.NewCommand(ctx, "gc").AddArguments(args...).
+		SetDescription(fmt.Sprintf("Object Pool Garbage Collection: %d", poolID)).
+		RunStdString(&git.RunOpts{Timeout: timeout, Dir: poolPath})
+	return err
+}
+
+// ListObjectPools returns the IDs of the object pools found on disk
+func ListObjectPools() ([]int64, error) {
+	entries, err := os.ReadDir(filepath.Dir(repo_model.ObjectPoolPath(0)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	poolIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".git")
+		if !entry.IsDir() || !ok {
+			continue
+		}
+		if poolID, err := strconv.ParseInt(name, 10, 64); err == nil {
+			poolIDs = append(poolIDs, poolID)
+		}
+	}
+	return poolIDs, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlternates(t *testing.T) {
+	root := t.TempDir()
+	repoPath := filepath.Join(root, "user2", "repo1.git")
+	poolPath := filepath.Join(root, repo_model.ObjectPoolsDir, "1.git")
+
+	alternates, err := ReadAlternates(repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, alternates)
+
+	require.NoError(t, WriteAlternates(repoPath, poolPath))
+	content, err := os.ReadFile(alternatesPath(repoPath))
+	require.NoError(t, err)
+	assert.Equal(t, "../../../.object-pools/1.git/objects\n", string(content))
+
+	alternates, err = ReadAlternates(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(poolPath, "objects")}, alternates)
+}
+
+func TestObjectPool(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, CreateObjectPool(db.DefaultContext, repo))
+	assert.EqualValues(t, 1, repo.ObjectPoolID)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1, ObjectPoolID: 1})
+
+	alternates, err := ReadAlternates(repo.RepoPath())
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repo_model.ObjectPoolPath(1), "objects")}, alternates)
+	_, _, err = git.NewCommand(db.DefaultContext, "fsck", "--connectivity-only").RunStdString(&git.RunOpts{Dir: repo.RepoPath()})
+	require.NoError(t, err)
+
+	// creating the pool again is a no-op
+	require.NoError(t, CreateObjectPool(db.DefaultContext, repo))
+
+	poolIDs, err := ListObjectPools()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, poolIDs)
+
+	// a pool with members is kept
+	require.NoError(t, GarbageCollectObjectPool(db.DefaultContext, 1, 0, nil))
+	exist, err := util.IsExist(repo_model.ObjectPoolPath(1))
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	require.NoError(t, DetachFromObjectPool(db.DefaultContext, repo))
+	assert.EqualValues(t, 0, repo.ObjectPoolID)
+	alternates, err = ReadAlternates(repo.RepoPath())
+	require.NoError(t, err)
+	assert.Empty(t, alternates)
+	_, _, err = git.NewCommand(db.DefaultContext, "fsck", "--connectivity-only").RunStdString(&git.RunOpts{Dir: repo.RepoPath()})
+	require.NoError(t, err)
+
+	// a pool without members is removed
+	require.NoError(t, GarbageCollectObjectPool(db.DefaultContext, 1, 0, nil))
+	exist, err = util.IsExist(repo_model.ObjectPoolPath(1))
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestJoinObjectPool(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is a fork of repo10
+	parent := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+	require.NoError(t, CreateObjectPool(db.DefaultContext, parent))
+
+	require.NoError(t, JoinObjectPool(db.DefaultContext, fork, parent.ObjectPoolID))
+	assert.EqualValues(t, 10, fork.ObjectPoolID)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11, ObjectPoolID: 10})
+	alternates, err := ReadAlternates(fork.RepoPath())
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repo_model.ObjectPoolPath(10), "objects")}, alternates)
+	_, _, err = git.NewCommand(db.DefaultContext, "fsck", "--connectivity-only").RunStdString(&git.RunOpts{Dir: fork.RepoPath()})
+	require.NoError(t, err)
+	stdout, _, err := git.NewCommand(db.DefaultContext, "for-each-ref", "--format=%(refname)", "refs/remotes/11/").RunStdString(&git.RunOpts{Dir: repo_model.ObjectPoolPath(10)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, stdout)
+
+	// joining the pool again only refreshes the references of the member
+	require.NoError(t, JoinObjectPool(db.DefaultContext, fork, parent.ObjectPoolID))
+	// a repository never borrows from two pools
+	require.Error(t, JoinObjectPool(db.DefaultContext, fork, 1))
+
+	require.NoError(t, DetachFromObjectPool(db.DefaultContext, fork))
+	require.NoError(t, DetachFromObjectPool(db.DefaultContext, parent))
+	require.NoError(t, GarbageCollectObjectPool(db.DefaultContext, 10, 0, nil))
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+// Moderation settings, users can report abusive content to the administrators of the instance
+var Moderation = struct {
+	Enabled bool `ini:"ENABLED"`
+}{
+	Enabled: false,
+}
+
+func loadModerationFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "moderation", &Moderation)
+}
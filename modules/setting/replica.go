@@ -0,0 +1,51 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/log"
+)
+
+// Replica settings, an instance in replica mode follows the public repositories of a primary
+// instance as pull mirrors and redirects all writes to it
+var Replica = struct {
+	Enabled       bool
+	PrimaryURL    string
+	PrimaryToken  string
+	WebhookSecret string
+	// MirrorInterval is the interval of the mirrors, as a fallback for missed push events
+	MirrorInterval time.Duration
+}{
+	MirrorInterval: time.Hour,
+}
+
+func loadReplicaFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("replica")
+	if err := sec.MapTo(&Replica); err != nil {
+		log.Fatal("Failed to map Replica settings: %v", err)
+	}
+	if !Replica.Enabled {
+		return
+	}
+
+	u, err := url.Parse(Replica.PrimaryURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Fatal("[replica] PRIMARY_URL must be the http(s) URL of the primary instance, got %q", Replica.PrimaryURL)
+	}
+	Replica.PrimaryURL = strings.TrimSuffix(u.String(), "/") + "/"
+	if Replica.WebhookSecret == "" {
+		log.Fatal("[replica] WEBHOOK_SECRET is required to check the push events of the primary instance")
+	}
+	if !Mirror.Enabled {
+		log.Fatal("[replica] requires [mirror] ENABLED = true")
+	}
+	if Replica.MirrorInterval < Mirror.MinInterval {
+		log.Warn("[replica] MIRROR_INTERVAL is less than [mirror] MIN_INTERVAL, set to %s", Mirror.MinInterval)
+		Replica.MirrorInterval = Mirror.MinInterval
+	}
+}
@@ -56,6 +56,7 @@ var (
 		AllowDeleteOfUnadoptedRepositories      bool
 		DisableDownloadSourceArchives           bool
 		AllowForkWithoutMaximumLimit            bool
+		EnableForkObjectPools                   bool
 
 		// Repository editor settings
 		Editor struct {
@@ -176,6 +177,7 @@ var (
 		DisableForks:                            false,
 		DefaultBranch:                           "main",
 		AllowForkWithoutMaximumLimit:            true,
+		EnableForkObjectPools:                   false,
 
 		// Repository editor settings
 		Editor: struct {
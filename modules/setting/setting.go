@@ -149,6 +149,8 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	loadI18nFrom(cfg)
 	loadGitFrom(cfg)
 	loadMirrorFrom(cfg)
+	loadReplicaFrom(cfg)
+	loadModerationFrom(cfg)
 	loadMarkupFrom(cfg)
 	loadQuotaFrom(cfg)
 	loadOtherFrom(cfg)
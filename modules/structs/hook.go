@@ -316,6 +316,12 @@ const (
 	HookIssueReviewRequested HookIssueAction = "review_requested"
 	// HookIssueReviewRequestRemoved is an issue action for removing a review request to someone on a pull request.
 	HookIssueReviewRequestRemoved HookIssueAction = "review_request_removed"
+	// HookIssueProjected is an issue action for when an issue is added to a project.
+	HookIssueProjected HookIssueAction = "projected"
+	// HookIssueDeprojected is an issue action for when an issue is removed from a project.
+	HookIssueDeprojected HookIssueAction = "deprojected"
+	// HookIssueProjectColumnChanged is an issue action for when an issue is moved to another column of its project.
+	HookIssueProjectColumnChanged HookIssueAction = "project_column_changed"
 )
 
 // IssuePayload represents the payload information that is sent along with an issue event.
@@ -328,6 +334,18 @@ type IssuePayload struct {
 	Sender     *User           `json:"sender"`
 	CommitID   string          `json:"commit_id"`
 	Label      *Label          `json:"label,omitempty"`
+	// set for the events of the issue_project type
+	ProjectCard *ProjectCardPayload `json:"project_card,omitempty"`
+}
+
+// ProjectCardPayload represents the placement of an issue in a project
+type ProjectCardPayload struct {
+	// the project of the issue, empty if the issue was removed from its project
+	Project *Project `json:"project,omitempty"`
+	// the column of the issue, empty if the issue was removed from its project
+	Column       *ProjectColumn `json:"column,omitempty"`
+	OldProjectID int64          `json:"old_project_id,omitempty"`
+	OldColumnID  int64          `json:"old_column_id,omitempty"`
 }
 
 // JSONPayload encodes the IssuePayload to JSON, with an indentation of two spaces.
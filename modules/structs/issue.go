@@ -98,6 +98,13 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// path of the issue form the issue is created with, in one of the issue templates directories, its body is
+	// then rendered from the form values
+	Template string `json:"template"`
+	// values of the fields of the issue form by field id, the checked options of dropdowns and checkboxes
+	// are given as comma separated indexes, and the values of user, label and file fields as comma
+	// separated user names, label names and attachment UUIDs
+	FormValues map[string]string `json:"form_values"`
 }
 
 // EditIssueOption options for editing an issue
@@ -131,7 +138,8 @@ type IssueDeadline struct {
 	Deadline *time.Time `json:"due_date"`
 }
 
-// IssueFormFieldType defines issue form field type, can be "markdown", "textarea", "input", "dropdown" or "checkboxes"
+// IssueFormFieldType defines issue form field type, can be "markdown", "textarea", "input", "dropdown", "checkboxes",
+// "date", "number", "user", "label" or "file"
 type IssueFormFieldType string
 
 const (
@@ -140,6 +148,22 @@ const (
 	IssueFormFieldTypeInput      IssueFormFieldType = "input"
 	IssueFormFieldTypeDropdown   IssueFormFieldType = "dropdown"
 	IssueFormFieldTypeCheckboxes IssueFormFieldType = "checkboxes"
+	IssueFormFieldTypeDate       IssueFormFieldType = "date"
+	IssueFormFieldTypeNumber     IssueFormFieldType = "number"
+	IssueFormFieldTypeUser       IssueFormFieldType = "user"
+	IssueFormFieldTypeLabel      IssueFormFieldType = "label"
+	IssueFormFieldTypeFile       IssueFormFieldType = "file"
+)
+
+// IssueFormFieldMapTo defines what the values of an issue form field are mapped to, can be "labels", "assignees",
+// "milestone" or "project"
+type IssueFormFieldMapTo string
+
+const (
+	IssueFormFieldMapToLabels    IssueFormFieldMapTo = "labels"
+	IssueFormFieldMapToAssignees IssueFormFieldMapTo = "assignees"
+	IssueFormFieldMapToMilestone IssueFormFieldMapTo = "milestone"
+	IssueFormFieldMapToProject   IssueFormFieldMapTo = "project"
 )
 
 // IssueFormField represents a form field
@@ -152,6 +176,12 @@ type IssueFormField struct {
 	Visible     []IssueFormFieldVisible `json:"visible,omitempty"`
 }
 
+// MapTo returns what the values of the field are mapped to, or an empty string
+func (iff IssueFormField) MapTo() IssueFormFieldMapTo {
+	mapTo, _ := iff.Attributes["map_to"].(string)
+	return IssueFormFieldMapTo(mapTo)
+}
+
 func (iff IssueFormField) VisibleOnForm() bool {
 	if len(iff.Visible) == 0 {
 		return true
@@ -184,6 +214,7 @@ type IssueTemplate struct {
 	About    string              `json:"about" yaml:"about"` // Using "description" in a template file is compatible
 	Labels   IssueTemplateLabels `json:"labels" yaml:"labels"`
 	Ref      string              `json:"ref" yaml:"ref"`
+	Branches []string            `json:"branches" yaml:"branches"` // The target branches a pull request template is chosen for, as glob patterns
 	Content  string              `json:"content" yaml:"-"`
 	Fields   []*IssueFormField   `json:"body" yaml:"body"`
 	FileName string              `json:"file_name" yaml:"-"`
@@ -267,3 +298,32 @@ type IssueMeta struct {
 	Owner string `json:"owner"`
 	Name  string `json:"repo"`
 }
+
+// SubIssueProgress counts the closed issues among the sub-issues of an issue, on all levels
+type SubIssueProgress struct {
+	Closed  int `json:"closed"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"`
+}
+
+// SubIssueNode represents a sub-issue with its own sub-issues
+type SubIssueNode struct {
+	Issue     *Issue            `json:"issue"`
+	Progress  *SubIssueProgress `json:"progress"`
+	SubIssues []*SubIssueNode   `json:"sub_issues"`
+}
+
+// SubIssueTree represents all the levels of sub-issues of an issue
+type SubIssueTree struct {
+	Progress  *SubIssueProgress `json:"progress"`
+	SubIssues []*SubIssueNode   `json:"sub_issues"`
+}
+
+// MoveSubIssueOption options for moving a sub-issue among the sub-issues of its parent
+type MoveSubIssueOption struct {
+	Index int64  `json:"index"`
+	Owner string `json:"owner"`
+	Name  string `json:"repo"`
+	// position of the sub-issue, starting at 0. A negative position moves it to the end.
+	Position int `json:"position"`
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// ExportedIssue represents an issue or a pull request in an export of the issues of a repository
+type ExportedIssue struct {
+	Number int64  `json:"number"`
+	IsPull bool   `json:"is_pull"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	// enum: open,closed
+	State     StateType `json:"state"`
+	Poster    string    `json:"poster"`
+	Assignees []string  `json:"assignees"`
+	Labels    []string  `json:"labels"`
+	Milestone string    `json:"milestone,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated"`
+	// swagger:strfmt date-time
+	Closed *time.Time `json:"closed,omitempty"`
+	// TimeSpent is the total tracked time in seconds
+	TimeSpent    int64                  `json:"time_spent"`
+	Comments     []*ExportedComment     `json:"comments"`
+	TrackedTimes []*ExportedTrackedTime `json:"tracked_times"`
+}
+
+// ExportedComment represents a comment in an export of the issues of a repository
+type ExportedComment struct {
+	Poster string `json:"poster"`
+	Body   string `json:"body"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
+
+// ExportedTrackedTime represents a tracked time in an export of the issues of a repository
+type ExportedTrackedTime struct {
+	User string `json:"user"`
+	// Time in seconds
+	Time int64 `json:"time"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
+
+// IssueImportResult is the result of an import of issues, or of its validation
+type IssueImportResult struct {
+	// Imported is false when the import was only validated or when it is invalid
+	Imported bool              `json:"imported"`
+	Valid    bool              `json:"valid"`
+	Issues   []*IssueImportRow `json:"issues"`
+}
+
+// IssueImportRow is an issue of an import
+type IssueImportRow struct {
+	// Line is the line in a CSV file, or the position in a JSON array, starting at 1
+	Line      int64    `json:"line"`
+	Title     string   `json:"title"`
+	Labels    []string `json:"labels"`
+	Milestone string   `json:"milestone,omitempty"`
+	Closed    bool     `json:"closed"`
+	Errors    []string `json:"errors,omitempty"`
+	// Number is the number of the issue created by the import
+	Number int64 `json:"number,omitempty"`
+}
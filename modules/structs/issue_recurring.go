@@ -0,0 +1,73 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// RecurringIssue is the definition of an issue created in a repository on a schedule
+type RecurringIssue struct {
+	ID        int64    `json:"id"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels"`
+	Assignees []string `json:"assignees"`
+	// cron expression, in UTC unless it starts with CRON_TZ=
+	Schedule string `json:"schedule"`
+	// whether no issue is created while the last one is still open
+	SkipIfOpen bool  `json:"skip_if_open"`
+	Active     bool  `json:"active"`
+	Creator    *User `json:"creator"`
+	// the ID of the last created issue
+	LastIssueID int64 `json:"last_issue_id"`
+	// swagger:strfmt date-time
+	LastRun *time.Time `json:"last_run"`
+	// swagger:strfmt date-time
+	NextRun *time.Time `json:"next_run"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateRecurringIssueOption options for creating a recurring issue
+type CreateRecurringIssueOption struct {
+	// required: true
+	Title string `json:"title" binding:"Required;MaxSize(255)"`
+	Body  string `json:"body"`
+	// names of labels of the repository or of its organization
+	Labels []string `json:"labels"`
+	// names of users who can be assigned
+	Assignees []string `json:"assignees"`
+	// cron expression such as "0 9 * * 1" or "@weekly", in UTC unless it starts with CRON_TZ=
+	// required: true
+	Schedule   string `json:"schedule" binding:"Required;MaxSize(255)"`
+	SkipIfOpen bool   `json:"skip_if_open"`
+	Active     *bool  `json:"active"`
+}
+
+// EditRecurringIssueOption options for editing a recurring issue
+type EditRecurringIssueOption struct {
+	Title      *string   `json:"title"`
+	Body       *string   `json:"body"`
+	Labels     *[]string `json:"labels"`
+	Assignees  *[]string `json:"assignees"`
+	Schedule   *string   `json:"schedule"`
+	SkipIfOpen *bool     `json:"skip_if_open"`
+	Active     *bool     `json:"active"`
+}
+
+// RecurringIssueRun is a run of a recurring issue, with the issue it created or why it did not
+type RecurringIssueRun struct {
+	ID int64 `json:"id"`
+	// the created issue, missing if no issue was created or if it was deleted
+	Issue *Issue `json:"issue"`
+	// whether no issue was created because the last one was still open
+	Skipped bool `json:"skipped"`
+	// why no issue was created
+	Error string `json:"error"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
@@ -35,3 +35,12 @@ type TrackedTime struct {
 
 // TrackedTimeList represents a list of tracked times
 type TrackedTimeList []*TrackedTime
+
+// TimesheetEntry is the total tracked time of a group of a timesheet
+type TimesheetEntry struct {
+	// Name is the name of the user, the full name of the repository, the name of the milestone or of the
+	// label, or the date of the group, it is empty for the tracked times without milestone or label
+	Name string `json:"name"`
+	// Time in seconds
+	Time int64 `json:"time"`
+}
@@ -11,3 +11,39 @@ type BlockedUser struct {
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 }
+
+// AbuseReport represents a report of a user about abusive content
+type AbuseReport struct {
+	ID int64 `json:"id"`
+	// enum: open,handled,ignored
+	Status   string `json:"status"`
+	Reporter *User  `json:"reporter"`
+	// enum: user,repository,issue,comment
+	ContentType string `json:"content_type"`
+	ContentID   int64  `json:"content_id"`
+	// name of the content when it was reported, e.g. owner/repo#12
+	ContentReference string `json:"content_reference"`
+	// URL of the content when it was reported
+	ContentURL string `json:"content_url"`
+	// enum: spam,malware,illegal_content,harassment,other
+	Category string `json:"category"`
+	Remarks  string `json:"remarks"`
+	Resolver *User  `json:"resolver,omitempty"`
+	// enum: none,hide_content,suspend_user,delete_repository
+	Action       string `json:"action"`
+	ActionReason string `json:"action_reason"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Resolved *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ResolveAbuseReportOption options to resolve all the open reports about some content
+type ResolveAbuseReportOption struct {
+	// action taken on the reported content, none dismisses the reports
+	// required: true
+	// enum: none,hide_content,suspend_user,delete_repository
+	Action string `json:"action" binding:"Required"`
+	// reason of the action, kept with the resolved reports
+	Reason string `json:"reason" binding:"MaxSize(2000)"`
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// OrgRuleset represents branch or tag protections applied to the repositories of an organization
+type OrgRuleset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// enum: branch,tag
+	Target string `json:"target"`
+	// enum: active,evaluate
+	Enforcement string `json:"enforcement"`
+	// semicolon separated globs matched against repository names
+	RepoNamePatterns string   `json:"repo_name_patterns"`
+	RepoTopics       []string `json:"repo_topics"`
+	// semicolon separated globs matched against branch or tag names
+	RefPatterns             string   `json:"ref_patterns"`
+	EnablePush              bool     `json:"enable_push"`
+	EnablePushWhitelist     bool     `json:"enable_push_whitelist"`
+	PushWhitelistUsernames  []string `json:"push_whitelist_usernames"`
+	PushWhitelistTeams      []string `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys bool     `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist    bool     `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames []string `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams     []string `json:"merge_whitelist_teams"`
+	EnableStatusCheck       bool     `json:"enable_status_check"`
+	StatusCheckContexts     []string `json:"status_check_contexts"`
+	RequiredApprovals       int64    `json:"required_approvals"`
+	BlockOnRejectedReviews  bool     `json:"block_on_rejected_reviews"`
+	RequireSignedCommits    bool     `json:"require_signed_commits"`
+	ApplyToAdmins           bool     `json:"apply_to_admins"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateOrgRulesetOption options for creating an organization ruleset
+type CreateOrgRulesetOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// enum: branch,tag
+	Target string `json:"target"`
+	// enum: active,evaluate
+	Enforcement      string   `json:"enforcement"`
+	RepoNamePatterns string   `json:"repo_name_patterns"`
+	RepoTopics       []string `json:"repo_topics"`
+	// required: true
+	RefPatterns             string   `json:"ref_patterns" binding:"Required"`
+	EnablePush              bool     `json:"enable_push"`
+	EnablePushWhitelist     bool     `json:"enable_push_whitelist"`
+	PushWhitelistUsernames  []string `json:"push_whitelist_usernames"`
+	PushWhitelistTeams      []string `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys bool     `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist    bool     `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames []string `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams     []string `json:"merge_whitelist_teams"`
+	EnableStatusCheck       bool     `json:"enable_status_check"`
+	StatusCheckContexts     []string `json:"status_check_contexts"`
+	RequiredApprovals       int64    `json:"required_approvals"`
+	BlockOnRejectedReviews  bool     `json:"block_on_rejected_reviews"`
+	RequireSignedCommits    bool     `json:"require_signed_commits"`
+	ApplyToAdmins           bool     `json:"apply_to_admins"`
+}
+
+// EditOrgRulesetOption options for editing an organization ruleset
+type EditOrgRulesetOption struct {
+	Name *string `json:"name" binding:"MaxSize(255)"`
+	// enum: active,evaluate
+	Enforcement             *string  `json:"enforcement"`
+	RepoNamePatterns        *string  `json:"repo_name_patterns"`
+	RepoTopics              []string `json:"repo_topics"`
+	RefPatterns             *string  `json:"ref_patterns"`
+	EnablePush              *bool    `json:"enable_push"`
+	EnablePushWhitelist     *bool    `json:"enable_push_whitelist"`
+	PushWhitelistUsernames  []string `json:"push_whitelist_usernames"`
+	PushWhitelistTeams      []string `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys *bool    `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist    *bool    `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames []string `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams     []string `json:"merge_whitelist_teams"`
+	EnableStatusCheck       *bool    `json:"enable_status_check"`
+	StatusCheckContexts     []string `json:"status_check_contexts"`
+	RequiredApprovals       *int64   `json:"required_approvals"`
+	BlockOnRejectedReviews  *bool    `json:"block_on_rejected_reviews"`
+	RequireSignedCommits    *bool    `json:"require_signed_commits"`
+	ApplyToAdmins           *bool    `json:"apply_to_admins"`
+}
+
+// OrgRulesetViolation represents a push that did not comply with an evaluate-only organization ruleset
+type OrgRulesetViolation struct {
+	ID         int64       `json:"id"`
+	RulesetID  int64       `json:"ruleset_id"`
+	Repository *Repository `json:"repository"`
+	Ref        string      `json:"ref"`
+	Doer       *User       `json:"doer"`
+	Reason     string      `json:"reason"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
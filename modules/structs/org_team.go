@@ -17,8 +17,25 @@ type Team struct {
 	// Deprecated: This variable should be replaced by UnitsMap and will be dropped in later versions.
 	Units []string `json:"units"`
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"}
-	UnitsMap         map[string]string `json:"units_map"`
-	CanCreateOrgRepo bool              `json:"can_create_org_repo"`
+	UnitsMap         map[string]string     `json:"units_map"`
+	CanCreateOrgRepo bool                  `json:"can_create_org_repo"`
+	ReviewAssignment *TeamReviewAssignment `json:"review_assignment"`
+}
+
+// TeamReviewAssignment represents how the members of a team are assigned when the team is requested to review a
+// pull request
+type TeamReviewAssignment struct {
+	// enum: ["", "round_robin", "load_balance"]
+	Algorithm string `json:"algorithm"`
+	// number of members to assign, from 1 to 10
+	Count int `json:"count"`
+	// members with this many pending review requests are skipped, 0 for no limit
+	MaxPending int `json:"max_pending"`
+	// usernames of the members who are never assigned
+	Excluded []string `json:"excluded"`
+	// usernames of the members who are never assigned to the pull requests of a user, by username of the user
+	// example: {"user1":["manager1","manager2"]}
+	Managers map[string][]string `json:"managers"`
 }
 
 // CreateTeamOption options for creating a team
@@ -49,6 +66,7 @@ type EditTeamOption struct {
 	// Deprecated: This variable should be replaced by UnitsMap and will be dropped in later versions.
 	Units []string `json:"units"`
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"}
-	UnitsMap         map[string]string `json:"units_map"`
-	CanCreateOrgRepo *bool             `json:"can_create_org_repo"`
+	UnitsMap         map[string]string     `json:"units_map"`
+	CanCreateOrgRepo *bool                 `json:"can_create_org_repo"`
+	ReviewAssignment *TeamReviewAssignment `json:"review_assignment"`
 }
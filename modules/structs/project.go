@@ -0,0 +1,184 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// Project represents a project of a repository, an organization or a user
+type Project struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// enum: ["open", "closed"]
+	State StateType `json:"state"`
+	// enum: ["repository", "organization", "individual"]
+	Type string `json:"type"`
+	// enum: ["text_only", "images_and_text"]
+	CardType     string          `json:"card_type"`
+	Owner        *User           `json:"owner,omitempty"`
+	Repo         *RepositoryMeta `json:"repository,omitempty"`
+	Creator      *User           `json:"creator,omitempty"`
+	OpenIssues   int             `json:"open_issues"`
+	ClosedIssues int             `json:"closed_issues"`
+	HTMLURL      string          `json:"html_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+	// swagger:strfmt date-time
+	Closed *time.Time `json:"closed_at"`
+}
+
+// ProjectColumn represents a column of a project
+type ProjectColumn struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Title     string `json:"title"`
+	Color     string `json:"color"`
+	// issues which are not placed in a column are shown in the default column
+	Default bool `json:"default"`
+	Sorting int  `json:"sorting"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateProjectOption options for creating a project
+type CreateProjectOption struct {
+	// required:true
+	Title       string `json:"title" binding:"Required;MaxSize(255)"`
+	Description string `json:"description"`
+	// columns created with the project
+	// enum: ["none", "basic_kanban", "bug_triage"]
+	Template string `json:"template"`
+	// enum: ["text_only", "images_and_text"]
+	CardType string `json:"card_type"`
+}
+
+// EditProjectOption options for editing a project
+type EditProjectOption struct {
+	Title       *string `json:"title" binding:"MaxSize(255)"`
+	Description *string `json:"description"`
+	// enum: ["text_only", "images_and_text"]
+	CardType *string `json:"card_type"`
+	// enum: ["open", "closed"]
+	State *string `json:"state"`
+}
+
+// CreateProjectColumnOption options for creating a project column
+type CreateProjectColumnOption struct {
+	// required:true
+	Title string `json:"title" binding:"Required;MaxSize(255)"`
+	// example: #00aabb
+	Color string `json:"color"`
+}
+
+// EditProjectColumnOption options for editing a project column
+type EditProjectColumnOption struct {
+	Title *string `json:"title" binding:"MaxSize(255)"`
+	// example: #00aabb
+	Color *string `json:"color"`
+	// only true is accepted, the previous default column is no longer the default one
+	Default *bool `json:"default"`
+}
+
+// SortProjectColumnsOption options for sorting the columns of a project
+type SortProjectColumnsOption struct {
+	// the IDs of all the columns of the project, in their new order
+	// required:true
+	Columns []int64 `json:"columns" binding:"Required"`
+}
+
+// AddProjectIssueOption options for adding an issue or a pull request to a project
+type AddProjectIssueOption struct {
+	// the ID of the issue or the pull request, which is not its number in the repository
+	// required:true
+	IssueID int64 `json:"issue_id" binding:"Required"`
+	// the column of the issue, the default column if empty
+	ColumnID int64 `json:"column_id"`
+}
+
+// MoveProjectIssueOption options for moving an issue or a pull request between the columns of a project
+type MoveProjectIssueOption struct {
+	// required:true
+	ColumnID int64 `json:"column_id" binding:"Required"`
+	// the position of the issue in the column, starting at 0, the end of the column if empty
+	Position *int `json:"position"`
+}
+
+// ProjectFieldOption represents an option of a single select custom field of projects
+type ProjectFieldOption struct {
+	Name string `json:"name"`
+	// example: #00aabb
+	Color string `json:"color"`
+}
+
+// ProjectFieldIteration represents an iteration of an iteration custom field of projects
+type ProjectFieldIteration struct {
+	Title string `json:"title"`
+	// the first day of the iteration
+	// example: 2026-01-31
+	StartDate string `json:"start_date"`
+	// the number of days of the iteration
+	Duration int `json:"duration"`
+}
+
+// ProjectField represents a custom field of the issues of a project, or of all the projects of an organization
+type ProjectField struct {
+	ID int64 `json:"id"`
+	// the project of the field, 0 if the field is shared by all the projects of an organization
+	ProjectID int64 `json:"project_id"`
+	// the organization sharing the field with all its projects, 0 if the field belongs to a single project
+	OwnerID int64  `json:"owner_id"`
+	Name    string `json:"name"`
+	// enum: ["text", "number", "date", "single_select", "iteration"]
+	Type       string                   `json:"type"`
+	Options    []*ProjectFieldOption    `json:"options"`
+	Iterations []*ProjectFieldIteration `json:"iterations"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateProjectFieldOption options for creating a custom field of projects
+type CreateProjectFieldOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// required:true
+	// enum: ["text", "number", "date", "single_select", "iteration"]
+	Type string `json:"type" binding:"Required"`
+	// the options of a single select field
+	Options []*ProjectFieldOption `json:"options"`
+	// the iterations of an iteration field
+	Iterations []*ProjectFieldIteration `json:"iterations"`
+}
+
+// EditProjectFieldOption options for editing a custom field of projects, its type cannot be changed
+type EditProjectFieldOption struct {
+	Name *string `json:"name" binding:"MaxSize(255)"`
+	// the options of a single select field, the values of the issues which are not an option anymore are removed
+	Options []*ProjectFieldOption `json:"options"`
+	// the iterations of an iteration field, the values of the issues which are not an iteration anymore are removed
+	Iterations []*ProjectFieldIteration `json:"iterations"`
+}
+
+// IssueProjectFieldValue represents the value of a custom field of projects for an issue
+type IssueProjectFieldValue struct {
+	Field *ProjectField `json:"field"`
+	// a number, a date formatted as YYYY-MM-DD, the name of an option or the title of an iteration
+	// depending on the type of the field, empty if the value is not set
+	Value string `json:"value"`
+}
+
+// SetIssueProjectFieldValueOption options for setting the value of a custom field of projects for an issue
+type SetIssueProjectFieldValueOption struct {
+	// a number, a date formatted as YYYY-MM-DD, the name of an option or the title of an iteration
+	// depending on the type of the field
+	// required:true
+	Value string `json:"value" binding:"Required"`
+}
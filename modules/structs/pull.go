@@ -118,3 +118,44 @@ type ChangedFile struct {
 	ContentsURL      string `json:"contents_url,omitempty"`
 	RawURL           string `json:"raw_url,omitempty"`
 }
+
+// PullRequestCodeOwners represents the approval of the code owners of the paths changed by a pull request
+type PullRequestCodeOwners struct {
+	// Required is true if the protection of the base branch requires the approval of the code owners
+	Required bool                         `json:"required"`
+	Paths    []*PullRequestCodeOwnersPath `json:"paths"`
+}
+
+// PullRequestCodeOwnersPath represents a changed path which has code owners
+type PullRequestCodeOwnersPath struct {
+	Path string `json:"path"`
+	// Owners are the users and the teams owning the path in the CODEOWNERS file
+	Owners     []string `json:"owners"`
+	ApprovedBy []*User  `json:"approved_by"`
+	Approved   bool     `json:"approved"`
+}
+
+// PullRequestRevision represents a head commit a pull request had, from its creation or after a push
+type PullRequestRevision struct {
+	// Index is the 1-based position of the revision in the history of the pull request
+	Index       int    `json:"index"`
+	HeadSHA     string `json:"head_sha"`
+	IsForcePush bool   `json:"is_force_push"`
+	// Pusher is not set for a head which is only known as the one replaced by a force push
+	Pusher *User `json:"pusher"`
+	// swagger:strfmt date-time
+	Created *time.Time `json:"created_at"`
+}
+
+// PullRequestMergeStatus represents whether a pull request is ready to be merged, regardless of who merges it
+type PullRequestMergeStatus struct {
+	Mergeable bool `json:"mergeable"`
+	// BlockedReason is the first reason why the pull request is not mergeable, empty if it is
+	BlockedReason string `json:"blocked_reason"`
+	// RequireResolvedConversations is true if the protection of the base branch requires all the conversations to be resolved
+	RequireResolvedConversations bool `json:"require_resolved_conversations"`
+	UnresolvedConversations      int  `json:"unresolved_conversations"`
+	// ReviewChecklist are the items of the review checklist of the repository an approval has to tick
+	ReviewChecklist         []string `json:"review_checklist"`
+	ReviewChecklistComplete bool     `json:"review_checklist_complete"`
+}
@@ -37,6 +37,8 @@ type PullReview struct {
 	Official          bool            `json:"official"`
 	Dismissed         bool            `json:"dismissed"`
 	CodeCommentsCount int             `json:"comments_count"`
+	// items of the review checklist of the repository ticked by the reviewer
+	Checklist []string `json:"checklist"`
 	// swagger:strfmt date-time
 	Submitted time.Time `json:"submitted_at"`
 	// swagger:strfmt date-time
@@ -65,6 +67,10 @@ type PullReviewComment struct {
 	DiffHunk     string `json:"diff_hunk"`
 	LineNum      uint64 `json:"position"`
 	OldLineNum   uint64 `json:"original_position"`
+	// first new file line of a comment on a range of lines ending at position, or 0
+	StartLineNum uint64 `json:"start_position"`
+	// first old file line of a comment on a range of lines ending at original_position, or 0
+	OldStartLineNum uint64 `json:"original_start_position"`
 
 	HTMLURL     string `json:"html_url"`
 	HTMLPullURL string `json:"pull_request_url"`
@@ -76,6 +82,8 @@ type CreatePullReviewOptions struct {
 	Body     string                    `json:"body"`
 	CommitID string                    `json:"commit_id"`
 	Comments []CreatePullReviewComment `json:"comments"`
+	// items of the review checklist of the repository to tick, all of them are required to approve
+	Checklist []string `json:"checklist"`
 }
 
 // CreatePullReviewComment represent a review comment for creation api
@@ -87,6 +95,10 @@ type CreatePullReviewComment struct {
 	OldLineNum int64 `json:"old_position"`
 	// if comment to new file line or 0
 	NewLineNum int64 `json:"new_position"`
+	// first old file line of a comment on a range of lines ending at old_position, or 0
+	OldStartLineNum int64 `json:"old_start_position"`
+	// first new file line of a comment on a range of lines ending at new_position, or 0
+	NewStartLineNum int64 `json:"new_start_position"`
 }
 
 type CreatePullReviewCommentOptions CreatePullReviewComment
@@ -95,6 +107,8 @@ type CreatePullReviewCommentOptions CreatePullReviewComment
 type SubmitPullReviewOptions struct {
 	Event ReviewStateType `json:"event"`
 	Body  string          `json:"body"`
+	// items of the review checklist of the repository to tick, all of them are required to approve
+	Checklist []string `json:"checklist"`
 }
 
 // DismissPullReviewOptions are options to dismiss a pull review
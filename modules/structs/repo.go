@@ -116,6 +116,8 @@ type Repository struct {
 	MirrorUpdated time.Time     `json:"mirror_updated,omitempty"`
 	RepoTransfer  *RepoTransfer `json:"repo_transfer"`
 	Topics        []string      `json:"topics"`
+	// size in bytes above which pushed files are rejected, 0 means unlimited
+	MaxPushedBlobSize int64 `json:"max_pushed_blob_size"`
 }
 
 // GetName implements the gitrepo.Repository interface
@@ -236,6 +238,8 @@ type EditRepoOption struct {
 	MirrorInterval *string `json:"mirror_interval,omitempty"`
 	// enable prune - remove obsolete remote-tracking references when mirroring
 	EnablePrune *bool `json:"enable_prune,omitempty"`
+	// set the size in bytes above which pushed files are rejected, `0` to allow files of any size
+	MaxPushedBlobSize *int64 `json:"max_pushed_blob_size,omitempty"`
 }
 
 // GenerateRepoOption options when creating repository using a template
@@ -35,6 +35,7 @@ type BranchProtection struct {
 	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
 	EnableStatusCheck             bool     `json:"enable_status_check"`
 	StatusCheckContexts           []string `json:"status_check_contexts"`
+	StatusCheckSources            []string `json:"status_check_sources"`
 	RequiredApprovals             int64    `json:"required_approvals"`
 	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
 	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
@@ -44,7 +45,9 @@ type BranchProtection struct {
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
 	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
 	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
+	RequireCodeOwnerApproval      bool     `json:"require_code_owner_approval"`
 	RequireSignedCommits          bool     `json:"require_signed_commits"`
+	RequireResolvedConversations  bool     `json:"require_resolved_conversations"`
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	ApplyToAdmins                 bool     `json:"apply_to_admins"`
@@ -69,6 +72,7 @@ type CreateBranchProtectionOption struct {
 	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
 	EnableStatusCheck             bool     `json:"enable_status_check"`
 	StatusCheckContexts           []string `json:"status_check_contexts"`
+	StatusCheckSources            []string `json:"status_check_sources"`
 	RequiredApprovals             int64    `json:"required_approvals"`
 	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
 	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
@@ -78,7 +82,9 @@ type CreateBranchProtectionOption struct {
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
 	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
 	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
+	RequireCodeOwnerApproval      bool     `json:"require_code_owner_approval"`
 	RequireSignedCommits          bool     `json:"require_signed_commits"`
+	RequireResolvedConversations  bool     `json:"require_resolved_conversations"`
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	ApplyToAdmins                 bool     `json:"apply_to_admins"`
@@ -96,6 +102,7 @@ type EditBranchProtectionOption struct {
 	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
 	EnableStatusCheck             *bool    `json:"enable_status_check"`
 	StatusCheckContexts           []string `json:"status_check_contexts"`
+	StatusCheckSources            []string `json:"status_check_sources"`
 	RequiredApprovals             *int64   `json:"required_approvals"`
 	EnableApprovalsWhitelist      *bool    `json:"enable_approvals_whitelist"`
 	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
@@ -105,7 +112,9 @@ type EditBranchProtectionOption struct {
 	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch"`
 	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals"`
 	IgnoreStaleApprovals          *bool    `json:"ignore_stale_approvals"`
+	RequireCodeOwnerApproval      *bool    `json:"require_code_owner_approval"`
 	RequireSignedCommits          *bool    `json:"require_signed_commits"`
+	RequireResolvedConversations  *bool    `json:"require_resolved_conversations"`
 	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
 	ApplyToAdmins                 *bool    `json:"apply_to_admins"`
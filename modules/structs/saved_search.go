@@ -0,0 +1,69 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import "time"
+
+// SavedSearchQuery represents the filters of a saved search of issues and pull requests
+type SavedSearchQuery struct {
+	// keywords to search for
+	Keyword string `json:"q"`
+	// enum: ,issues,pulls
+	Type string `json:"type"`
+	// enum: open,closed,all
+	State      string   `json:"state"`
+	Labels     []string `json:"labels"`
+	Milestones []string `json:"milestones"`
+	// full names (owner/name) of the repositories to search, all the accessible ones if empty
+	Repos []string `json:"repos"`
+	// username of an assignee
+	Assignee string `json:"assignee"`
+	// username of the poster
+	Poster string `json:"poster"`
+	// username of a mentioned user
+	Mentioned string `json:"mentioned"`
+	ProjectID int64  `json:"project_id"`
+	// enum: ,latest,oldest,recentupdate,leastupdate,mostcomment,leastcomment,nearduedate,farduedate
+	Sort string `json:"sort"`
+}
+
+// SavedSearch represents a named search of issues and pull requests belonging to a user or an organization
+type SavedSearch struct {
+	ID    int64            `json:"id"`
+	Name  string           `json:"name"`
+	Owner *User            `json:"owner"`
+	Query SavedSearchQuery `json:"query"`
+	// whether the authenticated user can change the search
+	CanEdit bool `json:"can_edit"`
+	// how the authenticated user follows the search
+	Subscription SavedSearchSubscription `json:"subscription"`
+	HTMLURL      string                  `json:"html_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// SavedSearchSubscription represents how a user follows a saved search
+type SavedSearchSubscription struct {
+	// show the search on the dashboard
+	OnDashboard bool `json:"on_dashboard"`
+	// notify about new matches
+	Notify bool `json:"notify"`
+}
+
+// CreateSavedSearchOption options to save a search
+type CreateSavedSearchOption struct {
+	// name of the organization which owns the search, the authenticated user if empty
+	Owner string `json:"owner"`
+	// required: true
+	Name  string           `json:"name" binding:"Required;MaxSize(255)"`
+	Query SavedSearchQuery `json:"query"`
+}
+
+// EditSavedSearchOption options to change a saved search
+type EditSavedSearchOption struct {
+	Name  *string           `json:"name" binding:"MaxSize(255)"`
+	Query *SavedSearchQuery `json:"query"`
+}
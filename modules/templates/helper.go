@@ -172,6 +172,9 @@ func NewFuncMap() template.FuncMap {
 		"FederationEnabled": func() bool {
 			return setting.Federation.Enabled
 		},
+		"ModerationEnabled": func() bool {
+			return setting.Moderation.Enabled
+		},
 
 		// -----------------------------------------------------------------
 		// render
@@ -12,6 +12,7 @@ type HookEvents struct {
 	IssueAssign              bool `json:"issue_assign"`
 	IssueLabel               bool `json:"issue_label"`
 	IssueMilestone           bool `json:"issue_milestone"`
+	IssueProject             bool `json:"issue_project"`
 	IssueComment             bool `json:"issue_comment"`
 	Push                     bool `json:"push"`
 	PullRequest              bool `json:"pull_request"`
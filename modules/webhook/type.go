@@ -16,6 +16,7 @@ const (
 	HookEventIssueAssign               HookEventType = "issue_assign"
 	HookEventIssueLabel                HookEventType = "issue_label"
 	HookEventIssueMilestone            HookEventType = "issue_milestone"
+	HookEventIssueProject              HookEventType = "issue_project"
 	HookEventIssueComment              HookEventType = "issue_comment"
 	HookEventPullRequest               HookEventType = "pull_request"
 	HookEventPullRequestAssign         HookEventType = "pull_request_assign"
@@ -46,7 +47,7 @@ func (h HookEventType) Event() string {
 		return "fork"
 	case HookEventPush:
 		return "push"
-	case HookEventIssues, HookEventIssueAssign, HookEventIssueLabel, HookEventIssueMilestone:
+	case HookEventIssues, HookEventIssueAssign, HookEventIssueLabel, HookEventIssueMilestone, HookEventIssueProject:
 		return "issues"
 	case HookEventPullRequest, HookEventPullRequestAssign, HookEventPullRequestLabel, HookEventPullRequestMilestone,
 		HookEventPullRequestSync, HookEventPullRequestReviewRequest:
@@ -0,0 +1,188 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/db"
+	moderation_model "forgejo.org/models/moderation"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	moderation_service "forgejo.org/services/moderation"
+)
+
+// ListAbuseReports lists the abuse reports of the moderation queue
+func ListAbuseReports(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/reports admin adminListAbuseReports
+	// ---
+	// summary: List the abuse reports, the oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: status
+	//   in: query
+	//   description: status of the reports
+	//   type: string
+	//   enum: [open, handled, ignored]
+	// - name: type
+	//   in: query
+	//   description: type of the reported content
+	//   type: string
+	//   enum: [user, repository, issue, comment]
+	// - name: category
+	//   in: query
+	//   description: category of the reports
+	//   type: string
+	//   enum: [spam, malware, illegal_content, harassment, other]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	reports, total, err := db.FindAndCount[moderation_model.AbuseReport](ctx, moderation_model.FindReportsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		Status:      moderation_model.ReportStatusFromString(ctx.FormString("status")),
+		ContentType: moderation_model.ContentTypeFromString(ctx.FormString("type")),
+		Category:    moderation_model.CategoryFromString(ctx.FormString("category")),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAndCount", err)
+		return
+	}
+	if err := moderation_model.AbuseReportList(reports).LoadAttributes(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return
+	}
+
+	apiReports := make([]*api.AbuseReport, len(reports))
+	for i, report := range reports {
+		apiReports[i] = convert.ToAPIAbuseReport(ctx, ctx.Doer, report)
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiReports)
+}
+
+// GetAbuseReport gets an abuse report
+func GetAbuseReport(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/reports/{id} admin adminGetAbuseReport
+	// ---
+	// summary: Get an abuse report
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	report := getAbuseReport(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIAbuseReport(ctx, ctx.Doer, report))
+}
+
+// ResolveAbuseReport takes an action on the content of an abuse report and resolves all its open reports
+func ResolveAbuseReport(ctx *context.APIContext) {
+	// swagger:operation POST /admin/moderation/reports/{id}/resolve admin adminResolveAbuseReport
+	// ---
+	// summary: Take an action on the reported content and resolve all the open reports about it
+	// description: The reporters are notified by email.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ResolveAbuseReportOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.ResolveAbuseReportOption)
+	report := getAbuseReport(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	action, ok := moderation_model.ActionFromString(form.Action)
+	if !ok {
+		ctx.Error(http.StatusUnprocessableEntity, "ActionFromString", "unknown action "+form.Action)
+		return
+	}
+	if err := moderation_service.ResolveReport(ctx, ctx.Doer, report, action, form.Reason); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusUnprocessableEntity, "ResolveReport", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ResolveReport", err)
+		}
+		return
+	}
+
+	report, err := moderation_model.GetAbuseReportByID(ctx, report.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetAbuseReportByID", err)
+		return
+	}
+	if err := report.LoadAttributes(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIAbuseReport(ctx, ctx.Doer, report))
+}
+
+func getAbuseReport(ctx *context.APIContext) *moderation_model.AbuseReport {
+	report, err := moderation_model.GetAbuseReportByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if moderation_model.IsErrAbuseReportNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetAbuseReportByID", err)
+		}
+		return nil
+	}
+	if err := report.LoadAttributes(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return nil
+	}
+	return report
+}
@@ -93,6 +93,7 @@ import (
 	"forgejo.org/routers/api/v1/notify"
 	"forgejo.org/routers/api/v1/org"
 	"forgejo.org/routers/api/v1/packages"
+	"forgejo.org/routers/api/v1/project"
 	"forgejo.org/routers/api/v1/repo"
 	"forgejo.org/routers/api/v1/settings"
 	"forgejo.org/routers/api/v1/user"
@@ -747,6 +748,17 @@ func mustEnableWiki(ctx *context.APIContext) {
 	}
 }
 
+func mustEnableProjects(ctx *context.APIContext) {
+	if unit.TypeProjects.UnitGlobalDisabled() {
+		ctx.NotFound()
+		return
+	}
+	if ctx.Repo.Repository != nil && !ctx.Repo.CanRead(unit.TypeProjects) {
+		ctx.NotFound()
+		return
+	}
+}
+
 func mustNotBeArchived(ctx *context.APIContext) {
 	if ctx.Repo.Repository.IsArchived {
 		ctx.Error(http.StatusLocked, "RepoArchived", fmt.Errorf("%s is archived", ctx.Repo.Repository.LogString()))
@@ -913,6 +925,7 @@ func Routes() *web.Route {
 				}, reqSelfOrAdmin())
 
 				m.Get("/activities/feeds", user.ListUserActivityFeeds)
+				m.Get("/projects", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue), mustEnableProjects, project.ListUserProjects)
 			}, context.UserAssignmentAPI(), checkTokenPublicOnly(), individualPermsChecker)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryUser))
 
@@ -1034,6 +1047,18 @@ func Routes() *web.Route {
 					}, repoAssignment(), checkTokenPublicOnly())
 				}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository))
 			}
+			m.Post("/projects", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue), mustEnableProjects, bind(api.CreateProjectOption{}), project.CreateUserProject)
+			m.Group("/searches", func() {
+				m.Combo("").Get(user.ListSavedSearches).
+					Post(bind(api.CreateSavedSearchOption{}), user.CreateSavedSearch)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(user.GetSavedSearch).
+						Patch(bind(api.EditSavedSearchOption{}), user.EditSavedSearch).
+						Delete(user.DeleteSavedSearch)
+					m.Get("/issues", user.ListSavedSearchIssues)
+					m.Put("/subscription", bind(api.SavedSearchSubscription{}), user.SetSavedSearchSubscription)
+				})
+			}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
 			m.Get("/times", repo.ListMyTrackedTimes)
 			m.Get("/stopwatches", repo.GetStopwatches)
 			m.Get("/subscriptions", user.GetMyWatchedRepos)
@@ -1196,6 +1221,16 @@ func Routes() *web.Route {
 					m.Combo("/{id}").Get(repo.GetDeployKey).
 						Delete(repo.DeleteDeploykey)
 				}, reqToken(), reqAdmin())
+				m.Group("/recurring_issues", func() {
+					m.Combo("").Get(repo.ListRecurringIssues).
+						Post(mustNotBeArchived, bind(api.CreateRecurringIssueOption{}), repo.CreateRecurringIssue)
+					m.Group("/{id}", func() {
+						m.Combo("").Get(repo.GetRecurringIssue).
+							Patch(mustNotBeArchived, bind(api.EditRecurringIssueOption{}), repo.EditRecurringIssue).
+							Delete(repo.DeleteRecurringIssue)
+						m.Get("/runs", repo.ListRecurringIssueRuns)
+					})
+				}, mustEnableIssues, reqToken(), reqAdmin())
 				m.Group("/times", func() {
 					m.Combo("").Get(repo.ListTrackedTimesByRepository)
 					m.Combo("/{timetrackingusername}").Get(repo.ListTrackedTimesByUser)
@@ -1265,6 +1300,14 @@ func Routes() *web.Route {
 						m.Post("/update", reqToken(), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.UpdatePullRequest)
 						m.Get("/commits", repo.GetPullRequestCommits)
 						m.Get("/files", repo.GetPullRequestFiles)
+						m.Get("/code_owners", repo.GetPullRequestCodeOwners)
+						m.Get("/revisions", repo.GetPullRequestRevisions)
+						m.Group("/stack", func() {
+							m.Get("", repo.GetPullRequestStack)
+							m.Post("/merge", reqToken(), mustNotBeArchived, context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequestStack)
+							m.Post("/rebase", reqToken(), mustNotBeArchived, context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.RebasePullRequestStack)
+						})
+						m.Get("/merge_status", repo.GetPullRequestMergeStatus)
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
@@ -1376,8 +1419,10 @@ func Routes() *web.Route {
 			m.Group("/{username}/{reponame}", func() {
 				m.Group("/issues", func() {
 					m.Combo("").Get(repo.ListIssues).
-						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), repo.CreateIssue)
+						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), context.ReferencesGitRepo(), repo.CreateIssue)
 					m.Get("/pinned", reqRepoReader(unit.TypeIssues), repo.ListPinnedIssues)
+					m.Get("/export", reqToken(), tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue), repo.ExportIssues)
+					m.Post("/import", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeIssues), repo.ImportIssues)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -1426,6 +1471,12 @@ func Routes() *web.Route {
 							m.Delete("/{id}", repo.DeleteTime)
 						}, reqToken())
 						m.Combo("/deadline").Post(reqToken(), bind(api.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+						m.Group("/project_fields", func() {
+							m.Get("", repo.ListIssueProjectFields)
+							m.Combo("/{field_id}", reqToken(), mustNotBeArchived).
+								Put(bind(api.SetIssueProjectFieldValueOption{}), repo.SetIssueProjectField).
+								Delete(repo.DeleteIssueProjectField)
+						})
 						m.Group("/stopwatch", func() {
 							m.Post("/start", repo.StartIssueStopwatch)
 							m.Post("/stop", repo.StopIssueStopwatch)
@@ -1454,6 +1505,15 @@ func Routes() *web.Route {
 							Get(repo.GetIssueDependencies).
 							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.CreateIssueDependency).
 							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveIssueDependency)
+						m.Group("/sub_issues", func() {
+							m.Combo("").
+								Get(repo.ListSubIssues).
+								Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+								Patch(reqToken(), mustNotBeArchived, bind(api.MoveSubIssueOption{}), repo.MoveSubIssue).
+								Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
+							m.Get("/tree", repo.GetSubIssueTree)
+						})
+						m.Get("/parent", repo.GetParentIssue)
 						m.Combo("/blocks").
 							Get(repo.GetIssueBlocks).
 							Post(reqToken(), bind(api.IssueMeta{}), repo.CreateIssueBlocking).
@@ -1480,9 +1540,42 @@ func Routes() *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditMilestoneOption{}), repo.EditMilestone).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteMilestone)
 				})
+				m.Combo("/projects", mustEnableProjects).Get(project.ListRepoProjects).
+					Post(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.CreateProjectOption{}), project.CreateRepoProject)
 			}, repoAssignment(), checkTokenPublicOnly())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
 
+		// Projects, of repositories, organizations and users
+		m.Group("/projects/{id}", func() {
+			m.Combo("").Get(project.GetProject).
+				Patch(reqToken(), bind(api.EditProjectOption{}), project.EditProject).
+				Delete(reqToken(), project.DeleteProject)
+			m.Group("/columns", func() {
+				m.Combo("").Get(project.ListColumns).
+					Post(reqToken(), bind(api.CreateProjectColumnOption{}), project.CreateColumn)
+				m.Put("/order", reqToken(), bind(api.SortProjectColumnsOption{}), project.SortColumns)
+				m.Group("/{column_id}", func() {
+					m.Combo("").Get(project.GetColumn).
+						Patch(reqToken(), bind(api.EditProjectColumnOption{}), project.EditColumn).
+						Delete(reqToken(), project.DeleteColumn)
+					m.Get("/issues", project.ListColumnIssues)
+				})
+			})
+			m.Group("/fields", func() {
+				m.Combo("").Get(project.ListFields).
+					Post(reqToken(), bind(api.CreateProjectFieldOption{}), project.CreateField)
+				m.Combo("/{field_id}", reqToken()).
+					Patch(bind(api.EditProjectFieldOption{}), project.EditField).
+					Delete(project.DeleteField)
+			})
+			m.Group("/issues", func() {
+				m.Post("", bind(api.AddProjectIssueOption{}), project.AddIssue)
+				m.Combo("/{issue_id}").
+					Patch(bind(api.MoveProjectIssueOption{}), project.MoveIssue).
+					Delete(project.RemoveIssue)
+			}, reqToken())
+		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
+
 		// NOTE: these are Gitea package management API - see packages.CommonRoutes and packages.DockerContainerRoutes for endpoints that implement package manager APIs
 		m.Group("/packages/{username}", func() {
 			m.Group("/{type}/{name}", func() {
@@ -1542,6 +1635,25 @@ func Routes() *web.Route {
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			})
+			m.Group("/projects", func() {
+				m.Combo("").Get(project.ListOrgProjects).
+					Post(reqToken(), bind(api.CreateProjectOption{}), project.CreateOrgProject)
+				m.Group("/fields", func() {
+					m.Combo("").Get(project.ListOrgFields).
+						Post(reqToken(), bind(api.CreateProjectFieldOption{}), project.CreateOrgField)
+					m.Combo("/{field_id}", reqToken()).
+						Patch(bind(api.EditProjectFieldOption{}), project.EditOrgField).
+						Delete(project.DeleteOrgField)
+				})
+			}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue), mustEnableProjects)
+			m.Group("/rulesets", func() {
+				m.Combo("").Get(org.ListRulesets).
+					Post(bind(api.CreateOrgRulesetOption{}), org.CreateRuleset)
+				m.Combo("/{id}").Get(org.GetRuleset).
+					Patch(bind(api.EditOrgRulesetOption{}), org.EditRuleset).
+					Delete(org.DeleteRuleset)
+				m.Get("/{id}/violations", org.ListRulesetViolations)
+			}, reqToken(), reqOrgOwnership())
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
@@ -1554,6 +1666,7 @@ func Routes() *web.Route {
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
+			m.Get("/timesheet", reqToken(), reqOrgMembership(), tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue), org.GetTimesheet)
 
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
@@ -1642,6 +1755,13 @@ func Routes() *web.Route {
 				m.Get("/registration-token", admin.GetRegistrationToken)
 				m.Get("/jobs", admin.SearchActionRunJobs)
 			})
+			if setting.Moderation.Enabled {
+				m.Group("/moderation/reports", func() {
+					m.Get("", admin.ListAbuseReports)
+					m.Get("/{id}", admin.GetAbuseReport)
+					m.Post("/{id}/resolve", bind(api.ResolveAbuseReportOption{}), admin.ResolveAbuseReport)
+				})
+			}
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
 					m.Group("/rules", func() {
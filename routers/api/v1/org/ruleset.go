@@ -0,0 +1,471 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListRulesets list the rulesets of an organization
+func ListRulesets(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/rulesets organization orgListRulesets
+	// ---
+	// summary: List an organization's branch and tag rulesets
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRulesetList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rulesets, err := git_model.FindOrgRulesets(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindOrgRulesets", err)
+		return
+	}
+
+	apiRulesets := make([]*api.OrgRuleset, 0, len(rulesets))
+	for _, rs := range rulesets {
+		apiRulesets = append(apiRulesets, convert.ToOrgRuleset(ctx, rs))
+	}
+	ctx.JSON(http.StatusOK, apiRulesets)
+}
+
+// GetRuleset get a ruleset of an organization
+func GetRuleset(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/rulesets/{id} organization orgGetRuleset
+	// ---
+	// summary: Get a branch or tag ruleset of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRuleset"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rs := getRulesetByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToOrgRuleset(ctx, rs))
+}
+
+// CreateRuleset create a ruleset for an organization
+func CreateRuleset(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/rulesets organization orgCreateRuleset
+	// ---
+	// summary: Create a branch or tag ruleset for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrgRulesetOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/OrgRuleset"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateOrgRulesetOption)
+	orgID := ctx.Org.Organization.ID
+
+	target, ok := git_model.RulesetTargetFromString(form.Target)
+	if !ok {
+		ctx.Error(http.StatusUnprocessableEntity, "Target", fmt.Errorf("unknown ruleset target %q", form.Target))
+		return
+	}
+	enforcement, ok := git_model.RulesetEnforcementFromString(form.Enforcement)
+	if !ok {
+		ctx.Error(http.StatusUnprocessableEntity, "Enforcement", fmt.Errorf("unknown ruleset enforcement %q", form.Enforcement))
+		return
+	}
+
+	if existing, err := git_model.GetOrgRulesetByName(ctx, orgID, form.Name); err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOrgRulesetByName", err)
+		return
+	} else if existing != nil {
+		ctx.Error(http.StatusForbidden, "Create ruleset", fmt.Errorf("ruleset %q already exists", form.Name))
+		return
+	}
+
+	rs := &git_model.OrgRuleset{
+		OrgID:                  orgID,
+		Name:                   form.Name,
+		Target:                 target,
+		Enforcement:            enforcement,
+		RepoNamePatterns:       form.RepoNamePatterns,
+		RefPatterns:            form.RefPatterns,
+		CanPush:                form.EnablePush,
+		EnableWhitelist:        form.EnablePush && form.EnablePushWhitelist,
+		WhitelistDeployKeys:    form.EnablePush && form.EnablePushWhitelist && form.PushWhitelistDeployKeys,
+		EnableMergeWhitelist:   form.EnableMergeWhitelist,
+		EnableStatusCheck:      form.EnableStatusCheck,
+		StatusCheckContexts:    form.StatusCheckContexts,
+		RequiredApprovals:      form.RequiredApprovals,
+		BlockOnRejectedReviews: form.BlockOnRejectedReviews,
+		RequireSignedCommits:   form.RequireSignedCommits,
+		ApplyToAdmins:          form.ApplyToAdmins,
+	}
+	if !setRulesetTargeting(ctx, rs, form.RepoTopics) {
+		return
+	}
+	if !setRulesetAllowlists(ctx, rs, form.PushWhitelistUsernames, form.PushWhitelistTeams, form.MergeWhitelistUsernames, form.MergeWhitelistTeams) {
+		return
+	}
+
+	if err := git_model.InsertOrgRuleset(ctx, rs); err != nil {
+		ctx.Error(http.StatusInternalServerError, "InsertOrgRuleset", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToOrgRuleset(ctx, rs))
+}
+
+// EditRuleset edit a ruleset of an organization
+func EditRuleset(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/rulesets/{id} organization orgEditRuleset
+	// ---
+	// summary: Edit a branch or tag ruleset of an organization. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditOrgRulesetOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRuleset"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditOrgRulesetOption)
+	rs := getRulesetByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil && *form.Name != rs.Name {
+		if existing, err := git_model.GetOrgRulesetByName(ctx, rs.OrgID, *form.Name); err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetOrgRulesetByName", err)
+			return
+		} else if existing != nil {
+			ctx.Error(http.StatusForbidden, "Edit ruleset", fmt.Errorf("ruleset %q already exists", *form.Name))
+			return
+		}
+		rs.Name = *form.Name
+	}
+	if form.Enforcement != nil {
+		enforcement, ok := git_model.RulesetEnforcementFromString(*form.Enforcement)
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "Enforcement", fmt.Errorf("unknown ruleset enforcement %q", *form.Enforcement))
+			return
+		}
+		rs.Enforcement = enforcement
+	}
+	if form.RepoNamePatterns != nil {
+		rs.RepoNamePatterns = *form.RepoNamePatterns
+	}
+	if form.RefPatterns != nil {
+		rs.RefPatterns = *form.RefPatterns
+	}
+	repoTopics := rs.RepoTopics
+	if form.RepoTopics != nil {
+		repoTopics = form.RepoTopics
+	}
+	if !setRulesetTargeting(ctx, rs, repoTopics) {
+		return
+	}
+
+	if form.EnablePush != nil {
+		rs.CanPush = *form.EnablePush
+	}
+	if form.EnablePushWhitelist != nil {
+		rs.EnableWhitelist = *form.EnablePushWhitelist
+	}
+	if form.PushWhitelistDeployKeys != nil {
+		rs.WhitelistDeployKeys = *form.PushWhitelistDeployKeys
+	}
+	if !rs.CanPush {
+		rs.EnableWhitelist = false
+	}
+	if !rs.EnableWhitelist {
+		rs.WhitelistDeployKeys = false
+	}
+	if form.EnableMergeWhitelist != nil {
+		rs.EnableMergeWhitelist = *form.EnableMergeWhitelist
+	}
+	if form.EnableStatusCheck != nil {
+		rs.EnableStatusCheck = *form.EnableStatusCheck
+	}
+	if form.StatusCheckContexts != nil {
+		rs.StatusCheckContexts = form.StatusCheckContexts
+	}
+	if form.RequiredApprovals != nil && *form.RequiredApprovals >= 0 {
+		rs.RequiredApprovals = *form.RequiredApprovals
+	}
+	if form.BlockOnRejectedReviews != nil {
+		rs.BlockOnRejectedReviews = *form.BlockOnRejectedReviews
+	}
+	if form.RequireSignedCommits != nil {
+		rs.RequireSignedCommits = *form.RequireSignedCommits
+	}
+	if form.ApplyToAdmins != nil {
+		rs.ApplyToAdmins = *form.ApplyToAdmins
+	}
+
+	if form.PushWhitelistUsernames != nil || form.PushWhitelistTeams != nil || form.MergeWhitelistUsernames != nil || form.MergeWhitelistTeams != nil {
+		pushUsers, pushTeams := form.PushWhitelistUsernames, form.PushWhitelistTeams
+		mergeUsers, mergeTeams := form.MergeWhitelistUsernames, form.MergeWhitelistTeams
+		current := convert.ToOrgRuleset(ctx, rs)
+		if pushUsers == nil {
+			pushUsers = current.PushWhitelistUsernames
+		}
+		if pushTeams == nil {
+			pushTeams = current.PushWhitelistTeams
+		}
+		if mergeUsers == nil {
+			mergeUsers = current.MergeWhitelistUsernames
+		}
+		if mergeTeams == nil {
+			mergeTeams = current.MergeWhitelistTeams
+		}
+		if !setRulesetAllowlists(ctx, rs, pushUsers, pushTeams, mergeUsers, mergeTeams) {
+			return
+		}
+	}
+
+	if err := git_model.UpdateOrgRuleset(ctx, rs); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateOrgRuleset", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgRuleset(ctx, rs))
+}
+
+// DeleteRuleset delete a ruleset of an organization
+func DeleteRuleset(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/rulesets/{id} organization orgDeleteRuleset
+	// ---
+	// summary: Delete a branch or tag ruleset of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := git_model.DeleteOrgRuleset(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id")); err != nil {
+		if git_model.IsErrOrgRulesetNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteOrgRuleset", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListRulesetViolations list the pushes violating an evaluate-only ruleset of an organization
+func ListRulesetViolations(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/rulesets/{id}/violations organization orgListRulesetViolations
+	// ---
+	// summary: List the pushes which did not comply with an organization ruleset while it was in evaluate-only mode
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRulesetViolationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rs := getRulesetByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	violations, count, err := git_model.FindOrgRulesetViolations(ctx, rs.ID, utils.GetListOptions(ctx))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindOrgRulesetViolations", err)
+		return
+	}
+
+	apiViolations := make([]*api.OrgRulesetViolation, 0, len(violations))
+	for _, v := range violations {
+		apiViolations = append(apiViolations, convert.ToOrgRulesetViolation(ctx, v, ctx.Doer))
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiViolations)
+}
+
+func getRulesetByParams(ctx *context.APIContext) *git_model.OrgRuleset {
+	rs, err := git_model.GetOrgRulesetByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if git_model.IsErrOrgRulesetNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetOrgRulesetByID", err)
+		}
+		return nil
+	}
+	return rs
+}
+
+// setRulesetTargeting validates the repository and reference patterns of the ruleset and sets its topics
+func setRulesetTargeting(ctx *context.APIContext, rs *git_model.OrgRuleset, repoTopics []string) bool {
+	if strings.TrimSpace(rs.RefPatterns) == "" {
+		ctx.Error(http.StatusUnprocessableEntity, "RefPatterns", errors.New("a ruleset requires at least one branch or tag pattern"))
+		return false
+	}
+	if err := git_model.ValidateRulesetPatterns(rs.RefPatterns); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "RefPatterns", err)
+		return false
+	}
+	if err := git_model.ValidateRulesetPatterns(rs.RepoNamePatterns); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "RepoNamePatterns", err)
+		return false
+	}
+
+	validTopics, invalidTopics := repo_model.SanitizeAndValidateTopics(repoTopics)
+	if len(invalidTopics) > 0 {
+		ctx.Error(http.StatusUnprocessableEntity, "RepoTopics", fmt.Errorf("invalid topics: %s", strings.Join(invalidTopics, ", ")))
+		return false
+	}
+	rs.RepoTopics = validTopics
+	return true
+}
+
+// setRulesetAllowlists resolves the user and team names of the allowlists of the ruleset
+func setRulesetAllowlists(ctx *context.APIContext, rs *git_model.OrgRuleset, pushUsers, pushTeams, mergeUsers, mergeTeams []string) bool {
+	var err error
+	resolveUsers := func(names []string) []int64 {
+		if err != nil {
+			return nil
+		}
+		var ids []int64
+		ids, err = user_model.GetUserIDsByNames(ctx, names, false)
+		return ids
+	}
+	resolveTeams := func(names []string) []int64 {
+		if err != nil {
+			return nil
+		}
+		var ids []int64
+		ids, err = organization.GetTeamIDsByNames(ctx, rs.OrgID, names, false)
+		return ids
+	}
+
+	rs.WhitelistUserIDs = resolveUsers(pushUsers)
+	rs.MergeWhitelistUserIDs = resolveUsers(mergeUsers)
+	rs.WhitelistTeamIDs = resolveTeams(pushTeams)
+	rs.MergeWhitelistTeamIDs = resolveTeams(mergeTeams)
+
+	switch {
+	case err == nil:
+		return true
+	case user_model.IsErrUserNotExist(err):
+		ctx.Error(http.StatusUnprocessableEntity, "User does not exist", err)
+	case organization.IsErrTeamNotExist(err):
+		ctx.Error(http.StatusUnprocessableEntity, "Team does not exist", err)
+	default:
+		ctx.Error(http.StatusInternalServerError, "GetIDsByNames", err)
+	}
+	return false
+}
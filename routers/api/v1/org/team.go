@@ -17,6 +17,7 @@ import (
 	unit_model "forgejo.org/models/unit"
 	"forgejo.org/modules/log"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/user"
 	"forgejo.org/routers/api/v1/utils"
@@ -284,6 +285,8 @@ func EditTeam(ctx *context.APIContext) {
 	//     "$ref": "#/responses/Team"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditTeamOption)
 	team := ctx.Org.Team
@@ -292,6 +295,23 @@ func EditTeam(ctx *context.APIContext) {
 		return
 	}
 
+	if form.ReviewAssignment != nil {
+		if err := org_service.UpdateTeamReviewAssignment(ctx, team, org_service.ReviewAssignmentOptions{
+			Algorithm:  organization.ReviewAssignmentAlgorithm(form.ReviewAssignment.Algorithm),
+			Count:      form.ReviewAssignment.Count,
+			MaxPending: form.ReviewAssignment.MaxPending,
+			Excluded:   form.ReviewAssignment.Excluded,
+			Managers:   form.ReviewAssignment.Managers,
+		}); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Error(http.StatusUnprocessableEntity, "UpdateTeamReviewAssignment", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "UpdateTeamReviewAssignment", err)
+			}
+			return
+		}
+	}
+
 	if form.CanCreateOrgRepo != nil {
 		team.CanCreateOrgRepo = team.IsOwnerTeam() || *form.CanCreateOrgRepo
 	}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	timesheet_service "forgejo.org/services/timesheet"
+)
+
+// GetTimesheet returns the time tracked in the repositories of an organization
+func GetTimesheet(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/timesheet organization orgGetTimesheet
+	// ---
+	// summary: Get the time tracked in the repositories of an organization, aggregated by a group
+	// produces:
+	// - application/json
+	// - text/csv
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_by
+	//   in: query
+	//   description: how the tracked times are aggregated
+	//   type: string
+	//   enum: [user, repo, milestone, label, date]
+	//   default: user
+	// - name: from
+	//   in: query
+	//   description: only the times tracked since this day, in the format YYYY-MM-DD
+	//   type: string
+	// - name: to
+	//   in: query
+	//   description: only the times tracked until this day included, in the format YYYY-MM-DD
+	//   type: string
+	// - name: user
+	//   in: query
+	//   description: only the times tracked by this user
+	//   type: string
+	// - name: repo
+	//   in: query
+	//   description: only the times tracked in this repository of the organization
+	//   type: string
+	// - name: milestone
+	//   in: query
+	//   description: only the times tracked on the issues of milestones with this name
+	//   type: string
+	// - name: label
+	//   in: query
+	//   description: only the times tracked on the issues with labels with this name
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: format of the response
+	//   type: string
+	//   enum: [json, csv]
+	//   default: json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TimesheetEntryList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Service.EnableTimetracking {
+		ctx.NotFound()
+		return
+	}
+	opts := timesheet_service.Options{
+		Group:     issues_model.TimesheetGroup(ctx.FormString("group_by")),
+		User:      ctx.FormTrim("user"),
+		Repo:      ctx.FormTrim("repo"),
+		Milestone: ctx.FormTrim("milestone"),
+		Label:     ctx.FormTrim("label"),
+		From:      ctx.FormTrim("from"),
+		To:        ctx.FormTrim("to"),
+	}
+	if opts.Group == "" {
+		opts.Group = issues_model.TimesheetGroupUser
+	}
+	format := ctx.FormString("format")
+	if format != "" && format != "json" && format != "csv" {
+		ctx.Error(http.StatusBadRequest, "format", "the format must be json or csv")
+		return
+	}
+
+	entries, err := timesheet_service.GetTimesheet(ctx, ctx.Doer, ctx.Org.Organization.AsUser(), opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, "GetTimesheet", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "GetTimesheet", err)
+		return
+	}
+
+	if format == "csv" {
+		ctx.SetServeHeaders(&context.ServeHeaderOptions{
+			ContentType:        "text/csv",
+			ContentTypeCharset: "utf-8",
+			Filename:           fmt.Sprintf("%s-timesheet-by-%s.csv", ctx.Org.Organization.Name, opts.Group),
+		})
+		if err := timesheet_service.WriteCSV(ctx.Resp, opts.Group, entries); err != nil {
+			log.Error("WriteCSV [org id: %d]: %v", ctx.Org.Organization.ID, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,346 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models/perm"
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListColumns list the columns of a project
+func ListColumns(ctx *context.APIContext) {
+	// swagger:operation GET /projects/{id}/columns project projectListColumns
+	// ---
+	// summary: List the columns of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectColumnList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getProject(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+
+	columns, err := p.GetColumns(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetColumns", err)
+		return
+	}
+
+	apiColumns := make([]*api.ProjectColumn, len(columns))
+	for i := range columns {
+		apiColumns[i] = convert.ToAPIProjectColumn(columns[i])
+	}
+	ctx.JSON(http.StatusOK, &apiColumns)
+}
+
+// CreateColumn create a column in a project
+func CreateColumn(ctx *context.APIContext) {
+	// swagger:operation POST /projects/{id}/columns project projectCreateColumn
+	// ---
+	// summary: Create a column in a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectColumnOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectColumn"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.CreateProjectColumnOption)
+
+	if form.Color != "" && !project_model.ColumnColorPattern.MatchString(form.Color) {
+		ctx.Error(http.StatusUnprocessableEntity, "CreateColumn", fmt.Sprintf("bad color code: %s", form.Color))
+		return
+	}
+
+	column := &project_model.Column{
+		ProjectID: p.ID,
+		Title:     form.Title,
+		Color:     form.Color,
+		CreatorID: ctx.Doer.ID,
+	}
+	if err := project_model.NewColumn(ctx, column); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "NewColumn", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIProjectColumn(column))
+}
+
+// GetColumn get a column of a project
+func GetColumn(ctx *context.APIContext) {
+	// swagger:operation GET /projects/{id}/columns/{column_id} project projectGetColumn
+	// ---
+	// summary: Get a column of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: column_id
+	//   in: path
+	//   description: id of the column
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectColumn"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	_, column := getProjectColumn(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectColumn(column))
+}
+
+// EditColumn edit a column of a project
+func EditColumn(ctx *context.APIContext) {
+	// swagger:operation PATCH /projects/{id}/columns/{column_id} project projectEditColumn
+	// ---
+	// summary: Edit a column of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: column_id
+	//   in: path
+	//   description: id of the column
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectColumnOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectColumn"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p, column := getProjectColumn(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.EditProjectColumnOption)
+
+	if form.Title != nil {
+		if *form.Title == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "EditColumn", "title must not be empty")
+			return
+		}
+		column.Title = *form.Title
+	}
+	if form.Color != nil {
+		if *form.Color != "" && !project_model.ColumnColorPattern.MatchString(*form.Color) {
+			ctx.Error(http.StatusUnprocessableEntity, "EditColumn", fmt.Sprintf("bad color code: %s", *form.Color))
+			return
+		}
+		column.Color = *form.Color
+	}
+	if form.Default != nil && !*form.Default {
+		ctx.Error(http.StatusUnprocessableEntity, "EditColumn", "another column has to be made the default one instead")
+		return
+	}
+
+	if err := project_model.UpdateColumn(ctx, column); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateColumn", err)
+		return
+	}
+	if form.Default != nil && !column.Default {
+		if err := project_model.SetDefaultColumn(ctx, p.ID, column.ID); err != nil {
+			ctx.Error(http.StatusInternalServerError, "SetDefaultColumn", err)
+			return
+		}
+	}
+
+	column, err := project_model.GetColumn(ctx, column.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectColumn(column))
+}
+
+// DeleteColumn delete a column of a project
+func DeleteColumn(ctx *context.APIContext) {
+	// swagger:operation DELETE /projects/{id}/columns/{column_id} project projectDeleteColumn
+	// ---
+	// summary: Delete a column of a project, its issues are moved to the default column
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: column_id
+	//   in: path
+	//   description: id of the column
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	_, column := getProjectColumn(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	if column.Default {
+		ctx.Error(http.StatusUnprocessableEntity, "DeleteColumn", "the default column cannot be deleted")
+		return
+	}
+
+	if err := project_model.DeleteColumnByID(ctx, column.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteColumnByID", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// SortColumns change the order of the columns of a project
+func SortColumns(ctx *context.APIContext) {
+	// swagger:operation PUT /projects/{id}/columns/order project projectSortColumns
+	// ---
+	// summary: Change the order of the columns of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SortProjectColumnsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectColumnList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.SortProjectColumnsOption)
+
+	columns, err := p.GetColumns(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetColumns", err)
+		return
+	}
+	if len(form.Columns) != len(columns) {
+		ctx.Error(http.StatusUnprocessableEntity, "SortColumns", "all the columns of the project have to be sorted")
+		return
+	}
+
+	sortedColumnIDs := make(map[int64]int64, len(form.Columns))
+	for sorting, columnID := range form.Columns {
+		sortedColumnIDs[int64(sorting)] = columnID
+	}
+	if err := project_model.MoveColumnsOnProject(ctx, p, sortedColumnIDs); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "MoveColumnsOnProject", err)
+		return
+	}
+
+	ListColumns(ctx)
+}
+
+// getProjectColumn returns the project and the column of the request if the doer has the given access to the project
+func getProjectColumn(ctx *context.APIContext, mode perm.AccessMode) (*project_model.Project, *project_model.Column) {
+	p := getProject(ctx, mode)
+	if ctx.Written() {
+		return nil, nil
+	}
+
+	column, err := project_model.GetColumn(ctx, ctx.ParamsInt64(":column_id"))
+	if err != nil {
+		if project_model.IsErrProjectColumnNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+		}
+		return nil, nil
+	}
+	if column.ProjectID != p.ID {
+		ctx.NotFound()
+		return nil, nil
+	}
+	return p, column
+}
@@ -0,0 +1,423 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/perm"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unit"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListFields list the custom fields of a project
+func ListFields(ctx *context.APIContext) {
+	// swagger:operation GET /projects/{id}/fields project projectListFields
+	// ---
+	// summary: List the custom fields of a project, including the fields shared by the projects of its organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getProject(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+
+	fields, err := p.GetFields(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFields", err)
+		return
+	}
+	writeFields(ctx, fields)
+}
+
+// CreateField create a custom field of a project
+func CreateField(ctx *context.APIContext) {
+	// swagger:operation POST /projects/{id}/fields project projectCreateField
+	// ---
+	// summary: Create a custom field of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	createField(ctx, &project_model.Field{ProjectID: p.ID})
+}
+
+// EditField edit a custom field of a project
+func EditField(ctx *context.APIContext) {
+	// swagger:operation PATCH /projects/{id}/fields/{field_id} project projectEditField
+	// ---
+	// summary: Edit a custom field of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	field := getField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if field.ProjectID != p.ID {
+		ctx.NotFound()
+		return
+	}
+	editField(ctx, field)
+}
+
+// DeleteField delete a custom field of a project
+func DeleteField(ctx *context.APIContext) {
+	// swagger:operation DELETE /projects/{id}/fields/{field_id} project projectDeleteField
+	// ---
+	// summary: Delete a custom field of a project and its values
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	field := getField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if field.ProjectID != p.ID {
+		ctx.NotFound()
+		return
+	}
+	deleteField(ctx, field)
+}
+
+// ListOrgFields list the custom fields shared by the projects of an organization
+func ListOrgFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/fields project projectListOrgFields
+	// ---
+	// summary: List the custom fields shared by the projects of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unit.TypeProjects) < perm.AccessModeRead {
+		ctx.NotFound()
+		return
+	}
+
+	fields, err := project_model.GetOrgFields(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOrgFields", err)
+		return
+	}
+	writeFields(ctx, fields)
+}
+
+// CreateOrgField create a custom field shared by the projects of an organization
+func CreateOrgField(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/projects/fields project projectCreateOrgField
+	// ---
+	// summary: Create a custom field shared by the projects of an organization, and of its repositories
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !canWriteOrgFields(ctx) {
+		return
+	}
+	createField(ctx, &project_model.Field{OwnerID: ctx.Org.Organization.ID})
+}
+
+// EditOrgField edit a custom field shared by the projects of an organization
+func EditOrgField(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/projects/fields/{field_id} project projectEditOrgField
+	// ---
+	// summary: Edit a custom field shared by the projects of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !canWriteOrgFields(ctx) {
+		return
+	}
+	field := getOrgField(ctx)
+	if ctx.Written() {
+		return
+	}
+	editField(ctx, field)
+}
+
+// DeleteOrgField delete a custom field shared by the projects of an organization
+func DeleteOrgField(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/projects/fields/{field_id} project projectDeleteOrgField
+	// ---
+	// summary: Delete a custom field shared by the projects of an organization and its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !canWriteOrgFields(ctx) {
+		return
+	}
+	field := getOrgField(ctx)
+	if ctx.Written() {
+		return
+	}
+	deleteField(ctx, field)
+}
+
+func canWriteOrgFields(ctx *context.APIContext) bool {
+	if !ctx.IsUserSiteAdmin() && ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unit.TypeProjects) < perm.AccessModeWrite {
+		ctx.Error(http.StatusForbidden, "reqProjectWriter", "user should have a permission to write to the projects of the organization")
+		return false
+	}
+	return true
+}
+
+func writeFields(ctx *context.APIContext, fields []*project_model.Field) {
+	apiFields := make([]*api.ProjectField, len(fields))
+	for i := range fields {
+		apiFields[i] = convert.ToAPIProjectField(fields[i])
+	}
+	ctx.JSON(http.StatusOK, &apiFields)
+}
+
+func createField(ctx *context.APIContext, field *project_model.Field) {
+	form := web.GetForm(ctx).(*api.CreateProjectFieldOption)
+
+	field.Name = form.Name
+	field.Type = project_model.FieldTypeFromString(form.Type)
+	field.Options = convert.ToProjectFieldOptions(form.Options)
+	field.Iterations = convert.ToProjectFieldIterations(form.Iterations)
+	if err := project_model.NewField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "NewField", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "NewField", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIProjectField(field))
+}
+
+func editField(ctx *context.APIContext, field *project_model.Field) {
+	form := web.GetForm(ctx).(*api.EditProjectFieldOption)
+
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Options != nil {
+		field.Options = convert.ToProjectFieldOptions(form.Options)
+	}
+	if form.Iterations != nil {
+		field.Iterations = convert.ToProjectFieldIterations(form.Iterations)
+	}
+	if err := project_model.UpdateField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateField", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateField", err)
+		}
+		return
+	}
+
+	field, err := project_model.GetFieldByID(ctx, field.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFieldByID", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectField(field))
+}
+
+func deleteField(ctx *context.APIContext, field *project_model.Field) {
+	if err := project_model.DeleteFieldByID(ctx, field.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteFieldByID", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func getField(ctx *context.APIContext) *project_model.Field {
+	field, err := project_model.GetFieldByID(ctx, ctx.ParamsInt64(":field_id"))
+	if err != nil {
+		if project_model.IsErrProjectFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetFieldByID", err)
+		}
+		return nil
+	}
+	return field
+}
+
+func getOrgField(ctx *context.APIContext) *project_model.Field {
+	field := getField(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	if !field.IsOrganizationField() || field.OwnerID != ctx.Org.Organization.ID {
+		ctx.NotFound()
+		return nil
+	}
+	return field
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/optional"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListColumnIssues list the issues and pull requests of a column of a project
+func ListColumnIssues(ctx *context.APIContext) {
+	// swagger:operation GET /projects/{id}/columns/{column_id}/issues project projectListColumnIssues
+	// ---
+	// summary: List the issues and pull requests of a column of a project, in their order in the column
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: column_id
+	//   in: path
+	//   description: id of the column
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: whether issue is open or closed, defaults to all
+	//   type: string
+	//   enum: [closed, open, all]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p, column := getProjectColumn(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+
+	var isClosed optional.Option[bool]
+	switch api.StateType(ctx.FormString("state")) {
+	case api.StateClosed:
+		isClosed = optional.Some(true)
+	case api.StateOpen:
+		isClosed = optional.Some(false)
+	}
+	var org *organization.Organization
+	if p.Type == project_model.TypeOrganization {
+		org = organization.OrgFromUser(p.Owner)
+	}
+
+	issues, err := issues_model.LoadIssuesFromColumn(ctx, column, ctx.Doer, org, isClosed)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssuesFromColumn", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+}
+
+// AddIssue add an issue or a pull request to a project
+func AddIssue(ctx *context.APIContext) {
+	// swagger:operation POST /projects/{id}/issues project projectAddIssue
+	// ---
+	// summary: Add an issue or a pull request to a project, or move it from another project
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/AddProjectIssueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.AddProjectIssueOption)
+
+	issue := getIssue(ctx, form.IssueID, true)
+	if ctx.Written() {
+		return
+	}
+	if form.ColumnID != 0 {
+		column, err := project_model.GetColumn(ctx, form.ColumnID)
+		if err != nil && !project_model.IsErrProjectColumnNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+			return
+		}
+		if err != nil || column.ProjectID != p.ID {
+			ctx.Error(http.StatusUnprocessableEntity, "AddIssue", "the column is not a column of the project")
+			return
+		}
+	}
+
+	if issue.ProjectID(ctx) == p.ID {
+		ctx.Error(http.StatusUnprocessableEntity, "AddIssue", "the issue is already in the project")
+		return
+	}
+	if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, p.ID, form.ColumnID); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.Error(http.StatusUnprocessableEntity, "AddIssue", "the issue cannot be added to the project")
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "AssignOrRemoveProject", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// MoveIssue move an issue or a pull request to a column of its project
+func MoveIssue(ctx *context.APIContext) {
+	// swagger:operation PATCH /projects/{id}/issues/{issue_id} project projectMoveIssue
+	// ---
+	// summary: Move an issue or a pull request to a column of its project, or to another position of its column
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: id of the issue or the pull request, which is not its number in the repository
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/MoveProjectIssueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p, issue := getProjectIssue(ctx, false)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.MoveProjectIssueOption)
+
+	column, err := project_model.GetColumn(ctx, form.ColumnID)
+	if err != nil && !project_model.IsErrProjectColumnNotExist(err) {
+		ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+		return
+	}
+	if err != nil || column.ProjectID != p.ID {
+		ctx.Error(http.StatusUnprocessableEntity, "MoveIssue", "the column is not a column of the project")
+		return
+	}
+
+	position := -1
+	if form.Position != nil {
+		position = *form.Position
+	}
+	if err := issue_service.MoveIssueOnProjectColumn(ctx, ctx.Doer, issue, column, position); err != nil {
+		ctx.Error(http.StatusInternalServerError, "MoveIssueOnProjectColumn", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveIssue remove an issue or a pull request from a project
+func RemoveIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /projects/{id}/issues/{issue_id} project projectRemoveIssue
+	// ---
+	// summary: Remove an issue or a pull request from a project
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: id of the issue or the pull request, which is not its number in the repository
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	_, issue := getProjectIssue(ctx, true)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, 0, 0); err != nil {
+		ctx.Error(http.StatusInternalServerError, "AssignOrRemoveProject", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// getIssue returns an issue if the doer can read it, and if the doer can change its project when write is true
+func getIssue(ctx *context.APIContext, issueID int64, write bool) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByID(ctx, issueID)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetIssueByID", "the issue does not exist")
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByID", err)
+		}
+		return nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadRepo", err)
+		return nil
+	}
+
+	permission, err := access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+		return nil
+	}
+	if !permission.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusUnprocessableEntity, "GetIssueByID", "the issue does not exist")
+		return nil
+	}
+	if write && !permission.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "reqRepoWriter", "user should have a permission to write to the issues of the repository")
+		return nil
+	}
+	if write && issue.Repo.IsArchived {
+		ctx.Error(http.StatusLocked, "RepoArchived", "the repository of the issue is archived")
+		return nil
+	}
+	return issue
+}
+
+// getProjectIssue returns the project and the issue of the request if the doer can write to the project. Moving the
+// issues of a project only requires to be able to read them like on its board, removing them requires to write to them.
+func getProjectIssue(ctx *context.APIContext, writeIssue bool) (*project_model.Project, *issues_model.Issue) {
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return nil, nil
+	}
+
+	issue := getIssue(ctx, ctx.ParamsInt64(":issue_id"), writeIssue)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if issue.ProjectID(ctx) != p.ID {
+		ctx.NotFound()
+		return nil, nil
+	}
+	return p, issue
+}
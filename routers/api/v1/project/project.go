@@ -0,0 +1,522 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/optional"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListRepoProjects list the projects of a repository
+func ListRepoProjects(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects project projectListRepoProjects
+	// ---
+	// summary: List the projects of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: Project state, Recognized values are open, closed and all. Defaults to "open"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listProjects(ctx, project_model.SearchOptions{
+		RepoID: ctx.Repo.Repository.ID,
+		Type:   project_model.TypeRepository,
+	})
+}
+
+// CreateRepoProject create a project for a repository
+func CreateRepoProject(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects project projectCreateRepoProject
+	// ---
+	// summary: Create a project for a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Project"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	createProject(ctx, &project_model.Project{
+		RepoID: ctx.Repo.Repository.ID,
+		Type:   project_model.TypeRepository,
+	})
+}
+
+// ListOrgProjects list the projects of an organization
+func ListOrgProjects(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects project projectListOrgProjects
+	// ---
+	// summary: List the projects of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: Project state, Recognized values are open, closed and all. Defaults to "open"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unit.TypeProjects) < perm.AccessModeRead {
+		ctx.NotFound()
+		return
+	}
+	listProjects(ctx, project_model.SearchOptions{
+		OwnerID: ctx.Org.Organization.ID,
+		Type:    project_model.TypeOrganization,
+	})
+}
+
+// CreateOrgProject create a project for an organization
+func CreateOrgProject(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/projects project projectCreateOrgProject
+	// ---
+	// summary: Create a project for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Project"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !ctx.IsUserSiteAdmin() && ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unit.TypeProjects) < perm.AccessModeWrite {
+		ctx.Error(http.StatusForbidden, "CreateOrgProject", "user should have a permission to write to the projects of the organization")
+		return
+	}
+	createProject(ctx, &project_model.Project{
+		OwnerID: ctx.Org.Organization.ID,
+		Type:    project_model.TypeOrganization,
+	})
+}
+
+// ListUserProjects list the projects of a user
+func ListUserProjects(ctx *context.APIContext) {
+	// swagger:operation GET /users/{username}/projects project projectListUserProjects
+	// ---
+	// summary: List the projects of a user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of user
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: Project state, Recognized values are open, closed and all. Defaults to "open"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !ctx.ContextUser.IsIndividual() {
+		ctx.NotFound()
+		return
+	}
+	listProjects(ctx, project_model.SearchOptions{
+		OwnerID: ctx.ContextUser.ID,
+		Type:    project_model.TypeIndividual,
+	})
+}
+
+// CreateUserProject create a project for the authenticated user
+func CreateUserProject(ctx *context.APIContext) {
+	// swagger:operation POST /user/projects project projectCreateUserProject
+	// ---
+	// summary: Create a project for the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Project"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	createProject(ctx, &project_model.Project{
+		OwnerID: ctx.Doer.ID,
+		Type:    project_model.TypeIndividual,
+	})
+}
+
+// GetProject get a project
+func GetProject(ctx *context.APIContext) {
+	// swagger:operation GET /projects/{id} project projectGetProject
+	// ---
+	// summary: Get a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Project"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getProject(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProject(ctx, p, ctx.Doer))
+}
+
+// EditProject edit a project, or change its state
+func EditProject(ctx *context.APIContext) {
+	// swagger:operation PATCH /projects/{id} project projectEditProject
+	// ---
+	// summary: Edit a project, or change its state
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Project"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.EditProjectOption)
+
+	if form.Title != nil {
+		if *form.Title == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "EditProject", "title must not be empty")
+			return
+		}
+		p.Title = *form.Title
+	}
+	if form.Description != nil {
+		p.Description = *form.Description
+	}
+	if form.CardType != nil {
+		cardType, ok := convert.ProjectCardTypes[*form.CardType]
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "EditProject", fmt.Sprintf("unknown card type: %s", *form.CardType))
+			return
+		}
+		p.CardType = cardType
+	}
+	if form.State != nil {
+		if state := api.StateType(*form.State); state != api.StateOpen && state != api.StateClosed {
+			ctx.Error(http.StatusUnprocessableEntity, "EditProject", fmt.Sprintf("unknown state: %s", *form.State))
+			return
+		}
+	}
+	if err := project_model.UpdateProject(ctx, p); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateProject", err)
+		return
+	}
+
+	if form.State != nil {
+		state := api.StateType(*form.State)
+		if err := project_model.ChangeProjectStatusByRepoIDAndID(ctx, p.RepoID, p.ID, state == api.StateClosed); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ChangeProjectStatusByRepoIDAndID", err)
+			return
+		}
+	}
+
+	p, err := project_model.GetProjectByID(ctx, p.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetProjectByID", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProject(ctx, p, ctx.Doer))
+}
+
+// DeleteProject delete a project
+func DeleteProject(ctx *context.APIContext) {
+	// swagger:operation DELETE /projects/{id} project projectDeleteProject
+	// ---
+	// summary: Delete a project
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteProjectByID(ctx, p.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteProjectByID", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func listProjects(ctx *context.APIContext, opts project_model.SearchOptions) {
+	switch api.StateType(ctx.FormString("state")) {
+	case api.StateClosed:
+		opts.IsClosed = optional.Some(true)
+	case api.StateAll:
+	default:
+		opts.IsClosed = optional.Some(false)
+	}
+	opts.ListOptions = utils.GetListOptions(ctx)
+	opts.OrderBy = db.SearchOrderByNewest
+
+	projects, total, err := db.FindAndCount[project_model.Project](ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "db.FindAndCount[project_model.Project]", err)
+		return
+	}
+
+	apiProjects := make([]*api.Project, len(projects))
+	for i := range projects {
+		apiProjects[i] = convert.ToAPIProject(ctx, projects[i], ctx.Doer)
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, &apiProjects)
+}
+
+func createProject(ctx *context.APIContext, p *project_model.Project) {
+	form := web.GetForm(ctx).(*api.CreateProjectOption)
+
+	p.Title = form.Title
+	p.Description = form.Description
+	p.CreatorID = ctx.Doer.ID
+	if form.Template != "" {
+		templateType, ok := convert.ProjectTemplateTypes[form.Template]
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateProject", fmt.Sprintf("unknown template: %s", form.Template))
+			return
+		}
+		p.TemplateType = templateType
+	}
+	if form.CardType != "" {
+		cardType, ok := convert.ProjectCardTypes[form.CardType]
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateProject", fmt.Sprintf("unknown card type: %s", form.CardType))
+			return
+		}
+		p.CardType = cardType
+	}
+
+	if err := project_model.NewProject(ctx, p); err != nil {
+		ctx.Error(http.StatusInternalServerError, "NewProject", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIProject(ctx, p, ctx.Doer))
+}
+
+// getProject returns the project of the request if the doer has the given access to it
+func getProject(ctx *context.APIContext, mode perm.AccessMode) *project_model.Project {
+	p, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetProjectByID", err)
+		}
+		return nil
+	}
+
+	accessMode, err := projectAccessMode(ctx, p)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "projectAccessMode", err)
+		return nil
+	}
+	if accessMode < perm.AccessModeRead {
+		ctx.NotFound()
+		return nil
+	}
+	if accessMode < mode {
+		ctx.Error(http.StatusForbidden, "reqProjectWriter", "user should have a permission to write to the project")
+		return nil
+	}
+	if mode >= perm.AccessModeWrite && p.Repo != nil && p.Repo.IsArchived {
+		ctx.Error(http.StatusLocked, "RepoArchived", fmt.Errorf("%s is archived", p.Repo.LogString()))
+		return nil
+	}
+	return p
+}
+
+// projectAccessMode returns the access of the doer to a project, which is the access to the
+// projects of its repository, of its organization, or the access of its owner for the projects of a user
+func projectAccessMode(ctx *context.APIContext, p *project_model.Project) (perm.AccessMode, error) {
+	if unit.TypeProjects.UnitGlobalDisabled() {
+		return perm.AccessModeNone, nil
+	}
+
+	if p.Type == project_model.TypeRepository {
+		if err := p.LoadRepo(ctx); err != nil {
+			return perm.AccessModeNone, err
+		}
+		if ctx.PublicOnly && p.Repo.IsPrivate {
+			return perm.AccessModeNone, nil
+		}
+		permission, err := access_model.GetUserRepoPermission(ctx, p.Repo, ctx.Doer)
+		if err != nil {
+			return perm.AccessModeNone, err
+		}
+		return permission.UnitAccessMode(unit.TypeProjects), nil
+	}
+
+	if err := p.LoadOwner(ctx); err != nil {
+		return perm.AccessModeNone, err
+	}
+	if ctx.PublicOnly && p.Owner.Visibility != api.VisibleTypePublic {
+		return perm.AccessModeNone, nil
+	}
+	if !organization.HasOrgOrUserVisible(ctx, p.Owner, ctx.Doer) {
+		return perm.AccessModeNone, nil
+	}
+	if ctx.IsUserSiteAdmin() {
+		return perm.AccessModeAdmin, nil
+	}
+	if p.Owner.IsOrganization() {
+		return organization.OrgFromUser(p.Owner).UnitPermission(ctx, ctx.Doer, unit.TypeProjects), nil
+	}
+	if ctx.Doer != nil && ctx.Doer.ID == p.OwnerID {
+		return perm.AccessModeOwner, nil
+	}
+	return perm.AccessModeRead, nil
+}
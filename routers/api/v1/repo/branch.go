@@ -19,6 +19,7 @@ import (
 	"forgejo.org/modules/optional"
 	repo_module "forgejo.org/modules/repository"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -612,6 +613,11 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		requiredApprovals = form.RequiredApprovals
 	}
 
+	statusCheckSources, ok := parseStatusCheckSources(ctx, form.StatusCheckSources)
+	if !ok {
+		return
+	}
+
 	whitelistUsers, err := user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
 	if err != nil {
 		if user_model.IsErrUserNotExist(err) {
@@ -679,13 +685,16 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		WhitelistDeployKeys:           form.EnablePush && form.EnablePushWhitelist && form.PushWhitelistDeployKeys,
 		EnableStatusCheck:             form.EnableStatusCheck,
 		StatusCheckContexts:           form.StatusCheckContexts,
+		StatusCheckSources:            statusCheckSources,
 		EnableApprovalsWhitelist:      form.EnableApprovalsWhitelist,
 		RequiredApprovals:             requiredApprovals,
 		BlockOnRejectedReviews:        form.BlockOnRejectedReviews,
 		BlockOnOfficialReviewRequests: form.BlockOnOfficialReviewRequests,
 		DismissStaleApprovals:         form.DismissStaleApprovals,
 		IgnoreStaleApprovals:          form.IgnoreStaleApprovals,
+		RequireCodeOwnerApproval:      form.RequireCodeOwnerApproval,
 		RequireSignedCommits:          form.RequireSignedCommits,
+		RequireResolvedConversations:  form.RequireResolvedConversations,
 		ProtectedFilePatterns:         form.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
@@ -837,6 +846,14 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.StatusCheckContexts = form.StatusCheckContexts
 	}
 
+	if form.StatusCheckSources != nil {
+		statusCheckSources, ok := parseStatusCheckSources(ctx, form.StatusCheckSources)
+		if !ok {
+			return
+		}
+		protectBranch.StatusCheckSources = statusCheckSources
+	}
+
 	if form.RequiredApprovals != nil && *form.RequiredApprovals >= 0 {
 		protectBranch.RequiredApprovals = *form.RequiredApprovals
 	}
@@ -861,10 +878,18 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.IgnoreStaleApprovals = *form.IgnoreStaleApprovals
 	}
 
+	if form.RequireCodeOwnerApproval != nil {
+		protectBranch.RequireCodeOwnerApproval = *form.RequireCodeOwnerApproval
+	}
+
 	if form.RequireSignedCommits != nil {
 		protectBranch.RequireSignedCommits = *form.RequireSignedCommits
 	}
 
+	if form.RequireResolvedConversations != nil {
+		protectBranch.RequireResolvedConversations = *form.RequireResolvedConversations
+	}
+
 	if form.ProtectedFilePatterns != nil {
 		protectBranch.ProtectedFilePatterns = *form.ProtectedFilePatterns
 	}
@@ -1083,3 +1108,22 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// parseStatusCheckSources parses the sources trusted to create some status checks of a branch protection,
+// writing the error to the response if one is invalid
+func parseStatusCheckSources(ctx *context.APIContext, lines []string) ([]*git_model.StatusCheckSource, bool) {
+	sources := make([]*git_model.StatusCheckSource, 0, len(lines))
+	for _, line := range lines {
+		source, err := git_model.ParseStatusCheckSource(ctx, line)
+		if err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+				ctx.Error(http.StatusUnprocessableEntity, "ParseStatusCheckSource", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "ParseStatusCheckSource", err)
+			}
+			return nil, false
+		}
+		sources = append(sources, source)
+	}
+	return sources, true
+}
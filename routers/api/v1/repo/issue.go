@@ -16,14 +16,17 @@ import (
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
 	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
 	issue_indexer "forgejo.org/modules/indexer/issues"
+	issue_template "forgejo.org/modules/issue/template"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -102,6 +105,18 @@ func SearchIssues(ctx *context.APIContext) {
 	//   description: Filter pull requests reviewed by the authenticated user
 	//   type: boolean
 	//   default: false
+	// - name: project_fields
+	//   in: query
+	//   description: Only show items whose custom fields of projects have the given values, formatted as field_id:value
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// - name: parent_id
+	//   in: query
+	//   description: Only show the sub-issues of the issue with the given id
+	//   type: integer
+	//   format: int64
 	// - name: owner
 	//   in: query
 	//   description: Filter by repository owner
@@ -255,6 +270,11 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	projectFieldValues := getProjectFieldValuesForFilter(ctx)
+	if ctx.Written() {
+		return
+	}
+
 	// this api is also used in UI,
 	// so the default limit is set to fit UI needs
 	limit := ctx.FormInt("limit")
@@ -276,8 +296,12 @@ func SearchIssues(ctx *context.APIContext) {
 		IsClosed:            isClosed,
 		IncludedAnyLabelIDs: includedAnyLabels,
 		MilestoneIDs:        includedMilestones,
+		ProjectFieldValues:  projectFieldValues,
 		SortBy:              issue_indexer.SortByCreatedDesc,
 	}
+	if parentID := ctx.FormInt64("parent_id"); parentID > 0 {
+		searchOpt.ParentIssueID = optional.Some(parentID)
+	}
 
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
@@ -389,6 +413,18 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: project_fields
+	//   in: query
+	//   description: Only show items whose custom fields of projects have the given values, formatted as field_id:value
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// - name: parent_id
+	//   in: query
+	//   description: Only show the sub-issues of the issue with the given id
+	//   type: integer
+	//   format: int64
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -509,14 +545,22 @@ func ListIssues(ctx *context.APIContext) {
 	if ctx.Written() {
 		return
 	}
+	projectFieldValues := getProjectFieldValuesForFilter(ctx)
+	if ctx.Written() {
+		return
+	}
 
 	searchOpt := &issue_indexer.SearchOptions{
-		Paginator: &listOptions,
-		Keyword:   keyword,
-		RepoIDs:   []int64{ctx.Repo.Repository.ID},
-		IsPull:    isPull,
-		IsClosed:  isClosed,
-		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
+		Paginator:          &listOptions,
+		Keyword:            keyword,
+		RepoIDs:            []int64{ctx.Repo.Repository.ID},
+		IsPull:             isPull,
+		IsClosed:           isClosed,
+		ProjectFieldValues: projectFieldValues,
+		SortBy:             issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
+	}
+	if parentID := ctx.FormInt64("parent_id"); parentID > 0 {
+		searchOpt.ParentIssueID = optional.Some(parentID)
 	}
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
@@ -588,6 +632,40 @@ func getUserIDForFilter(ctx *context.APIContext, queryName string) int64 {
 	return user.ID
 }
 
+// getProjectFieldValuesForFilter returns the values of custom fields of projects to filter by, in their canonical form
+func getProjectFieldValuesForFilter(ctx *context.APIContext) map[int64]string {
+	var values map[int64]string
+	for _, filter := range ctx.FormStrings("project_fields") {
+		fieldID, value, ok := strings.Cut(filter, ":")
+		id, err := strconv.ParseInt(fieldID, 10, 64)
+		if !ok || err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "project_fields", fmt.Sprintf("%q is not formatted as field_id:value", filter))
+			return nil
+		}
+
+		field, err := project_model.GetFieldByID(ctx, id)
+		if err != nil {
+			if project_model.IsErrProjectFieldNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "project_fields", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetFieldByID", err)
+			}
+			return nil
+		}
+		normalizedValue, err := field.NormalizeValue(value)
+		if err != nil || normalizedValue == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "project_fields", fmt.Sprintf("%q is not a value of the field %q", value, field.Name))
+			return nil
+		}
+
+		if values == nil {
+			values = make(map[int64]string)
+		}
+		values[field.ID] = normalizedValue
+	}
+	return values
+}
+
 // GetIssue get an issue of a repository
 func GetIssue(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/issues/{index} issue issueGetIssue
@@ -725,7 +803,32 @@ func CreateIssue(ctx *context.APIContext) {
 		form.Labels = make([]int64, 0)
 	}
 
-	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs); err != nil {
+	var attachments []string
+	var projectID int64
+	if form.Template != "" {
+		var template *api.IssueTemplate
+		if ctx.Repo.GitRepo != nil && issue_service.IsTemplatePath(form.Template, false) {
+			template, err = issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, form.Template)
+		}
+		if template == nil || err != nil || template.Type() != api.IssueTemplateTypeYaml {
+			ctx.Error(http.StatusUnprocessableEntity, "Template", fmt.Sprintf("the issue form %q does not exist or is invalid", form.Template))
+			return
+		}
+		issueForm, err := issue_service.SubmitIssueForm(ctx, ctx.Repo.Repository, ctx.Doer, template, issue_template.ValuesFromMap(template, form.FormValues), false)
+		if err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Error(http.StatusUnprocessableEntity, "SubmitIssueForm", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "SubmitIssueForm", err)
+			}
+			return
+		}
+		issue.Content = issueForm.Content
+		form.Labels, assigneeIDs, issue.MilestoneID, projectID = issueForm.MergeMetas(form.Labels, assigneeIDs, issue.MilestoneID, 0)
+		attachments = issueForm.Attachments
+	}
+
+	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, attachments, assigneeIDs); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "BlockedByUser", err)
 			return
@@ -737,6 +840,13 @@ func CreateIssue(ctx *context.APIContext) {
 		return
 	}
 
+	if projectID > 0 {
+		if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
+			ctx.Error(http.StatusInternalServerError, "AssignOrRemoveProject", err)
+			return
+		}
+	}
+
 	if form.Closed {
 		if err := issue_service.ChangeStatus(ctx, issue, ctx.Doer, "", true); err != nil {
 			if issues_model.IsErrDependenciesLeft(err) {
@@ -835,6 +945,10 @@ func EditIssue(ctx *context.APIContext) {
 		}
 	}
 	if form.Body != nil {
+		if issue.IsHidden {
+			ctx.Error(http.StatusForbidden, "IsHidden", "the content of the issue is hidden by a moderator")
+			return
+		}
 		err = issue_service.ChangeContent(ctx, issue, ctx.Doer, *form.Body, issue.ContentVersion)
 		if err != nil {
 			if errors.Is(err, issues_model.ErrIssueAlreadyChanged) {
@@ -0,0 +1,191 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ExportIssues exports the issues and pull requests of a repository
+func ExportIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/export issue issueExport
+	// ---
+	// summary: Export the issues and pull requests of a repository with their comments and tracked times
+	// produces:
+	// - text/csv
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: format
+	//   in: query
+	//   description: format of the export
+	//   type: string
+	//   enum: [csv, json]
+	//   required: true
+	// - name: type
+	//   in: query
+	//   description: filter by type (issues / pulls), both are exported if not set
+	//   type: string
+	//   enum: [issues, pulls]
+	// - name: state
+	//   in: query
+	//   description: filter by state (open / closed), both are exported if not set
+	//   type: string
+	//   enum: [open, closed]
+	// responses:
+	//   "200":
+	//     description: the export of the issues
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	format := ctx.FormString("format")
+	if format != issue_service.IssuesFormatCSV && format != issue_service.IssuesFormatJSON {
+		ctx.Error(http.StatusBadRequest, "format", "the format must be csv or json")
+		return
+	}
+
+	opts := issue_service.ExportIssuesOptions{
+		IncludeIssues: ctx.Repo.CanRead(unit.TypeIssues),
+		IncludePulls:  ctx.Repo.CanRead(unit.TypePullRequests),
+	}
+	if ctx.Doer != nil {
+		opts.AllTrackedTimes = ctx.Doer.IsAdmin || ctx.IsUserRepoWriter([]unit.Type{unit.TypeIssues})
+		opts.TrackedTimesUserID = ctx.Doer.ID
+	}
+	switch ctx.FormString("type") {
+	case "issues":
+		opts.IncludePulls = false
+	case "pulls":
+		opts.IncludeIssues = false
+	}
+	switch ctx.FormString("state") {
+	case "open":
+		opts.IsClosed = optional.Some(false)
+	case "closed":
+		opts.IsClosed = optional.Some(true)
+	}
+
+	contentType := "text/csv"
+	if format == issue_service.IssuesFormatJSON {
+		contentType = "application/json"
+	}
+	ctx.SetServeHeaders(&context.ServeHeaderOptions{
+		ContentType:        contentType,
+		ContentTypeCharset: "utf-8",
+		Filename:           fmt.Sprintf("%s-%s-issues.%s", ctx.Repo.Owner.Name, ctx.Repo.Repository.Name, format),
+	})
+	if err := issue_service.ExportIssues(ctx, ctx.Resp, ctx.Repo.Repository, format, opts); err != nil {
+		// the export is partly written, it cannot be replaced by an error
+		log.Error("ExportIssues [repo id: %d]: %v", ctx.Repo.Repository.ID, err)
+	}
+}
+
+// ImportIssues imports issues into a repository from a CSV or JSON file
+func ImportIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/import issue issueImport
+	// ---
+	// summary: Import issues from a CSV or JSON file
+	// consumes:
+	// - multipart/form-data
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: file
+	//   in: formData
+	//   description: CSV or JSON file of the issues to import
+	//   type: file
+	//   required: true
+	// - name: format
+	//   in: formData
+	//   description: format of the file, detected from the name of the file if not set
+	//   type: string
+	//   enum: [csv, json]
+	// - name: create_missing
+	//   in: formData
+	//   description: create the labels and milestones which do not exist instead of failing
+	//   type: boolean
+	// - name: dry_run
+	//   in: formData
+	//   description: only validate the issues
+	//   type: boolean
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueImportResult"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	file, header, err := ctx.Req.FormFile("file")
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "FormFile", err)
+		return
+	}
+	defer file.Close()
+	if header.Size > issue_service.MaxImportSize {
+		ctx.Error(http.StatusRequestEntityTooLarge, "FileSize", "the file is too large")
+		return
+	}
+
+	format := ctx.FormString("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(path.Ext(header.Filename)), ".")
+	}
+	result, err := issue_service.ImportIssues(ctx, ctx.Doer, ctx.Repo.Repository, file, issue_service.ImportIssuesOptions{
+		Format:        format,
+		CreateMissing: ctx.FormBool("create_missing"),
+		DryRun:        ctx.FormBool("dry_run"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ImportIssues", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ImportIssues", err)
+		return
+	}
+	if !result.Valid {
+		ctx.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unit"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListIssueProjectFields list the custom fields of the project of an issue and their values
+func ListIssueProjectFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/project_fields issue issueListProjectFields
+	// ---
+	// summary: List the custom fields of the project of an issue and their values
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueProjectFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getIssueForProjectFields(ctx, false)
+	if ctx.Written() {
+		return
+	}
+
+	apiValues := make([]*api.IssueProjectFieldValue, 0)
+	if issue.Project == nil {
+		ctx.JSON(http.StatusOK, &apiValues)
+		return
+	}
+
+	fields, err := issue.Project.GetFields(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFields", err)
+		return
+	}
+	values, err := project_model.GetIssueFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueFieldValues", err)
+		return
+	}
+	for _, field := range fields {
+		apiValues = append(apiValues, &api.IssueProjectFieldValue{
+			Field: convert.ToAPIProjectField(field),
+			Value: values[field.ID],
+		})
+	}
+	ctx.JSON(http.StatusOK, &apiValues)
+}
+
+// SetIssueProjectField set the value of a custom field of the project of an issue
+func SetIssueProjectField(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issues/{index}/project_fields/{field_id} issue issueSetProjectField
+	// ---
+	// summary: Set the value of a custom field of the project of an issue
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueProjectFieldValueOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueProjectFieldValue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetIssueProjectFieldValueOption)
+	setIssueProjectField(ctx, form.Value)
+}
+
+// DeleteIssueProjectField remove the value of a custom field of the project of an issue
+func DeleteIssueProjectField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/project_fields/{field_id} issue issueDeleteProjectField
+	// ---
+	// summary: Remove the value of a custom field of the project of an issue
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	setIssueProjectField(ctx, "")
+}
+
+func setIssueProjectField(ctx *context.APIContext, value string) {
+	issue := getIssueForProjectFields(ctx, true)
+	if ctx.Written() {
+		return
+	}
+
+	field, err := project_model.GetFieldByID(ctx, ctx.ParamsInt64(":field_id"))
+	if err != nil {
+		if project_model.IsErrProjectFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetFieldByID", err)
+		}
+		return
+	}
+
+	if err := issue_service.SetProjectFieldValue(ctx, ctx.Doer, issue, field, value); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetProjectFieldValue", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetProjectFieldValue", err)
+		}
+		return
+	}
+
+	if value == "" {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	values, err := project_model.GetIssueFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueFieldValues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.IssueProjectFieldValue{
+		Field: convert.ToAPIProjectField(field),
+		Value: values[field.ID],
+	})
+}
+
+func getIssueForProjectFields(ctx *context.APIContext, write bool) *issues_model.Issue {
+	if unit.TypeProjects.UnitGlobalDisabled() {
+		ctx.NotFound()
+		return nil
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return nil
+	}
+	if write && !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "reqRepoWriter", "user should have a permission to write to the issues of the repository")
+		return nil
+	}
+
+	if err := issue.LoadProject(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadProject", err)
+		return nil
+	}
+	return issue
+}
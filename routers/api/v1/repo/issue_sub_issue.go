@@ -0,0 +1,417 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListSubIssues list the sub-issues of an issue
+func ListSubIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueListSubIssues
+	// ---
+	// summary: List the sub-issues of an issue, in their order
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	tree := getSubIssueTree(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssues := make(issues_model.IssueList, 0, len(tree))
+	for _, node := range tree {
+		subIssues = append(subIssues, node.Issue)
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, subIssues))
+}
+
+// GetSubIssueTree get all the levels of sub-issues of an issue
+func GetSubIssueTree(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues/tree issue issueGetSubIssueTree
+	// ---
+	// summary: Get all the levels of sub-issues of an issue, with the progress of each level
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SubIssueTree"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	tree := getSubIssueTree(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPISubIssueTree(ctx, ctx.Doer, tree))
+}
+
+// GetParentIssue get the parent issue of an issue
+func GetParentIssue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/parent issue issueGetParentIssue
+	// ---
+	// summary: Get the parent issue of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getReadableParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	parentID, err := issues_model.GetParentIssueID(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetParentIssueID", err)
+		return
+	}
+	if parentID == 0 {
+		ctx.NotFound()
+		return
+	}
+	parent, err := issues_model.GetIssueByID(ctx, parentID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueByID", err)
+		return
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadRepo", err)
+		return
+	}
+	perm := getPermissionForRepo(ctx, parent.Repo)
+	if ctx.Written() {
+		return
+	}
+	if !perm.CanReadIssuesOrPulls(parent.IsPull) {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, parent))
+}
+
+// AddSubIssue add a sub-issue to an issue
+func AddSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueAddSubIssue
+	// ---
+	// summary: Make the issue in the form the last sub-issue of the issue in the url
+	// description: The issue in the form must not have a parent issue yet, and must be in a repository of the same owner.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: the issue in the form already has a parent issue
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.IssueMeta)
+	parent, subIssue := getSubIssuePair(ctx, form.Owner, form.Name, form.Index)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.Error(http.StatusConflict, "AddSubIssue", err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "AddSubIssue", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "AddSubIssue", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// RemoveSubIssue remove a sub-issue from an issue
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueRemoveSubIssue
+	// ---
+	// summary: Remove the issue in the form from the sub-issues of the issue in the url
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.IssueMeta)
+	parent, subIssue := getSubIssuePair(ctx, form.Owner, form.Name, form.Index)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if issues_model.IsErrSubIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RemoveSubIssue", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// MoveSubIssue move a sub-issue among the sub-issues of an issue
+func MoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueMoveSubIssue
+	// ---
+	// summary: Move the issue in the form to another position among the sub-issues of the issue in the url
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/MoveSubIssueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.MoveSubIssueOption)
+	parent, subIssue := getSubIssuePair(ctx, form.Owner, form.Name, form.Index)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.MoveSubIssue(ctx, parent, subIssue, form.Position); err != nil {
+		if issues_model.IsErrSubIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "MoveSubIssue", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func getReadableParamsIssue(ctx *context.APIContext) *issues_model.Issue {
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return nil
+	}
+	return issue
+}
+
+func getSubIssueTree(ctx *context.APIContext) issues_model.SubIssueTree {
+	issue := getReadableParamsIssue(ctx)
+	if ctx.Written() {
+		return nil
+	}
+
+	tree, err := issue_service.GetReadableSubIssueTree(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetReadableSubIssueTree", err)
+		return nil
+	}
+	return tree
+}
+
+// getSubIssuePair returns the issue in the url and the issue given by the form, if the doer can
+// change the issues of both repositories
+func getSubIssuePair(ctx *context.APIContext, owner, name string, index int64) (parent, subIssue *issues_model.Issue) {
+	parent = getReadableParamsIssue(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(parent.IsPull) {
+		ctx.Error(http.StatusForbidden, "reqRepoWriter", "user should have a permission to write to the issues of the repository")
+		return nil, nil
+	}
+
+	repo := ctx.Repo.Repository
+	if owner != repo.OwnerName || name != repo.Name {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, owner, name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound("IsErrRepoNotExist", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByOwnerAndName", err)
+			}
+			return nil, nil
+		}
+	}
+	subIssue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound("IsErrIssueNotExist", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil, nil
+	}
+	subIssue.Repo = repo
+
+	perm := getPermissionForRepo(ctx, repo)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !perm.CanReadIssuesOrPulls(subIssue.IsPull) {
+		ctx.NotFound()
+		return nil, nil
+	}
+	if repo.IsArchived {
+		ctx.Error(http.StatusLocked, "RepoArchived", "the repository of the sub-issue is archived")
+		return nil, nil
+	}
+	if !perm.CanWriteIssuesOrPulls(subIssue.IsPull) {
+		ctx.Error(http.StatusForbidden, "reqRepoWriter", "user should have a permission to write to the issues of the repository of the sub-issue")
+		return nil, nil
+	}
+	return parent, subIssue
+}
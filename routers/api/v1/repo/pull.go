@@ -847,6 +847,86 @@ func IsPullRequestMerged(ctx *context.APIContext) {
 	ctx.NotFound()
 }
 
+// GetPullRequestMergeStatus returns whether a pull request is ready to be merged
+func GetPullRequestMergeStatus(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/merge_status repository repoGetPullRequestMergeStatus
+	// ---
+	// summary: Get whether a pull request is ready to be merged, including its conversations and review checklist
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestMergeStatus"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	status := &api.PullRequestMergeStatus{Mergeable: true}
+	if err := pull_service.CheckPullMergeStatus(ctx, pr); err != nil {
+		var disallowed models.ErrDisallowedToMerge
+		switch {
+		case errors.As(err, &disallowed):
+			status.BlockedReason = disallowed.Reason
+		case errors.Is(err, pull_service.ErrHasMerged), errors.Is(err, pull_service.ErrIsClosed),
+			errors.Is(err, pull_service.ErrIsWorkInProgress), errors.Is(err, pull_service.ErrIsChecking),
+			errors.Is(err, pull_service.ErrNotMergeableState), errors.Is(err, pull_service.ErrReviewChecklistNotComplete),
+			errors.Is(err, pull_service.ErrDependenciesLeft):
+			status.BlockedReason = err.Error()
+		default:
+			ctx.Error(http.StatusInternalServerError, "CheckPullMergeStatus", err)
+			return
+		}
+		status.Mergeable = false
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFirstMatchProtectedBranchRule", err)
+		return
+	}
+	status.RequireResolvedConversations = pb != nil && pb.RequireResolvedConversations
+	if status.UnresolvedConversations, err = issues_model.CountUnresolvedCodeConversations(ctx, pr.IssueID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "CountUnresolvedCodeConversations", err)
+		return
+	}
+	if status.ReviewChecklist, err = pull_service.GetReviewChecklist(ctx, ctx.Repo.Repository); err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetReviewChecklist", err)
+		return
+	}
+	if status.ReviewChecklistComplete, err = pull_service.IsReviewChecklistComplete(ctx, pr); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsReviewChecklistComplete", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
 // MergePullRequest merges a PR given an index
 func MergePullRequest(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/merge repository repoMergePullRequest
@@ -944,6 +1024,8 @@ func MergePullRequest(ctx *context.APIContext) {
 			ctx.Error(http.StatusMethodNotAllowed, "PR not in mergeable state", "Please try again later")
 		} else if models.IsErrDisallowedToMerge(err) {
 			ctx.Error(http.StatusMethodNotAllowed, "PR is not ready to be merged", err)
+		} else if errors.Is(err, pull_service.ErrReviewChecklistNotComplete) {
+			ctx.Error(http.StatusMethodNotAllowed, "PR is not ready to be merged", err)
 		} else if asymkey_service.IsErrWontSign(err) {
 			ctx.Error(http.StatusMethodNotAllowed, fmt.Sprintf("Protected branch %s requires signed commits but this merge would not be signed", pr.BaseBranch), err)
 		} else {
@@ -1670,3 +1752,343 @@ func GetPullRequestFiles(ctx *context.APIContext) {
 
 	ctx.JSON(http.StatusOK, &apiFiles)
 }
+
+// GetPullRequestCodeOwners gets the approval of the code owners of the paths changed by a pull request
+func GetPullRequestCodeOwners(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/code_owners repository repoGetPullRequestCodeOwners
+	// ---
+	// summary: Get the code owners of the paths changed by a pull request and whether they approved it
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestCodeOwners"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFirstMatchProtectedBranchRule", err)
+		return
+	}
+	required := pb != nil && pb.RequireCodeOwnerApproval
+	ignoreStale := pb != nil && pb.IgnoreStaleApprovals
+
+	approvals, err := pull_service.GetCodeOwnersApprovals(ctx, pr, ignoreStale)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetCodeOwnersApprovals", err)
+		return
+	}
+
+	apiCodeOwners := &api.PullRequestCodeOwners{
+		Required: required,
+		Paths:    make([]*api.PullRequestCodeOwnersPath, 0, len(approvals)),
+	}
+	for _, approval := range approvals {
+		apiPath := &api.PullRequestCodeOwnersPath{
+			Path:       approval.Path,
+			Owners:     approval.Owners,
+			ApprovedBy: make([]*api.User, 0, len(approval.ApprovedBy)),
+			Approved:   approval.IsApproved(),
+		}
+		for _, u := range approval.ApprovedBy {
+			apiPath.ApprovedBy = append(apiPath.ApprovedBy, convert.ToUser(ctx, u, ctx.Doer))
+		}
+		apiCodeOwners.Paths = append(apiCodeOwners.Paths, apiPath)
+	}
+	ctx.JSON(http.StatusOK, apiCodeOwners)
+}
+
+// GetPullRequestRevisions lists the revisions of a pull request, the head commits it had over time
+func GetPullRequestRevisions(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/revisions repository repoGetPullRequestRevisions
+	// ---
+	// summary: List the revisions of a pull request, the head commits it had after each push, oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestRevisionList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	revisions, err := issues_model.GetPullRequestRevisions(ctx, pr)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetPullRequestRevisions", err)
+		return
+	}
+
+	apiRevisions := make([]*api.PullRequestRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		apiRevision := &api.PullRequestRevision{
+			Index:       revision.Index,
+			HeadSHA:     revision.HeadCommitID,
+			IsForcePush: revision.IsForcePush,
+		}
+		if revision.Pusher != nil {
+			apiRevision.Pusher = convert.ToUser(ctx, revision.Pusher, ctx.Doer)
+		}
+		if revision.CreatedUnix > 0 {
+			created := revision.CreatedUnix.AsTime()
+			apiRevision.Created = &created
+		}
+		apiRevisions = append(apiRevisions, apiRevision)
+	}
+	ctx.JSON(http.StatusOK, apiRevisions)
+}
+
+// GetPullRequestStack gets the stack of open pull requests a pull request belongs to
+func GetPullRequestStack(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/stack repository repoGetPullRequestStack
+	// ---
+	// summary: Get the stack of open pull requests a pull request belongs to, from the bottom of the stack to its top
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	stack, err := issues_model.GetPullRequestStack(ctx, pr)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetPullRequestStack", err)
+		return
+	}
+	apiPRs := make([]*api.PullRequest, 0, len(stack))
+	for _, pr := range stack {
+		apiPRs = append(apiPRs, convert.ToAPIPullRequest(ctx, pr, ctx.Doer))
+	}
+	ctx.JSON(http.StatusOK, apiPRs)
+}
+
+// MergePullRequestStack merges in order the pull requests of the stack of a pull request up to it
+func MergePullRequestStack(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/stack/merge repository repoMergePullRequestStack
+	// ---
+	// summary: Merge in order the pull requests of the stack of a pull request, from the bottom of the stack up to it
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request to merge the stack up to
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: style
+	//   in: query
+	//   description: merge style, the default merge style of the repository if empty
+	//   type: string
+	//   enum: [merge, rebase, rebase-merge, squash, fast-forward-only]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "413":
+	//     "$ref": "#/responses/quotaExceeded"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pr := getOpenPullRequestForStack(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	mergeStyle := repo_model.MergeStyle(ctx.FormString("style"))
+	if mergeStyle == "" {
+		mergeStyle = ctx.Repo.Repository.MustGetUnit(ctx, unit.TypePullRequests).PullRequestsConfig().GetDefaultMergeStyle()
+	}
+
+	if merged, err := pull_service.MergeStack(ctx, ctx.Doer, ctx.Repo.GitRepo, pr, mergeStyle); err != nil {
+		pullRequestStackError(ctx, "MergeStack", fmt.Errorf("merged pull requests: %d: %w", merged, err))
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// RebasePullRequestStack rebases the pull requests stacked on a pull request
+func RebasePullRequestStack(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/stack/rebase repository repoRebasePullRequestStack
+	// ---
+	// summary: Rebase the pull requests stacked on a pull request on their base branches
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request whose stacked pull requests are rebased
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "413":
+	//     "$ref": "#/responses/quotaExceeded"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pr := getOpenPullRequestForStack(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if rebased, err := pull_service.RebaseStack(ctx, ctx.Doer, pr); err != nil {
+		pullRequestStackError(ctx, "RebaseStack", fmt.Errorf("rebased pull requests: %d: %w", rebased, err))
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+func getOpenPullRequestForStack(ctx *context.APIContext) *issues_model.PullRequest {
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return nil
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return nil
+	}
+	if pr.HasMerged || pr.Issue.IsClosed {
+		ctx.Error(http.StatusUnprocessableEntity, "", "the pull request is not open")
+		return nil
+	}
+	return pr
+}
+
+func pullRequestStackError(ctx *context.APIContext, name string, err error) {
+	var mergeConflicts models.ErrMergeConflicts
+	var rebaseConflicts models.ErrRebaseConflicts
+	var invalidMergeStyle models.ErrInvalidMergeStyle
+	switch {
+	case errors.Is(err, util.ErrPermissionDenied):
+		ctx.Error(http.StatusForbidden, name, err)
+	case errors.Is(err, util.ErrInvalidArgument), errors.As(err, &invalidMergeStyle):
+		ctx.Error(http.StatusUnprocessableEntity, name, err)
+	case errors.As(err, &mergeConflicts), errors.As(err, &rebaseConflicts),
+		errors.Is(err, pull_service.ErrIsChecking), errors.Is(err, pull_service.ErrNotMergeableState),
+		errors.Is(err, pull_service.ErrIsWorkInProgress), errors.Is(err, pull_service.ErrDependenciesLeft),
+		errors.Is(err, pull_service.ErrReviewChecklistNotComplete):
+		ctx.Error(http.StatusConflict, name, err)
+	default:
+		ctx.Error(http.StatusInternalServerError, name, err)
+	}
+}
@@ -4,6 +4,7 @@
 package repo
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/gitrepo"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -325,9 +327,9 @@ func CreatePullReviewComment(ctx *context.APIContext) {
 		return
 	}
 
-	line := opts.NewLineNum
+	line, startLine := opts.NewLineNum, opts.NewStartLineNum
 	if opts.OldLineNum > 0 {
-		line = opts.OldLineNum * -1
+		line, startLine = opts.OldLineNum*-1, opts.OldStartLineNum*-1
 	}
 
 	comment, err := pull_service.CreateCodeCommentKnownReviewID(ctx,
@@ -337,10 +339,15 @@ func CreatePullReviewComment(ctx *context.APIContext) {
 		opts.Body,
 		opts.Path,
 		line,
+		startLine,
 		review.ID,
 		nil,
 	)
 	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateCodeCommentKnownReviewID", err)
+			return
+		}
 		ctx.InternalServerError(err)
 		return
 	}
@@ -493,9 +500,9 @@ func CreatePullReview(ctx *context.APIContext) {
 
 	// create review comments
 	for _, c := range opts.Comments {
-		line := c.NewLineNum
+		line, startLine := c.NewLineNum, c.NewStartLineNum
 		if c.OldLineNum > 0 {
-			line = c.OldLineNum * -1
+			line, startLine = c.OldLineNum*-1, c.OldStartLineNum*-1
 		}
 
 		if _, err := pull_service.CreateCodeComment(ctx,
@@ -503,6 +510,7 @@ func CreatePullReview(ctx *context.APIContext) {
 			ctx.Repo.GitRepo,
 			pr.Issue,
 			line,
+			startLine,
 			c.Body,
 			c.Path,
 			true, // pending review
@@ -510,14 +518,22 @@ func CreatePullReview(ctx *context.APIContext) {
 			opts.CommitID,
 			nil,
 		); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Error(http.StatusUnprocessableEntity, "CreateCodeComment", err)
+				return
+			}
 			ctx.Error(http.StatusInternalServerError, "CreateCodeComment", err)
 			return
 		}
 	}
 
 	// create review and associate all pending review comments
-	review, _, err := pull_service.SubmitReview(ctx, ctx.Doer, ctx.Repo.GitRepo, pr.Issue, reviewType, opts.Body, opts.CommitID, nil)
+	review, _, err := pull_service.SubmitReview(ctx, ctx.Doer, ctx.Repo.GitRepo, pr.Issue, reviewType, opts.Body, opts.CommitID, opts.Checklist, nil)
 	if err != nil {
+		if pull_service.IsErrReviewChecklistIncomplete(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "SubmitReview", err)
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, "SubmitReview", err)
 		return
 	}
@@ -604,8 +620,12 @@ func SubmitPullReview(ctx *context.APIContext) {
 	}
 
 	// create review and associate all pending review comments
-	review, _, err = pull_service.SubmitReview(ctx, ctx.Doer, ctx.Repo.GitRepo, pr.Issue, reviewType, opts.Body, headCommitID, nil)
+	review, _, err = pull_service.SubmitReview(ctx, ctx.Doer, ctx.Repo.GitRepo, pr.Issue, reviewType, opts.Body, headCommitID, opts.Checklist, nil)
 	if err != nil {
+		if pull_service.IsErrReviewChecklistIncomplete(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "SubmitReview", err)
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, "SubmitReview", err)
 		return
 	}
@@ -901,13 +921,13 @@ func apiReviewRequest(ctx *context.APIContext, opts api.PullReviewRequestOptions
 		}
 
 		for _, teamReviewer := range teamReviewers {
-			comment, err := issue_service.TeamReviewRequest(ctx, pr.Issue, ctx.Doer, teamReviewer, isAdd)
+			comments, err := issue_service.TeamReviewRequest(ctx, pr.Issue, ctx.Doer, teamReviewer, isAdd)
 			if err != nil {
 				ctx.ServerError("TeamReviewRequest", err)
 				return
 			}
 
-			if comment != nil && isAdd {
+			for _, comment := range comments {
 				if err = comment.LoadReview(ctx); err != nil {
 					ctx.ServerError("ReviewRequest", err)
 					return
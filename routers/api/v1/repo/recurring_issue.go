@@ -0,0 +1,359 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListRecurringIssues lists the recurring issues of a repository
+func ListRecurringIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/recurring_issues repository repoListRecurringIssues
+	// ---
+	// summary: List the recurring issues of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RecurringIssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rs, count, err := db.FindAndCount[issues_model.RecurringIssue](ctx, issues_model.FindRecurringIssuesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindRecurringIssues", err)
+		return
+	}
+
+	apiRecurring := make([]*api.RecurringIssue, 0, len(rs))
+	for _, r := range rs {
+		apiRecurring = append(apiRecurring, convert.ToAPIRecurringIssue(ctx, ctx.Doer, r))
+	}
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiRecurring)
+}
+
+func getRecurringIssue(ctx *context.APIContext) *issues_model.RecurringIssue {
+	r, err := issues_model.GetRecurringIssueByRepoID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrRecurringIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetRecurringIssueByRepoID", err)
+		}
+		return nil
+	}
+	return r
+}
+
+// GetRecurringIssue gets a recurring issue of a repository
+func GetRecurringIssue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/recurring_issues/{id} repository repoGetRecurringIssue
+	// ---
+	// summary: Get a recurring issue of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the recurring issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RecurringIssue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIRecurringIssue(ctx, ctx.Doer, r))
+}
+
+// CreateRecurringIssue creates a recurring issue in a repository
+func CreateRecurringIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/recurring_issues repository repoCreateRecurringIssue
+	// ---
+	// summary: Create a recurring issue in a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRecurringIssueOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/RecurringIssue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateRecurringIssueOption)
+	r := &issues_model.RecurringIssue{
+		RepoID:     ctx.Repo.Repository.ID,
+		Title:      strings.TrimSpace(form.Title),
+		Content:    form.Body,
+		Labels:     form.Labels,
+		Assignees:  form.Assignees,
+		Schedule:   strings.TrimSpace(form.Schedule),
+		SkipIfOpen: form.SkipIfOpen,
+		IsActive:   form.Active == nil || *form.Active,
+		CreatorID:  ctx.Doer.ID,
+	}
+	if !saveRecurringIssue(ctx, r) {
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIRecurringIssue(ctx, ctx.Doer, r))
+}
+
+// EditRecurringIssue edits a recurring issue of a repository
+func EditRecurringIssue(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/recurring_issues/{id} repository repoEditRecurringIssue
+	// ---
+	// summary: Edit a recurring issue of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the recurring issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditRecurringIssueOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RecurringIssue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditRecurringIssueOption)
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Title != nil {
+		r.Title = strings.TrimSpace(*form.Title)
+	}
+	if form.Body != nil {
+		r.Content = *form.Body
+	}
+	if form.Labels != nil {
+		r.Labels = *form.Labels
+	}
+	if form.Assignees != nil {
+		r.Assignees = *form.Assignees
+	}
+	if form.Schedule != nil {
+		r.Schedule = strings.TrimSpace(*form.Schedule)
+	}
+	if form.SkipIfOpen != nil {
+		r.SkipIfOpen = *form.SkipIfOpen
+	}
+	if form.Active != nil {
+		r.IsActive = *form.Active
+	}
+	if !saveRecurringIssue(ctx, r) {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIRecurringIssue(ctx, ctx.Doer, r))
+}
+
+func saveRecurringIssue(ctx *context.APIContext, r *issues_model.RecurringIssue) bool {
+	if err := issue_service.SaveRecurringIssue(ctx, ctx.Repo.Repository, r); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "SaveRecurringIssue", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SaveRecurringIssue", err)
+		}
+		return false
+	}
+	return true
+}
+
+// DeleteRecurringIssue deletes a recurring issue of a repository
+func DeleteRecurringIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/recurring_issues/{id} repository repoDeleteRecurringIssue
+	// ---
+	// summary: Delete a recurring issue of a repository, the issues it created are kept
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the recurring issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteRecurringIssue(ctx, r.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteRecurringIssue", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListRecurringIssueRuns lists the history of a recurring issue
+func ListRecurringIssueRuns(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/recurring_issues/{id}/runs repository repoListRecurringIssueRuns
+	// ---
+	// summary: List the runs of a recurring issue, with the issues they created
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the recurring issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RecurringIssueRunList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	runs, count, err := db.FindAndCount[issues_model.RecurringIssueRun](ctx, issues_model.FindRecurringIssueRunsOptions{
+		ListOptions:      utils.GetListOptions(ctx),
+		RecurringIssueID: r.ID,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindRecurringIssueRuns", err)
+		return
+	}
+	if err := issues_model.LoadRecurringIssueRunIssues(ctx, runs); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadRecurringIssueRunIssues", err)
+		return
+	}
+
+	apiRuns := make([]*api.RecurringIssueRun, 0, len(runs))
+	for _, run := range runs {
+		apiRuns = append(apiRuns, convert.ToAPIRecurringIssueRun(ctx, ctx.Doer, run))
+	}
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiRuns)
+}
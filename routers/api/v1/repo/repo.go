@@ -736,6 +736,15 @@ func updateBasicProperties(ctx *context.APIContext, opts api.EditRepoOption) err
 		repo.IsTemplate = *opts.Template
 	}
 
+	if opts.MaxPushedBlobSize != nil {
+		if *opts.MaxPushedBlobSize < 0 {
+			err := fmt.Errorf("max_pushed_blob_size cannot be negative")
+			ctx.Error(http.StatusUnprocessableEntity, "MaxPushedBlobSize", err)
+			return err
+		}
+		repo.MaxPushedBlobSize = *opts.MaxPushedBlobSize
+	}
+
 	if ctx.Repo.GitRepo == nil && !repo.IsEmpty {
 		var err error
 		ctx.Repo.GitRepo, err = gitrepo.OpenRepository(ctx, repo)
@@ -6,12 +6,15 @@ package repo
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
+	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	actions_service "forgejo.org/services/actions"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	commitstatus_service "forgejo.org/services/repository/commitstatus"
@@ -64,6 +67,31 @@ func NewCommitStatus(ctx *context.APIContext) {
 		Description: form.Description,
 		Context:     form.Context,
 	}
+	if appID, ok := ctx.Data["OAuth2ApplicationID"].(int64); ok {
+		status.OAuth2ApplicationID = appID
+	}
+	if ctx.Doer.IsActions() {
+		// the token of a task creates the status on behalf of the workflow of its run, only for the commit the run
+		// was triggered for: the workflow of any branch could otherwise post a status to any commit
+		task, err := actions_model.GetTaskByID(ctx, ctx.Data["ActionsTaskID"].(int64))
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetTaskByID", err)
+			return
+		}
+		if err := task.LoadAttributes(ctx); err != nil {
+			ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+			return
+		}
+		if run := task.Job.Run; strings.EqualFold(sha, run.CommitSHA) {
+			workflowRef, err := actions_service.GetWorkflowRef(run)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetWorkflowRef", err)
+				return
+			}
+			status.ActionsWorkflowID = run.WorkflowID
+			status.ActionsWorkflowRef = workflowRef
+		}
+	}
 	if err := commitstatus_service.CreateCommitStatus(ctx, ctx.Repo.Repository, ctx.Doer, sha, status); err != nil {
 		ctx.Error(http.StatusInternalServerError, "CreateCommitStatus", err)
 		return
@@ -21,6 +21,13 @@ type swaggerResponseIssueList struct {
 	Body []api.Issue `json:"body"`
 }
 
+// SubIssueTree
+// swagger:response SubIssueTree
+type swaggerResponseSubIssueTree struct {
+	// in:body
+	Body api.SubIssueTree `json:"body"`
+}
+
 // Comment
 // swagger:response Comment
 type swaggerResponseComment struct {
@@ -84,6 +91,34 @@ type swaggerResponseTrackedTimeList struct {
 	Body []api.TrackedTime `json:"body"`
 }
 
+// TimesheetEntryList
+// swagger:response TimesheetEntryList
+type swaggerResponseTimesheetEntryList struct {
+	// in:body
+	Body []api.TimesheetEntry `json:"body"`
+}
+
+// RecurringIssue
+// swagger:response RecurringIssue
+type swaggerResponseRecurringIssue struct {
+	// in:body
+	Body api.RecurringIssue `json:"body"`
+}
+
+// RecurringIssueList
+// swagger:response RecurringIssueList
+type swaggerResponseRecurringIssueList struct {
+	// in:body
+	Body []api.RecurringIssue `json:"body"`
+}
+
+// RecurringIssueRunList
+// swagger:response RecurringIssueRunList
+type swaggerResponseRecurringIssueRunList struct {
+	// in:body
+	Body []api.RecurringIssueRun `json:"body"`
+}
+
 // IssueDeadline
 // swagger:response IssueDeadline
 type swaggerIssueDeadline struct {
@@ -125,3 +160,10 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// IssueImportResult
+// swagger:response IssueImportResult
+type swaggerIssueImportResult struct {
+	// in:body
+	Body api.IssueImportResult `json:"body"`
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package swagger
+
+import (
+	api "forgejo.org/modules/structs"
+)
+
+// AbuseReport
+// swagger:response AbuseReport
+type swaggerResponseAbuseReport struct {
+	// in:body
+	Body api.AbuseReport `json:"body"`
+}
+
+// AbuseReportList
+// swagger:response AbuseReportList
+type swaggerResponseAbuseReportList struct {
+	// in:body
+	Body []api.AbuseReport `json:"body"`
+}
@@ -52,6 +52,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	IssueMeta api.IssueMeta
 
+	// in:body
+	MoveSubIssueOption api.MoveSubIssueOption
+
 	// in:body
 	IssueLabelsOption api.IssueLabelsOption
 
@@ -239,4 +242,58 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	NoteOptions api.NoteOptions
+
+	// in:body
+	CreateOrgRulesetOption api.CreateOrgRulesetOption
+
+	// in:body
+	EditOrgRulesetOption api.EditOrgRulesetOption
+
+	// in:body
+	CreateProjectOption api.CreateProjectOption
+
+	// in:body
+	EditProjectOption api.EditProjectOption
+
+	// in:body
+	CreateProjectColumnOption api.CreateProjectColumnOption
+
+	// in:body
+	EditProjectColumnOption api.EditProjectColumnOption
+
+	// in:body
+	SortProjectColumnsOption api.SortProjectColumnsOption
+
+	// in:body
+	AddProjectIssueOption api.AddProjectIssueOption
+
+	// in:body
+	MoveProjectIssueOption api.MoveProjectIssueOption
+
+	// in:body
+	CreateProjectFieldOption api.CreateProjectFieldOption
+
+	// in:body
+	EditProjectFieldOption api.EditProjectFieldOption
+
+	// in:body
+	SetIssueProjectFieldValueOption api.SetIssueProjectFieldValueOption
+
+	// in:body
+	ResolveAbuseReportOption api.ResolveAbuseReportOption
+
+	// in:body
+	CreateSavedSearchOption api.CreateSavedSearchOption
+
+	// in:body
+	EditSavedSearchOption api.EditSavedSearchOption
+
+	// in:body
+	SavedSearchSubscription api.SavedSearchSubscription
+
+	// in:body
+	CreateRecurringIssueOption api.CreateRecurringIssueOption
+
+	// in:body
+	EditRecurringIssueOption api.EditRecurringIssueOption
 }
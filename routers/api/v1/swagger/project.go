@@ -0,0 +1,64 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package swagger
+
+import (
+	api "forgejo.org/modules/structs"
+)
+
+// Project
+// swagger:response Project
+type swaggerResponseProject struct {
+	// in:body
+	Body api.Project `json:"body"`
+}
+
+// ProjectList
+// swagger:response ProjectList
+type swaggerResponseProjectList struct {
+	// in:body
+	Body []api.Project `json:"body"`
+}
+
+// ProjectColumn
+// swagger:response ProjectColumn
+type swaggerResponseProjectColumn struct {
+	// in:body
+	Body api.ProjectColumn `json:"body"`
+}
+
+// ProjectColumnList
+// swagger:response ProjectColumnList
+type swaggerResponseProjectColumnList struct {
+	// in:body
+	Body []api.ProjectColumn `json:"body"`
+}
+
+// ProjectField
+// swagger:response ProjectField
+type swaggerResponseProjectField struct {
+	// in:body
+	Body api.ProjectField `json:"body"`
+}
+
+// ProjectFieldList
+// swagger:response ProjectFieldList
+type swaggerResponseProjectFieldList struct {
+	// in:body
+	Body []api.ProjectField `json:"body"`
+}
+
+// IssueProjectFieldValue
+// swagger:response IssueProjectFieldValue
+type swaggerResponseIssueProjectFieldValue struct {
+	// in:body
+	Body api.IssueProjectFieldValue `json:"body"`
+}
+
+// IssueProjectFieldValueList
+// swagger:response IssueProjectFieldValueList
+type swaggerResponseIssueProjectFieldValueList struct {
+	// in:body
+	Body []api.IssueProjectFieldValue `json:"body"`
+}
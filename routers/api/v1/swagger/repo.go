@@ -267,6 +267,27 @@ type swaggerCommitList struct {
 	Body []api.Commit `json:"body"`
 }
 
+// PullRequestCodeOwners
+// swagger:response PullRequestCodeOwners
+type swaggerPullRequestCodeOwners struct {
+	// in:body
+	Body api.PullRequestCodeOwners `json:"body"`
+}
+
+// PullRequestRevisionList
+// swagger:response PullRequestRevisionList
+type swaggerPullRequestRevisionList struct {
+	// in:body
+	Body []api.PullRequestRevision `json:"body"`
+}
+
+// PullRequestMergeStatus
+// swagger:response PullRequestMergeStatus
+type swaggerPullRequestMergeStatus struct {
+	// in:body
+	Body api.PullRequestMergeStatus `json:"body"`
+}
+
 // ChangedFileList
 // swagger:response ChangedFileList
 type swaggerChangedFileList struct {
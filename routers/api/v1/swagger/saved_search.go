@@ -0,0 +1,22 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package swagger
+
+import (
+	api "forgejo.org/modules/structs"
+)
+
+// SavedSearch
+// swagger:response SavedSearch
+type swaggerResponseSavedSearch struct {
+	// in:body
+	Body api.SavedSearch `json:"body"`
+}
+
+// SavedSearchList
+// swagger:response SavedSearchList
+type swaggerResponseSavedSearchList struct {
+	// in:body
+	Body []api.SavedSearch `json:"body"`
+}
@@ -0,0 +1,438 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"net/http"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListSavedSearches lists the saved searches of the authenticated user and of their organizations
+func ListSavedSearches(ctx *context.APIContext) {
+	// swagger:operation GET /user/searches user userListSavedSearches
+	// ---
+	// summary: List the saved searches of the authenticated user and of their organizations
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearchList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	orgs, err := organization.GetUserOrgsList(ctx, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetUserOrgsList", err)
+		return
+	}
+	ownerIDs := make([]int64, 0, len(orgs)+1)
+	ownerIDs = append(ownerIDs, ctx.Doer.ID)
+	for _, org := range orgs {
+		ownerIDs = append(ownerIDs, org.ID)
+	}
+
+	searches, total, err := db.FindAndCount[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerIDs:    ownerIDs,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAndCount", err)
+		return
+	}
+	if err := issues_model.SavedSearchList(searches).LoadOwners(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadOwners", err)
+		return
+	}
+	subscriptions, err := issues_model.GetSavedSearchSubscriptionsByUser(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSavedSearchSubscriptionsByUser", err)
+		return
+	}
+
+	apiSearches := make([]*api.SavedSearch, len(searches))
+	for i, search := range searches {
+		canEdit, err := issue_service.CanEditSavedSearch(ctx, ctx.Doer, search)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "CanEditSavedSearch", err)
+			return
+		}
+		apiSearches[i] = convert.ToAPISavedSearch(ctx, ctx.Doer, search, canEdit, subscriptions[search.ID])
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiSearches)
+}
+
+// CreateSavedSearch saves a search for the authenticated user or one of their organizations
+func CreateSavedSearch(ctx *context.APIContext) {
+	// swagger:operation POST /user/searches user userCreateSavedSearch
+	// ---
+	// summary: Save a search of issues and pull requests
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSavedSearchOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateSavedSearchOption)
+
+	owner := ctx.Doer
+	if form.Owner != "" && form.Owner != ctx.Doer.Name {
+		org, err := user_model.GetUserByName(ctx, form.Owner)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetUserByName", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return
+		}
+		isMember := false
+		if org.IsOrganization() {
+			if isMember, err = organization.IsOrganizationMember(ctx, org.ID, ctx.Doer.ID); err != nil {
+				ctx.Error(http.StatusInternalServerError, "IsOrganizationMember", err)
+				return
+			}
+		}
+		if !isMember {
+			ctx.Error(http.StatusForbidden, "", "searches can only be saved for yourself or an organization you are a member of")
+			return
+		}
+		owner = org
+	}
+
+	search := &issues_model.SavedSearch{
+		OwnerID:   owner.ID,
+		Owner:     owner,
+		Name:      form.Name,
+		CreatorID: ctx.Doer.ID,
+	}
+	search.SetFilter(savedSearchFilterFromAPI(form.Query))
+	if err := issues_model.CreateSavedSearch(ctx, search); err != nil {
+		if issues_model.IsErrSavedSearchAlreadyExists(err) {
+			ctx.Error(http.StatusConflict, "CreateSavedSearch", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateSavedSearch", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPISavedSearch(ctx, ctx.Doer, search, true, nil))
+}
+
+// GetSavedSearch gets a saved search
+func GetSavedSearch(ctx *context.APIContext) {
+	// swagger:operation GET /user/searches/{id} user userGetSavedSearch
+	// ---
+	// summary: Get a saved search
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	search, canEdit := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	responseSavedSearch(ctx, http.StatusOK, search, canEdit)
+}
+
+// EditSavedSearch changes the name or the filters of a saved search
+func EditSavedSearch(ctx *context.APIContext) {
+	// swagger:operation PATCH /user/searches/{id} user userEditSavedSearch
+	// ---
+	// summary: Change a saved search
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSavedSearchOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditSavedSearchOption)
+	search, canEdit := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !canEdit {
+		ctx.Error(http.StatusForbidden, "", "you cannot change this saved search")
+		return
+	}
+
+	if form.Name != nil {
+		if *form.Name == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "", "the name of a saved search cannot be empty")
+			return
+		}
+		search.Name = *form.Name
+	}
+	if form.Query != nil {
+		search.SetFilter(savedSearchFilterFromAPI(*form.Query))
+	}
+	if err := issues_model.UpdateSavedSearch(ctx, search); err != nil {
+		if issues_model.IsErrSavedSearchAlreadyExists(err) {
+			ctx.Error(http.StatusConflict, "UpdateSavedSearch", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateSavedSearch", err)
+		}
+		return
+	}
+	responseSavedSearch(ctx, http.StatusOK, search, canEdit)
+}
+
+// DeleteSavedSearch deletes a saved search
+func DeleteSavedSearch(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/searches/{id} user userDeleteSavedSearch
+	// ---
+	// summary: Delete a saved search
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	search, canEdit := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !canEdit {
+		ctx.Error(http.StatusForbidden, "", "you cannot delete this saved search")
+		return
+	}
+	if err := issues_model.DeleteSavedSearch(ctx, search.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteSavedSearch", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListSavedSearchIssues runs a saved search
+func ListSavedSearchIssues(ctx *context.APIContext) {
+	// swagger:operation GET /user/searches/{id}/issues user userListSavedSearchIssues
+	// ---
+	// summary: List the issues and pull requests matching a saved search
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	search, _ := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	listOptions := utils.GetListOptions(ctx)
+	issues, total, err := issue_service.RunSavedSearch(ctx, ctx.Doer, search, &listOptions, 0)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "RunSavedSearch", err)
+		return
+	}
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+}
+
+// SetSavedSearchSubscription changes how the authenticated user follows a saved search
+func SetSavedSearchSubscription(ctx *context.APIContext) {
+	// swagger:operation PUT /user/searches/{id}/subscription user userSetSavedSearchSubscription
+	// ---
+	// summary: Show a saved search on the dashboard and be notified about its new matches
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SavedSearchSubscription"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	form := web.GetForm(ctx).(*api.SavedSearchSubscription)
+	search, canEdit := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.SetSavedSearchSubscription(ctx, search.ID, ctx.Doer.ID, form.OnDashboard, form.Notify); err != nil {
+		ctx.Error(http.StatusInternalServerError, "SetSavedSearchSubscription", err)
+		return
+	}
+	responseSavedSearch(ctx, http.StatusOK, search, canEdit)
+}
+
+// getSavedSearch returns the saved search of the request if the authenticated user can view it,
+// and whether they can edit it
+func getSavedSearch(ctx *context.APIContext) (*issues_model.SavedSearch, bool) {
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrSavedSearchNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetSavedSearchByID", err)
+		}
+		return nil, false
+	}
+	canView, err := issue_service.CanViewSavedSearch(ctx, ctx.Doer, search)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "CanViewSavedSearch", err)
+		return nil, false
+	}
+	if !canView {
+		ctx.NotFound()
+		return nil, false
+	}
+	if err := search.LoadOwner(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadOwner", err)
+		return nil, false
+	}
+	canEdit, err := issue_service.CanEditSavedSearch(ctx, ctx.Doer, search)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "CanEditSavedSearch", err)
+		return nil, false
+	}
+	return search, canEdit
+}
+
+func responseSavedSearch(ctx *context.APIContext, status int, search *issues_model.SavedSearch, canEdit bool) {
+	sub, err := issues_model.GetSavedSearchSubscription(ctx, search.ID, ctx.Doer.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSavedSearchSubscription", err)
+		return
+	}
+	ctx.JSON(status, convert.ToAPISavedSearch(ctx, ctx.Doer, search, canEdit, sub))
+}
+
+func savedSearchFilterFromAPI(query api.SavedSearchQuery) issues_model.SavedSearchFilter {
+	filter := issues_model.SavedSearchFilter{
+		Keyword:    query.Keyword,
+		Type:       query.Type,
+		State:      query.State,
+		Labels:     query.Labels,
+		Milestones: query.Milestones,
+		Repos:      query.Repos,
+		Assignee:   query.Assignee,
+		Poster:     query.Poster,
+		Mentioned:  query.Mentioned,
+		ProjectID:  query.ProjectID,
+		Sort:       query.Sort,
+	}
+	// normalize the filters the same way as those of the web forms
+	return issues_model.ParseSavedSearchFilter(filter.Values())
+}
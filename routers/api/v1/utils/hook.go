@@ -192,6 +192,7 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 				IssueAssign:              issuesHook(form.Events, string(webhook_module.HookEventIssueAssign)),
 				IssueLabel:               issuesHook(form.Events, string(webhook_module.HookEventIssueLabel)),
 				IssueMilestone:           issuesHook(form.Events, string(webhook_module.HookEventIssueMilestone)),
+				IssueProject:             issuesHook(form.Events, string(webhook_module.HookEventIssueProject)),
 				IssueComment:             issuesHook(form.Events, string(webhook_module.HookEventIssueComment)),
 				Push:                     util.SliceContainsString(form.Events, string(webhook_module.HookEventPush), true),
 				PullRequest:              pullHook(form.Events, "pull_request_only"),
@@ -377,6 +378,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.IssueAssign = issuesHook(form.Events, string(webhook_module.HookEventIssueAssign))
 	w.IssueLabel = issuesHook(form.Events, string(webhook_module.HookEventIssueLabel))
 	w.IssueMilestone = issuesHook(form.Events, string(webhook_module.HookEventIssueMilestone))
+	w.IssueProject = issuesHook(form.Events, string(webhook_module.HookEventIssueProject))
 	w.IssueComment = issuesHook(form.Events, string(webhook_module.HookEventIssueComment))
 
 	// Pull requests
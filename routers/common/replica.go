@@ -0,0 +1,122 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	replica_service "forgejo.org/services/replica"
+)
+
+// replicaEditPages are the pages outside of the repositories which are only used to edit content, they are
+// redirected to the primary
+var replicaEditPages = []string{"/repo/create", "/repo/migrate", "/repo/fork", "/org/create", "/user/settings"}
+
+// replicaRepoEditPages are the pages of a repository, after /{owner}/{repo}/, which are only used to edit content
+var replicaRepoEditPages = []string{"_edit", "_new", "_upload", "_delete", "_diffpatch", "_cherrypick", "settings", "fork"}
+
+// replicaNewPages are the lists, after /{owner}/{repo}/ or /{owner}/-/, whose "new" page creates an item
+var replicaNewPages = []string{"issues", "releases", "milestones", "projects"}
+
+// replicaReadOnlyPosts are the POST requests which do not write anything, or which only write to the replica sessions
+var replicaReadOnlyPosts = []string{"/user/login", "/user/logout", "/-/markup"}
+
+// maxReplicaEventSize is the size of the largest webhook payload accepted from the primary
+const maxReplicaEventSize = 32 * 1024 * 1024
+
+// isReplicaWrite returns true if a request would write to a read-only replica
+func isReplicaWrite(req *http.Request) bool {
+	path := strings.TrimPrefix(req.URL.Path, setting.AppSubURL)
+	// the internal API of the SSH server, the push events of the primary and the administration of the replica itself
+	if strings.HasPrefix(path, "/api/internal/") || strings.HasPrefix(path, "/-/replica/") ||
+		path == "/admin" || strings.HasPrefix(path, "/admin/") {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if strings.HasSuffix(path, "/info/refs") {
+			return req.URL.Query().Get("service") == "git-receive-pack"
+		}
+		return isReplicaEditPage(req, path)
+	}
+
+	// fetches and clones negotiate with a POST, and the web UI loads the last commits with one
+	if strings.HasSuffix(path, "/git-upload-pack") || strings.Contains(path, "/lastcommit/") {
+		return false
+	}
+	return !slices.ContainsFunc(replicaReadOnlyPosts, func(p string) bool {
+		return strings.HasPrefix(path, p)
+	})
+}
+
+// isReplicaEditPage returns true if the page is only used to edit content. The pages are matched on the segments
+// of the routes, so that files, wiki pages, users and repositories named like them are not mistaken for them.
+func isReplicaEditPage(req *http.Request, path string) bool {
+	if slices.ContainsFunc(replicaEditPages, func(p string) bool {
+		return path == p || strings.HasPrefix(path, p+"/")
+	}) {
+		return true
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 3 {
+		return false
+	}
+	if segments[0] == "org" {
+		// /org/{org}/settings
+		return segments[2] == "settings"
+	}
+	// /{owner}/{repo}/..., or /{owner}/-/... for the pages of a user or an organization
+	switch page := segments[2]; {
+	case segments[1] != "-" && slices.Contains(replicaRepoEditPages, page):
+		return true
+	case segments[1] != "-" && page == "wiki":
+		action := req.URL.Query().Get("action")
+		return action == "_edit" || action == "_new"
+	case slices.Contains(replicaNewPages, page):
+		return len(segments) > 3 && segments[3] == "new"
+	}
+	return false
+}
+
+// ReplicaRedirect redirects the requests which would write to a read-only replica to the primary
+func ReplicaRedirect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if !isReplicaWrite(req) {
+			next.ServeHTTP(resp, req)
+			return
+		}
+		target := setting.Replica.PrimaryURL + strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, setting.AppSubURL), "/")
+		if req.URL.RawQuery != "" {
+			target += "?" + req.URL.RawQuery
+		}
+		// 307 keeps the method and the body, so that git and API clients retry their request on the primary
+		http.Redirect(resp, req, target, http.StatusTemporaryRedirect)
+	})
+}
+
+// ReplicaPushEvent receives the webhook events of the primary, and syncs the replicas of its repositories
+func ReplicaPushEvent(resp http.ResponseWriter, req *http.Request) {
+	payload, err := io.ReadAll(io.LimitReader(req.Body, maxReplicaEventSize))
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !replica_service.VerifySignature(payload, req.Header.Get("X-Forgejo-Signature")) {
+		http.Error(resp, "invalid signature", http.StatusForbidden)
+		return
+	}
+	if err := replica_service.HandleEvent(req.Context(), req.Header.Get("X-Forgejo-Event"), payload); err != nil {
+		log.Error("Replica: unable to handle the %s event of the primary: %v", req.Header.Get("X-Forgejo-Event"), err)
+		http.Error(resp, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
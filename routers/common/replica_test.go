@@ -0,0 +1,94 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReplicaWrite(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		write  bool
+	}{
+		{http.MethodGet, "/user2/repo1", false},
+		{http.MethodGet, "/user2/repo1/raw/branch/master/README.md", false},
+		{http.MethodGet, "/user2/repo1/archive/master.zip", false},
+		{http.MethodGet, "/user2/repo1.git/info/refs?service=git-upload-pack", false},
+		{http.MethodPost, "/user2/repo1.git/git-upload-pack", false},
+		{http.MethodGet, "/user2/repo1.git/info/refs?service=git-receive-pack", true},
+		{http.MethodPost, "/user2/repo1.git/git-receive-pack", true},
+		{http.MethodGet, "/user2/repo1/_edit/master/README.md", true},
+		{http.MethodGet, "/user2/repo1/settings", true},
+		{http.MethodGet, "/user2/repo1/fork", true},
+		{http.MethodGet, "/user2/repo1/forks", false},
+		{http.MethodGet, "/user2/repo1/wiki/Home?action=_edit", true},
+		{http.MethodGet, "/user2/repo1/wiki?action=_new", true},
+		{http.MethodGet, "/user2/repo1/settings/branches", true},
+		{http.MethodGet, "/user2/repo1/issues/new", true},
+		{http.MethodGet, "/user2/repo1/issues/new/choose", true},
+		{http.MethodGet, "/user2/repo1/releases/new", true},
+		{http.MethodGet, "/user2/-/projects/new", true},
+		{http.MethodGet, "/user/settings", true},
+		{http.MethodGet, "/user/settings/keys", true},
+		{http.MethodGet, "/org/org3/settings", true},
+		{http.MethodGet, "/repo/create", true},
+		{http.MethodGet, "/repo/fork/1", true},
+		// files, wiki pages, users and repositories named like the edit pages
+		{http.MethodGet, "/user2/repo1/src/branch/master/settings.py", false},
+		{http.MethodGet, "/user2/repo1/src/branch/master/settings/fork", false},
+		{http.MethodGet, "/user2/repo1/src/branch/master/issues/new", false},
+		{http.MethodGet, "/user2/repo1/src/branch/master/_edit/README.md", false},
+		{http.MethodGet, "/user2/repo1/wiki/settings", false},
+		{http.MethodGet, "/user2/repo1/wiki/issues/new", false},
+		{http.MethodGet, "/user2/repo1/wiki/_edit", false},
+		{http.MethodGet, "/user2/settings", false},
+		{http.MethodGet, "/user2/settings-x", false},
+		{http.MethodGet, "/user2/settings-x/issues", false},
+		{http.MethodGet, "/user2/fork", false},
+		{http.MethodGet, "/user2/fork/issues", false},
+		{http.MethodGet, "/fork/repo1", false},
+		{http.MethodGet, "/fork/repo1/releases", false},
+		{http.MethodGet, "/user/settingsx", false},
+		{http.MethodGet, "/user2/repo1/issues/1", false},
+		{http.MethodPost, "/user2/repo1/issues/1/comments", true},
+		{http.MethodPatch, "/api/v1/repos/user2/repo1", true},
+		{http.MethodPost, "/user/login", false},
+		{http.MethodPost, "/admin", false},
+		{http.MethodPost, "/admin/config", false},
+		{http.MethodGet, "/administrator/repo1/settings", true},
+		{http.MethodPost, "/adminorg/repo1/issues/new", true},
+		{http.MethodPost, "/-/replica/push", false},
+		{http.MethodPost, "/api/internal/serv/command/1/user2/repo1", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.url, nil)
+		assert.Equal(t, tt.write, isReplicaWrite(req), "%s %s", tt.method, tt.url)
+	}
+}
+
+func TestReplicaRedirect(t *testing.T) {
+	defer test.MockVariableValue(&setting.Replica.PrimaryURL, "https://primary.example.com/")()
+
+	handler := ReplicaRedirect(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.WriteHeader(http.StatusOK)
+	}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/user2/repo1.git/info/refs?service=git-receive-pack", nil))
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.Code)
+	assert.Equal(t, "https://primary.example.com/user2/repo1.git/info/refs?service=git-receive-pack", resp.Header().Get("Location"))
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/user2/repo1", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
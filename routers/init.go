@@ -37,6 +37,7 @@ import (
 	"forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/oauth2"
 	"forgejo.org/services/automerge"
+	backport_service "forgejo.org/services/backport"
 	"forgejo.org/services/cron"
 	feed_service "forgejo.org/services/feed"
 	indexer_service "forgejo.org/services/indexer"
@@ -47,9 +48,11 @@ import (
 	mirror_service "forgejo.org/services/mirror"
 	pull_service "forgejo.org/services/pull"
 	release_service "forgejo.org/services/release"
+	replica_service "forgejo.org/services/replica"
 	repo_service "forgejo.org/services/repository"
 	"forgejo.org/services/repository/archiver"
 	"forgejo.org/services/task"
+	triage_service "forgejo.org/services/triage"
 	"forgejo.org/services/uinotification"
 	"forgejo.org/services/webhook"
 )
@@ -150,9 +153,12 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(indexer_service.Init)
 
 	mirror_service.InitSyncMirrors()
+	mustInit(replica_service.Init)
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(triage_service.Init)
+	mustInit(backport_service.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
 	eventsource.GetManager().Init()
@@ -176,6 +182,10 @@ func NormalRoutes() *web.Route {
 	_ = templates.HTMLRenderer()
 	r := web.NewRoute()
 	r.Use(common.ProtocolMiddlewares()...)
+	if setting.Replica.Enabled {
+		r.Use(common.ReplicaRedirect)
+		r.Post("/-/replica/push", common.ReplicaPushEvent)
+	}
 
 	r.Mount("/", web_routers.Routes())
 	r.Mount("/api/v1", apiv1.Routes())
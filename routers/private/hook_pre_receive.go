@@ -18,6 +18,7 @@ import (
 	quota_model "forgejo.org/models/quota"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/private"
@@ -217,11 +218,49 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		if ctx.Written() {
 			return
 		}
+		if !ourCtx.assertBlobSizeLimit(newCommitID, refFullName) {
+			return
+		}
 	}
 
 	ctx.PlainText(http.StatusOK, "ok")
 }
 
+// assertBlobSizeLimit rejects the push if it introduces a blob bigger than the repository allows
+func (ctx *preReceiveContext) assertBlobSizeLimit(newCommitID string, refFullName git.RefName) bool {
+	repo := ctx.Repo.Repository
+	if ctx.opts.IsWiki || repo.MaxPushedBlobSize <= 0 || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	blobs, err := git.FindLargeBlobs(ctx, repo.RepoPath(), newCommitID, repo.MaxPushedBlobSize, ctx.env)
+	if err != nil {
+		log.Error("Unable to check blob sizes of %s for %s in %-v: %v", newCommitID, refFullName, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check blob sizes of %s for %s: %v", newCommitID, refFullName, err),
+		})
+		return false
+	}
+	if len(blobs) == 0 {
+		return true
+	}
+
+	log.Warn("Forbidden: User %d pushing %d blob(s) larger than %d bytes to %s in %-v", ctx.opts.UserID, len(blobs), repo.MaxPushedBlobSize, refFullName, repo)
+	msg := fmt.Sprintf("push to %s contains files larger than the limit of %s allowed in this repository:", refFullName.ShortName(), base.FileSize(repo.MaxPushedBlobSize))
+	for _, blob := range blobs {
+		name := blob.Path
+		if name == "" {
+			name = blob.ID
+		}
+		msg += fmt.Sprintf("\n  %s (%s)", name, base.FileSize(blob.Size))
+	}
+	msg += "\nStore large files with Git LFS instead, see `git lfs track`, and rewrite the commits which added them."
+	ctx.JSON(http.StatusForbidden, private.Response{
+		UserMsg: msg,
+	})
+	return false
+}
+
 func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	branchName := refFullName.BranchName()
 	ctx.branchName = branchName
@@ -231,7 +270,6 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 	}
 
 	repo := ctx.Repo.Repository
-	gitRepo := ctx.Repo.GitRepo
 	objectFormat := ctx.Repo.GetObjectFormat()
 
 	if branchName == repo.DefaultBranch && newCommitID == objectFormat.EmptyObjectID().String() {
@@ -251,16 +289,96 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
-	// Allow pushes to non-protected branches
-	if protectBranch == nil {
-		// ...unless the user is over quota, and the operation is not a delete
-		if newCommitID != objectFormat.EmptyObjectID().String() && ctx.isOverQuota {
-			ctx.quotaExceeded()
+	rulesets, err := git_model.FindMatchedOrgRulesets(ctx, repo, git_model.RulesetTargetBranch, branchName)
+	if err != nil {
+		log.Error("Unable to get organization rulesets for branch: %s in %-v Error: %v", branchName, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+
+	isProtected := protectBranch != nil
+	if protectBranch != nil {
+		protectBranch.Repo = repo
+		if violation := ctx.checkBranchProtection(protectBranch, false, oldCommitID, newCommitID, branchName); violation != nil {
+			ctx.rejectPush(violation, branchName)
+			return
+		}
+	}
+
+	for _, rs := range rulesets {
+		violation := ctx.checkBranchProtection(rs.ToProtectedBranch(repo), true, oldCommitID, newCommitID, branchName)
+		if ctx.Written() {
+			return
+		}
+		if rs.IsEvaluateOnly() {
+			ctx.recordRulesetViolation(rs, violation, refFullName)
+			continue
 		}
+		isProtected = true
+		if violation != nil {
+			ctx.rejectPush(violation, branchName)
+			return
+		}
+	}
+
+	// Allow pushes to non-protected branches, unless the user is over quota and the operation is not a delete
+	if !isProtected && newCommitID != objectFormat.EmptyObjectID().String() && ctx.isOverQuota {
+		ctx.quotaExceeded()
+	}
+}
+
+// pushViolation is the response to send back when a push does not comply with a protection rule
+type pushViolation struct {
+	status int
+	private.Response
+}
+
+func (ctx *preReceiveContext) rejectPush(violation *pushViolation, branchName string) {
+	if ctx.Written() {
+		return
+	}
+	if violation.status == http.StatusForbidden {
+		log.Warn("Forbidden: User %d pushing to branch %s in %-v: %s", ctx.opts.UserID, branchName, ctx.Repo.Repository, violation.UserMsg)
+	}
+	ctx.JSON(violation.status, violation.Response)
+}
 
+// recordRulesetViolation keeps track of a push that would have been rejected by an evaluate-only ruleset
+func (ctx *preReceiveContext) recordRulesetViolation(rs *git_model.OrgRuleset, violation *pushViolation, refFullName git.RefName) {
+	if violation == nil {
 		return
 	}
-	protectBranch.Repo = repo
+	if violation.status != http.StatusForbidden {
+		log.Error("Unable to evaluate organization ruleset %q for %s in %-v: %s", rs.Name, refFullName, ctx.Repo.Repository, violation.Err)
+		return
+	}
+	log.Info("Organization ruleset %q (evaluate-only) is violated by user %d pushing to %s in %-v: %s", rs.Name, ctx.opts.UserID, refFullName, ctx.Repo.Repository, violation.UserMsg)
+	if err := git_model.InsertOrgRulesetViolation(ctx, &git_model.OrgRulesetViolation{
+		RulesetID: rs.ID,
+		RepoID:    ctx.Repo.Repository.ID,
+		RefName:   refFullName.String(),
+		DoerID:    ctx.opts.UserID,
+		Reason:    violation.UserMsg,
+	}); err != nil {
+		log.Error("InsertOrgRulesetViolation: %v", err)
+	}
+}
+
+// checkBranchProtection checks a push to a branch against a protection rule, either the one of the repository
+// or one from an organization ruleset, and returns why it is not allowed if so
+func (ctx *preReceiveContext) checkBranchProtection(protectBranch *git_model.ProtectedBranch, isRuleset bool, oldCommitID, newCommitID, branchName string) *pushViolation {
+	repo := ctx.Repo.Repository
+	gitRepo := ctx.Repo.GitRepo
+	objectFormat := ctx.Repo.GetObjectFormat()
+
+	forbidden := func(format string, args ...any) *pushViolation {
+		return &pushViolation{status: http.StatusForbidden, Response: private.Response{UserMsg: fmt.Sprintf(format, args...)}}
+	}
+	internalError := func(format string, args ...any) *pushViolation {
+		return &pushViolation{status: http.StatusInternalServerError, Response: private.Response{Err: fmt.Sprintf(format, args...)}}
+	}
 
 	// This ref is a protected branch.
 	//
@@ -268,11 +386,7 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 	//
 	// 1. Detect and prevent deletion of the branch
 	if newCommitID == objectFormat.EmptyObjectID().String() {
-		log.Warn("Forbidden: Branch: %s in %-v is protected from deletion", branchName, repo)
-		ctx.JSON(http.StatusForbidden, private.Response{
-			UserMsg: fmt.Sprintf("branch %s is protected from deletion", branchName),
-		})
-		return
+		return forbidden("branch %s is protected from deletion", branchName)
 	}
 
 	// 2. Disallow force pushes to protected branches
@@ -280,16 +394,9 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		output, _, err := git.NewCommand(ctx, "rev-list", "--max-count=1").AddDynamicArguments(oldCommitID, "^"+newCommitID).RunStdString(&git.RunOpts{Dir: repo.RepoPath(), Env: ctx.env})
 		if err != nil {
 			log.Error("Unable to detect force push between: %s and %s in %-v Error: %v", oldCommitID, newCommitID, repo, err)
-			ctx.JSON(http.StatusInternalServerError, private.Response{
-				Err: fmt.Sprintf("Fail to detect force push: %v", err),
-			})
-			return
+			return internalError("Fail to detect force push: %v", err)
 		} else if len(output) > 0 {
-			log.Warn("Forbidden: Branch: %s in %-v is protected from force push", branchName, repo)
-			ctx.JSON(http.StatusForbidden, private.Response{
-				UserMsg: fmt.Sprintf("branch %s is protected from force push", branchName),
-			})
-			return
+			return forbidden("branch %s is protected from force push", branchName)
 		}
 	}
 
@@ -299,17 +406,10 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		if err != nil {
 			if !isErrUnverifiedCommit(err) {
 				log.Error("Unable to check commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
-				ctx.JSON(http.StatusInternalServerError, private.Response{
-					Err: fmt.Sprintf("Unable to check commits from %s to %s: %v", oldCommitID, newCommitID, err),
-				})
-				return
+				return internalError("Unable to check commits from %s to %s: %v", oldCommitID, newCommitID, err)
 			}
 			unverifiedCommit := err.(*errUnverifiedCommit).sha
-			log.Warn("Forbidden: Branch: %s in %-v is protected from unverified commit %s", branchName, repo, unverifiedCommit)
-			ctx.JSON(http.StatusForbidden, private.Response{
-				UserMsg: fmt.Sprintf("branch %s is protected from unverified commit %s", branchName, unverifiedCommit),
-			})
-			return
+			return forbidden("branch %s is protected from unverified commit %s", branchName, unverifiedCommit)
 		}
 	}
 
@@ -325,10 +425,7 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		if err != nil {
 			if !models.IsErrFilePathProtected(err) {
 				log.Error("Unable to check file protection for commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
-				ctx.JSON(http.StatusInternalServerError, private.Response{
-					Err: fmt.Sprintf("Unable to check file protection for commits from %s to %s: %v", oldCommitID, newCommitID, err),
-				})
-				return
+				return internalError("Unable to check file protection for commits from %s to %s: %v", oldCommitID, newCommitID, err)
 			}
 
 			changedProtectedfiles = true
@@ -344,125 +441,98 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		user, err := user_model.GetUserByID(ctx, ctx.opts.UserID)
 		if err != nil {
 			log.Error("Unable to GetUserByID for commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
-			ctx.JSON(http.StatusInternalServerError, private.Response{
-				Err: fmt.Sprintf("Unable to GetUserByID for commits from %s to %s: %v", oldCommitID, newCommitID, err),
-			})
-			return
+			return internalError("Unable to GetUserByID for commits from %s to %s: %v", oldCommitID, newCommitID, err)
 		}
 		canPush = !changedProtectedfiles && protectBranch.CanUserPush(ctx, user)
 	}
 
-	// 6. If we're not allowed to push directly
-	if !canPush {
-		// Is this is a merge from the UI/API?
-		if ctx.opts.PullRequestID == 0 {
-			// 6a. If we're not merging from the UI/API then there are two ways we got here:
-			//
-			// We are changing a protected file and we're not allowed to do that
-			if changedProtectedfiles {
-				log.Warn("Forbidden: Branch: %s in %-v is protected from changing file %s", branchName, repo, protectedFilePath)
-				ctx.JSON(http.StatusForbidden, private.Response{
-					UserMsg: fmt.Sprintf("branch %s is protected from changing file %s", branchName, protectedFilePath),
-				})
-				return
-			}
-
-			// Allow commits that only touch unprotected files
-			globs := protectBranch.GetUnprotectedFilePatterns()
-			if len(globs) > 0 {
-				unprotectedFilesOnly, err := pull_service.CheckUnprotectedFiles(gitRepo, oldCommitID, newCommitID, globs, ctx.env)
-				if err != nil {
-					log.Error("Unable to check file protection for commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
-					ctx.JSON(http.StatusInternalServerError, private.Response{
-						Err: fmt.Sprintf("Unable to check file protection for commits from %s to %s: %v", oldCommitID, newCommitID, err),
-					})
-					return
-				}
-				if unprotectedFilesOnly {
-					// Commit only touches unprotected files, this is allowed
-					return
-				}
-			}
+	// 6. If we're allowed to push directly, we're done
+	if canPush {
+		return nil
+	}
 
-			// Or we're simply not able to push to this protected branch
-			log.Warn("Forbidden: User %d is not allowed to push to protected branch: %s in %-v", ctx.opts.UserID, branchName, repo)
-			ctx.JSON(http.StatusForbidden, private.Response{
-				UserMsg: fmt.Sprintf("Not allowed to push to protected branch %s", branchName),
-			})
-			return
+	// Is this is a merge from the UI/API?
+	if ctx.opts.PullRequestID == 0 {
+		// 6a. If we're not merging from the UI/API then there are two ways we got here:
+		//
+		// We are changing a protected file and we're not allowed to do that
+		if changedProtectedfiles {
+			return forbidden("branch %s is protected from changing file %s", branchName, protectedFilePath)
 		}
-		// 6b. Merge (from UI or API)
 
-		// Get the PR, user and permissions for the user in the repository
-		pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
-		if err != nil {
-			log.Error("Unable to get PullRequest %d Error: %v", ctx.opts.PullRequestID, err)
-			ctx.JSON(http.StatusInternalServerError, private.Response{
-				Err: fmt.Sprintf("Unable to get PullRequest %d Error: %v", ctx.opts.PullRequestID, err),
-			})
-			return
+		// Allow commits that only touch unprotected files
+		globs := protectBranch.GetUnprotectedFilePatterns()
+		if len(globs) > 0 {
+			unprotectedFilesOnly, err := pull_service.CheckUnprotectedFiles(gitRepo, oldCommitID, newCommitID, globs, ctx.env)
+			if err != nil {
+				log.Error("Unable to check file protection for commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
+				return internalError("Unable to check file protection for commits from %s to %s: %v", oldCommitID, newCommitID, err)
+			}
+			if unprotectedFilesOnly {
+				// Commit only touches unprotected files, this is allowed
+				return nil
+			}
 		}
 
-		// although we should have called `loadPusherAndPermission` before, here we call it explicitly again because we need to access ctx.user below
-		if !ctx.loadPusherAndPermission() {
-			// if error occurs, loadPusherAndPermission had written the error response
-			return
-		}
+		// Or we're simply not able to push to this protected branch
+		return forbidden("Not allowed to push to protected branch %s", branchName)
+	}
+	// 6b. Merge (from UI or API)
 
-		// Now check if the user is allowed to merge PRs for this repository
-		// Note: we can use ctx.perm and ctx.user directly as they will have been loaded above
-		allowedMerge, err := pull_service.IsUserAllowedToMerge(ctx, pr, ctx.userPerm, ctx.user)
+	// Get the PR, user and permissions for the user in the repository
+	pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
+	if err != nil {
+		log.Error("Unable to get PullRequest %d Error: %v", ctx.opts.PullRequestID, err)
+		return internalError("Unable to get PullRequest %d Error: %v", ctx.opts.PullRequestID, err)
+	}
+
+	// although we should have called `loadPusherAndPermission` before, here we call it explicitly again because we need to access ctx.user below
+	if !ctx.loadPusherAndPermission() {
+		// if error occurs, loadPusherAndPermission had written the error response
+		return internalError("Unable to load pusher %d", ctx.opts.UserID)
+	}
+
+	// Now check if the user is allowed to merge PRs for this repository
+	// Note: we can use ctx.perm and ctx.user directly as they will have been loaded above
+	var allowedMerge bool
+	if isRuleset {
+		allowedMerge = git_model.IsUserMergeWhitelisted(ctx, protectBranch, ctx.user.ID, ctx.userPerm)
+	} else {
+		allowedMerge, err = pull_service.IsUserAllowedToMerge(ctx, pr, ctx.userPerm, ctx.user)
 		if err != nil {
 			log.Error("Error calculating if allowed to merge: %v", err)
-			ctx.JSON(http.StatusInternalServerError, private.Response{
-				Err: fmt.Sprintf("Error calculating if allowed to merge: %v", err),
-			})
-			return
-		}
-
-		if !allowedMerge {
-			log.Warn("Forbidden: User %d is not allowed to push to protected branch: %s in %-v and is not allowed to merge pr #%d", ctx.opts.UserID, branchName, repo, pr.Index)
-			ctx.JSON(http.StatusForbidden, private.Response{
-				UserMsg: fmt.Sprintf("Not allowed to push to protected branch %s", branchName),
-			})
-			return
+			return internalError("Error calculating if allowed to merge: %v", err)
 		}
+	}
+	if !allowedMerge {
+		log.Debug("User %d is not allowed to merge pr #%d in %-v", ctx.opts.UserID, pr.Index, repo)
+		return forbidden("Not allowed to push to protected branch %s", branchName)
+	}
 
-		// If we're an admin for the instance, we can ignore checks
-		if ctx.user.IsAdmin {
-			return
-		}
+	// If we're an admin for the instance, we can ignore checks
+	if ctx.user.IsAdmin {
+		return nil
+	}
 
-		// It's not allowed t overwrite protected files. Unless if the user is an
-		// admin and the protected branch rule doesn't apply to admins.
-		if changedProtectedfiles && (!ctx.userPerm.IsAdmin() || protectBranch.ApplyToAdmins) {
-			log.Warn("Forbidden: Branch: %s in %-v is protected from changing file %s", branchName, repo, protectedFilePath)
-			ctx.JSON(http.StatusForbidden, private.Response{
-				UserMsg: fmt.Sprintf("branch %s is protected from changing file %s", branchName, protectedFilePath),
-			})
-			return
-		}
+	// It's not allowed t overwrite protected files. Unless if the user is an
+	// admin and the protected branch rule doesn't apply to admins.
+	if changedProtectedfiles && (!ctx.userPerm.IsAdmin() || protectBranch.ApplyToAdmins) {
+		return forbidden("branch %s is protected from changing file %s", branchName, protectedFilePath)
+	}
 
-		// Check all status checks and reviews are ok
-		if pb, err := pull_service.CheckPullBranchProtections(ctx, pr, true); err != nil {
-			if models.IsErrDisallowedToMerge(err) {
-				// Allow this if the rule doesn't apply to admins and the user is an admin.
-				if ctx.userPerm.IsAdmin() && !pb.ApplyToAdmins {
-					return
-				}
-				log.Warn("Forbidden: User %d is not allowed push to protected branch %s in %-v and pr #%d is not ready to be merged: %s", ctx.opts.UserID, branchName, repo, pr.Index, err.Error())
-				ctx.JSON(http.StatusForbidden, private.Response{
-					UserMsg: fmt.Sprintf("Not allowed to push to protected branch %s and pr #%d is not ready to be merged: %s", branchName, ctx.opts.PullRequestID, err.Error()),
-				})
-				return
+	// Check all status checks and reviews are ok
+	if err := pull_service.CheckPullBranchProtection(ctx, pr, protectBranch, true); err != nil {
+		if models.IsErrDisallowedToMerge(err) {
+			// Allow this if the rule doesn't apply to admins and the user is an admin.
+			if ctx.userPerm.IsAdmin() && !protectBranch.ApplyToAdmins {
+				return nil
 			}
-			log.Error("Unable to check if mergeable: protected branch %s in %-v and pr #%d. Error: %v", ctx.opts.UserID, branchName, repo, pr.Index, err)
-			ctx.JSON(http.StatusInternalServerError, private.Response{
-				Err: fmt.Sprintf("Unable to get status of pull request %d. Error: %v", ctx.opts.PullRequestID, err),
-			})
-			return
+			return forbidden("Not allowed to push to protected branch %s and pr #%d is not ready to be merged: %s", branchName, ctx.opts.PullRequestID, err.Error())
 		}
+		log.Error("Unable to check if mergeable: protected branch %s in %-v and pr #%d. Error: %v", branchName, repo, pr.Index, err)
+		return internalError("Unable to get status of pull request %d. Error: %v", ctx.opts.PullRequestID, err)
 	}
+	return nil
 }
 
 func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) { //nolint:unparam
@@ -500,6 +570,38 @@ func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refF
 		return
 	}
 
+	rulesets, err := git_model.FindMatchedOrgRulesets(ctx, ctx.Repo.Repository, git_model.RulesetTargetTag, tagName)
+	if err != nil {
+		log.Error("Unable to get organization rulesets for tag: %s in %-v Error: %v", tagName, ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+	for _, rs := range rulesets {
+		isAllowed, err := rs.IsUserAllowedToModifyTag(ctx, ctx.Repo.Repository, ctx.opts.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return
+		}
+		if isAllowed {
+			continue
+		}
+		violation := &pushViolation{
+			status:   http.StatusForbidden,
+			Response: private.Response{UserMsg: fmt.Sprintf("Tag %s is protected by organization ruleset %s", tagName, rs.Name)},
+		}
+		if rs.IsEvaluateOnly() {
+			ctx.recordRulesetViolation(rs, violation, refFullName)
+			continue
+		}
+		log.Warn("Forbidden: Tag %s in %-v is protected by organization ruleset %q", tagName, ctx.Repo.Repository, rs.Name)
+		ctx.JSON(violation.status, violation.Response)
+		return
+	}
+
 	// If the user is over quota, and the push isn't a tag deletion, deny it
 	if ctx.isOverQuota {
 		objectFormat := ctx.Repo.GetObjectFormat()
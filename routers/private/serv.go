@@ -273,6 +273,14 @@ func ServCommand(ctx *context.PrivateContext) {
 		}
 	}
 
+	// Don't allow pushing to a read-only replica
+	if mode > perm.AccessModeRead && setting.Replica.Enabled {
+		ctx.JSON(http.StatusUnauthorized, private.Response{
+			UserMsg: fmt.Sprintf("This instance is a read-only replica, push to %s instead.", setting.Replica.PrimaryURL),
+		})
+		return
+	}
+
 	// Don't allow pushing if the repo is archived
 	if repoExist && mode > perm.AccessModeRead && repo.IsArchived {
 		ctx.JSON(http.StatusUnauthorized, private.Response{
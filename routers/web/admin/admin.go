@@ -36,6 +36,7 @@ const (
 	tplStacktrace   base.TplName = "admin/stacktrace"
 	tplQueueManage  base.TplName = "admin/queue_manage"
 	tplStats        base.TplName = "admin/stats"
+	tplReplica      base.TplName = "admin/replica"
 )
 
 var sysStatus struct {
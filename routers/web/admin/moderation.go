@@ -0,0 +1,157 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models/db"
+	moderation_model "forgejo.org/models/moderation"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	moderation_service "forgejo.org/services/moderation"
+)
+
+const (
+	tplAbuseReports    base.TplName = "admin/moderation/list"
+	tplViewAbuseReport base.TplName = "admin/moderation/view"
+)
+
+// AbuseReports shows the moderation queue
+func AbuseReports(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.moderation")
+	ctx.Data["PageIsAdminModeration"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	status := ctx.FormString("status")
+	if status == "" {
+		status = moderation_model.ReportStatusOpen.String()
+	}
+	opts := moderation_model.FindReportsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.Admin.NoticePagingNum,
+		},
+		Status:      moderation_model.ReportStatusFromString(status),
+		ContentType: moderation_model.ContentTypeFromString(ctx.FormString("type")),
+		Category:    moderation_model.CategoryFromString(ctx.FormString("category")),
+	}
+	reports, total, err := db.FindAndCount[moderation_model.AbuseReport](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAndCount", err)
+		return
+	}
+	if err := moderation_model.AbuseReportList(reports).LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	ctx.Data["Reports"] = reports
+	ctx.Data["Total"] = total
+	ctx.Data["Status"] = status
+	ctx.Data["ContentType"] = opts.ContentType.String()
+	ctx.Data["Category"] = opts.Category.String()
+	ctx.Data["Categories"] = moderation_model.Categories()
+
+	pager := context.NewPagination(int(total), opts.PageSize, page, 5)
+	pager.AddParamString("status", status)
+	pager.AddParamString("type", opts.ContentType.String())
+	pager.AddParamString("category", opts.Category.String())
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplAbuseReports)
+}
+
+// ViewAbuseReport shows a report, the other reports about the same content and the actions a moderator can take
+func ViewAbuseReport(ctx *context.Context) {
+	report, err := moderation_model.GetAbuseReportByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetAbuseReportByID", moderation_model.IsErrAbuseReportNotExist, err)
+		return
+	}
+	if err := report.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("admin.moderation.report", report.ID)
+	ctx.Data["PageIsAdminModeration"] = true
+	ctx.Data["Report"] = report
+
+	content, err := moderation_service.GetReportedContent(ctx, ctx.Doer, report.ContentType, report.ContentID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetReportedContent", err)
+		return
+	}
+	ctx.Data["Content"] = content
+	if content != nil && report.IsOpen() {
+		ctx.Data["Actions"] = report.ContentType.Actions()
+	}
+
+	others, err := db.Find[moderation_model.AbuseReport](ctx, moderation_model.FindReportsOptions{
+		ListOptions: db.ListOptionsAll,
+		ContentType: report.ContentType,
+		ContentID:   report.ContentID,
+	})
+	if err != nil {
+		ctx.ServerError("Find", err)
+		return
+	}
+	if err := moderation_model.AbuseReportList(others).LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["ContentReports"] = others
+
+	ctx.HTML(http.StatusOK, tplViewAbuseReport)
+}
+
+// ResolveAbuseReport takes the action of a moderator on the content of a report and resolves its open reports
+func ResolveAbuseReport(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ResolveAbuseReportForm)
+	report, err := moderation_model.GetAbuseReportByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetAbuseReportByID", moderation_model.IsErrAbuseReportNotExist, err)
+		return
+	}
+	reportLink := fmt.Sprintf("%s/admin/moderation/%d", setting.AppSubURL, report.ID)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(reportLink)
+		return
+	}
+
+	action, ok := moderation_model.ActionFromString(form.Action)
+	if !ok {
+		ctx.Flash.Error(ctx.Tr("admin.moderation.action_invalid"))
+		ctx.Redirect(reportLink)
+		return
+	}
+	if err := moderation_service.ResolveReport(ctx, ctx.Doer, report, action, form.Reason); err != nil {
+		switch {
+		case moderation_model.IsErrAbuseReportResolved(err):
+			ctx.Flash.Error(ctx.Tr("admin.moderation.already_resolved"))
+		case errors.Is(err, util.ErrInvalidArgument), errors.Is(err, util.ErrNotExist):
+			ctx.Flash.Error(ctx.Tr("admin.moderation.action_failed", err.Error()))
+		default:
+			ctx.ServerError("ResolveReport", err)
+			return
+		}
+		ctx.Redirect(reportLink)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.moderation.resolved", report.ContentReference))
+	ctx.Redirect(setting.AppSubURL + "/admin/moderation")
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+// MonitorReplica shows how far behind the primary the repositories of the replica are
+func MonitorReplica(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.monitor.replica")
+	ctx.Data["PageIsAdminMonitorReplica"] = true
+
+	total, err := repo_model.CountReplicaRepositories(ctx)
+	if err != nil {
+		ctx.ServerError("CountReplicaRepositories", err)
+		return
+	}
+	lagging, err := repo_model.FindLaggingReplicaRepositories(ctx, setting.UI.Admin.RepoPagingNum)
+	if err != nil {
+		ctx.ServerError("FindLaggingReplicaRepositories", err)
+		return
+	}
+
+	now := time.Now()
+	ctx.Data["Now"] = now
+	ctx.Data["PrimaryURL"] = setting.Replica.PrimaryURL
+	ctx.Data["Total"] = total
+	ctx.Data["LaggingRepos"] = lagging
+	if len(lagging) > 0 {
+		ctx.Data["MaxLag"] = lagging[0].Lag(now)
+	}
+	ctx.HTML(http.StatusOK, tplReplica)
+}
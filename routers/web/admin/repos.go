@@ -31,10 +31,11 @@ func Repos(ctx *context.Context) {
 	ctx.Data["PageIsAdminRepositories"] = true
 
 	explore.RenderRepoSearch(ctx, &explore.RepoSearchOptions{
-		Private:          true,
-		PageSize:         setting.UI.Admin.RepoPagingNum,
-		TplName:          tplRepos,
-		OnlyShowRelevant: false,
+		Private:               true,
+		PageSize:              setting.UI.Admin.RepoPagingNum,
+		TplName:               tplRepos,
+		OnlyShowRelevant:      false,
+		WithMaintenanceStatus: true,
 	})
 }
 
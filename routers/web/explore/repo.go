@@ -30,6 +30,8 @@ type RepoSearchOptions struct {
 	PageSize         int
 	OnlyShowRelevant bool
 	TplName          base.TplName
+	// WithMaintenanceStatus loads the git maintenance status of the repositories, for the admin panel
+	WithMaintenanceStatus bool
 }
 
 // RenderRepoSearch render repositories search page
@@ -136,6 +138,18 @@ func RenderRepoSearch(ctx *context.Context, opts *RepoSearchOptions) {
 	ctx.Data["Keyword"] = keyword
 	ctx.Data["Total"] = count
 	ctx.Data["Repos"] = repos
+	if opts.WithMaintenanceStatus {
+		repoIDs := make([]int64, 0, len(repos))
+		for _, repo := range repos {
+			repoIDs = append(repoIDs, repo.ID)
+		}
+		maintenances, err := repo_model.FindRepoMaintenancesByRepoIDs(ctx, repoIDs)
+		if err != nil {
+			ctx.ServerError("FindRepoMaintenancesByRepoIDs", err)
+			return
+		}
+		ctx.Data["RepoMaintenances"] = maintenances
+	}
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
 
 	pager := context.NewPagination(int(count), opts.PageSize, page, 5)
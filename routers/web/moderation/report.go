@@ -0,0 +1,85 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"errors"
+	"net/http"
+
+	moderation_model "forgejo.org/models/moderation"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	moderation_service "forgejo.org/services/moderation"
+)
+
+const tplNewReport base.TplName = "moderation/new_report"
+
+// NewReport shows the form to report some content to the moderators
+func NewReport(ctx *context.Context) {
+	content := getReportedContent(ctx, ctx.FormString("type"), ctx.FormInt64("id"))
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["category"] = ""
+	renderNewReport(ctx, content)
+}
+
+// NewReportPost saves the report of some content
+func NewReportPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ReportAbuseForm)
+	content := getReportedContent(ctx, form.ContentType, form.ContentID)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		renderNewReport(ctx, content)
+		return
+	}
+
+	category := moderation_model.CategoryFromString(form.Category)
+	if _, err := moderation_service.ReportAbuse(ctx, ctx.Doer, content, category, form.Remarks); err != nil {
+		switch {
+		case moderation_model.IsErrAbuseReportAlreadyExists(err):
+			ctx.Flash.Info(ctx.Tr("moderation.report.already_reported"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			prepareNewReport(ctx, content)
+			ctx.Data["Err_Category"] = category == 0
+			ctx.RenderWithErr(ctx.Tr("moderation.report.invalid"), tplNewReport, form)
+			return
+		default:
+			ctx.ServerError("ReportAbuse", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("moderation.report.success"))
+	}
+	ctx.Redirect(content.Link)
+}
+
+func getReportedContent(ctx *context.Context, contentType string, contentID int64) *moderation_service.ReportedContent {
+	content, err := moderation_service.GetReportedContent(ctx, ctx.Doer, moderation_model.ContentTypeFromString(contentType), contentID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetReportedContent", err)
+		} else {
+			ctx.ServerError("GetReportedContent", err)
+		}
+		return nil
+	}
+	ctx.Data["ReportedContent"] = content
+	return content
+}
+
+func prepareNewReport(ctx *context.Context, content *moderation_service.ReportedContent) {
+	ctx.Data["Title"] = ctx.Tr("moderation.report.title", content.Reference)
+	ctx.Data["Categories"] = moderation_model.Categories()
+}
+
+func renderNewReport(ctx *context.Context, content *moderation_service.ReportedContent) {
+	prepareNewReport(ctx, content)
+	ctx.HTML(http.StatusOK, tplNewReport)
+}
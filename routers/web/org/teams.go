@@ -5,6 +5,7 @@
 package org
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -22,6 +23,7 @@ import (
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/validation"
 	"forgejo.org/modules/web"
 	shared_user "forgejo.org/routers/web/shared/user"
@@ -471,11 +473,88 @@ func EditTeam(ctx *context.Context) {
 		ctx.ServerError("LoadHeaderCount", err)
 		return
 	}
+	if err := ctx.Org.Team.LoadMembers(ctx); err != nil {
+		ctx.ServerError("LoadMembers", err)
+		return
+	}
 	ctx.Data["Team"] = ctx.Org.Team
 	ctx.Data["Units"] = unit_model.Units
+	excludedNames := make([]string, 0, len(ctx.Org.Team.ReviewAssignmentExcludedUserIDs))
+	for _, member := range ctx.Org.Team.Members {
+		if ctx.Org.Team.IsReviewAssignmentExcluded(member.ID) {
+			excludedNames = append(excludedNames, member.Name)
+		}
+	}
+	ctx.Data["ReviewAssignmentExcludedUsers"] = strings.Join(excludedNames, ",")
+	managers, err := teamReviewManagersText(ctx, ctx.Org.Team)
+	if err != nil {
+		ctx.ServerError("teamReviewManagersText", err)
+		return
+	}
+	ctx.Data["ReviewAssignmentManagers"] = managers
 	ctx.HTML(http.StatusOK, tplTeamNew)
 }
 
+// teamReviewManagersText returns the managers of a team as edited in the form, one "user: manager, manager" line
+// per managed user
+func teamReviewManagersText(ctx *context.Context, t *org_model.Team) (string, error) {
+	managers, err := org_model.GetTeamReviewManagers(ctx, t.ID)
+	if err != nil {
+		return "", err
+	}
+	userIDs := make([]int64, 0, len(managers)*2)
+	for _, m := range managers {
+		userIDs = append(userIDs, m.UserID, m.ManagerID)
+	}
+	users, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return "", err
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+	}
+
+	var lines []string
+	managerNames := make(map[string][]string)
+	for _, m := range managers {
+		userName, managerName := names[m.UserID], names[m.ManagerID]
+		if userName == "" || managerName == "" {
+			continue
+		}
+		if _, ok := managerNames[userName]; !ok {
+			lines = append(lines, userName)
+		}
+		managerNames[userName] = append(managerNames[userName], managerName)
+	}
+	for i, userName := range lines {
+		lines[i] = userName + ": " + strings.Join(managerNames[userName], ", ")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// parseTeamReviewManagers parses the managers of a team as edited in the form
+func parseTeamReviewManagers(text string) (map[string][]string, error) {
+	managers := make(map[string][]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		userName, managerNames, ok := strings.Cut(line, ":")
+		userName = strings.TrimSpace(userName)
+		if !ok || userName == "" {
+			return nil, util.NewInvalidArgumentErrorf("invalid manager line %q", line)
+		}
+		for _, managerName := range strings.Split(managerNames, ",") {
+			if managerName = strings.TrimSpace(managerName); managerName != "" {
+				managers[userName] = append(managers[userName], managerName)
+			}
+		}
+	}
+	return managers, nil
+}
+
 // EditTeamPost response for modify team information
 func EditTeamPost(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.CreateTeamForm)
@@ -548,6 +627,44 @@ func EditTeamPost(ctx *context.Context) {
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
+// TeamReviewAssignmentPost response for modify the review assignment policy of a team
+func TeamReviewAssignmentPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.TeamReviewAssignmentForm)
+	t := ctx.Org.Team
+	link := ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName) + "/edit"
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.Tr("org.teams.review_assignment.invalid"))
+		ctx.Redirect(link)
+		return
+	}
+
+	opts := org_service.ReviewAssignmentOptions{
+		Algorithm:  org_model.ReviewAssignmentAlgorithm(form.ReviewAssignment),
+		Count:      form.Count,
+		MaxPending: form.MaxPending,
+	}
+	if strings.TrimSpace(form.ExcludedUsers) != "" {
+		opts.Excluded = strings.Split(form.ExcludedUsers, ",")
+	}
+	managers, err := parseTeamReviewManagers(form.Managers)
+	if err == nil {
+		opts.Managers = managers
+		err = org_service.UpdateTeamReviewAssignment(ctx, t, opts)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("org.teams.review_assignment.invalid"))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("UpdateTeamReviewAssignment", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("org.teams.review_assignment.updated"))
+	ctx.Redirect(link)
+}
+
 // DeleteTeam response for the delete team request
 func DeleteTeam(ctx *context.Context) {
 	if err := models.DeleteTeam(ctx, ctx.Org.Team); err != nil {
@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	timesheet_service "forgejo.org/services/timesheet"
+)
+
+const tplTimesheet base.TplName = "org/timesheet"
+
+// timesheetOptions reads the filters of a timesheet from the query
+func timesheetOptions(ctx *context.Context) timesheet_service.Options {
+	opts := timesheet_service.Options{
+		Group:     issues_model.TimesheetGroup(ctx.FormString("group_by")),
+		User:      ctx.FormTrim("user"),
+		Repo:      ctx.FormTrim("repo"),
+		Milestone: ctx.FormTrim("milestone"),
+		Label:     ctx.FormTrim("label"),
+		From:      ctx.FormTrim("from"),
+		To:        ctx.FormTrim("to"),
+	}
+	if opts.Group == "" {
+		opts.Group = issues_model.TimesheetGroupUser
+	}
+	return opts
+}
+
+// Timesheet shows the time tracked in the repositories of an organization
+func Timesheet(ctx *context.Context) {
+	if !setting.Service.EnableTimetracking {
+		ctx.NotFound("Timesheet", nil)
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("org.timesheet")
+	ctx.Data["PageIsOrgTimesheet"] = true
+
+	opts := timesheetOptions(ctx)
+	ctx.Data["Options"] = opts
+	ctx.Data["Groups"] = issues_model.TimesheetGroups
+	ctx.Data["TimesheetQuery"] = ctx.Req.URL.RawQuery
+
+	entries, err := timesheet_service.GetTimesheet(ctx, ctx.Doer, ctx.Org.Organization.AsUser(), opts)
+	if err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("GetTimesheet", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("org.timesheet.invalid", err.Error()), true)
+	}
+	ctx.Data["Entries"] = entries
+	ctx.Data["TotalTime"] = timesheet_service.TotalTime(entries)
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplTimesheet)
+}
+
+// TimesheetExport exports the time tracked in the repositories of an organization in CSV
+func TimesheetExport(ctx *context.Context) {
+	if !setting.Service.EnableTimetracking {
+		ctx.NotFound("TimesheetExport", nil)
+		return
+	}
+	opts := timesheetOptions(ctx)
+	entries, err := timesheet_service.GetTimesheet(ctx, ctx.Doer, ctx.Org.Organization.AsUser(), opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, err.Error())
+			return
+		}
+		ctx.ServerError("GetTimesheet", err)
+		return
+	}
+
+	ctx.SetServeHeaders(&context.ServeHeaderOptions{
+		ContentType:        "text/csv",
+		ContentTypeCharset: "utf-8",
+		Filename:           fmt.Sprintf("%s-timesheet-by-%s.csv", ctx.Org.Organization.Name, opts.Group),
+	})
+	if err := timesheet_service.WriteCSV(ctx.Resp, opts.Group, entries); err != nil {
+		log.Error("WriteCSV [org id: %d]: %v", ctx.Org.Organization.ID, err)
+	}
+}
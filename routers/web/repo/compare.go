@@ -39,6 +39,7 @@ import (
 	"forgejo.org/services/context"
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/gitdiff"
+	issue_service "forgejo.org/services/issue"
 )
 
 const (
@@ -829,11 +830,7 @@ func CompareDiff(ctx *context.Context) {
 	ctx.Data["Title"] = "Comparing " + ctx.Data["Comparing"].(string)
 
 	ctx.Data["IsDiffCompare"] = true
-	_, templateErrs := setTemplateIfExists(ctx, pullRequestTemplateKey, pullRequestTemplateCandidates)
-
-	if len(templateErrs) > 0 {
-		ctx.Flash.Warning(renderErrorOfTemplates(ctx, templateErrs), true)
-	}
+	setPullRequestTemplate(ctx, ci.BaseBranch)
 
 	if content, ok := ctx.Data["content"].(string); ok && content != "" {
 		// If a template content is set, prepend the "content". In this case that's only
@@ -876,6 +873,48 @@ func CompareDiff(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplCompare)
 }
 
+// pullRequestTemplateChoice is a template of the pull request templates directory offered on the compare page
+type pullRequestTemplateChoice struct {
+	Template   *api.IssueTemplate
+	Link       string
+	IsSelected bool
+}
+
+// setPullRequestTemplate loads the template of the new pull request: the one chosen with the "template"
+// query parameter, by path or by name in the templates directory, else the first one of the templates
+// directory chosen for the target branch, else the default template
+func setPullRequestTemplate(ctx *context.Context, baseBranch string) {
+	templates, templateErrs := issue_service.GetPullRequestTemplatesFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
+	if templateErrs == nil {
+		templateErrs = map[string]error{}
+	}
+
+	candidates := pullRequestTemplateCandidates
+	chosen := issue_service.FindPullRequestTemplate(templates, ctx.FormString("template"), baseBranch)
+	if chosen != nil {
+		candidates = append([]string{chosen.FileName}, pullRequestTemplateCandidates...)
+	}
+	_, errs := setTemplateIfExists(ctx, pullRequestTemplateKey, candidates)
+	for k, v := range errs {
+		templateErrs[k] = v
+	}
+	if len(templateErrs) > 0 {
+		ctx.Flash.Warning(renderErrorOfTemplates(ctx, templateErrs), true)
+	}
+
+	choices := make([]*pullRequestTemplateChoice, 0, len(templates))
+	for _, it := range templates {
+		query := ctx.Req.URL.Query()
+		query.Set("template", it.FileName)
+		choices = append(choices, &pullRequestTemplateChoice{
+			Template:   it,
+			Link:       ctx.Link + "?" + query.Encode(),
+			IsSelected: it == chosen,
+		})
+	}
+	ctx.Data["PullRequestTemplates"] = choices
+}
+
 // ExcerptBlob render blob excerpt contents
 func ExcerptBlob(ctx *context.Context) {
 	commitID := ctx.Params("sha")
@@ -58,6 +58,7 @@ import (
 	issue_service "forgejo.org/services/issue"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
+	files_service "forgejo.org/services/repository/files"
 
 	"code.forgejo.org/go-chi/binding"
 )
@@ -917,6 +918,17 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 
 			ctx.Data["Fields"] = template.Fields
 			ctx.Data["TemplateFile"] = template.FileName
+
+			// the user fields are chosen among the users who can be assigned
+			if slices.ContainsFunc(template.Fields, func(field *api.IssueFormField) bool {
+				return field.Type == api.IssueFormFieldTypeUser
+			}) {
+				users, err := repo_model.GetRepoAssignees(ctx, ctx.Repo.Repository)
+				if err != nil {
+					log.Error("GetRepoAssignees: %v", err)
+				}
+				ctx.Data["FormUsers"] = users
+			}
 		}
 		labelIDs := make([]string, 0, len(template.Labels))
 		if repoLabels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{}); err == nil {
@@ -1206,6 +1218,29 @@ func ValidateRepoMetas(ctx *context.Context, form forms.CreateIssueForm, isPull
 	return labelIDs, assigneeIDs, milestoneID, form.ProjectID
 }
 
+// submitIssueForm validates the values of the issue form a new issue or pull request is created with, and
+// returns its content and the metadata it is mapped to, or nil if there is no issue form
+func submitIssueForm(ctx *context.Context, isPull bool) *issue_service.IssueForm {
+	filename := ctx.Req.Form.Get("template-file")
+	if filename == "" || !issue_service.IsTemplatePath(filename, isPull) {
+		return nil
+	}
+	template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename)
+	if err != nil {
+		return nil
+	}
+	issueForm, err := issue_service.SubmitIssueForm(ctx, ctx.Repo.Repository, ctx.Doer, template, ctx.Req.Form, isPull)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+			return nil
+		}
+		ctx.ServerError("SubmitIssueForm", err)
+		return nil
+	}
+	return issueForm
+}
+
 // NewIssuePost response for creating new issue
 func NewIssuePost(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.CreateIssueForm)
@@ -1241,10 +1276,14 @@ func NewIssuePost(ctx *context.Context) {
 	}
 
 	content := form.Content
-	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+	if issueForm := submitIssueForm(ctx, false); issueForm != nil {
+		content = issueForm.Content
+		labelIDs, assigneeIDs, milestoneID, projectID = issueForm.MergeMetas(labelIDs, assigneeIDs, milestoneID, projectID)
+		if setting.Attachment.Enabled {
+			attachments = append(attachments, issueForm.Attachments...)
 		}
+	} else if ctx.Written() {
+		return
 	}
 
 	issue := &issues_model.Issue{
@@ -1280,8 +1319,8 @@ func NewIssuePost(ctx *context.Context) {
 			ctx.Error(http.StatusBadRequest, "user hasn't permissions to read projects")
 			return
 		}
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
-			ctx.ServerError("IssueAssignOrRemoveProject", err)
+		if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
+			ctx.ServerError("AssignOrRemoveProject", err)
 			return
 		}
 	}
@@ -1502,6 +1541,10 @@ func ViewIssue(ctx *context.Context) {
 		}
 	}
 	ctx.Data["IssueWatch"] = iw
+	if issue.IsHidden {
+		// the content hidden by a moderator is shown to nobody, moderators find it in the admin panel
+		issue.Content = ""
+	}
 	issue.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
@@ -1574,6 +1617,16 @@ func ViewIssue(ctx *context.Context) {
 		}
 	}
 
+	retrieveIssueProjectFields(ctx, issue)
+	if ctx.Written() {
+		return
+	}
+
+	retrieveSubIssues(ctx, issue)
+	if ctx.Written() {
+		return
+	}
+
 	if issue.IsPull {
 		canChooseReviewer := false
 		if ctx.Doer != nil && ctx.IsSigned {
@@ -1656,6 +1709,9 @@ func ViewIssue(ctx *context.Context) {
 
 	for _, comment = range issue.Comments {
 		comment.Issue = issue
+		if comment.IsHidden {
+			comment.Content = ""
+		}
 
 		if comment.Type == issues_model.CommentTypeComment || comment.Type == issues_model.CommentTypeReview {
 			comment.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
@@ -1734,6 +1790,18 @@ func ViewIssue(ctx *context.Context) {
 					return
 				}
 			}
+		} else if comment.Type >= issues_model.CommentTypeAddSubIssue && comment.Type <= issues_model.CommentTypeRemoveParentIssue {
+			if err = comment.LoadDepIssueDetails(ctx); err != nil {
+				if !issues_model.IsErrIssueNotExist(err) {
+					ctx.ServerError("LoadDepIssueDetails", err)
+					return
+				}
+			} else if comment.DependentIssue != nil {
+				if err = comment.DependentIssue.LoadRepo(ctx); err != nil {
+					ctx.ServerError("LoadRepo", err)
+					return
+				}
+			}
 		} else if comment.Type.HasContentSupport() {
 			comment.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 				Links: markup.Links{
@@ -1884,10 +1952,23 @@ func ViewIssue(ctx *context.Context) {
 				ctx.ServerError("CanMarkConversation", err)
 				return
 			}
+			if ctx.Data["CanApplySuggestions"], err = files_service.CanApplySuggestions(ctx, ctx.Doer, pull); err != nil {
+				ctx.ServerError("CanApplySuggestions", err)
+				return
+			}
 		}
 
 		ctx.Data["AllowMerge"] = allowMerge
 
+		stack, err := issues_model.GetPullRequestStack(ctx, pull)
+		if err != nil {
+			ctx.ServerError("GetPullRequestStack", err)
+			return
+		}
+		if len(stack) > 1 {
+			ctx.Data["PullRequestStack"] = stack
+		}
+
 		prUnit, err := repo.GetUnit(ctx, unit.TypePullRequests)
 		if err != nil {
 			ctx.ServerError("GetUnit", err)
@@ -1969,6 +2050,22 @@ func ViewIssue(ctx *context.Context) {
 			ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, pb, pull)
 			ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pull)
 			ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pb, pull)
+			if pb.RequireResolvedConversations {
+				unresolvedConversations, err := issues_model.CountUnresolvedCodeConversations(ctx, issue.ID)
+				if err != nil {
+					ctx.ServerError("CountUnresolvedCodeConversations", err)
+					return
+				}
+				ctx.Data["UnresolvedConversations"] = unresolvedConversations
+				ctx.Data["IsBlockedByUnresolvedConversations"] = unresolvedConversations > 0
+			}
+			missingCodeOwnersApprovals, err := pull_service.GetMissingCodeOwnersApprovals(ctx, pb, pull)
+			if err != nil {
+				ctx.ServerError("GetMissingCodeOwnersApprovals", err)
+				return
+			}
+			ctx.Data["MissingCodeOwnersApprovals"] = missingCodeOwnersApprovals
+			ctx.Data["IsBlockedByCodeOwners"] = len(missingCodeOwnersApprovals) > 0
 			ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pb, pull)
 			ctx.Data["RequireSigned"] = pb.RequireSignedCommits
 			ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
@@ -1976,6 +2073,32 @@ func ViewIssue(ctx *context.Context) {
 			ctx.Data["ChangedProtectedFilesNum"] = len(pull.ChangedProtectedFiles)
 			ctx.Data["ShowMergeInstructions"] = showMergeInstructions
 		}
+		// The enforced organization rulesets block the merge like the protection rule of the base branch
+		rulesetRules, err := git_model.GetEnforcedRulesetBranchRules(ctx, pull.BaseRepoID, pull.BaseBranch)
+		if err != nil {
+			ctx.ServerError("GetEnforcedRulesetBranchRules", err)
+			return
+		}
+		for _, rule := range rulesetRules {
+			isBlockedByApprovals := !issues_model.HasEnoughApprovals(ctx, rule, pull)
+			// the rule shown is the first one whose required approvals are missing
+			if ctx.Data["ProtectedBranch"] == nil || (isBlockedByApprovals && ctx.Data["IsBlockedByApprovals"] != true) {
+				ctx.Data["ProtectedBranch"] = rule
+				ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, rule, pull)
+			}
+			ctx.Data["IsBlockedByApprovals"] = isBlockedByApprovals || ctx.Data["IsBlockedByApprovals"] == true
+			ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, rule, pull) || ctx.Data["IsBlockedByRejection"] == true
+			ctx.Data["RequireSigned"] = rule.RequireSignedCommits || ctx.Data["RequireSigned"] == true
+			if ctx.Doer == nil || !rule.CanUserPush(ctx, ctx.Doer) {
+				ctx.Data["ShowMergeInstructions"] = false
+			}
+		}
+		reviewChecklistComplete, err := pull_service.IsReviewChecklistComplete(ctx, pull)
+		if err != nil {
+			ctx.ServerError("IsReviewChecklistComplete", err)
+			return
+		}
+		ctx.Data["IsBlockedByReviewChecklist"] = !reviewChecklistComplete
 		ctx.Data["WillSign"] = false
 		if ctx.Doer != nil {
 			sign, key, _, err := asymkey_service.SignMerge(ctx, pull, ctx.Doer, pull.BaseRepo.RepoPath(), pull.BaseBranch, pull.GetGitRefName())
@@ -2311,7 +2434,7 @@ func UpdateIssueContent(ctx *context.Context) {
 		return
 	}
 
-	if !ctx.IsSigned || (ctx.Doer.ID != issue.PosterID && !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)) {
+	if !ctx.IsSigned || (ctx.Doer.ID != issue.PosterID && !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)) || issue.IsHidden {
 		ctx.Error(http.StatusForbidden)
 		return
 	}
@@ -3285,7 +3408,7 @@ func UpdateCommentContent(ctx *context.Context) {
 		return
 	}
 
-	if !ctx.IsSigned || (ctx.Doer.ID != comment.PosterID && !ctx.Repo.CanWriteIssuesOrPulls(comment.Issue.IsPull)) {
+	if !ctx.IsSigned || (ctx.Doer.ID != comment.PosterID && !ctx.Repo.CanWriteIssuesOrPulls(comment.Issue.IsPull)) || comment.IsHidden {
 		ctx.Error(http.StatusForbidden)
 		return
 	}
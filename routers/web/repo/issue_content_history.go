@@ -46,6 +46,12 @@ func GetContentHistoryList(ctx *context.Context) {
 	}
 
 	commentID := ctx.FormInt64("comment_id")
+	if isContentHistoryHidden(ctx, issue, commentID) {
+		ctx.JSON(http.StatusNotFound, map[string]any{
+			"message": "Can not find the content history",
+		})
+		return
+	}
 	items, _ := issues_model.FetchIssueContentHistoryList(ctx, issue.ID, commentID)
 
 	// render history list to HTML for frontend dropdown items: (name, value)
@@ -85,6 +91,20 @@ func GetContentHistoryList(ctx *context.Context) {
 	})
 }
 
+// isContentHistoryHidden checks whether the history of an issue or a comment is hidden from the current user.
+// The content hidden by a moderator is shown to nobody, like when viewing the issue, moderators find it in the admin panel.
+func isContentHistoryHidden(ctx *context.Context, issue *issues_model.Issue, commentID int64) bool {
+	if commentID == 0 {
+		return issue.IsHidden
+	}
+	comment, err := issues_model.GetCommentByID(ctx, commentID)
+	if err != nil {
+		// a missing comment has no history to show
+		return !issues_model.IsErrCommentNotExist(err)
+	}
+	return comment.IssueID != issue.ID || comment.IsHidden
+}
+
 // canSoftDeleteContentHistory checks whether current user can soft-delete a history revision
 // Admins or owners can always delete history revisions. Normal users can only delete own history revisions.
 func canSoftDeleteContentHistory(ctx *context.Context, issue *issues_model.Issue, comment *issues_model.Comment,
@@ -124,7 +144,7 @@ func GetContentHistoryDetail(ctx *context.Context) {
 
 	historyID := ctx.FormInt64("history_id")
 	history, prevHistory, err := issues_model.GetIssueContentHistoryAndPrev(ctx, issue.ID, historyID)
-	if err != nil {
+	if err != nil || isContentHistoryHidden(ctx, issue, history.CommentID) {
 		ctx.JSON(http.StatusNotFound, map[string]any{
 			"message": "Can not find the content history",
 		})
@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentHistoryOfHiddenContent(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	require.NoError(t, issues_model.SaveIssueContentHistory(db.DefaultContext, 2, 1, 0, timeutil.TimeStampNow(), "secret issue", false))
+	require.NoError(t, issues_model.SaveIssueContentHistory(db.DefaultContext, 3, 1, 2, timeutil.TimeStampNow(), "secret comment", false))
+	issueHistory, err := issues_model.FetchIssueContentHistoryList(db.DefaultContext, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, issueHistory, 1)
+	commentHistory, err := issues_model.FetchIssueContentHistoryList(db.DefaultContext, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, commentHistory, 1)
+
+	_, err = db.GetEngine(db.DefaultContext).ID(1).Cols("is_hidden").Update(&issues_model.Issue{IsHidden: true})
+	require.NoError(t, err)
+	_, err = db.GetEngine(db.DefaultContext).ID(2).Cols("is_hidden").Update(&issues_model.Comment{IsHidden: true})
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		doerID int64
+		status int
+	}{
+		{2, http.StatusNotFound}, // the repository owner is not a moderator
+		{1, http.StatusNotFound}, // admins find the hidden content in the admin panel
+	} {
+		for _, commentID := range []int64{0, 2} {
+			ctx, _ := contexttest.MockContext(t, fmt.Sprintf("user2/repo1/issues/1/content-history/list?comment_id=%d", commentID))
+			contexttest.LoadUser(t, ctx, testCase.doerID)
+			contexttest.LoadRepo(t, ctx, 1)
+			ctx.SetParams(":index", "1")
+			GetContentHistoryList(ctx)
+			assert.Equal(t, testCase.status, ctx.Resp.Status(), "doer %d, comment %d", testCase.doerID, commentID)
+		}
+
+		for _, historyID := range []int64{issueHistory[0].HistoryID, commentHistory[0].HistoryID} {
+			ctx, _ := contexttest.MockContext(t, fmt.Sprintf("user2/repo1/issues/1/content-history/detail?history_id=%d", historyID))
+			contexttest.LoadUser(t, ctx, testCase.doerID)
+			contexttest.LoadRepo(t, ctx, 1)
+			ctx.SetParams(":index", "1")
+			GetContentHistoryDetail(ctx)
+			assert.Equal(t, testCase.status, ctx.Resp.Status(), "doer %d, history %d", testCase.doerID, historyID)
+		}
+	}
+}
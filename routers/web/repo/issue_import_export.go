@@ -0,0 +1,147 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+const tplIssueImport base.TplName = "repo/issue/import"
+
+// ExportIssues streams the issues and the pull requests of a repository the doer can read in CSV or JSON
+func ExportIssues(ctx *context.Context) {
+	format := ctx.FormString("format")
+	if format != issue_service.IssuesFormatCSV && format != issue_service.IssuesFormatJSON {
+		ctx.Error(http.StatusBadRequest, "unknown format")
+		return
+	}
+
+	opts := issue_service.ExportIssuesOptions{
+		IncludeIssues: ctx.Repo.CanRead(unit.TypeIssues),
+		IncludePulls:  ctx.Repo.CanRead(unit.TypePullRequests),
+	}
+	if ctx.Doer != nil {
+		opts.AllTrackedTimes = ctx.Doer.IsAdmin || ctx.IsUserRepoWriter([]unit.Type{unit.TypeIssues})
+		opts.TrackedTimesUserID = ctx.Doer.ID
+	}
+	switch ctx.FormString("type") {
+	case "issues":
+		opts.IncludePulls = false
+	case "pulls":
+		opts.IncludeIssues = false
+	}
+	switch ctx.FormString("state") {
+	case "open":
+		opts.IsClosed = optional.Some(false)
+	case "closed":
+		opts.IsClosed = optional.Some(true)
+	}
+
+	contentType := "text/csv"
+	if format == issue_service.IssuesFormatJSON {
+		contentType = "application/json"
+	}
+	ctx.SetServeHeaders(&context.ServeHeaderOptions{
+		ContentType:        contentType,
+		ContentTypeCharset: "utf-8",
+		Filename:           fmt.Sprintf("%s-%s-issues.%s", ctx.Repo.Owner.Name, ctx.Repo.Repository.Name, format),
+	})
+	if err := issue_service.ExportIssues(ctx, ctx.Resp, ctx.Repo.Repository, format, opts); err != nil {
+		// the export is partly written, it cannot be replaced by an error page
+		log.Error("ExportIssues [repo id: %d]: %v", ctx.Repo.Repository.ID, err)
+	}
+}
+
+// ImportIssues shows the form to import issues from a file
+func ImportIssues(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.issues.import")
+	ctx.Data["PageIsIssueList"] = true
+	ctx.Data["MaxImportedIssues"] = issue_service.MaxImportedIssues
+	ctx.HTML(http.StatusOK, tplIssueImport)
+}
+
+// ImportIssuesPost validates the issues of a file and shows them, or imports them once they
+// are confirmed
+func ImportIssuesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ImportIssuesForm)
+	ctx.Data["Title"] = ctx.Tr("repo.issues.import")
+	ctx.Data["PageIsIssueList"] = true
+	ctx.Data["MaxImportedIssues"] = issue_service.MaxImportedIssues
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplIssueImport)
+		return
+	}
+
+	// the file is validated first, and its content is sent back with the confirmation
+	confirmed := form.File == nil && form.Content != ""
+	var content []byte
+	format := form.Format
+	if confirmed {
+		content = []byte(form.Content)
+	} else {
+		if form.File == nil {
+			ctx.RenderWithErr(ctx.Tr("repo.issues.import.no_file"), tplIssueImport, form)
+			return
+		}
+		if form.File.Size > issue_service.MaxImportSize {
+			ctx.RenderWithErr(ctx.Tr("repo.issues.import.too_big", base.FileSize(issue_service.MaxImportSize)), tplIssueImport, form)
+			return
+		}
+		f, err := form.File.Open()
+		if err != nil {
+			ctx.ServerError("Open", err)
+			return
+		}
+		defer f.Close()
+		if content, err = io.ReadAll(f); err != nil {
+			ctx.ServerError("ReadAll", err)
+			return
+		}
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(path.Ext(form.File.Filename)), ".")
+		}
+	}
+
+	result, err := issue_service.ImportIssues(ctx, ctx.Doer, ctx.Repo.Repository, strings.NewReader(string(content)), issue_service.ImportIssuesOptions{
+		Format:        format,
+		CreateMissing: form.CreateMissing,
+		DryRun:        !confirmed,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("repo.issues.import.invalid", err.Error()), tplIssueImport, form)
+			return
+		}
+		ctx.ServerError("ImportIssues", err)
+		return
+	}
+
+	if result.Imported {
+		ctx.Flash.Success(ctx.Tr("repo.issues.import.success", len(result.Issues)))
+		ctx.Redirect(ctx.Repo.RepoLink + "/issues")
+		return
+	}
+
+	ctx.Data["ImportResult"] = result
+	ctx.Data["ImportContent"] = string(content)
+	ctx.Data["ImportFormat"] = format
+	ctx.Data["CreateMissing"] = form.CreateMissing
+	ctx.HTML(http.StatusOK, tplIssueImport)
+}
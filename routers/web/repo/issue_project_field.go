@@ -0,0 +1,71 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+type issueProjectField struct {
+	*project_model.Field
+	Value string
+}
+
+// retrieveIssueProjectFields loads the custom fields of the project of an issue and their values for its sidebar
+func retrieveIssueProjectFields(ctx *context.Context, issue *issues_model.Issue) {
+	if err := issue.LoadProject(ctx); err != nil {
+		ctx.ServerError("LoadProject", err)
+		return
+	}
+	if issue.Project == nil {
+		return
+	}
+
+	fields, err := issue.Project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return
+	}
+	values, err := project_model.GetIssueFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueFieldValues", err)
+		return
+	}
+
+	issueFields := make([]*issueProjectField, 0, len(fields))
+	for _, field := range fields {
+		issueFields = append(issueFields, &issueProjectField{Field: field, Value: values[field.ID]})
+	}
+	ctx.Data["IssueProjectFields"] = issueFields
+}
+
+// UpdateIssueProjectField sets or removes the value of a custom field of the project of an issue
+func UpdateIssueProjectField(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	field, err := project_model.GetFieldByID(ctx, ctx.ParamsInt64(":fieldID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetFieldByID", project_model.IsErrProjectFieldNotExist, err)
+		return
+	}
+
+	if err := issue_service.SetProjectFieldValue(ctx, ctx.Doer, issue, field, ctx.FormString("value")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(ctx.Tr("repo.issues.project_fields.invalid_value", field.Name))
+			return
+		}
+		ctx.ServerError("SetProjectFieldValue", err)
+		return
+	}
+	ctx.JSONRedirect(issue.Link())
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// retrieveSubIssues loads the parent issue and the tree of sub-issues of an issue shown in the sidebar
+func retrieveSubIssues(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+
+	parentID, err := issues_model.GetParentIssueID(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetParentIssueID", err)
+		return
+	}
+	if parentID > 0 {
+		parent, err := issues_model.GetIssueByID(ctx, parentID)
+		if err != nil {
+			ctx.ServerError("GetIssueByID", err)
+			return
+		}
+		if err := parent.LoadRepo(ctx); err != nil {
+			ctx.ServerError("LoadRepo", err)
+			return
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, parent.Repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
+		}
+		if perm.CanReadIssuesOrPulls(parent.IsPull) {
+			ctx.Data["ParentIssue"] = parent
+		}
+	}
+
+	tree, err := issue_service.GetReadableSubIssueTree(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.ServerError("GetReadableSubIssueTree", err)
+		return
+	}
+	ctx.Data["SubIssueTree"] = tree
+	ctx.Data["SubIssueProgress"] = tree.Progress()
+	ctx.Data["CanEditSubIssues"] = ctx.Repo.CanWriteIssuesOrPulls(false) && !ctx.Repo.Repository.IsArchived
+}
+
+// AddSubIssue makes the issue referenced in the form the last sub-issue of an issue
+func AddSubIssue(ctx *context.Context) {
+	parent := getEditableSubIssueParent(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssue := getSubIssueByReference(ctx, strings.TrimSpace(ctx.FormString("sub_issue")))
+	if ctx.Written() {
+		return
+	}
+	if subIssue == nil {
+		ctx.Redirect(parent.Link())
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_has_parent"))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_circular"))
+		case issues_model.IsErrSubIssueOtherOwner(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_other_owner"))
+		case issues_model.IsErrSubIssueTooDeep(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_too_deep", issues_model.MaxSubIssueDepth))
+		case subIssue.IsPull:
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_pull"))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+			return
+		}
+	}
+	ctx.Redirect(parent.Link())
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of an issue
+func RemoveSubIssue(ctx *context.Context) {
+	parent := getEditableSubIssueParent(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("sub_issue_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueByID", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if !issues_model.IsErrSubIssueNotExist(err) {
+			ctx.ServerError("RemoveSubIssue", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.remove_error_not_exist"))
+	}
+	ctx.Redirect(parent.Link())
+}
+
+// MoveSubIssue moves a sub-issue to another position among the sub-issues of an issue
+func MoveSubIssue(ctx *context.Context) {
+	parent := getEditableSubIssueParent(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("sub_issue_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueByID", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	if err := issues_model.MoveSubIssue(ctx, parent, subIssue, ctx.FormInt("position")); err != nil {
+		ctx.NotFoundOrServerError("MoveSubIssue", issues_model.IsErrSubIssueNotExist, err)
+		return
+	}
+	ctx.Redirect(parent.Link())
+}
+
+func getEditableSubIssueParent(ctx *context.Context) *issues_model.Issue {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.Error(http.StatusForbidden)
+		return nil
+	}
+	return issue
+}
+
+// getSubIssueByReference returns the issue referenced as #index, repo#index or owner/repo#index if
+// the doer can change its sub-issues, or adds a flash error
+func getSubIssueByReference(ctx *context.Context, ref string) *issues_model.Issue {
+	repoName, indexStr, ok := strings.Cut(ref, "#")
+	index, err := strconv.ParseInt(indexStr, 10, 64)
+	if !ok || err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_bad_reference", ref))
+		return nil
+	}
+
+	repo := ctx.Repo.Repository
+	if repoName != "" {
+		ownerName := repo.OwnerName
+		if owner, name, ok := strings.Cut(repoName, "/"); ok {
+			ownerName, repoName = owner, name
+		}
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if !repo_model.IsErrRepoNotExist(err) {
+				ctx.ServerError("GetRepositoryByOwnerAndName", err)
+				return nil
+			}
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_not_exist", ref))
+			return nil
+		}
+	}
+
+	subIssue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if err != nil {
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.ServerError("GetIssueByIndex", err)
+			return nil
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_not_exist", ref))
+		return nil
+	}
+	subIssue.Repo = repo
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return nil
+	}
+	if !perm.CanReadIssuesOrPulls(subIssue.IsPull) {
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_not_exist", ref))
+		return nil
+	}
+	if repo.IsArchived || !perm.CanWriteIssuesOrPulls(subIssue.IsPull) {
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_no_permission", ref))
+		return nil
+	}
+	return subIssue
+}
@@ -23,8 +23,10 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
+	shared_project "forgejo.org/routers/web/shared/project"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
 )
 
 const (
@@ -363,6 +365,11 @@ func ViewProject(ctx *context.Context) {
 	}
 	ctx.Data["LinkedPRs"] = linkedPrsMap
 
+	shared_project.RetrieveFieldValues(ctx, project, issuesMap)
+	if ctx.Written() {
+		return
+	}
+
 	project.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
@@ -407,11 +414,11 @@ func UpdateIssueProject(ctx *context.Context) {
 		if issue.Project != nil && issue.Project.ID == projectID {
 			continue
 		}
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
+		if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
 			if errors.Is(err, util.ErrPermissionDenied) {
 				continue
 			}
-			ctx.ServerError("IssueAssignOrRemoveProject", err)
+			ctx.ServerError("AssignOrRemoveProject", err)
 			return
 		}
 	}
@@ -675,7 +682,7 @@ func MoveIssues(ctx *context.Context) {
 		}
 	}
 
-	if err = project_model.MoveIssuesOnProjectColumn(ctx, column, sortedIssueIDs); err != nil {
+	if err = issue_service.MoveIssuesOnProjectColumn(ctx, ctx.Doer, column, sortedIssueIDs); err != nil {
 		ctx.ServerError("MoveIssuesOnProjectColumn", err)
 		return
 	}
@@ -12,6 +12,7 @@ import (
 	"html"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -32,7 +33,6 @@ import (
 	"forgejo.org/modules/emoji"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/gitrepo"
-	issue_template "forgejo.org/modules/issue/template"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
@@ -46,9 +46,11 @@ import (
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/gitdiff"
+	issue_service "forgejo.org/services/issue"
 	notify_service "forgejo.org/services/notify"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
+	files_service "forgejo.org/services/repository/files"
 
 	"github.com/gobwas/glob"
 )
@@ -552,7 +554,22 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 		ctx.ServerError("LoadProtectedBranch", err)
 		return nil
 	}
-	ctx.Data["EnableStatusCheck"] = pb != nil && pb.EnableStatusCheck
+	rules, err := git_model.GetEnforcedRulesetBranchRules(ctx, repo.ID, pull.BaseBranch)
+	if err != nil {
+		ctx.ServerError("GetEnforcedRulesetBranchRules", err)
+		return nil
+	}
+	if pb != nil {
+		rules = append(git_model.ProtectedBranchRules{pb}, rules...)
+	}
+	// The status checks are required by the protection rule of the base branch and by the organization rulesets
+	var statusCheckRules git_model.ProtectedBranchRules
+	for _, rule := range rules {
+		if rule.EnableStatusCheck {
+			statusCheckRules = append(statusCheckRules, rule)
+		}
+	}
+	ctx.Data["EnableStatusCheck"] = len(statusCheckRules) > 0
 
 	var baseGitRepo *git.Repository
 	if pull.BaseRepoID == ctx.Repo.Repository.ID && ctx.Repo.GitRepo != nil {
@@ -686,26 +703,43 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 		ctx.Data["LatestCommitStatus"] = git_model.CalcCommitStatus(commitStatuses)
 	}
 
-	if pb != nil && pb.EnableStatusCheck {
-		var missingRequiredChecks []string
-		for _, requiredContext := range pb.StatusCheckContexts {
-			contextFound := false
-			matchesRequiredContext := createRequiredContextMatcher(requiredContext)
-			for _, presentStatus := range commitStatuses {
-				if matchesRequiredContext(presentStatus.Context) {
-					contextFound = true
-					break
+	if len(statusCheckRules) > 0 {
+		var missingRequiredChecks, requiredContexts []string
+		requiredStatusCheckState := structs.CommitStatusSuccess
+		for _, rule := range statusCheckRules {
+			requiredStatuses := commitStatuses
+			if len(rule.StatusCheckSources) > 0 {
+				requiredStatuses, err = pull_service.GetRequiredCommitStatuses(ctx, repo.ID, sha, rule)
+				if err != nil {
+					ctx.ServerError("GetRequiredCommitStatuses", err)
+					return nil
+				}
+			}
+
+			for _, requiredContext := range rule.StatusCheckContexts {
+				contextFound := false
+				matchesRequiredContext := createRequiredContextMatcher(requiredContext)
+				for _, presentStatus := range requiredStatuses {
+					if matchesRequiredContext(presentStatus.Context) {
+						contextFound = true
+						break
+					}
+				}
+
+				if !contextFound && !slices.Contains(missingRequiredChecks, requiredContext) {
+					missingRequiredChecks = append(missingRequiredChecks, requiredContext)
 				}
 			}
+			requiredContexts = append(requiredContexts, rule.StatusCheckContexts...)
 
-			if !contextFound {
-				missingRequiredChecks = append(missingRequiredChecks, requiredContext)
+			if state := pull_service.MergeRequiredContextsCommitStatus(requiredStatuses, rule.StatusCheckContexts); state.NoBetterThan(requiredStatusCheckState) {
+				requiredStatusCheckState = state
 			}
 		}
 		ctx.Data["MissingRequiredChecks"] = missingRequiredChecks
 
 		ctx.Data["is_context_required"] = func(context string) bool {
-			for _, c := range pb.StatusCheckContexts {
+			for _, c := range requiredContexts {
 				if c == context {
 					return true
 				}
@@ -720,7 +754,7 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 			}
 			return false
 		}
-		ctx.Data["RequiredStatusCheckState"] = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts)
+		ctx.Data["RequiredStatusCheckState"] = requiredStatusCheckState
 	}
 
 	ctx.Data["HeadBranchMovedOn"] = headBranchSha != sha
@@ -1063,6 +1097,10 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 			ctx.ServerError("CanMarkConversation", err)
 			return
 		}
+		if ctx.Data["CanApplySuggestions"], err = files_service.CanApplySuggestions(ctx, ctx.Doer, pull); err != nil {
+			ctx.ServerError("CanApplySuggestions", err)
+			return
+		}
 	}
 
 	setCompareContext(ctx, baseCommit, commit, ctx.Repo.Owner.Name, ctx.Repo.Repository.Name)
@@ -1099,6 +1137,13 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 	ctx.Data["CurrentReview"] = currentReview
 	ctx.Data["PendingCodeCommentNumber"] = numPendingCodeComments
 
+	reviewChecklist, err := pull_service.GetReviewChecklist(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetReviewChecklist", err)
+		return
+	}
+	ctx.Data["ReviewChecklist"] = reviewChecklist
+
 	getBranchData(ctx, issue)
 	ctx.Data["IsIssuePoster"] = ctx.IsSigned && issue.IsPoster(ctx.Doer.ID)
 	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
@@ -1258,6 +1303,8 @@ func MergePullRequest(ctx *context.Context) {
 			ctx.JSONError(err.Error()) // has no translation ...
 		case errors.Is(err, pull_service.ErrDependenciesLeft):
 			ctx.JSONError(ctx.Tr("repo.issues.dependency.pr_close_blocked"))
+		case errors.Is(err, pull_service.ErrReviewChecklistNotComplete):
+			ctx.JSONError(ctx.Tr("repo.pulls.blocked_by_review_checklist"))
 		default:
 			ctx.ServerError("WebCheck", err)
 		}
@@ -1499,10 +1546,14 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	}
 
 	content := form.Content
-	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+	if issueForm := submitIssueForm(ctx, true); issueForm != nil {
+		content = issueForm.Content
+		labelIDs, assigneeIDs, milestoneID, projectID = issueForm.MergeMetas(labelIDs, assigneeIDs, milestoneID, projectID)
+		if setting.Attachment.Enabled {
+			attachments = append(attachments, issueForm.Attachments...)
 		}
+	} else if ctx.Written() {
+		return
 	}
 
 	pullIssue := &issues_model.Issue{
@@ -1563,9 +1614,9 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	}
 
 	if projectID > 0 && ctx.Repo.CanWrite(unit.TypeProjects) {
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, pullIssue, ctx.Doer, projectID, 0); err != nil {
+		if err := issue_service.AssignOrRemoveProject(ctx, pullIssue, ctx.Doer, projectID, 0); err != nil {
 			if !errors.Is(err, util.ErrPermissionDenied) {
-				ctx.ServerError("IssueAssignOrRemoveProject", err)
+				ctx.ServerError("AssignOrRemoveProject", err)
 				return
 			}
 		}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	pull_service "forgejo.org/services/pull"
+)
+
+const tplPullRangeDiff base.TplName = "repo/pulls/range_diff"
+
+// ViewPullRangeDiff render the range diff between two revisions of a pull request
+func ViewPullRangeDiff(ctx *context.Context) {
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["PageIsPullRangeDiff"] = true
+
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	pull := issue.PullRequest
+
+	if pull.HasMerged {
+		PrepareMergedViewPullInfo(ctx, issue)
+	} else {
+		PrepareViewPullInfo(ctx, issue)
+	}
+	if ctx.Written() {
+		return
+	}
+
+	revisions, err := issues_model.GetPullRequestRevisions(ctx, pull)
+	if err != nil {
+		ctx.ServerError("GetPullRequestRevisions", err)
+		return
+	}
+	oldRevision := issues_model.GetPullRequestRevision(revisions, ctx.Params("shaFrom"))
+	newRevision := issues_model.GetPullRequestRevision(revisions, ctx.Params("shaTo"))
+	if oldRevision == nil || newRevision == nil {
+		ctx.NotFound("GetPullRequestRevision", nil)
+		return
+	}
+	ctx.Data["Revisions"] = revisions
+	ctx.Data["OldRevision"] = oldRevision
+	ctx.Data["NewRevision"] = newRevision
+
+	entries, err := pull_service.GetRangeDiff(ctx, ctx.Repo.GitRepo, pull, oldRevision.HeadCommitID, newRevision.HeadCommitID)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			ctx.ServerError("GetRangeDiff", err)
+			return
+		}
+		ctx.Data["RangeDiffError"] = err.Error()
+	}
+	ctx.Data["RangeDiff"] = entries
+
+	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
+	ctx.Data["IsIssuePoster"] = ctx.IsSigned && issue.IsPoster(ctx.Doer.ID)
+
+	PrepareBranchList(ctx)
+	if ctx.Written() {
+		return
+	}
+	getBranchData(ctx, issue)
+	ctx.HTML(http.StatusOK, tplPullRangeDiff)
+}
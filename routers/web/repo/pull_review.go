@@ -7,18 +7,23 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"forgejo.org/models"
 	issues_model "forgejo.org/models/issues"
 	pull_model "forgejo.org/models/pull"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/json"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/services/context"
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/forms"
 	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
 )
 
 const (
@@ -72,9 +77,10 @@ func CreateCodeComment(ctx *context.Context) {
 		return
 	}
 
-	signedLine := form.Line
+	signedLine, signedStartLine := form.Line, form.StartLine
 	if form.Side == "previous" {
 		signedLine *= -1
+		signedStartLine *= -1
 	}
 
 	var attachments []string
@@ -105,6 +111,7 @@ func CreateCodeComment(ctx *context.Context) {
 		ctx.Repo.GitRepo,
 		issue,
 		signedLine,
+		signedStartLine,
 		form.Content,
 		form.TreePath,
 		pendingReview,
@@ -113,6 +120,11 @@ func CreateCodeComment(ctx *context.Context) {
 		attachments,
 	)
 	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(fmt.Sprintf("%s/pulls/%d/files", ctx.Repo.RepoLink, issue.Index))
+			return
+		}
 		ctx.ServerError("CreateCodeComment", err)
 		return
 	}
@@ -211,6 +223,10 @@ func renderConversation(ctx *context.Context, comment *issues_model.Comment, ori
 		return
 	}
 	ctx.Data["AfterCommitID"] = pullHeadCommitID
+	if ctx.Data["CanApplySuggestions"], err = files_service.CanApplySuggestions(ctx, ctx.Doer, comment.Issue.PullRequest); err != nil {
+		ctx.ServerError("CanApplySuggestions", err)
+		return
+	}
 	if origin == "diff" {
 		ctx.HTML(http.StatusOK, tplDiffConversation)
 	} else if origin == "timeline" {
@@ -218,6 +234,49 @@ func renderConversation(ctx *context.Context, comment *issues_model.Comment, ori
 	}
 }
 
+// ApplySuggestions commits one or a batch of suggestions of code comments to the head branch of a pull request
+func ApplySuggestions(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ApplySuggestionsForm)
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	redirect := issue.Link() + "/files"
+	if form.Origin == "timeline" {
+		redirect = issue.Link()
+	}
+
+	refs := make([]files_service.SuggestionRef, 0, len(form.Suggestions))
+	for _, suggestion := range form.Suggestions {
+		commentID, index, _ := strings.Cut(suggestion, "-")
+		ref := files_service.SuggestionRef{Index: -1}
+		ref.CommentID, _ = strconv.ParseInt(commentID, 10, 64)
+		if i, err := strconv.Atoi(index); err == nil {
+			ref.Index = i
+		}
+		refs = append(refs, ref)
+	}
+
+	if _, err := files_service.ApplySuggestions(ctx, ctx.Doer, issue.PullRequest, refs, form.Message); err != nil {
+		switch {
+		case errors.Is(err, util.ErrPermissionDenied), models.IsErrUserCannotCommit(err), models.IsErrFilePathProtected(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.apply_not_allowed"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.apply_failed", err.Error()))
+		case models.IsErrSHADoesNotMatch(err), models.IsErrCommitIDDoesNotMatch(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.head_changed"))
+		default:
+			ctx.ServerError("ApplySuggestions", err)
+			return
+		}
+		ctx.Redirect(redirect)
+		return
+	}
+
+	ctx.Flash.Success(ctx.TrN(len(refs), "repo.pulls.suggestion.applied_1", "repo.pulls.suggestion.applied_n", len(refs)))
+	ctx.Redirect(redirect)
+}
+
 // SubmitReview creates a review out of the existing pending review or creates a new one if no pending review exist
 func SubmitReview(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.SubmitReviewForm)
@@ -261,11 +320,14 @@ func SubmitReview(ctx *context.Context) {
 		attachments = form.Files
 	}
 
-	_, comm, err := pull_service.SubmitReview(ctx, ctx.Doer, ctx.Repo.GitRepo, issue, reviewType, form.Content, form.CommitID, attachments)
+	_, comm, err := pull_service.SubmitReview(ctx, ctx.Doer, ctx.Repo.GitRepo, issue, reviewType, form.Content, form.CommitID, form.Checklist, attachments)
 	if err != nil {
 		if issues_model.IsContentEmptyErr(err) {
 			ctx.Flash.Error(ctx.Tr("repo.issues.review.content.empty"))
 			ctx.JSONRedirect(fmt.Sprintf("%s/pulls/%d/files", ctx.Repo.RepoLink, issue.Index))
+		} else if pull_service.IsErrReviewChecklistIncomplete(err) {
+			ctx.Flash.Error(ctx.Tr("repo.issues.review.checklist.incomplete"))
+			ctx.JSONRedirect(fmt.Sprintf("%s/pulls/%d/files", ctx.Repo.RepoLink, issue.Index))
 		} else {
 			ctx.ServerError("SubmitReview", err)
 		}
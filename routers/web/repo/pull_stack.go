@@ -0,0 +1,59 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/log"
+	"forgejo.org/services/context"
+	pull_service "forgejo.org/services/pull"
+)
+
+// MergePullRequestStack merges in order the pull requests of the stack of a pull request up to it
+func MergePullRequestStack(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		ctx.NotFound("MergePullRequestStack", nil)
+		return
+	}
+
+	mergeStyle := repo_model.MergeStyle(ctx.FormString("do"))
+	if mergeStyle == "" {
+		mergeStyle = ctx.Repo.Repository.MustGetUnit(ctx, unit.TypePullRequests).PullRequestsConfig().GetDefaultMergeStyle()
+	}
+
+	merged, err := pull_service.MergeStack(ctx, ctx.Doer, ctx.Repo.GitRepo, issue.PullRequest, mergeStyle)
+	if err != nil {
+		log.Error("MergeStack %-v: %v", issue.PullRequest, err)
+		ctx.Flash.Error(ctx.Tr("repo.pulls.stack.merge_failed", merged, err.Error()))
+	} else {
+		ctx.Flash.Success(ctx.Tr("repo.pulls.stack.merged", merged))
+	}
+	ctx.Redirect(issue.Link())
+}
+
+// RebasePullRequestStack rebases the pull requests stacked on a pull request
+func RebasePullRequestStack(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		ctx.NotFound("RebasePullRequestStack", nil)
+		return
+	}
+
+	rebased, err := pull_service.RebaseStack(ctx, ctx.Doer, issue.PullRequest)
+	if err != nil {
+		log.Error("RebaseStack %-v: %v", issue.PullRequest, err)
+		ctx.Flash.Error(ctx.Tr("repo.pulls.stack.rebase_failed", rebased, err.Error()))
+	} else {
+		ctx.Flash.Success(ctx.Tr("repo.pulls.stack.rebased", rebased))
+	}
+	ctx.Redirect(issue.Link())
+}
@@ -16,6 +16,7 @@ import (
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/repo"
 	"forgejo.org/services/context"
@@ -81,6 +82,11 @@ func SettingsProtectedBranch(c *context.Context) {
 	c.Data["merge_whitelist_users"] = strings.Join(base.Int64sToStrings(rule.MergeWhitelistUserIDs), ",")
 	c.Data["approvals_whitelist_users"] = strings.Join(base.Int64sToStrings(rule.ApprovalsWhitelistUserIDs), ",")
 	c.Data["status_check_contexts"] = strings.Join(rule.StatusCheckContexts, "\n")
+	statusCheckSources := make([]string, 0, len(rule.StatusCheckSources))
+	for _, source := range rule.StatusCheckSources {
+		statusCheckSources = append(statusCheckSources, git_model.FormatStatusCheckSource(c, source))
+	}
+	c.Data["status_check_sources"] = strings.Join(statusCheckSources, "\n")
 	contexts, _ := git_model.FindRepoRecentCommitStatusContexts(c, c.Repo.Repository.ID, 7*24*time.Hour) // Find last week status check contexts
 	c.Data["recent_status_checks"] = contexts
 
@@ -215,8 +221,29 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			return
 		}
 		protectBranch.StatusCheckContexts = validPatterns
+
+		lines := strings.Split(strings.ReplaceAll(f.StatusCheckSources, "\r", "\n"), "\n")
+		sources := make([]*git_model.StatusCheckSource, 0, len(lines))
+		for _, line := range lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			source, err := git_model.ParseStatusCheckSource(ctx, line)
+			if err != nil {
+				if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+					ctx.Flash.Error(ctx.Tr("repo.settings.protect_invalid_status_check_source", err.Error()))
+					ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, url.QueryEscape(protectBranch.RuleName)))
+					return
+				}
+				ctx.ServerError("ParseStatusCheckSource", err)
+				return
+			}
+			sources = append(sources, source)
+		}
+		protectBranch.StatusCheckSources = sources
 	} else {
 		protectBranch.StatusCheckContexts = nil
+		protectBranch.StatusCheckSources = nil
 	}
 
 	protectBranch.RequiredApprovals = f.RequiredApprovals
@@ -237,6 +264,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.ProtectedFilePatterns = f.ProtectedFilePatterns
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
+	protectBranch.RequireResolvedConversations = f.RequireResolvedConversations
+	protectBranch.RequireCodeOwnerApproval = f.RequireCodeOwnerApproval
 	protectBranch.ApplyToAdmins = f.ApplyToAdmins
 
 	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
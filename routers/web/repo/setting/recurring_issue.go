@@ -0,0 +1,179 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+const tplRecurringIssues base.TplName = "repo/settings/recurring_issues"
+
+func setRecurringIssuesData(ctx *context.Context, pageType string) {
+	ctx.Data["PageIsSettingsRecurringIssues"] = true
+	ctx.Data["PageType"] = pageType
+	ctx.Data["RecurringIssuesLink"] = ctx.Repo.RepoLink + "/settings/recurring_issues"
+}
+
+// RecurringIssues shows the recurring issues of a repository
+func RecurringIssues(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("recurring_issues.title")
+	setRecurringIssuesData(ctx, "list")
+
+	rs, err := db.Find[issues_model.RecurringIssue](ctx, issues_model.FindRecurringIssuesOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindRecurringIssues", err)
+		return
+	}
+	ctx.Data["RecurringIssues"] = rs
+	ctx.HTML(http.StatusOK, tplRecurringIssues)
+}
+
+// NewRecurringIssue shows the form to create a recurring issue
+func NewRecurringIssue(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("recurring_issues.new")
+	setRecurringIssuesData(ctx, "edit")
+	ctx.Data["RecurringIssue"] = &issues_model.RecurringIssue{Schedule: "@weekly", IsActive: true}
+	ctx.HTML(http.StatusOK, tplRecurringIssues)
+}
+
+// NewRecurringIssuePost creates a recurring issue
+func NewRecurringIssuePost(ctx *context.Context) {
+	r := &issues_model.RecurringIssue{
+		RepoID:    ctx.Repo.Repository.ID,
+		CreatorID: ctx.Doer.ID,
+	}
+	if !saveRecurringIssue(ctx, r) {
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("recurring_issues.create_success", r.Title))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/recurring_issues")
+}
+
+func getRecurringIssue(ctx *context.Context) *issues_model.RecurringIssue {
+	r, err := issues_model.GetRecurringIssueByRepoID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetRecurringIssueByRepoID", issues_model.IsErrRecurringIssueNotExist, err)
+		return nil
+	}
+	return r
+}
+
+// EditRecurringIssue shows the form to edit a recurring issue
+func EditRecurringIssue(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("recurring_issues.edit")
+	setRecurringIssuesData(ctx, "edit")
+
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["RecurringIssue"] = r
+	ctx.Data["IsEditRecurringIssue"] = true
+	ctx.HTML(http.StatusOK, tplRecurringIssues)
+}
+
+// EditRecurringIssuePost updates a recurring issue
+func EditRecurringIssuePost(ctx *context.Context) {
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["IsEditRecurringIssue"] = true
+	if !saveRecurringIssue(ctx, r) {
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("recurring_issues.update_success", r.Title))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/recurring_issues")
+}
+
+// DeleteRecurringIssue deletes a recurring issue
+func DeleteRecurringIssue(ctx *context.Context) {
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteRecurringIssue(ctx, r.ID); err != nil {
+		ctx.ServerError("DeleteRecurringIssue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("recurring_issues.delete_success", r.Title))
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/recurring_issues")
+}
+
+// RecurringIssueHistory shows the issues created by a recurring issue
+func RecurringIssueHistory(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("recurring_issues.history")
+	setRecurringIssuesData(ctx, "history")
+
+	r := getRecurringIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["RecurringIssue"] = r
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	runs, count, err := db.FindAndCount[issues_model.RecurringIssueRun](ctx, issues_model.FindRecurringIssueRunsOptions{
+		ListOptions:      db.ListOptions{Page: page, PageSize: 50},
+		RecurringIssueID: r.ID,
+	})
+	if err != nil {
+		ctx.ServerError("FindRecurringIssueRuns", err)
+		return
+	}
+	if err := issues_model.LoadRecurringIssueRunIssues(ctx, runs); err != nil {
+		ctx.ServerError("LoadRecurringIssueRunIssues", err)
+		return
+	}
+	ctx.Data["RecurringIssueRuns"] = runs
+
+	pager := context.NewPagination(int(count), 50, page, 5)
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tplRecurringIssues)
+}
+
+// saveRecurringIssue sets a recurring issue from the form and saves it, and renders the form again with
+// the error if it is invalid
+func saveRecurringIssue(ctx *context.Context, r *issues_model.RecurringIssue) bool {
+	form := web.GetForm(ctx).(*forms.RecurringIssueForm)
+
+	r.Title = strings.TrimSpace(form.Title)
+	r.Content = form.Content
+	r.Labels = splitTriageList(form.Labels)
+	r.Assignees = splitTriageList(form.Assignees)
+	r.Schedule = strings.TrimSpace(form.Schedule)
+	r.SkipIfOpen = form.SkipIfOpen
+	r.IsActive = form.IsActive
+	ctx.Data["RecurringIssue"] = r
+
+	if ctx.HasError() {
+		setRecurringIssuesData(ctx, "edit")
+		ctx.HTML(http.StatusOK, tplRecurringIssues)
+		return false
+	}
+
+	if err := issue_service.SaveRecurringIssue(ctx, ctx.Repo.Repository, r); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("SaveRecurringIssue", err)
+			return false
+		}
+		setRecurringIssuesData(ctx, "edit")
+		ctx.RenderWithErr(ctx.Tr("recurring_issues.invalid", err.Error()), tplRecurringIssues, form)
+		return false
+	}
+	return true
+}
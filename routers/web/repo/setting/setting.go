@@ -8,7 +8,9 @@ package setting
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -69,6 +71,7 @@ func SettingsCtxData(ctx *context.Context) {
 	ctx.Data["SigningKeyAvailable"] = len(signing) > 0
 	ctx.Data["SigningSettings"] = setting.Repository.Signing
 	ctx.Data["CodeIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["MaxPushedBlobSizeMiB"] = maxPushedBlobSizeMiB(ctx.Repo.Repository)
 
 	if ctx.Doer.IsAdmin {
 		if setting.Indexer.RepoIndexerEnabled {
@@ -251,6 +254,12 @@ func UnitsPost(ctx *context.Context) {
 	}
 
 	if form.EnablePulls && !unit_model.TypePullRequests.UnitGlobalDisabled() {
+		var reviewChecklist []string
+		for _, item := range strings.Split(strings.ReplaceAll(form.PullsReviewChecklist, "\r", "\n"), "\n") {
+			if item = strings.TrimSpace(item); item != "" && !slices.Contains(reviewChecklist, item) {
+				reviewChecklist = append(reviewChecklist, item)
+			}
+		}
 		units = append(units, repo_model.RepoUnit{
 			RepoID: repo.ID,
 			Type:   unit_model.TypePullRequests,
@@ -268,6 +277,7 @@ func UnitsPost(ctx *context.Context) {
 				DefaultMergeStyle:             repo_model.MergeStyle(form.PullsDefaultMergeStyle),
 				DefaultUpdateStyle:            repo_model.UpdateStyle(form.PullsDefaultUpdateStyle),
 				DefaultAllowMaintainerEdit:    form.DefaultAllowMaintainerEdit,
+				ReviewChecklist:               reviewChecklist,
 			},
 		})
 	} else if !unit_model.TypePullRequests.UnitGlobalDisabled() {
@@ -316,6 +326,7 @@ func SettingsPost(ctx *context.Context) {
 	ctx.Data["SigningKeyAvailable"] = len(signing) > 0
 	ctx.Data["SigningSettings"] = setting.Repository.Signing
 	ctx.Data["CodeIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["MaxPushedBlobSizeMiB"] = maxPushedBlobSizeMiB(ctx.Repo.Repository)
 
 	repo := ctx.Repo.Repository
 
@@ -738,6 +749,32 @@ func SettingsPost(ctx *context.Context) {
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
 		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
 
+	case "push_limits":
+		if form.MaxPushedBlobSize < 0 {
+			ctx.Flash.Error(ctx.Tr("repo.settings.max_pushed_blob_size.invalid"))
+			ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+			return
+		}
+		// the limit is stored in bytes, which must fit in an int64
+		if form.MaxPushedBlobSize > math.MaxInt64/(1024*1024) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.max_pushed_blob_size.too_large"))
+			ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+			return
+		}
+
+		// the limit is stored in bytes, it is only overwritten if the value shown in MiB was changed
+		if form.MaxPushedBlobSize != maxPushedBlobSizeMiB(repo) {
+			repo.MaxPushedBlobSize = form.MaxPushedBlobSize * 1024 * 1024
+			if err := repo_service.UpdateRepository(ctx, repo, false); err != nil {
+				ctx.ServerError("UpdateRepository", err)
+				return
+			}
+		}
+		log.Trace("Repository push limits updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
 	case "admin":
 		if !ctx.Doer.IsAdmin {
 			ctx.Error(http.StatusForbidden)
@@ -1116,3 +1153,9 @@ func selectPushMirrorByForm(ctx *context.Context, form *forms.RepoSettingForm, r
 
 	return nil, fmt.Errorf("PushMirror[%v] not associated to repository %v", id, repo)
 }
+
+// maxPushedBlobSizeMiB returns the pushed blob size limit of a repository in MiB as it is edited on the web,
+// rounded up so that a limit set in bytes through the API is not shown as unlimited
+func maxPushedBlobSizeMiB(repo *repo_model.Repository) int64 {
+	return (repo.MaxPushedBlobSize + 1024*1024 - 1) / (1024 * 1024)
+}
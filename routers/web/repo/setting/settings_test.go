@@ -4,6 +4,7 @@
 package setting
 
 import (
+	"math"
 	"net/http"
 	"testing"
 
@@ -410,3 +411,35 @@ func TestDeleteTeam(t *testing.T) {
 
 	assert.False(t, repo_service.HasRepository(db.DefaultContext, team, re.ID))
 }
+
+func TestSettingsPostPushLimits(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	// a limit of 1.5 MiB set through the API
+	_, err := db.GetEngine(db.DefaultContext).ID(1).Cols("max_pushed_blob_size").Update(&repo_model.Repository{MaxPushedBlobSize: 3 * 512 * 1024})
+	require.NoError(t, err)
+
+	post := func(maxPushedBlobSize int64) *repo_model.Repository {
+		ctx, _ := contexttest.MockContext(t, "POST user2/repo1/settings?action=push_limits")
+		contexttest.LoadUser(t, ctx, 2)
+		contexttest.LoadRepo(t, ctx, 1)
+		web.SetForm(ctx, &forms.RepoSettingForm{MaxPushedBlobSize: maxPushedBlobSize})
+		SettingsPost(ctx)
+		assert.Equal(t, http.StatusSeeOther, ctx.Resp.Status())
+		return unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	}
+
+	// the limit is shown rounded up to 2 MiB and is kept if it is not changed
+	repo := post(2)
+	assert.EqualValues(t, 3*512*1024, repo.MaxPushedBlobSize)
+
+	repo = post(3)
+	assert.EqualValues(t, 3*1024*1024, repo.MaxPushedBlobSize)
+
+	repo = post(0)
+	assert.Zero(t, repo.MaxPushedBlobSize)
+
+	// a limit which overflows once converted to bytes is rejected
+	repo = post(math.MaxInt64/(1024*1024) + 1)
+	assert.Zero(t, repo.MaxPushedBlobSize)
+}
@@ -0,0 +1,430 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	triage_service "forgejo.org/services/triage"
+)
+
+const (
+	tplRepoTriage base.TplName = "repo/settings/triage"
+	tplOrgTriage  base.TplName = "org/settings/triage"
+)
+
+type triageCtx struct {
+	OwnerID        int64
+	RepoID         int64
+	IsRepo         bool
+	IsOrg          bool
+	TriageTemplate base.TplName
+	RedirectLink   string
+}
+
+func getTriageCtx(ctx *context.Context) (*triageCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		ctx.Data["TriageRulesLink"] = ctx.Repo.RepoLink + "/settings/triage"
+		return &triageCtx{
+			OwnerID:        ctx.Repo.Repository.OwnerID,
+			RepoID:         ctx.Repo.Repository.ID,
+			IsRepo:         true,
+			TriageTemplate: tplRepoTriage,
+			RedirectLink:   ctx.Repo.RepoLink + "/settings/triage",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		err := shared_user.LoadHeaderCount(ctx)
+		if err != nil {
+			ctx.ServerError("LoadHeaderCount", err)
+			return nil, nil
+		}
+		ctx.Data["TriageRulesLink"] = ctx.Org.OrgLink + "/settings/triage"
+		return &triageCtx{
+			OwnerID:        ctx.ContextUser.ID,
+			RepoID:         0,
+			IsOrg:          true,
+			TriageTemplate: tplOrgTriage,
+			RedirectLink:   ctx.Org.OrgLink + "/settings/triage",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set Triage context")
+}
+
+// TriageRules shows the triage rules of a repository or of an organization
+func TriageRules(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("triage.rules")
+	ctx.Data["PageIsSettingsTriage"] = true
+	ctx.Data["PageType"] = "list"
+
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	rules, err := db.Find[issues_model.TriageRule](ctx, issues_model.FindTriageRulesOptions{OwnerID: tCtx.OwnerID, RepoID: tCtx.RepoID})
+	if err != nil {
+		ctx.ServerError("FindTriageRules", err)
+		return
+	}
+	ctx.Data["TriageRules"] = rules
+
+	if tCtx.IsRepo && ctx.Repo.Owner.IsOrganization() {
+		ctx.Data["IsOrgRepo"] = true
+		isOwner, err := organization.IsOrganizationOwner(ctx, ctx.Repo.Owner.ID, ctx.Doer.ID)
+		if err != nil {
+			ctx.ServerError("IsOrganizationOwner", err)
+			return
+		}
+		if isOwner {
+			ctx.Data["OrgTriageRulesLink"] = ctx.Repo.Owner.OrganisationLink() + "/settings/triage"
+		}
+	}
+	ctx.HTML(http.StatusOK, tCtx.TriageTemplate)
+}
+
+// NewTriageRule shows the form to create a triage rule
+func NewTriageRule(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("triage.rules.new")
+	ctx.Data["PageIsSettingsTriage"] = true
+	ctx.Data["PageType"] = "edit"
+
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["TriageRule"] = &issues_model.TriageRule{Event: issues_model.TriageEventOpened, IsActive: true, DryRun: true}
+	if !prepareTriageRuleForm(ctx, tCtx) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tCtx.TriageTemplate)
+}
+
+// NewTriageRulePost creates a triage rule
+func NewTriageRulePost(ctx *context.Context) {
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	rule := &issues_model.TriageRule{
+		OwnerID:   tCtx.OwnerID,
+		RepoID:    tCtx.RepoID,
+		CreatorID: ctx.Doer.ID,
+	}
+	if tCtx.IsRepo {
+		rule.OwnerID = 0
+	}
+	if !saveTriageRule(ctx, tCtx, rule) {
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("triage.rules.create_success", rule.Name))
+	ctx.Redirect(tCtx.RedirectLink)
+}
+
+func getTriageRule(ctx *context.Context, tCtx *triageCtx) *issues_model.TriageRule {
+	rule, err := issues_model.GetTriageRuleByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetTriageRuleByID", issues_model.IsErrTriageRuleNotExist, err)
+		return nil
+	}
+	if rule.RepoID != tCtx.RepoID || (tCtx.IsOrg && rule.OwnerID != tCtx.OwnerID) {
+		ctx.NotFound("GetTriageRuleByID", nil)
+		return nil
+	}
+	return rule
+}
+
+// EditTriageRule shows the form to edit a triage rule
+func EditTriageRule(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("triage.rules.edit")
+	ctx.Data["PageIsSettingsTriage"] = true
+	ctx.Data["PageType"] = "edit"
+
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	rule := getTriageRule(ctx, tCtx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["TriageRule"] = rule
+	ctx.Data["IsEditRule"] = true
+	if !prepareTriageRuleForm(ctx, tCtx) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tCtx.TriageTemplate)
+}
+
+// EditTriageRulePost updates a triage rule
+func EditTriageRulePost(ctx *context.Context) {
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	rule := getTriageRule(ctx, tCtx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["IsEditRule"] = true
+	if !saveTriageRule(ctx, tCtx, rule) {
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("triage.rules.update_success", rule.Name))
+	ctx.Redirect(tCtx.RedirectLink)
+}
+
+// DeleteTriageRule deletes a triage rule
+func DeleteTriageRule(ctx *context.Context) {
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	rule := getTriageRule(ctx, tCtx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteTriageRule(ctx, rule.ID); err != nil {
+		ctx.ServerError("DeleteTriageRule", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("triage.rules.delete_success", rule.Name))
+	ctx.JSONRedirect(tCtx.RedirectLink)
+}
+
+// TriageRuleLog shows the issues and pull requests on which a triage rule ran
+func TriageRuleLog(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("triage.rules.log")
+	ctx.Data["PageIsSettingsTriage"] = true
+	ctx.Data["PageType"] = "log"
+
+	tCtx, err := getTriageCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getTriageCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	rule := getTriageRule(ctx, tCtx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["TriageRule"] = rule
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	logs, count, err := db.FindAndCount[issues_model.TriageLog](ctx, issues_model.FindTriageLogsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: 50},
+		RuleID:      rule.ID,
+	})
+	if err != nil {
+		ctx.ServerError("FindTriageLogs", err)
+		return
+	}
+	if err := issues_model.LoadTriageLogIssues(ctx, logs); err != nil {
+		ctx.ServerError("LoadTriageLogIssues", err)
+		return
+	}
+	ctx.Data["TriageLogs"] = logs
+
+	pager := context.NewPagination(int(count), 50, page, 5)
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tCtx.TriageTemplate)
+}
+
+// prepareTriageRuleForm sets the teams and the project columns the actions of a rule can use
+func prepareTriageRuleForm(ctx *context.Context, tCtx *triageCtx) bool {
+	ctx.Data["TriageEvents"] = issues_model.TriageEvents()
+
+	isOrg := tCtx.IsOrg || ctx.Repo.Owner.IsOrganization()
+	if isOrg {
+		teams, err := organization.FindOrgTeams(ctx, tCtx.OwnerID)
+		if err != nil {
+			ctx.ServerError("FindOrgTeams", err)
+			return false
+		}
+		ctx.Data["Teams"] = teams
+	}
+
+	var projects []*project_model.Project
+	if tCtx.IsRepo {
+		repoProjects, err := db.Find[project_model.Project](ctx, project_model.SearchOptions{
+			RepoID:   tCtx.RepoID,
+			IsClosed: optional.Some(false),
+			Type:     project_model.TypeRepository,
+		})
+		if err != nil {
+			ctx.ServerError("FindProjects", err)
+			return false
+		}
+		projects = append(projects, repoProjects...)
+	}
+	if isOrg {
+		orgProjects, err := db.Find[project_model.Project](ctx, project_model.SearchOptions{
+			OwnerID:  tCtx.OwnerID,
+			IsClosed: optional.Some(false),
+			Type:     project_model.TypeOrganization,
+		})
+		if err != nil {
+			ctx.ServerError("FindProjects", err)
+			return false
+		}
+		projects = append(projects, orgProjects...)
+	}
+
+	type projectColumn struct {
+		ID    int64
+		Title string
+	}
+	columns := make([]*projectColumn, 0, len(projects)*3)
+	for _, project := range projects {
+		projectColumns, err := project.GetColumns(ctx)
+		if err != nil {
+			ctx.ServerError("GetColumns", err)
+			return false
+		}
+		for _, column := range projectColumns {
+			columns = append(columns, &projectColumn{ID: column.ID, Title: fmt.Sprintf("%s / %s", project.Title, column.Title)})
+		}
+	}
+	ctx.Data["ProjectColumns"] = columns
+	return true
+}
+
+// saveTriageRule sets a rule from the form and saves it, and renders the form again with the error
+// if it is invalid
+func saveTriageRule(ctx *context.Context, tCtx *triageCtx, rule *issues_model.TriageRule) bool {
+	form := web.GetForm(ctx).(*forms.TriageRuleForm)
+
+	rule.Name = form.Name
+	rule.Event = issues_model.TriageEventFromString(form.Event)
+	rule.StaleDays = 0
+	if rule.Event == issues_model.TriageEventStale {
+		rule.StaleDays = form.StaleDays
+	}
+	rule.IsActive = form.IsActive
+	rule.DryRun = form.DryRun
+	rule.Conditions = issues_model.TriageConditions{
+		Type:           form.Type,
+		Labels:         splitTriageList(form.Labels),
+		ExcludedLabels: splitTriageList(form.ExcludedLabels),
+		Authors:        splitTriageList(form.Authors),
+		TitleRegex:     form.TitleRegex,
+		BodyRegex:      form.BodyRegex,
+	}
+	for _, line := range strings.Split(form.Fields, "\n") {
+		name, value, ok := strings.Cut(line, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			continue
+		}
+		if rule.Conditions.Fields == nil {
+			rule.Conditions.Fields = make(map[string]string)
+		}
+		rule.Conditions.Fields[name] = value
+	}
+	rule.Actions = issues_model.TriageActions{
+		AddLabels:       splitTriageList(form.AddLabels),
+		RemoveLabels:    splitTriageList(form.RemoveLabels),
+		AssignTeam:      strings.TrimSpace(form.AssignTeam),
+		Comment:         strings.TrimSpace(form.Comment),
+		Close:           form.Close,
+		ProjectColumnID: form.ProjectColumn,
+	}
+	ctx.Data["TriageRule"] = rule
+
+	if ctx.HasError() {
+		ctx.Data["PageIsSettingsTriage"] = true
+		ctx.Data["PageType"] = "edit"
+		if prepareTriageRuleForm(ctx, tCtx) {
+			ctx.HTML(http.StatusOK, tCtx.TriageTemplate)
+		}
+		return false
+	}
+
+	if err := triage_service.ValidateRule(ctx, rule); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("ValidateRule", err)
+			return false
+		}
+		ctx.Data["PageIsSettingsTriage"] = true
+		ctx.Data["PageType"] = "edit"
+		if prepareTriageRuleForm(ctx, tCtx) {
+			ctx.RenderWithErr(ctx.Tr("triage.rules.invalid", err.Error()), tCtx.TriageTemplate, form)
+		}
+		return false
+	}
+
+	var err error
+	if rule.ID > 0 {
+		err = issues_model.UpdateTriageRule(ctx, rule)
+	} else {
+		err = issues_model.CreateTriageRule(ctx, rule)
+	}
+	if err != nil {
+		ctx.ServerError("SaveTriageRule", err)
+		return false
+	}
+	return true
+}
+
+// splitTriageList splits a comma separated list of names
+func splitTriageList(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
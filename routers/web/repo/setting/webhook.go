@@ -161,6 +161,7 @@ func ParseHookEvent(form forms.WebhookCoreForm) *webhook_module.HookEvent {
 			IssueAssign:              form.IssueAssign,
 			IssueLabel:               form.IssueLabel,
 			IssueMilestone:           form.IssueMilestone,
+			IssueProject:             form.IssueProject,
 			IssueComment:             form.IssueComment,
 			Release:                  form.Release,
 			Push:                     form.Push,
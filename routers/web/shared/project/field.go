@@ -0,0 +1,122 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+)
+
+// RetrieveFieldValues loads the custom fields of a project and their values for the issues shown on its board
+func RetrieveFieldValues(ctx *context.Context, project *project_model.Project, issuesMap map[int64]issues_model.IssueList) {
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return
+	}
+	ctx.Data["ProjectFields"] = fields
+	if len(fields) == 0 {
+		return
+	}
+
+	var issueIDs []int64
+	for _, issues := range issuesMap {
+		for _, issue := range issues {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	values, err := project_model.GetFieldValuesByIssueIDs(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetFieldValuesByIssueIDs", err)
+		return
+	}
+	ctx.Data["ProjectFieldValues"] = values
+}
+
+// AddField adds a custom field to a project
+func AddField(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	field := &project_model.Field{
+		ProjectID: project.ID,
+		Name:      ctx.FormString("name"),
+		Type:      project_model.FieldTypeFromString(ctx.FormString("type")),
+	}
+	switch field.Type {
+	case project_model.FieldTypeSingleSelect:
+		for _, name := range strings.Split(ctx.FormString("options"), "\n") {
+			if name = strings.TrimSpace(name); name != "" {
+				field.Options = append(field.Options, &project_model.FieldOption{Name: name})
+			}
+		}
+	case project_model.FieldTypeIteration:
+		// the iterations follow each other from the start date
+		start, err := time.Parse(project_model.FieldDateFormat, ctx.FormString("iteration_start"))
+		duration := ctx.FormInt("iteration_duration")
+		if err == nil && duration > 0 {
+			for i := range min(ctx.FormInt("iteration_count"), 52) {
+				field.Iterations = append(field.Iterations, &project_model.FieldIteration{
+					Title:     ctx.Locale.TrString("repo.projects.fields.iteration_title", i+1),
+					StartDate: start.AddDate(0, 0, i*duration).Format(project_model.FieldDateFormat),
+					Duration:  duration,
+				})
+			}
+		}
+	}
+
+	if err := project_model.NewField(ctx, field); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("NewField", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.projects.fields.invalid", err.Error()))
+	}
+	ctx.Redirect(project.Link(ctx))
+}
+
+// DeleteField deletes a custom field of a project and its values
+func DeleteField(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	field, err := project_model.GetFieldByID(ctx, ctx.ParamsInt64(":fieldID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetFieldByID", project_model.IsErrProjectFieldNotExist, err)
+		return
+	}
+	if field.ProjectID != project.ID {
+		ctx.NotFound("DeleteField", nil)
+		return
+	}
+
+	if err := project_model.DeleteFieldByID(ctx, field.ID); err != nil {
+		ctx.ServerError("DeleteFieldByID", err)
+		return
+	}
+	ctx.Redirect(project.Link(ctx))
+}
+
+func getProject(ctx *context.Context) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.ContextUser.ID, ctx.Repo.Repository) {
+		ctx.NotFound("CanBeAccessedByOwnerRepo", nil)
+		return nil
+	}
+	return project
+}
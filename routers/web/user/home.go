@@ -134,6 +134,13 @@ func Dashboard(ctx *context.Context) {
 
 	ctx.Data["Feeds"] = feeds
 
+	if ctxUser.ID == ctx.Doer.ID && ctx.Org.Team == nil {
+		prepareSavedSearchWidgets(ctx)
+		if ctx.Written() {
+			return
+		}
+	}
+
 	pager := context.NewPagination(int(count), setting.UI.FeedPagingNum, page, 5)
 	pager.AddParam(ctx, "date", "Date")
 	ctx.Data["Page"] = pager
@@ -0,0 +1,399 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+	pull_service "forgejo.org/services/pull"
+)
+
+const (
+	tplSavedSearches     base.TplName = "user/searches/list"
+	tplSavedSearch       base.TplName = "user/searches/view"
+	tplSavedSearchEdit   base.TplName = "user/searches/edit"
+	savedSearchWidgetNum              = 5
+)
+
+// SavedSearchWidget is a saved search shown on the dashboard with its first results
+type SavedSearchWidget struct {
+	Search *issues_model.SavedSearch
+	Issues issues_model.IssueList
+	Total  int64
+}
+
+// savedSearchOwners returns the doer and the organizations the doer is a member of, who can own
+// the saved searches the doer creates
+func savedSearchOwners(ctx *context.Context) []*user_model.User {
+	orgs, err := organization.GetUserOrgsList(ctx, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserOrgsList", err)
+		return nil
+	}
+	owners := make([]*user_model.User, 0, len(orgs)+1)
+	owners = append(owners, ctx.Doer)
+	for _, org := range orgs {
+		owners = append(owners, org.AsUser())
+	}
+	return owners
+}
+
+// SavedSearches renders the saved searches of the doer and of their organizations
+func SavedSearches(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("saved_searches")
+	ctx.Data["PageIsSavedSearches"] = true
+
+	owners := savedSearchOwners(ctx)
+	if ctx.Written() {
+		return
+	}
+	ownerIDs := make([]int64, 0, len(owners))
+	for _, owner := range owners {
+		ownerIDs = append(ownerIDs, owner.ID)
+	}
+
+	searches, err := db.Find[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchesOptions{OwnerIDs: ownerIDs})
+	if err != nil {
+		ctx.ServerError("FindSavedSearches", err)
+		return
+	}
+	if err := issues_model.SavedSearchList(searches).LoadOwners(ctx); err != nil {
+		ctx.ServerError("LoadOwners", err)
+		return
+	}
+	subscriptions, err := issues_model.GetSavedSearchSubscriptionsByUser(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchSubscriptionsByUser", err)
+		return
+	}
+
+	ctx.Data["SavedSearches"] = searches
+	ctx.Data["Subscriptions"] = subscriptions
+	ctx.HTML(http.StatusOK, tplSavedSearches)
+}
+
+// NewSavedSearch renders the form to save a search, filled with the filters of the query
+func NewSavedSearch(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("saved_searches.new")
+	ctx.Data["PageIsSavedSearches"] = true
+	ctx.Data["Owners"] = savedSearchOwners(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["OwnerID"] = ctx.Doer.ID
+	ctx.Data["Filter"] = issues_model.ParseSavedSearchFilter(ctx.Req.URL.Query())
+	ctx.HTML(http.StatusOK, tplSavedSearchEdit)
+}
+
+// NewSavedSearchPost saves a search
+func NewSavedSearchPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchForm)
+	ctx.Data["Title"] = ctx.Tr("saved_searches.new")
+	ctx.Data["PageIsSavedSearches"] = true
+	owners := savedSearchOwners(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Owners"] = owners
+	ctx.Data["OwnerID"] = form.OwnerID
+	ctx.Data["Filter"] = savedSearchFormFilter(form)
+
+	var owner *user_model.User
+	for _, u := range owners {
+		if u.ID == form.OwnerID {
+			owner = u
+		}
+	}
+	if owner == nil {
+		ctx.Data["Err_OwnerID"] = true
+		ctx.RenderWithErr(ctx.Tr("form.saved_search_owner_invalid"), tplSavedSearchEdit, form)
+		return
+	}
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSavedSearchEdit)
+		return
+	}
+
+	search := &issues_model.SavedSearch{
+		OwnerID:   owner.ID,
+		Owner:     owner,
+		Name:      form.Name,
+		CreatorID: ctx.Doer.ID,
+	}
+	search.SetFilter(savedSearchFormFilter(form))
+	if err := issues_model.CreateSavedSearch(ctx, search); err != nil {
+		if issues_model.IsErrSavedSearchAlreadyExists(err) {
+			ctx.Data["Err_Name"] = true
+			ctx.RenderWithErr(ctx.Tr("form.saved_search_name_been_taken", form.Name), tplSavedSearchEdit, form)
+			return
+		}
+		ctx.ServerError("CreateSavedSearch", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("saved_searches.create_success", search.Name))
+	ctx.Redirect(fmt.Sprintf("%s/searches/%d", setting.AppSubURL, search.ID))
+}
+
+func savedSearchFormFilter(form *forms.SavedSearchForm) issues_model.SavedSearchFilter {
+	values := url.Values{}
+	values.Set("q", form.Keyword)
+	values.Set("type", form.Type)
+	values.Set("state", form.State)
+	values.Set("labels", form.Labels)
+	values.Set("milestones", form.Milestones)
+	values.Set("repos", form.Repos)
+	values.Set("assignee", form.Assignee)
+	values.Set("poster", form.Poster)
+	values.Set("mentioned", form.Mentioned)
+	if form.Project > 0 {
+		values.Set("project", fmt.Sprint(form.Project))
+	}
+	values.Set("sort", form.Sort)
+	return issues_model.ParseSavedSearchFilter(values)
+}
+
+// getSavedSearch returns the saved search of the request if the doer can view it
+func getSavedSearch(ctx *context.Context) *issues_model.SavedSearch {
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetSavedSearchByID", issues_model.IsErrSavedSearchNotExist, err)
+		return nil
+	}
+	canView, err := issue_service.CanViewSavedSearch(ctx, ctx.Doer, search)
+	if err != nil {
+		ctx.ServerError("CanViewSavedSearch", err)
+		return nil
+	}
+	if !canView {
+		ctx.NotFound("CanViewSavedSearch", nil)
+		return nil
+	}
+	if err := search.LoadOwner(ctx); err != nil {
+		ctx.ServerError("LoadOwner", err)
+		return nil
+	}
+	canEdit, err := issue_service.CanEditSavedSearch(ctx, ctx.Doer, search)
+	if err != nil {
+		ctx.ServerError("CanEditSavedSearch", err)
+		return nil
+	}
+	ctx.Data["SavedSearch"] = search
+	ctx.Data["CanEditSavedSearch"] = canEdit
+	return search
+}
+
+// getEditableSavedSearch returns the saved search of the request if the doer can edit it
+func getEditableSavedSearch(ctx *context.Context) *issues_model.SavedSearch {
+	search := getSavedSearch(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	if !ctx.Data["CanEditSavedSearch"].(bool) {
+		ctx.Error(http.StatusForbidden)
+		return nil
+	}
+	return search
+}
+
+// ViewSavedSearch runs a saved search and renders its results
+func ViewSavedSearch(ctx *context.Context) {
+	search := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = search.Name
+	ctx.Data["PageIsSavedSearches"] = true
+	ctx.Data["Filter"] = search.Filter()
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	issues, total, err := issue_service.RunSavedSearch(ctx, ctx.Doer, search, &db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.IssuePagingNum,
+	}, 0)
+	if err != nil {
+		ctx.ServerError("RunSavedSearch", err)
+		return
+	}
+	prepareSavedSearchIssueList(ctx, issues)
+	if ctx.Written() {
+		return
+	}
+
+	subscription, err := issues_model.GetSavedSearchSubscription(ctx, search.ID, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchSubscription", err)
+		return
+	}
+	ctx.Data["Subscription"] = subscription
+	ctx.Data["Total"] = total
+	ctx.Data["Page"] = context.NewPagination(int(total), setting.UI.IssuePagingNum, page, 5)
+	ctx.HTML(http.StatusOK, tplSavedSearch)
+}
+
+// prepareSavedSearchIssueList fills the data needed to render a list of issues of various repositories
+func prepareSavedSearchIssueList(ctx *context.Context, issues issues_model.IssueList) {
+	if err := issues.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("issues.LoadAttributes", err)
+		return
+	}
+	commitStatuses, lastStatus, err := pull_service.GetIssuesAllCommitStatus(ctx, issues)
+	if err != nil {
+		ctx.ServerError("GetIssuesAllCommitStatus", err)
+		return
+	}
+	for key := range commitStatuses {
+		git_model.CommitStatusesHideActionsURL(ctx, commitStatuses[key])
+	}
+	approvalCounts, err := issues.GetApprovalCounts(ctx)
+	if err != nil {
+		ctx.ServerError("ApprovalCounts", err)
+		return
+	}
+
+	ctx.Data["Issues"] = issues
+	ctx.Data["CommitLastStatus"] = lastStatus
+	ctx.Data["CommitStatuses"] = commitStatuses
+	ctx.Data["IssueRefEndNames"], ctx.Data["IssueRefURLs"] = issue_service.GetRefEndNamesAndURLs(issues, "")
+	ctx.Data["ApprovalCounts"] = func(issueID int64, typ string) int64 {
+		counts, ok := approvalCounts[issueID]
+		if !ok || len(counts) == 0 {
+			return 0
+		}
+		reviewTyp := issues_model.ReviewTypeApprove
+		if typ == "reject" {
+			reviewTyp = issues_model.ReviewTypeReject
+		} else if typ == "waiting" {
+			reviewTyp = issues_model.ReviewTypeRequest
+		}
+		for _, count := range counts {
+			if count.Type == reviewTyp {
+				return count.Count
+			}
+		}
+		return 0
+	}
+}
+
+// EditSavedSearch renders the form to change a saved search
+func EditSavedSearch(ctx *context.Context) {
+	search := getEditableSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("saved_searches.edit")
+	ctx.Data["PageIsSavedSearches"] = true
+	ctx.Data["PageIsEditSavedSearch"] = true
+	ctx.Data["name"] = search.Name
+	ctx.Data["Filter"] = search.Filter()
+	ctx.HTML(http.StatusOK, tplSavedSearchEdit)
+}
+
+// EditSavedSearchPost changes the name and the filters of a saved search
+func EditSavedSearchPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchForm)
+	search := getEditableSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("saved_searches.edit")
+	ctx.Data["PageIsSavedSearches"] = true
+	ctx.Data["PageIsEditSavedSearch"] = true
+	ctx.Data["Filter"] = savedSearchFormFilter(form)
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSavedSearchEdit)
+		return
+	}
+
+	search.Name = form.Name
+	search.SetFilter(savedSearchFormFilter(form))
+	if err := issues_model.UpdateSavedSearch(ctx, search); err != nil {
+		if issues_model.IsErrSavedSearchAlreadyExists(err) {
+			ctx.Data["Err_Name"] = true
+			ctx.RenderWithErr(ctx.Tr("form.saved_search_name_been_taken", form.Name), tplSavedSearchEdit, form)
+			return
+		}
+		ctx.ServerError("UpdateSavedSearch", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("saved_searches.edit_success", search.Name))
+	ctx.Redirect(fmt.Sprintf("%s/searches/%d", setting.AppSubURL, search.ID))
+}
+
+// DeleteSavedSearch deletes a saved search
+func DeleteSavedSearch(ctx *context.Context) {
+	search := getEditableSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteSavedSearch(ctx, search.ID); err != nil {
+		ctx.ServerError("DeleteSavedSearch", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("saved_searches.delete_success", search.Name))
+	ctx.Redirect(setting.AppSubURL + "/searches")
+}
+
+// SavedSearchSubscriptionPost changes whether the doer shows a saved search on their dashboard and
+// is notified about its new matches
+func SavedSearchSubscriptionPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchSubscriptionForm)
+	search := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.SetSavedSearchSubscription(ctx, search.ID, ctx.Doer.ID, form.OnDashboard, form.Notify); err != nil {
+		ctx.ServerError("SetSavedSearchSubscription", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("saved_searches.subscription_success"))
+	ctx.Redirect(fmt.Sprintf("%s/searches/%d", setting.AppSubURL, search.ID))
+}
+
+// prepareSavedSearchWidgets loads the first results of the saved searches the doer shows on their dashboard
+func prepareSavedSearchWidgets(ctx *context.Context) {
+	searches, err := issues_model.GetDashboardSavedSearches(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetDashboardSavedSearches", err)
+		return
+	}
+
+	widgets := make([]*SavedSearchWidget, 0, len(searches))
+	for _, search := range searches {
+		if canView, err := issue_service.CanViewSavedSearch(ctx, ctx.Doer, search); err != nil {
+			ctx.ServerError("CanViewSavedSearch", err)
+			return
+		} else if !canView {
+			continue
+		}
+		issues, total, err := issue_service.RunSavedSearch(ctx, ctx.Doer, search, &db.ListOptions{Page: 1, PageSize: savedSearchWidgetNum}, 0)
+		if err != nil {
+			ctx.ServerError("RunSavedSearch", err)
+			return
+		}
+		if _, err := issues.LoadRepositories(ctx); err != nil {
+			ctx.ServerError("LoadRepositories", err)
+			return
+		}
+		widgets = append(widgets, &SavedSearchWidget{Search: search, Issues: issues, Total: total})
+	}
+	ctx.Data["SavedSearchWidgets"] = widgets
+}
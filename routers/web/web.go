@@ -31,6 +31,7 @@ import (
 	"forgejo.org/routers/web/feed"
 	"forgejo.org/routers/web/healthcheck"
 	"forgejo.org/routers/web/misc"
+	"forgejo.org/routers/web/moderation"
 	"forgejo.org/routers/web/org"
 	org_setting "forgejo.org/routers/web/org/setting"
 	"forgejo.org/routers/web/repo"
@@ -378,6 +379,13 @@ func registerRoutes(m *web.Route) {
 		}
 	}
 
+	moderationEnabled := func(ctx *context.Context) {
+		if !setting.Moderation.Enabled {
+			ctx.Error(http.StatusForbidden)
+			return
+		}
+	}
+
 	feedEnabled := func(ctx *context.Context) {
 		if !setting.Other.EnableFeed {
 			ctx.Error(http.StatusNotFound)
@@ -424,6 +432,20 @@ func registerRoutes(m *web.Route) {
 		})
 	}
 
+	addSettingsTriageRoutes := func() {
+		m.Group("/triage", func() {
+			m.Get("", repo_setting.TriageRules)
+			m.Combo("/new").Get(repo_setting.NewTriageRule).
+				Post(web.Bind(forms.TriageRuleForm{}), repo_setting.NewTriageRulePost)
+			m.Group("/{id}", func() {
+				m.Combo("").Get(repo_setting.EditTriageRule).
+					Post(web.Bind(forms.TriageRuleForm{}), repo_setting.EditTriageRulePost)
+				m.Post("/delete", repo_setting.DeleteTriageRule)
+				m.Get("/log", repo_setting.TriageRuleLog)
+			})
+		})
+	}
+
 	addSettingsRunnersRoutes := func() {
 		m.Group("/runners", func() {
 			m.Get("", repo_setting.Runners)
@@ -476,6 +498,19 @@ func registerRoutes(m *web.Route) {
 	m.Get("/pulls", reqSignIn, user.Pulls)
 	m.Get("/milestones", reqSignIn, reqMilestonesDashboardPageEnabled, user.Milestones)
 
+	m.Group("/searches", func() {
+		m.Get("", user.SavedSearches)
+		m.Combo("/new").Get(user.NewSavedSearch).
+			Post(web.Bind(forms.SavedSearchForm{}), user.NewSavedSearchPost)
+		m.Group("/{id}", func() {
+			m.Get("", user.ViewSavedSearch)
+			m.Combo("/edit").Get(user.EditSavedSearch).
+				Post(web.Bind(forms.SavedSearchForm{}), user.EditSavedSearchPost)
+			m.Post("/delete", user.DeleteSavedSearch)
+			m.Post("/subscription", web.Bind(forms.SavedSearchSubscriptionForm{}), user.SavedSearchSubscriptionPost)
+		})
+	}, reqSignIn)
+
 	// ***** START: User *****
 	// "user/login" doesn't need signOut, then logged-in users can still access this route for redirection purposes by "/user/login?redirec_to=..."
 	m.Get("/user/login", auth.SignIn)
@@ -664,6 +699,10 @@ func registerRoutes(m *web.Route) {
 
 	m.Get("/avatar/{hash}", user.AvatarByEmailHash)
 
+	m.Combo("/report_abuse", reqSignIn, moderationEnabled).
+		Get(moderation.NewReport).
+		Post(web.Bind(forms.ReportAbuseForm{}), moderation.NewReportPost)
+
 	adminReq := verifyAuthWithOptions(&common.VerifyOptions{SignInRequired: true, AdminRequired: true})
 
 	// ***** START: Admin *****
@@ -696,6 +735,9 @@ func registerRoutes(m *web.Route) {
 				m.Post("/remove-all-items", admin.QueueRemoveAllItems)
 			})
 			m.Get("/diagnosis", admin.MonitorDiagnosis)
+			if setting.Replica.Enabled {
+				m.Get("/replica", admin.MonitorReplica)
+			}
 		})
 
 		m.Group("/users", func() {
@@ -753,6 +795,12 @@ func registerRoutes(m *web.Route) {
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
 		})
 
+		m.Group("/moderation", func() {
+			m.Get("", admin.AbuseReports)
+			m.Get("/{id}", admin.ViewAbuseReport)
+			m.Post("/{id}/resolve", web.Bind(forms.ResolveAbuseReportForm{}), admin.ResolveAbuseReport)
+		}, moderationEnabled)
+
 		m.Group("/notices", func() {
 			m.Get("", admin.Notices)
 			m.Post("/delete", admin.DeleteNotices)
@@ -774,7 +822,7 @@ func registerRoutes(m *web.Route) {
 			addSettingsRunnersRoutes()
 			addSettingsVariablesRoutes()
 		})
-	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled))
+	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableReplica", setting.Replica.Enabled, "EnableModeration", setting.Moderation.Enabled))
 	// ***** END: Admin *****
 
 	m.Group("", func() {
@@ -792,6 +840,7 @@ func registerRoutes(m *web.Route) {
 	reqRepoReleaseReader := context.RequireRepoReader(unit.TypeReleases)
 	reqRepoWikiWriter := context.RequireRepoWriter(unit.TypeWiki)
 	reqRepoIssueReader := context.RequireRepoReader(unit.TypeIssues)
+	reqRepoIssueWriter := context.RequireRepoWriter(unit.TypeIssues)
 	reqRepoPullsReader := context.RequireRepoReader(unit.TypePullRequests)
 	reqRepoIssuesOrPullsWriter := context.RequireRepoWriterOr(unit.TypeIssues, unit.TypePullRequests)
 	reqRepoIssuesOrPullsReader := context.RequireRepoReaderOr(unit.TypeIssues, unit.TypePullRequests)
@@ -855,6 +904,8 @@ func registerRoutes(m *web.Route) {
 			m.Get("/milestones/{team}", reqMilestonesDashboardPageEnabled, user.Milestones)
 			m.Post("/members/action/{action}", org.MembersAction)
 			m.Get("/teams", org.Teams)
+			m.Get("/timesheet", org.Timesheet)
+			m.Get("/timesheet/export", org.TimesheetExport)
 		}, context.OrgAssignment(true, false, true))
 
 		m.Group("/{org}", func() {
@@ -875,6 +926,7 @@ func registerRoutes(m *web.Route) {
 			m.Post("/teams/new", web.Bind(forms.CreateTeamForm{}), org.NewTeamPost)
 			m.Get("/teams/{team}/edit", org.EditTeam)
 			m.Post("/teams/{team}/edit", web.Bind(forms.CreateTeamForm{}), org.EditTeamPost)
+			m.Post("/teams/{team}/review_assignment", web.Bind(forms.TeamReviewAssignmentForm{}), org.TeamReviewAssignmentPost)
 			m.Post("/teams/{team}/delete", org.DeleteTeam)
 
 			m.Group("/settings", func() {
@@ -912,6 +964,8 @@ func registerRoutes(m *web.Route) {
 					m.Post("/initialize", web.Bind(forms.InitializeLabelsForm{}), org.InitializeLabels)
 				})
 
+				addSettingsTriageRoutes()
+
 				m.Group("/actions", func() {
 					m.Get("", org_setting.RedirectToDefaultSetting)
 					addSettingsRunnersRoutes()
@@ -993,6 +1047,8 @@ func registerRoutes(m *web.Route) {
 				m.Group("/{id}", func() {
 					m.Post("", web.Bind(forms.EditProjectColumnForm{}), org.AddColumnToProjectPost)
 					m.Post("/move", project.MoveColumns)
+					m.Post("/fields", project.AddField)
+					m.Post("/fields/{fieldID}/delete", project.DeleteField)
 					m.Post("/delete", org.DeleteProject)
 
 					m.Get("/edit", org.RenderEditProject)
@@ -1081,6 +1137,20 @@ func registerRoutes(m *web.Route) {
 				})
 			}, webhooksEnabled)
 
+			addSettingsTriageRoutes()
+
+			m.Group("/recurring_issues", func() {
+				m.Get("", repo_setting.RecurringIssues)
+				m.Combo("/new").Get(repo_setting.NewRecurringIssue).
+					Post(web.Bind(forms.RecurringIssueForm{}), repo_setting.NewRecurringIssuePost)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(repo_setting.EditRecurringIssue).
+						Post(web.Bind(forms.RecurringIssueForm{}), repo_setting.EditRecurringIssuePost)
+					m.Post("/delete", repo_setting.DeleteRecurringIssue)
+					m.Get("/history", repo_setting.RecurringIssueHistory)
+				})
+			}, repo.MustEnableIssues)
+
 			m.Group("/keys", func() {
 				m.Combo("").Get(repo_setting.DeployKeys).
 					Post(web.Bind(forms.AddKeyForm{}), repo_setting.DeployKeysPost)
@@ -1162,6 +1232,8 @@ func registerRoutes(m *web.Route) {
 				m.Get("/choose", context.RepoRef(), repo.NewIssueChooseTemplate)
 			})
 			m.Get("/search", repo.ListIssues)
+			m.Combo("/import", reqRepoIssueWriter).Get(repo.ImportIssues).
+				Post(web.Bind(forms.ImportIssuesForm{}), repo.ImportIssuesPost)
 		}, context.RepoMustNotBeArchived(), reqRepoIssueReader)
 		// FIXME: should use different URLs but mostly same logic for comments of issue and pull request.
 		// So they can apply their own enable/disable logic on routers.
@@ -1170,6 +1242,7 @@ func registerRoutes(m *web.Route) {
 				m.Post("/title", repo.UpdateIssueTitle)
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", web.Bind(structs.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+				m.Post("/project_fields/{fieldID}", reqRepoIssuesOrPullsWriter, repo.UpdateIssueProjectField)
 				m.Post("/watch", repo.IssueWatch)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/pin", reqRepoAdmin, repo.IssuePinOrUnpin)
@@ -1178,6 +1251,11 @@ func registerRoutes(m *web.Route) {
 					m.Post("/add", repo.AddDependency)
 					m.Post("/delete", repo.RemoveDependency)
 				})
+				m.Group("/sub_issues", func() {
+					m.Post("/add", repo.AddSubIssue)
+					m.Post("/remove", repo.RemoveSubIssue)
+					m.Post("/move", repo.MoveSubIssue)
+				})
 				m.Combo("/comments").Post(repo.MustAllowUserComment, web.Bind(forms.CreateCommentForm{}), repo.NewComment)
 				m.Group("/times", func() {
 					m.Post("/add", web.Bind(forms.AddTimeManuallyForm{}), repo.AddTimeManually)
@@ -1327,6 +1405,7 @@ func registerRoutes(m *web.Route) {
 	m.Group("/{username}/{reponame}", func() {
 		m.Group("", func() {
 			m.Get("/issues/posters", repo.IssuePosters) // it can't use {type:issues|pulls} because other routes like "/pulls/{index}" has higher priority
+			m.Get("/issues/export", reqRepoIssuesOrPullsReader, repo.ExportIssues)
 			m.Get("/{type:issues|pulls}", repo.Issues)
 			m.Get("/{type:issues|pulls}/{index}", repo.ViewIssue)
 			m.Group("/{type:issues|pulls}/{index}/content-history", func() {
@@ -1371,6 +1450,8 @@ func registerRoutes(m *web.Route) {
 				m.Group("/{id}", func() {
 					m.Post("", web.Bind(forms.EditProjectColumnForm{}), repo.AddColumnToProjectPost)
 					m.Post("/move", project.MoveColumns)
+					m.Post("/fields", project.AddField)
+					m.Post("/fields/{fieldID}/delete", project.DeleteField)
 					m.Post("/delete", repo.DeleteProject)
 
 					m.Get("/edit", repo.RenderEditProject)
@@ -1505,9 +1586,14 @@ func registerRoutes(m *web.Route) {
 				m.Get("/list", context.RepoRef(), repo.GetPullCommits)
 				m.Get("/{sha:[a-f0-9]{4,40}}", context.RepoRef(), repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForSingleCommit)
 			})
+			m.Get("/range-diff/{shaFrom:[a-f0-9]{40,64}}..{shaTo:[a-f0-9]{40,64}}", context.RepoRef(), repo.GetPullDiffStats, repo.ViewPullRangeDiff)
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
+			m.Group("/stack", func() {
+				m.Post("/merge", context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequestStack)
+				m.Post("/rebase", repo.RebasePullRequestStack)
+			}, reqSignIn, context.RepoMustNotBeArchived())
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), context.RepoRef(), repo.CleanUpPullRequest)
 			m.Group("/files", func() {
@@ -1518,6 +1604,7 @@ func registerRoutes(m *web.Route) {
 					m.Get("/new_comment", repo.RenderNewCodeCommentForm)
 					m.Post("/comments", web.Bind(forms.CodeCommentForm{}), repo.SetShowOutdatedComments, repo.CreateCodeComment)
 					m.Post("/submit", web.Bind(forms.SubmitReviewForm{}), repo.SubmitReview)
+					m.Post("/suggestions/apply", web.Bind(forms.ApplySuggestionsForm{}), repo.ApplySuggestions)
 				}, context.RepoMustNotBeArchived())
 			})
 		}, repo.MustAllowPulls)
@@ -13,6 +13,7 @@ import (
 	git_model "forgejo.org/models/git"
 	user_model "forgejo.org/models/user"
 	actions_module "forgejo.org/modules/actions"
+	"forgejo.org/modules/git"
 	"forgejo.org/modules/log"
 	api "forgejo.org/modules/structs"
 	webhook_module "forgejo.org/modules/webhook"
@@ -122,6 +123,11 @@ func createCommitStatus(ctx context.Context, job *actions_model.ActionRunJob) er
 		return fmt.Errorf("getIndexOfJob: %w", err)
 	}
 
+	workflowRef, err := GetWorkflowRef(run)
+	if err != nil {
+		return fmt.Errorf("GetWorkflowRef: %w", err)
+	}
+
 	creator := user_model.NewActionsUser()
 	if err := commitstatus_service.CreateCommitStatus(ctx, repo, creator,
 		sha,
@@ -132,6 +138,9 @@ func createCommitStatus(ctx context.Context, job *actions_model.ActionRunJob) er
 			Context:     ctxname,
 			CreatorID:   creator.ID,
 			State:       state,
+
+			ActionsWorkflowID:  run.WorkflowID,
+			ActionsWorkflowRef: workflowRef,
 		}); err != nil {
 		return fmt.Errorf("NewCommitStatus: %w", err)
 	}
@@ -139,6 +148,22 @@ func createCommitStatus(ctx context.Context, job *actions_model.ActionRunJob) er
 	return nil
 }
 
+// GetWorkflowRef returns the ref the workflow file of a run was read from: the base branch of the pull request
+// for pull_request_target, the ref of the run otherwise
+func GetWorkflowRef(run *actions_model.ActionRun) (string, error) {
+	if run.TriggerEvent != actions_module.GithubEventPullRequestTarget {
+		return run.Ref, nil
+	}
+	payload, err := run.GetPullRequestEventPayload()
+	if err != nil {
+		return "", fmt.Errorf("GetPullRequestEventPayload: %w", err)
+	}
+	if payload.PullRequest == nil || payload.PullRequest.Base == nil {
+		return "", fmt.Errorf("base of pull request is missing in event payload")
+	}
+	return git.RefNameFromBranch(payload.PullRequest.Base.Ref).String(), nil
+}
+
 func toCommitStatus(status actions_model.Status) api.CommitStatusState {
 	switch status {
 	case actions_model.StatusSuccess, actions_model.StatusSkipped:
@@ -73,24 +73,24 @@ func (b *Basic) Verify(req *http.Request, w http.ResponseWriter, store DataStore
 	}
 
 	// check oauth2 token
-	uid, _ := CheckOAuthAccessToken(req.Context(), authToken)
-	if uid != 0 {
-		log.Trace("Basic Authorization: Valid OAuthAccessToken for user[%d]", uid)
+	if grant := checkOAuthAccessTokenGrant(req.Context(), authToken); grant != nil {
+		log.Trace("Basic Authorization: Valid OAuthAccessToken for user[%d]", grant.UserID)
 
-		u, err := user_model.GetUserByID(req.Context(), uid)
+		u, err := user_model.GetUserByID(req.Context(), grant.UserID)
 		if err != nil {
 			log.Error("GetUserByID:  %v", err)
 			return nil, err
 		}
 
 		store.GetData()["IsApiToken"] = true
+		store.GetData()["OAuth2ApplicationID"] = grant.ApplicationID
 		return u, nil
 	}
 
 	// check personal access token
 	token, err := auth_model.GetAccessTokenBySHA(req.Context(), authToken)
 	if err == nil {
-		log.Trace("Basic Authorization: Valid AccessToken for user[%d]", uid)
+		log.Trace("Basic Authorization: Valid AccessToken for user[%d]", token.UID)
 		u, err := user_model.GetUserByID(req.Context(), token.UID)
 		if err != nil {
 			log.Error("GetUserByID:  %v", err)
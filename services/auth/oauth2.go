@@ -69,30 +69,39 @@ func grantAdditionalScopes(grantScopes string) string {
 // CheckOAuthAccessToken returns uid of user from oauth token
 // + non default openid scopes requested
 func CheckOAuthAccessToken(ctx context.Context, accessToken string) (int64, string) {
-	if !setting.OAuth2.Enabled {
+	grant := checkOAuthAccessTokenGrant(ctx, accessToken)
+	if grant == nil {
 		return 0, ""
 	}
+	grantScopes := grantAdditionalScopes(grant.Scope)
+	return grant.UserID, grantScopes
+}
+
+// checkOAuthAccessTokenGrant returns the grant of a valid oauth token, nil otherwise
+func checkOAuthAccessTokenGrant(ctx context.Context, accessToken string) *auth_model.OAuth2Grant {
+	if !setting.OAuth2.Enabled {
+		return nil
+	}
 	// JWT tokens require a "."
 	if !strings.Contains(accessToken, ".") {
-		return 0, ""
+		return nil
 	}
 	token, err := oauth2.ParseToken(accessToken, oauth2.DefaultSigningKey)
 	if err != nil {
 		log.Trace("oauth2.ParseToken: %v", err)
-		return 0, ""
+		return nil
 	}
 	var grant *auth_model.OAuth2Grant
 	if grant, err = auth_model.GetOAuth2GrantByID(ctx, token.GrantID); err != nil || grant == nil {
-		return 0, ""
+		return nil
 	}
 	if token.Type != oauth2.TypeAccessToken {
-		return 0, ""
+		return nil
 	}
 	if token.ExpiresAt.Before(time.Now()) || token.IssuedAt.After(time.Now()) {
-		return 0, ""
+		return nil
 	}
-	grantScopes := grantAdditionalScopes(grant.Scope)
-	return grant.UserID, grantScopes
+	return grant
 }
 
 // CheckTaskIsRunning verifies that the TaskID corresponds to a running task
@@ -160,16 +169,18 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 		}
 
 		// Otherwise, check if this is an OAuth access token
-		uid, grantScopes := CheckOAuthAccessToken(ctx, tokenSHA)
-		if uid != 0 {
-			store.GetData()["IsApiToken"] = true
-			if grantScopes != "" {
-				store.GetData()["ApiTokenScope"] = auth_model.AccessTokenScope(grantScopes)
-			} else {
-				store.GetData()["ApiTokenScope"] = auth_model.AccessTokenScopeAll // fallback to all
-			}
+		grant := checkOAuthAccessTokenGrant(ctx, tokenSHA)
+		if grant == nil {
+			return 0
+		}
+		store.GetData()["IsApiToken"] = true
+		store.GetData()["OAuth2ApplicationID"] = grant.ApplicationID
+		if grantScopes := grantAdditionalScopes(grant.Scope); grantScopes != "" {
+			store.GetData()["ApiTokenScope"] = auth_model.AccessTokenScope(grantScopes)
+		} else {
+			store.GetData()["ApiTokenScope"] = auth_model.AccessTokenScopeAll // fallback to all
 		}
-		return uid
+		return grant.UserID
 	}
 	t, err := auth_model.GetAccessTokenBySHA(ctx, tokenSHA)
 	if err != nil {
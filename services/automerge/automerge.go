@@ -200,6 +200,10 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 			log.Info("%-v was scheduled to automerge by an unauthorized user", pr)
 			return
 		}
+		if errors.Is(err, pull_service.ErrReviewChecklistNotComplete) {
+			log.Trace("%-v was scheduled to automerge but its review checklist is not complete", pr)
+			return
+		}
 		log.Error("%-v CheckPullMergeable: %v", pr, err)
 		return
 	}
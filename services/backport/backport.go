@@ -0,0 +1,339 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package backport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/models"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	issue_service "forgejo.org/services/issue"
+	notify_service "forgejo.org/services/notify"
+	pull_service "forgejo.org/services/pull"
+	repo_service "forgejo.org/services/repository"
+	files_service "forgejo.org/services/repository/files"
+)
+
+// LabelPrefix is the prefix of the labels which request the backport of a pull request to the branch named
+// after the prefix once it is merged
+const LabelPrefix = "backport/"
+
+var backportQueue *queue.WorkerPoolQueue[int64]
+
+// Init registers the notifier which backports the merged pull requests
+func Init() error {
+	backportQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "pull_backport", handler)
+	if backportQueue == nil {
+		return fmt.Errorf("unable to create pull_backport queue")
+	}
+	go graceful.GetManager().RunWithCancel(backportQueue)
+
+	notify_service.RegisterNotifier(NewNotifier())
+	return nil
+}
+
+func handler(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, id := range items {
+		if err := handle(ctx, id); err != nil {
+			log.Error("backport %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// errBackport is an error reported on the pull request which is backported
+type errBackport struct {
+	msg string
+}
+
+func (err errBackport) Error() string {
+	return err.msg
+}
+
+func handle(ctx context.Context, id int64) error {
+	backport, err := issues_model.GetPullBackportByID(ctx, id)
+	if err != nil {
+		if issues_model.IsErrPullBackportNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if backport.Status != issues_model.PullBackportPending {
+		return nil
+	}
+	pr, err := issues_model.GetPullRequestByID(ctx, backport.PullID)
+	if err != nil {
+		return err
+	}
+	if !pr.HasMerged {
+		// the backport runs once the pull request is merged
+		return nil
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	doer, err := user_model.GetUserByID(ctx, backport.DoerID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			doer = user_model.NewGhostUser()
+		} else {
+			return err
+		}
+	}
+
+	var report string
+	backportPR, err := backportPullRequest(ctx, doer, pr, backport.TargetBranch)
+	if err == nil {
+		backport.Status = issues_model.PullBackportDone
+		backport.BackportPullID = backportPR.ID
+		backport.Error = ""
+		report = fmt.Sprintf("Backported to `%s` in #%d.", backport.TargetBranch, backportPR.Index)
+	} else {
+		backport.Status = issues_model.PullBackportFailed
+		backport.Error = err.Error()
+		var conflict models.ErrCherryPickConflict
+		var reported errBackport
+		switch {
+		case errors.As(err, &conflict):
+			report = fmt.Sprintf("Backporting to `%s` failed: commit %s conflicts with the branch in:\n", backport.TargetBranch, conflict.CommitID)
+			for _, file := range conflict.Files {
+				report += fmt.Sprintf("\n* `%s`", file)
+			}
+		case errors.As(err, &reported):
+			report = fmt.Sprintf("Backporting to `%s` failed: %s.", backport.TargetBranch, reported.msg)
+		default:
+			log.Error("backport of %-v to %s: %v", pr, backport.TargetBranch, err)
+			report = fmt.Sprintf("Backporting to `%s` failed because of an internal error.", backport.TargetBranch)
+		}
+	}
+	if err := issues_model.UpdatePullBackportResult(ctx, backport); err != nil {
+		return err
+	}
+	if doer.IsGhost() {
+		return nil
+	}
+	_, err = issue_service.CreateIssueComment(ctx, doer, pr.BaseRepo, pr.Issue, report, nil)
+	return err
+}
+
+// backportBranchName returns the name of the branch holding the backport of a pull request
+func backportBranchName(pr *issues_model.PullRequest, targetBranch string) string {
+	return fmt.Sprintf("backport-%d-%s", pr.Index, strings.ReplaceAll(targetBranch, "/", "-"))
+}
+
+// backportPullRequest cherry-picks the commits of a merged pull request onto a new branch created from the
+// target branch, and opens a pull request from this branch against the target branch. The merge commits of
+// the pull request are skipped.
+func backportPullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, targetBranch string) (*issues_model.PullRequest, error) {
+	repo := pr.BaseRepo
+	if doer.IsGhost() {
+		return nil, errBackport{"the user who requested it does not exist anymore"}
+	}
+	if repo.IsArchived {
+		return nil, errBackport{"the repository is archived"}
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanWrite(unit.TypeCode) {
+		return nil, errBackport{fmt.Sprintf("@%s cannot write to the repository", doer.Name)}
+	}
+	if targetBranch == pr.BaseBranch {
+		return nil, errBackport{"it is the base branch of the pull request"}
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	if !gitRepo.IsBranchExist(targetBranch) {
+		return nil, errBackport{"the branch does not exist"}
+	}
+	branch := backportBranchName(pr, targetBranch)
+	if gitRepo.IsBranchExist(branch) {
+		return nil, errBackport{fmt.Sprintf("the branch `%s` already exists", branch)}
+	}
+	mergeBase, err := gitRepo.GetBranchCommitID(targetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return nil, err
+	}
+	commits, err := gitRepo.CommitsBetweenIDs(headCommitID, pr.MergeBase)
+	if err != nil {
+		return nil, err
+	}
+	commits = slices.DeleteFunc(commits, func(c *git.Commit) bool { return c.ParentCount() > 1 })
+	if len(commits) == 0 {
+		return nil, errBackport{"the pull request has no commits to backport"}
+	}
+	// the commits are listed from the newest
+	slices.Reverse(commits)
+
+	oldBranch := targetBranch
+	for _, commit := range commits {
+		opts := &files_service.ApplyDiffPatchOptions{
+			OldBranch: oldBranch,
+			NewBranch: branch,
+			Message:   fmt.Sprintf("%s\n\n(cherry picked from commit %s)", strings.TrimSpace(commit.Message()), commit.ID),
+			Content:   commit.ID.String(),
+			Author: &files_service.IdentityOptions{
+				Name:  commit.Author.Name,
+				Email: commit.Author.Email,
+			},
+			Committer: &files_service.IdentityOptions{
+				Name:  doer.DisplayName(),
+				Email: doer.GetEmail(),
+			},
+			Dates: &files_service.CommitDateOptions{
+				Author:    commit.Author.When,
+				Committer: time.Now(),
+			},
+		}
+		if _, err := files_service.CherryPick(ctx, repo, doer, false, opts); err != nil {
+			if oldBranch == branch {
+				if err := repo_service.DeleteBranch(ctx, doer, repo, gitRepo, branch); err != nil {
+					log.Error("DeleteBranch %s of %-v: %v", branch, repo, err)
+				}
+			}
+			return nil, err
+		}
+		oldBranch = branch
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    fmt.Sprintf("[Backport %s] %s", targetBranch, pr.Issue.Title),
+		PosterID: doer.ID,
+		Poster:   doer,
+		IsPull:   true,
+		Content:  fmt.Sprintf("Backport of #%d to `%s`.", pr.Index, targetBranch),
+	}
+	backportPR := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: branch,
+		BaseBranch: targetBranch,
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		MergeBase:  mergeBase,
+		Type:       issues_model.PullRequestGitea,
+	}
+	if err := pull_service.NewPullRequest(ctx, repo, issue, nil, nil, backportPR, nil); err != nil {
+		return nil, err
+	}
+	return backportPR, nil
+}
+
+// requestBackports records the backports requested with the labels of a merged pull request and queues all
+// its pending backports
+func requestBackports(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return err
+	}
+	if err := pr.Issue.LoadLabels(ctx); err != nil {
+		return err
+	}
+	for _, label := range pr.Issue.Labels {
+		targetBranch, ok := strings.CutPrefix(label.Name, LabelPrefix)
+		if !ok || targetBranch == "" {
+			continue
+		}
+		if _, err := issues_model.RequestPullBackport(ctx, pr.ID, targetBranch, doer.ID); err != nil {
+			return err
+		}
+	}
+
+	backports, err := issues_model.GetPullBackports(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	for _, backport := range backports {
+		if backport.Status == issues_model.PullBackportPending {
+			push(backport.ID)
+		}
+	}
+	return nil
+}
+
+// parseBackportCommand returns the target branches of the lines of a comment of the form
+// "/backport <branch>..."
+func parseBackportCommand(content string) []string {
+	var branches []string
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "/backport" {
+			continue
+		}
+		for _, branch := range fields[1:] {
+			if !slices.Contains(branches, branch) {
+				branches = append(branches, branch)
+			}
+		}
+	}
+	return branches
+}
+
+// requestBackportsFromComment records the backports requested by a comment, and queues them if the pull
+// request is already merged. The comments of the users who cannot write to the repository are ignored.
+func requestBackportsFromComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content string) error {
+	branches := parseBackportCommand(content)
+	if len(branches) == 0 {
+		return nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWrite(unit.TypeCode) {
+		return nil
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		return err
+	}
+	for _, branch := range branches {
+		if !git.IsValidRefPattern(branch) {
+			continue
+		}
+		backport, err := issues_model.RequestPullBackport(ctx, issue.PullRequest.ID, branch, doer.ID)
+		if err != nil {
+			return err
+		}
+		if issue.PullRequest.HasMerged && backport.Status == issues_model.PullBackportPending {
+			push(backport.ID)
+		}
+	}
+	return nil
+}
+
+func push(id int64) {
+	if err := backportQueue.Push(id); err != nil {
+		log.Error("push backport %d to the queue: %v", id, err)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package backport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBackportCommand(t *testing.T) {
+	assert.Empty(t, parseBackportCommand("Please backport this"))
+	assert.Empty(t, parseBackportCommand("/backport"))
+	assert.Equal(t, []string{"release/1.2", "release/1.3"},
+		parseBackportCommand("LGTM\n/backport release/1.2\r\n/backport release/1.3 release/1.2\nsee /backport v1"))
+}
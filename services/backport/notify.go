@@ -0,0 +1,46 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package backport
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	notify_service "forgejo.org/services/notify"
+)
+
+type backportNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &backportNotifier{}
+
+// NewNotifier create a new backportNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &backportNotifier{}
+}
+
+func (*backportNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	if err := requestBackports(ctx, doer, pr); err != nil {
+		log.Error("requestBackports of %-v: %v", pr, err)
+	}
+}
+
+func (*backportNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	if err := requestBackports(ctx, doer, pr); err != nil {
+		log.Error("requestBackports of %-v: %v", pr, err)
+	}
+}
+
+func (*backportNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment, _ []*user_model.User) {
+	if !issue.IsPull || comment == nil {
+		return
+	}
+	if err := requestBackportsFromComment(ctx, doer, repo, issue, comment.Content); err != nil {
+		log.Error("requestBackportsFromComment of issue %d: %v", issue.ID, err)
+	}
+}
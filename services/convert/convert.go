@@ -7,6 +7,7 @@ package convert
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -153,6 +154,11 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		branchName = bp.RuleName
 	}
 
+	statusCheckSources := make([]string, 0, len(bp.StatusCheckSources))
+	for _, source := range bp.StatusCheckSources {
+		statusCheckSources = append(statusCheckSources, git_model.FormatStatusCheckSource(ctx, source))
+	}
+
 	return &api.BranchProtection{
 		BranchName:                    branchName,
 		RuleName:                      bp.RuleName,
@@ -166,6 +172,7 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		MergeWhitelistTeams:           mergeWhitelistTeams,
 		EnableStatusCheck:             bp.EnableStatusCheck,
 		StatusCheckContexts:           bp.StatusCheckContexts,
+		StatusCheckSources:            statusCheckSources,
 		RequiredApprovals:             bp.RequiredApprovals,
 		EnableApprovalsWhitelist:      bp.EnableApprovalsWhitelist,
 		ApprovalsWhitelistUsernames:   approvalsWhitelistUsernames,
@@ -175,7 +182,9 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		BlockOnOutdatedBranch:         bp.BlockOnOutdatedBranch,
 		DismissStaleApprovals:         bp.DismissStaleApprovals,
 		IgnoreStaleApprovals:          bp.IgnoreStaleApprovals,
+		RequireCodeOwnerApproval:      bp.RequireCodeOwnerApproval,
 		RequireSignedCommits:          bp.RequireSignedCommits,
+		RequireResolvedConversations:  bp.RequireResolvedConversations,
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		ApplyToAdmins:                 bp.ApplyToAdmins,
@@ -370,6 +379,12 @@ func ToTeams(ctx context.Context, teams []*organization.Team, loadOrgs bool) ([]
 			UnitsMap:                t.GetUnitsMap(),
 		}
 
+		reviewAssignment, err := toTeamReviewAssignment(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		apiTeam.ReviewAssignment = reviewAssignment
+
 		if loadOrgs {
 			apiOrg, ok := cache[t.OrgID]
 			if !ok {
@@ -388,6 +403,45 @@ func ToTeams(ctx context.Context, teams []*organization.Team, loadOrgs bool) ([]
 	return apiTeams, nil
 }
 
+func toTeamReviewAssignment(ctx context.Context, t *organization.Team) (*api.TeamReviewAssignment, error) {
+	managers, err := organization.GetTeamReviewManagers(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := slices.Clone(t.ReviewAssignmentExcludedUserIDs)
+	for _, m := range managers {
+		userIDs = append(userIDs, m.UserID, m.ManagerID)
+	}
+	users, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+	}
+
+	apiReviewAssignment := &api.TeamReviewAssignment{
+		Algorithm:  string(t.ReviewAssignment),
+		Count:      t.ReviewAssignmentCount,
+		MaxPending: t.ReviewAssignmentMaxPending,
+		Excluded:   make([]string, 0, len(t.ReviewAssignmentExcludedUserIDs)),
+		Managers:   make(map[string][]string),
+	}
+	for _, userID := range t.ReviewAssignmentExcludedUserIDs {
+		if name, ok := names[userID]; ok {
+			apiReviewAssignment.Excluded = append(apiReviewAssignment.Excluded, name)
+		}
+	}
+	for _, m := range managers {
+		userName, managerName := names[m.UserID], names[m.ManagerID]
+		if userName != "" && managerName != "" {
+			apiReviewAssignment.Managers[userName] = append(apiReviewAssignment.Managers[userName], managerName)
+		}
+	}
+	return apiReviewAssignment, nil
+}
+
 // ToAnnotatedTag convert git.Tag to api.AnnotatedTag
 func ToAnnotatedTag(ctx context.Context, repo *repo_model.Repository, t *git.Tag, c *git.Commit) *api.AnnotatedTag {
 	return &api.AnnotatedTag{
@@ -56,6 +56,10 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		Updated:     issue.UpdatedUnix.AsTime(),
 		PinOrder:    issue.PinOrder,
 	}
+	if issue.IsHidden {
+		// hidden by a moderator
+		apiIssue.Body = ""
+	}
 
 	if issue.Repo != nil {
 		if err := issue.Repo.LoadOwner(ctx); err != nil {
@@ -136,6 +140,35 @@ func ToAPIIssueList(ctx context.Context, doer *user_model.User, il issues_model.
 	return result
 }
 
+// ToAPISubIssueTree converts a tree of sub-issues to API format
+func ToAPISubIssueTree(ctx context.Context, doer *user_model.User, tree issues_model.SubIssueTree) *api.SubIssueTree {
+	return &api.SubIssueTree{
+		Progress:  ToAPISubIssueProgress(tree.Progress()),
+		SubIssues: toAPISubIssueNodes(ctx, doer, tree),
+	}
+}
+
+func toAPISubIssueNodes(ctx context.Context, doer *user_model.User, tree issues_model.SubIssueTree) []*api.SubIssueNode {
+	result := make([]*api.SubIssueNode, len(tree))
+	for i, node := range tree {
+		result[i] = &api.SubIssueNode{
+			Issue:     ToAPIIssue(ctx, doer, node.Issue),
+			Progress:  ToAPISubIssueProgress(node.Children.Progress()),
+			SubIssues: toAPISubIssueNodes(ctx, doer, node.Children),
+		}
+	}
+	return result
+}
+
+// ToAPISubIssueProgress converts the progress of sub-issues to API format
+func ToAPISubIssueProgress(progress issues_model.SubIssueProgress) *api.SubIssueProgress {
+	return &api.SubIssueProgress{
+		Closed:  progress.Closed,
+		Total:   progress.Total,
+		Percent: progress.Percent(),
+	}
+}
+
 // ToTrackedTime converts TrackedTime to API format
 func ToTrackedTime(ctx context.Context, doer *user_model.User, t *issues_model.TrackedTime) (apiT *api.TrackedTime) {
 	apiT = &api.TrackedTime{
@@ -22,13 +22,21 @@ func ToAPIComment(ctx context.Context, repo *repo_model.Repository, c *issues_mo
 		HTMLURL:     c.HTMLURL(ctx),
 		IssueURL:    c.IssueURL(ctx),
 		PRURL:       c.PRURL(ctx),
-		Body:        c.Content,
+		Body:        commentBody(c),
 		Attachments: ToAPIAttachments(repo, c.Attachments),
 		Created:     c.CreatedUnix.AsTime(),
 		Updated:     c.UpdatedUnix.AsTime(),
 	}
 }
 
+// commentBody returns the content of a comment, unless a moderator hid it
+func commentBody(c *issues_model.Comment) string {
+	if c.IsHidden {
+		return ""
+	}
+	return c.Content
+}
+
 // ToTimelineComment converts a issues_model.Comment to the api.TimelineComment format
 func ToTimelineComment(ctx context.Context, repo *repo_model.Repository, c *issues_model.Comment, doer *user_model.User) *api.TimelineComment {
 	err := c.LoadMilestone(ctx)
@@ -85,7 +93,7 @@ func ToTimelineComment(ctx context.Context, repo *repo_model.Repository, c *issu
 		HTMLURL:  c.HTMLURL(ctx),
 		IssueURL: c.IssueURL(ctx),
 		PRURL:    c.PRURL(ctx),
-		Body:     c.Content,
+		Body:     commentBody(c),
 		Created:  c.CreatedUnix.AsTime(),
 		Updated:  c.UpdatedUnix.AsTime(),
 
@@ -0,0 +1,39 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+	"strings"
+
+	moderation_model "forgejo.org/models/moderation"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPIAbuseReport converts an abuse report to API format, the reporter and the resolver must be loaded
+func ToAPIAbuseReport(ctx context.Context, doer *user_model.User, report *moderation_model.AbuseReport) *api.AbuseReport {
+	apiReport := &api.AbuseReport{
+		ID:               report.ID,
+		Status:           report.Status.String(),
+		Reporter:         ToUser(ctx, report.Reporter, doer),
+		ContentType:      report.ContentType.String(),
+		ContentID:        report.ContentID,
+		ContentReference: report.ContentReference,
+		ContentURL:       setting.AppURL + strings.TrimPrefix(strings.TrimPrefix(report.ContentLink, setting.AppSubURL), "/"),
+		Category:         report.Category.String(),
+		Remarks:          report.Remarks,
+		Action:           report.Action.String(),
+		ActionReason:     report.ActionReason,
+		Created:          report.CreatedUnix.AsTime(),
+	}
+	if report.Resolver != nil {
+		apiReport.Resolver = ToUser(ctx, report.Resolver, doer)
+	}
+	if report.ResolvedUnix > 0 {
+		apiReport.Resolved = report.ResolvedUnix.AsTimePtr()
+	}
+	return apiReport
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+	"slices"
+
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	api "forgejo.org/modules/structs"
+)
+
+// ToOrgRuleset converts a git_model.OrgRuleset to an api.OrgRuleset
+func ToOrgRuleset(ctx context.Context, rs *git_model.OrgRuleset) *api.OrgRuleset {
+	userNames := func(ids []int64) []string {
+		users, err := user_model.GetUsersByIDs(ctx, ids)
+		if err != nil {
+			log.Error("GetUsersByIDs: %v", err)
+		}
+		names := make([]string, 0, len(users))
+		for _, u := range users {
+			names = append(names, u.Name)
+		}
+		return names
+	}
+
+	teams, err := organization.FindOrgTeams(ctx, rs.OrgID)
+	if err != nil {
+		log.Error("FindOrgTeams: %v", err)
+	}
+	teamNames := func(ids []int64) []string {
+		names := make([]string, 0, len(ids))
+		for _, t := range teams {
+			if slices.Contains(ids, t.ID) {
+				names = append(names, t.Name)
+			}
+		}
+		return names
+	}
+
+	return &api.OrgRuleset{
+		ID:                      rs.ID,
+		Name:                    rs.Name,
+		Target:                  rs.Target.String(),
+		Enforcement:             rs.Enforcement.String(),
+		RepoNamePatterns:        rs.RepoNamePatterns,
+		RepoTopics:              rs.RepoTopics,
+		RefPatterns:             rs.RefPatterns,
+		EnablePush:              rs.CanPush,
+		EnablePushWhitelist:     rs.EnableWhitelist,
+		PushWhitelistUsernames:  userNames(rs.WhitelistUserIDs),
+		PushWhitelistTeams:      teamNames(rs.WhitelistTeamIDs),
+		PushWhitelistDeployKeys: rs.WhitelistDeployKeys,
+		EnableMergeWhitelist:    rs.EnableMergeWhitelist,
+		MergeWhitelistUsernames: userNames(rs.MergeWhitelistUserIDs),
+		MergeWhitelistTeams:     teamNames(rs.MergeWhitelistTeamIDs),
+		EnableStatusCheck:       rs.EnableStatusCheck,
+		StatusCheckContexts:     rs.StatusCheckContexts,
+		RequiredApprovals:       rs.RequiredApprovals,
+		BlockOnRejectedReviews:  rs.BlockOnRejectedReviews,
+		RequireSignedCommits:    rs.RequireSignedCommits,
+		ApplyToAdmins:           rs.ApplyToAdmins,
+		Created:                 rs.CreatedUnix.AsTime(),
+		Updated:                 rs.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToOrgRulesetViolation converts a git_model.OrgRulesetViolation to an api.OrgRulesetViolation
+func ToOrgRulesetViolation(ctx context.Context, v *git_model.OrgRulesetViolation, doer *user_model.User) *api.OrgRulesetViolation {
+	violation := &api.OrgRulesetViolation{
+		ID:        v.ID,
+		RulesetID: v.RulesetID,
+		Ref:       v.RefName,
+		Reason:    v.Reason,
+		Created:   v.CreatedUnix.AsTime(),
+	}
+
+	if repo, err := repo_model.GetRepositoryByID(ctx, v.RepoID); err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", v.RepoID, err)
+	} else {
+		violation.Repository = ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeRead})
+	}
+
+	if pusher, err := user_model.GetPossibleUserByID(ctx, v.DoerID); err != nil {
+		log.Error("GetPossibleUserByID[%d]: %v", v.DoerID, err)
+	} else {
+		violation.Doer = ToUser(ctx, pusher, doer)
+	}
+
+	return violation
+}
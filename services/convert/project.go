@@ -0,0 +1,156 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+	"fmt"
+
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	api "forgejo.org/modules/structs"
+)
+
+var (
+	projectTypeNames = map[project_model.Type]string{
+		project_model.TypeIndividual:   "individual",
+		project_model.TypeRepository:   "repository",
+		project_model.TypeOrganization: "organization",
+	}
+
+	// ProjectTemplateTypes maps the names of the project templates of the API to their type
+	ProjectTemplateTypes = map[string]project_model.TemplateType{
+		"none":         project_model.TemplateTypeNone,
+		"basic_kanban": project_model.TemplateTypeBasicKanban,
+		"bug_triage":   project_model.TemplateTypeBugTriage,
+	}
+
+	// ProjectCardTypes maps the names of the project card types of the API to their type
+	ProjectCardTypes = map[string]project_model.CardType{
+		"text_only":       project_model.CardTypeTextOnly,
+		"images_and_text": project_model.CardTypeImagesAndText,
+	}
+)
+
+// ToAPIProject converts a project_model.Project to an api.Project
+func ToAPIProject(ctx context.Context, p *project_model.Project, doer *user_model.User) *api.Project {
+	apiProject := &api.Project{
+		ID:           p.ID,
+		Title:        p.Title,
+		Description:  p.Description,
+		State:        api.StateOpen,
+		Type:         projectTypeNames[p.Type],
+		OpenIssues:   p.NumOpenIssues(ctx),
+		ClosedIssues: p.NumClosedIssues(ctx),
+		Created:      p.CreatedUnix.AsTime(),
+		Updated:      p.UpdatedUnix.AsTime(),
+	}
+	for name, cardType := range ProjectCardTypes {
+		if cardType == p.CardType {
+			apiProject.CardType = name
+		}
+	}
+	if p.IsClosed {
+		apiProject.State = api.StateClosed
+		apiProject.Closed = p.ClosedDateUnix.AsTimePtr()
+	}
+
+	if p.RepoID > 0 {
+		if err := p.LoadRepo(ctx); err != nil {
+			log.Error("LoadRepo: %v", err)
+		} else {
+			apiProject.Repo = &api.RepositoryMeta{
+				ID:       p.Repo.ID,
+				Name:     p.Repo.Name,
+				Owner:    p.Repo.OwnerName,
+				FullName: p.Repo.FullName(),
+			}
+			apiProject.HTMLURL = fmt.Sprintf("%s/projects/%d", p.Repo.HTMLURL(), p.ID)
+		}
+	} else if p.OwnerID > 0 {
+		if err := p.LoadOwner(ctx); err != nil {
+			log.Error("LoadOwner: %v", err)
+		} else {
+			apiProject.Owner = ToUser(ctx, p.Owner, doer)
+			apiProject.HTMLURL = fmt.Sprintf("%s/-/projects/%d", p.Owner.HTMLURL(), p.ID)
+		}
+	}
+
+	creator, err := user_model.GetPossibleUserByID(ctx, p.CreatorID)
+	if err != nil {
+		log.Error("GetPossibleUserByID: %v", err)
+	} else {
+		apiProject.Creator = ToUser(ctx, creator, doer)
+	}
+	return apiProject
+}
+
+// ToAPIProjectColumn converts a project_model.Column to an api.ProjectColumn
+func ToAPIProjectColumn(column *project_model.Column) *api.ProjectColumn {
+	return &api.ProjectColumn{
+		ID:        column.ID,
+		ProjectID: column.ProjectID,
+		Title:     column.Title,
+		Color:     column.Color,
+		Default:   column.Default,
+		Sorting:   int(column.Sorting),
+		Created:   column.CreatedUnix.AsTime(),
+		Updated:   column.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToAPIProjectField converts a project_model.Field to an api.ProjectField
+func ToAPIProjectField(field *project_model.Field) *api.ProjectField {
+	apiField := &api.ProjectField{
+		ID:         field.ID,
+		ProjectID:  field.ProjectID,
+		OwnerID:    field.OwnerID,
+		Name:       field.Name,
+		Type:       field.Type.String(),
+		Options:    make([]*api.ProjectFieldOption, 0, len(field.Options)),
+		Iterations: make([]*api.ProjectFieldIteration, 0, len(field.Iterations)),
+		Created:    field.CreatedUnix.AsTime(),
+		Updated:    field.UpdatedUnix.AsTime(),
+	}
+	for _, option := range field.Options {
+		apiField.Options = append(apiField.Options, &api.ProjectFieldOption{
+			Name:  option.Name,
+			Color: option.Color,
+		})
+	}
+	for _, iteration := range field.Iterations {
+		apiField.Iterations = append(apiField.Iterations, &api.ProjectFieldIteration{
+			Title:     iteration.Title,
+			StartDate: iteration.StartDate,
+			Duration:  iteration.Duration,
+		})
+	}
+	return apiField
+}
+
+// ToProjectFieldOptions converts the options of a custom field of projects of the API
+func ToProjectFieldOptions(options []*api.ProjectFieldOption) []*project_model.FieldOption {
+	fieldOptions := make([]*project_model.FieldOption, 0, len(options))
+	for _, option := range options {
+		fieldOptions = append(fieldOptions, &project_model.FieldOption{
+			Name:  option.Name,
+			Color: option.Color,
+		})
+	}
+	return fieldOptions
+}
+
+// ToProjectFieldIterations converts the iterations of a custom field of projects of the API
+func ToProjectFieldIterations(iterations []*api.ProjectFieldIteration) []*project_model.FieldIteration {
+	fieldIterations := make([]*project_model.FieldIteration, 0, len(iterations))
+	for _, iteration := range iterations {
+		fieldIterations = append(fieldIterations, &project_model.FieldIteration{
+			Title:     iteration.Title,
+			StartDate: iteration.StartDate,
+			Duration:  iteration.Duration,
+		})
+	}
+	return fieldIterations
+}
@@ -31,6 +31,7 @@ func ToPullReview(ctx context.Context, r *issues_model.Review, doer *user_model.
 		Official:          r.Official,
 		Dismissed:         r.Dismissed,
 		CodeCommentsCount: r.GetCodeCommentsCount(ctx),
+		Checklist:         r.Checklist,
 		Submitted:         r.CreatedUnix.AsTime(),
 		Updated:           r.UpdatedUnix.AsTime(),
 		HTMLURL:           r.HTMLURL(ctx),
@@ -98,8 +99,10 @@ func ToPullReviewComment(ctx context.Context, review *issues_model.Review, comme
 
 	if comment.Line < 0 {
 		apiComment.OldLineNum = comment.UnsignedLine()
+		apiComment.OldStartLineNum = comment.UnsignedStartLine()
 	} else {
 		apiComment.LineNum = comment.UnsignedLine()
+		apiComment.StartLineNum = comment.UnsignedStartLine()
 	}
 
 	return apiComment, nil
@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPIRecurringIssue converts a recurring issue to API format
+func ToAPIRecurringIssue(ctx context.Context, doer *user_model.User, r *issues_model.RecurringIssue) *api.RecurringIssue {
+	apiRecurring := &api.RecurringIssue{
+		ID:          r.ID,
+		Title:       r.Title,
+		Body:        r.Content,
+		Labels:      r.Labels,
+		Assignees:   r.Assignees,
+		Schedule:    r.Schedule,
+		SkipIfOpen:  r.SkipIfOpen,
+		Active:      r.IsActive,
+		LastIssueID: r.LastIssueID,
+		Created:     r.CreatedUnix.AsTime(),
+		Updated:     r.UpdatedUnix.AsTime(),
+	}
+	if apiRecurring.Labels == nil {
+		apiRecurring.Labels = []string{}
+	}
+	if apiRecurring.Assignees == nil {
+		apiRecurring.Assignees = []string{}
+	}
+	if r.LastRunUnix > 0 {
+		lastRun := r.LastRunUnix.AsTime()
+		apiRecurring.LastRun = &lastRun
+	}
+	if r.NextRunUnix > 0 {
+		nextRun := r.NextRunUnix.AsTime()
+		apiRecurring.NextRun = &nextRun
+	}
+	creator, err := user_model.GetPossibleUserByID(ctx, r.CreatorID)
+	if err != nil {
+		creator = user_model.NewGhostUser()
+	}
+	apiRecurring.Creator = ToUser(ctx, creator, doer)
+	return apiRecurring
+}
+
+// ToAPIRecurringIssueRun converts a run of a recurring issue to API format, its issue must be loaded
+func ToAPIRecurringIssueRun(ctx context.Context, doer *user_model.User, run *issues_model.RecurringIssueRun) *api.RecurringIssueRun {
+	apiRun := &api.RecurringIssueRun{
+		ID:      run.ID,
+		Skipped: run.Skipped,
+		Error:   run.Error,
+		Created: run.CreatedUnix.AsTime(),
+	}
+	if run.Issue != nil {
+		apiRun.Issue = ToAPIIssue(ctx, doer, run.Issue)
+	}
+	return apiRun
+}
@@ -242,6 +242,7 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 		RepoTransfer:                  transfer,
 		Topics:                        repo.Topics,
 		ObjectFormatName:              repo.ObjectFormatName,
+		MaxPushedBlobSize:             repo.MaxPushedBlobSize,
 	}
 }
 
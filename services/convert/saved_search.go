@@ -0,0 +1,52 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+	"fmt"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPISavedSearchQuery converts the filters of a saved search to API format
+func ToAPISavedSearchQuery(filter issues_model.SavedSearchFilter) api.SavedSearchQuery {
+	return api.SavedSearchQuery{
+		Keyword:    filter.Keyword,
+		Type:       filter.Type,
+		State:      filter.State,
+		Labels:     filter.Labels,
+		Milestones: filter.Milestones,
+		Repos:      filter.Repos,
+		Assignee:   filter.Assignee,
+		Poster:     filter.Poster,
+		Mentioned:  filter.Mentioned,
+		ProjectID:  filter.ProjectID,
+		Sort:       filter.Sort,
+	}
+}
+
+// ToAPISavedSearch converts a saved search to API format, the owner must be loaded
+func ToAPISavedSearch(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch, canEdit bool, sub *issues_model.SavedSearchSubscription) *api.SavedSearch {
+	apiSearch := &api.SavedSearch{
+		ID:      search.ID,
+		Name:    search.Name,
+		Owner:   ToUser(ctx, search.Owner, doer),
+		Query:   ToAPISavedSearchQuery(search.Filter()),
+		CanEdit: canEdit,
+		HTMLURL: fmt.Sprintf("%ssearches/%d", setting.AppURL, search.ID),
+		Created: search.CreatedUnix.AsTime(),
+		Updated: search.UpdatedUnix.AsTime(),
+	}
+	if sub != nil {
+		apiSearch.Subscription = api.SavedSearchSubscription{
+			OnDashboard: sub.OnDashboard,
+			Notify:      sub.Notify,
+		}
+	}
+	return apiSearch
+}
@@ -14,11 +14,14 @@ import (
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/setting"
 	"forgejo.org/services/auth"
+	issue_service "forgejo.org/services/issue"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
 	packages_cleanup_service "forgejo.org/services/packages/cleanup"
+	replica_service "forgejo.org/services/replica"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
+	triage_service "forgejo.org/services/triage"
 )
 
 func registerUpdateMirrorTask() {
@@ -42,6 +45,16 @@ func registerUpdateMirrorTask() {
 	})
 }
 
+func registerReplicaSyncRepositories() {
+	RegisterTaskFatal("replica_sync_repositories", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return replica_service.SyncRepositories(ctx)
+	})
+}
+
 func registerRepoHealthCheck() {
 	type RepoHealthCheckConfig struct {
 		BaseConfig
@@ -63,6 +76,36 @@ func registerRepoHealthCheck() {
 	})
 }
 
+func registerCheckSavedSearches() {
+	RegisterTaskFatal("check_saved_searches", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.CheckSavedSearchNotifications(ctx)
+	})
+}
+
+func registerTriageStaleIssues() {
+	RegisterTaskFatal("triage_stale_issues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return triage_service.CheckStaleIssues(ctx)
+	})
+}
+
+func registerCreateRecurringIssues() {
+	RegisterTaskFatal("create_recurring_issues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.CreateDueRecurringIssues(ctx)
+	})
+}
+
 func registerCheckRepoStats() {
 	RegisterTaskFatal("check_repo_stats", &BaseConfig{
 		Enabled:    true,
@@ -160,8 +203,14 @@ func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
 	}
+	if setting.Replica.Enabled {
+		registerReplicaSyncRepositories()
+	}
 	registerRepoHealthCheck()
 	registerCheckRepoStats()
+	registerCheckSavedSearches()
+	registerTriageStaleIssues()
+	registerCreateRecurringIssues()
 	registerArchiveCleanup()
 	registerSyncExternalUsers()
 	registerDeletedBranchesCleanup()
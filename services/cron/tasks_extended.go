@@ -5,6 +5,8 @@ package cron
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	activities_model "forgejo.org/models/activities"
@@ -17,6 +19,7 @@ import (
 	"forgejo.org/modules/updatechecker"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
+	timesheet_service "forgejo.org/services/timesheet"
 	user_service "forgejo.org/services/user"
 )
 
@@ -61,10 +64,57 @@ func registerGarbageCollectRepositories() {
 	}, func(ctx context.Context, _ *user_model.User, config Config) error {
 		rhcConfig := config.(*RepoHealthCheckConfig)
 		// the git args are set by config, they can be safe to be trusted
+		// object pools go first, so that the objects they received can then be dropped from their members
+		if err := repo_service.GitGcObjectPools(ctx, rhcConfig.Timeout, git.ToTrustedCmdArgs(rhcConfig.Args)); err != nil {
+			return err
+		}
 		return repo_service.GitGcRepos(ctx, rhcConfig.Timeout, git.ToTrustedCmdArgs(rhcConfig.Args))
 	})
 }
 
+func registerMaintainRepositories() {
+	type RepoMaintenanceConfig struct {
+		BaseConfig
+		Timeout           time.Duration
+		Tasks             []string `delim:","`
+		Interval          time.Duration
+		PushThreshold     int64
+		MinRepoSize       int64
+		BitmapMinRepoSize int64
+	}
+	RegisterTaskFatal("git_maintenance_repos", &RepoMaintenanceConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 1h",
+		},
+		Timeout:           time.Duration(setting.Git.Timeout.GC) * time.Second,
+		Tasks:             []string{"commit-graph", "loose-objects", "incremental-repack", "pack-bitmap"},
+		Interval:          24 * time.Hour,
+		PushThreshold:     100,
+		MinRepoSize:       0,
+		BitmapMinRepoSize: 100 * 1024 * 1024,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		rmConfig := config.(*RepoMaintenanceConfig)
+		tasks := make([]git.MaintenanceTask, 0, len(rmConfig.Tasks))
+		for _, name := range rmConfig.Tasks {
+			task := git.MaintenanceTask(strings.TrimSpace(name))
+			if !task.IsValid() {
+				return fmt.Errorf("unknown maintenance task %q", name)
+			}
+			tasks = append(tasks, task)
+		}
+		return repo_service.MaintainRepositories(ctx, &repo_service.MaintenanceOptions{
+			Timeout:           rmConfig.Timeout,
+			Tasks:             tasks,
+			Interval:          rmConfig.Interval,
+			PushThreshold:     rmConfig.PushThreshold,
+			MinRepoSize:       rmConfig.MinRepoSize,
+			BitmapMinRepoSize: rmConfig.BitmapMinRepoSize,
+		})
+	})
+}
+
 func registerRewriteAllPublicKeys() {
 	RegisterTaskFatal("resync_all_sshkeys", &BaseConfig{
 		Enabled:    false,
@@ -225,10 +275,21 @@ func registerRebuildIssueIndexer() {
 	})
 }
 
+func registerTimesheetWeeklySummary() {
+	RegisterTaskFatal("timesheet_weekly_summary", &BaseConfig{
+		Enabled:    false,
+		RunAtStart: false,
+		Schedule:   "@weekly",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return timesheet_service.SendWeeklySummaries(ctx)
+	})
+}
+
 func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
 	registerGarbageCollectRepositories()
+	registerMaintainRepositories()
 	registerRewriteAllPublicKeys()
 	registerRewriteAllPrincipalKeys()
 	registerRepositoryUpdateHook()
@@ -240,4 +301,5 @@ func initExtendedTasks() {
 	registerDeleteOldSystemNotices()
 	registerGCLFS()
 	registerRebuildIssueIndexer()
+	registerTimesheetWeeklySummary()
 }
@@ -0,0 +1,138 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package doctor
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// objectPoolIDFromAlternate returns the ID of the object pool an alternate object directory belongs to, if any
+func objectPoolIDFromAlternate(dir string) (int64, bool) {
+	rel, err := filepath.Rel(filepath.Join(setting.RepoRootPath, repo_model.ObjectPoolsDir), dir)
+	if err != nil {
+		return 0, false
+	}
+	name, ok := strings.CutSuffix(filepath.ToSlash(rel), ".git/objects")
+	if !ok {
+		return 0, false
+	}
+	poolID, err := strconv.ParseInt(name, 10, 64)
+	return poolID, err == nil
+}
+
+func checkObjectPools(ctx context.Context, logger log.Logger, autofix bool) error {
+	numMembers := 0
+	numBroken := 0
+	numFixed := 0
+	if err := iterateRepositories(ctx, func(repo *repo_model.Repository) error {
+		alternates, err := repo_module.ReadAlternates(repo.RepoPath())
+		if err != nil {
+			logger.Error("Unable to read the alternates of %s. Error: %v", repo.FullName(), err)
+			return err
+		}
+
+		if repo.ObjectPoolID == 0 {
+			for _, dir := range alternates {
+				poolID, ok := objectPoolIDFromAlternate(dir)
+				if !ok {
+					continue
+				}
+				numBroken++
+				logger.Warn("%s borrows objects from object pool %d but is not recorded as one of its members", repo.FullName(), poolID)
+				if autofix {
+					// the pool must know about the repository, or it could be removed while still in use
+					repo.ObjectPoolID = poolID
+					if err := repo_model.UpdateRepositoryCols(ctx, repo, "object_pool_id"); err != nil {
+						logger.Error("Unable to record %s as a member of object pool %d. Error: %v", repo.FullName(), poolID, err)
+						return err
+					}
+					numFixed++
+				}
+				break
+			}
+			return nil
+		}
+		numMembers++
+
+		poolObjects := filepath.Join(repo.ObjectPoolPath(), "objects")
+		isDir, err := util.IsDir(poolObjects)
+		if err != nil {
+			logger.Error("Unable to check if %s exists. Error: %v", poolObjects, err)
+			return err
+		}
+		if !isDir {
+			numBroken++
+			logger.Critical("Object pool %d of %s is missing, the objects it borrowed are lost", repo.ObjectPoolID, repo.FullName())
+			return nil
+		}
+
+		if !slices.Contains(alternates, filepath.Clean(poolObjects)) {
+			numBroken++
+			logger.Warn("%s does not borrow objects from its object pool %d", repo.FullName(), repo.ObjectPoolID)
+			if autofix {
+				if err := repo_module.WriteAlternates(repo.RepoPath(), repo.ObjectPoolPath()); err != nil {
+					logger.Error("Unable to write the alternates of %s. Error: %v", repo.FullName(), err)
+					return err
+				}
+				numFixed++
+			}
+		}
+
+		if _, _, err := git.NewCommand(ctx, "fsck", "--connectivity-only", "--no-dangling").RunStdString(&git.RunOpts{Dir: repo.RepoPath()}); err != nil {
+			numBroken++
+			logger.Critical("%s has objects missing from its object pool %d. Error: %v", repo.FullName(), repo.ObjectPoolID, err)
+			return nil
+		}
+
+		canShare, err := repo.CanShareObjects(ctx)
+		if err != nil {
+			return err
+		}
+		if !canShare {
+			numBroken++
+			logger.Warn("%s is not public but still shares its objects through object pool %d", repo.FullName(), repo.ObjectPoolID)
+			if autofix {
+				if err := repo_module.DetachFromObjectPool(ctx, repo); err != nil {
+					logger.Error("Unable to detach %s from its object pool. Error: %v", repo.FullName(), err)
+					return err
+				}
+				numFixed++
+			}
+		}
+		return nil
+	}); err != nil {
+		logger.Critical("Unable to checkObjectPools: %v", err)
+		return err
+	}
+
+	if autofix {
+		logger.Info("Checked %d object pool members, fixed %d of %d problems.", numMembers, numFixed, numBroken)
+	} else if numBroken > 0 {
+		logger.Warn("Checked %d object pool members, found %d problems.", numMembers, numBroken)
+	} else {
+		logger.Info("Checked %d object pool members, no problem found.", numMembers)
+	}
+	return nil
+}
+
+func init() {
+	Register(&Check{
+		Title:     "Check the git alternates of repositories sharing an object pool",
+		Name:      "check-object-pools",
+		IsDefault: false,
+		Run:       checkObjectPools,
+		Priority:  9,
+	})
+}
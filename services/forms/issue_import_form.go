@@ -0,0 +1,29 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forms
+
+import (
+	"mime/multipart"
+	"net/http"
+
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/context"
+
+	"code.forgejo.org/go-chi/binding"
+)
+
+// ImportIssuesForm form for importing issues from a file
+type ImportIssuesForm struct {
+	File          *multipart.FileHeader
+	Format        string `binding:"In(,csv,json)"`
+	CreateMissing bool
+	// Content is the content of the file which was validated, sent back to import it
+	Content string
+}
+
+// Validate validates form fields
+func (f *ImportIssuesForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
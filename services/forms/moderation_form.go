@@ -0,0 +1,39 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forms
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/context"
+
+	"code.forgejo.org/go-chi/binding"
+)
+
+// ReportAbuseForm form for reporting abusive content to the moderators
+type ReportAbuseForm struct {
+	ContentType string `binding:"Required"`
+	ContentID   int64  `binding:"Required"`
+	Category    string `binding:"Required"`
+	Remarks     string `binding:"MaxSize(2000)"`
+}
+
+// Validate validates form fields
+func (f *ReportAbuseForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ResolveAbuseReportForm form for a moderator to resolve an abuse report
+type ResolveAbuseReportForm struct {
+	Action string `binding:"Required"`
+	Reason string `binding:"MaxSize(2000)"`
+}
+
+// Validate validates form fields
+func (f *ResolveAbuseReportForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -74,3 +74,18 @@ func (f *CreateTeamForm) Validate(req *http.Request, errs binding.Errors) bindin
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// TeamReviewAssignmentForm form for the review assignment policy of a team
+type TeamReviewAssignmentForm struct {
+	ReviewAssignment string
+	Count            int `binding:"Range(1,10)"`
+	MaxPending       int `binding:"Range(0,1000)"`
+	ExcludedUsers    string
+	Managers         string
+}
+
+// Validate validates the fields
+func (f *TeamReviewAssignmentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forms
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/context"
+
+	"code.forgejo.org/go-chi/binding"
+)
+
+// RecurringIssueForm form for creating and editing a recurring issue
+type RecurringIssueForm struct {
+	Title      string `binding:"Required;MaxSize(255)"`
+	Content    string
+	Labels     string
+	Assignees  string
+	Schedule   string `binding:"Required;MaxSize(255)"`
+	SkipIfOpen bool
+	IsActive   bool
+}
+
+// Validate validates form fields
+func (f *RecurringIssueForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
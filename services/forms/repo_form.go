@@ -151,6 +151,9 @@ type RepoSettingForm struct {
 	// Signing Settings
 	TrustModel string
 
+	// Push limits, in MiB
+	MaxPushedBlobSize int64
+
 	// Admin settings
 	EnableHealthCheck  bool
 	RequestReindexType string
@@ -194,6 +197,7 @@ type RepoUnitSettingForm struct {
 	PullsAllowRebaseUpdate                bool
 	DefaultDeleteBranchAfterMerge         bool
 	DefaultAllowMaintainerEdit            bool
+	PullsReviewChecklist                  string
 	EnableTimetracker                     bool
 	AllowOnlyContributorsToTrackTime      bool
 	EnableIssueDependencies               bool
@@ -225,6 +229,7 @@ type ProtectBranchForm struct {
 	MergeWhitelistTeams           string
 	EnableStatusCheck             bool
 	StatusCheckContexts           string
+	StatusCheckSources            string
 	RequiredApprovals             int64
 	EnableApprovalsWhitelist      bool
 	ApprovalsWhitelistUsers       string
@@ -234,7 +239,9 @@ type ProtectBranchForm struct {
 	BlockOnOutdatedBranch         bool
 	DismissStaleApprovals         bool
 	IgnoreStaleApprovals          bool
+	RequireCodeOwnerApproval      bool
 	RequireSignedCommits          bool
+	RequireResolvedConversations  bool
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
 	ApplyToAdmins                 bool
@@ -263,6 +270,7 @@ type WebhookCoreForm struct {
 	IssueAssign              bool
 	IssueLabel               bool
 	IssueMilestone           bool
+	IssueProject             bool
 	IssueComment             bool
 	Release                  bool
 	Push                     bool
@@ -467,6 +475,7 @@ type CodeCommentForm struct {
 	Content        string `binding:"Required"`
 	Side           string `binding:"Required;In(previous,proposed)"`
 	Line           int64
+	StartLine      int64
 	TreePath       string `form:"path" binding:"Required"`
 	SingleReview   bool   `form:"single_review"`
 	Reply          int64  `form:"reply"`
@@ -480,12 +489,27 @@ func (f *CodeCommentForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// ApplySuggestionsForm form for applying suggestions of code comments
+type ApplySuggestionsForm struct {
+	Origin string `binding:"Required;In(timeline,diff)"`
+	// Suggestions are <comment id>-<suggestion index>
+	Suggestions []string `binding:"Required"`
+	Message     string
+}
+
+// Validate validates the fields
+func (f *ApplySuggestionsForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // SubmitReviewForm for submitting a finished code review
 type SubmitReviewForm struct {
-	Content  string
-	Type     string
-	CommitID string
-	Files    []string
+	Content   string
+	Type      string
+	CommitID  string
+	Checklist []string
+	Files     []string
 }
 
 // Validate validates the fields
@@ -0,0 +1,48 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forms
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/context"
+
+	"code.forgejo.org/go-chi/binding"
+)
+
+// SavedSearchForm form for creating and editing a saved search of issues and pull requests
+type SavedSearchForm struct {
+	OwnerID    int64
+	Name       string `binding:"Required;MaxSize(255)"`
+	Keyword    string `form:"q"`
+	Type       string
+	State      string
+	Labels     string
+	Milestones string
+	Repos      string
+	Assignee   string
+	Poster     string
+	Mentioned  string
+	Project    int64
+	Sort       string
+}
+
+// Validate validates form fields
+func (f *SavedSearchForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SavedSearchSubscriptionForm form for following a saved search
+type SavedSearchSubscriptionForm struct {
+	OnDashboard bool
+	Notify      bool
+}
+
+// Validate validates form fields
+func (f *SavedSearchSubscriptionForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forms
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/context"
+
+	"code.forgejo.org/go-chi/binding"
+)
+
+// TriageRuleForm form for creating and editing a triage rule
+type TriageRuleForm struct {
+	Name           string `binding:"Required;MaxSize(255)"`
+	Event          string `binding:"Required;In(opened,labeled,commented,stale)"`
+	StaleDays      int
+	IsActive       bool
+	DryRun         bool
+	Type           string `binding:"In(,issues,pulls)"`
+	Labels         string
+	ExcludedLabels string
+	Authors        string
+	TitleRegex     string `binding:"RegexPattern"`
+	BodyRegex      string `binding:"RegexPattern"`
+	Fields         string
+	AddLabels      string
+	RemoveLabels   string
+	AssignTeam     string
+	Comment        string
+	Close          bool
+	ProjectColumn  int64
+}
+
+// Validate validates form fields
+func (f *TriageRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
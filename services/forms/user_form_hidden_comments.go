@@ -70,6 +70,12 @@ var hiddenCommentTypeGroups = hiddenCommentTypeGroupsType{
 	"issue_ref": {
 		/*33*/ issues_model.CommentTypeChangeIssueRef,
 	},
+	"sub_issue": {
+		/*39*/ issues_model.CommentTypeAddSubIssue,
+		/*40*/ issues_model.CommentTypeRemoveSubIssue,
+		/*41*/ issues_model.CommentTypeAddParentIssue,
+		/*42*/ issues_model.CommentTypeRemoveParentIssue,
+	},
 }
 
 // UserHiddenCommentTypesFromRequest parse the form to hidden comment types bitset
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	code_indexer "forgejo.org/modules/indexer/code"
@@ -143,6 +144,18 @@ func (r *indexerNotifier) IssueChangeMilestone(ctx context.Context, doer *user_m
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
 
+func (r *indexerNotifier) IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID, oldColumnID int64) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
+func (r *indexerNotifier) IssueChangeProjectField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *project_model.Field, oldValue string) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
+func (r *indexerNotifier) IssueChangeSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue, removed bool) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
 func (r *indexerNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 	addedLabels, removedLabels []*issues_model.Label,
 ) {
@@ -211,23 +211,29 @@ func IsValidTeamReviewRequest(ctx context.Context, reviewer *organization.Team,
 	}
 }
 
-// TeamReviewRequest add or remove a review request from a team for this PR, and make comment for it.
-func TeamReviewRequest(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, reviewer *organization.Team, isAdd bool) (comment *issues_model.Comment, err error) {
-	if isAdd {
-		comment, err = issues_model.AddTeamReviewRequest(ctx, issue, reviewer, doer)
-	} else {
-		comment, err = issues_model.RemoveTeamReviewRequest(ctx, issue, reviewer, doer)
+// TeamReviewRequest add or remove a review request from a team for this PR, and make comments for it. When the
+// team has a review assignment policy, adding the request requests the review from some of its members instead.
+func TeamReviewRequest(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, reviewer *organization.Team, isAdd bool) (comments []*issues_model.Comment, err error) {
+	if !isAdd {
+		_, err = issues_model.RemoveTeamReviewRequest(ctx, issue, reviewer, doer)
+		return nil, err
 	}
 
+	notifiers, err := addTeamReviewRequest(ctx, issue, doer, reviewer)
 	if err != nil {
 		return nil, err
 	}
 
-	if comment == nil || !isAdd {
-		return nil, nil
+	comments = make([]*issues_model.Comment, 0, len(notifiers))
+	for _, notifier := range notifiers {
+		comments = append(comments, notifier.Comment)
+		if notifier.Reviewer != nil {
+			notify_service.PullRequestReviewRequest(ctx, doer, issue, notifier.Reviewer, true, notifier.Comment)
+		} else if err := teamReviewRequestNotify(ctx, issue, doer, notifier.ReviewTeam, true, notifier.Comment); err != nil {
+			return comments, err
+		}
 	}
-
-	return comment, teamReviewRequestNotify(ctx, issue, doer, reviewer, isAdd, comment)
+	return comments, nil
 }
 
 func ReviewRequestNotify(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, reviewNotifers []*ReviewRequestNotifier) {
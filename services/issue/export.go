@@ -0,0 +1,245 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/optional"
+	api "forgejo.org/modules/structs"
+)
+
+// The formats of exports and imports of issues
+const (
+	IssuesFormatCSV  = "csv"
+	IssuesFormatJSON = "json"
+)
+
+// exportPageSize is the number of issues which are loaded at once during an export
+const exportPageSize = 50
+
+// issuesCSVHeader are the columns of a CSV export of issues, an import reads the columns with the
+// same names
+var issuesCSVHeader = []string{"number", "type", "title", "state", "poster", "assignees", "labels", "milestone", "created", "updated", "closed", "time_spent", "body", "comments"}
+
+// ExportIssuesOptions are the options of an export of issues
+type ExportIssuesOptions struct {
+	IncludeIssues bool
+	IncludePulls  bool
+	IsClosed      optional.Option[bool]
+	// AllTrackedTimes exports the times tracked by every user, otherwise only the ones of TrackedTimesUserID,
+	// like the tracked times of a repository are listed to users who are not writers of its issues
+	AllTrackedTimes    bool
+	TrackedTimesUserID int64
+}
+
+// ExportIssues writes the issues and the pull requests of a repository, with their comments and
+// tracked times, in CSV or JSON. The issues are loaded and written page after page.
+func ExportIssues(ctx context.Context, w io.Writer, repo *repo_model.Repository, format string, opts ExportIssuesOptions) error {
+	if !opts.IncludeIssues && !opts.IncludePulls {
+		return nil
+	}
+	searchOpts := &issues_model.IssuesOptions{
+		RepoIDs:  []int64{repo.ID},
+		IsClosed: opts.IsClosed,
+		SortType: "oldest",
+	}
+	if !opts.IncludeIssues {
+		searchOpts.IsPull = optional.Some(true)
+	} else if !opts.IncludePulls {
+		searchOpts.IsPull = optional.Some(false)
+	}
+	withTimes := repo.IsTimetrackerEnabled(ctx)
+
+	var writeIssue func(*api.ExportedIssue) error
+	var finish func() error
+	switch format {
+	case IssuesFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(issuesCSVHeader); err != nil {
+			return err
+		}
+		writeIssue = func(issue *api.ExportedIssue) error {
+			return cw.Write(exportedIssueCSVRecord(issue))
+		}
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case IssuesFormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		first := true
+		writeIssue = func(issue *api.ExportedIssue) error {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			data, err := json.Marshal(issue)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(append([]byte("\n"), data...))
+			return err
+		}
+		finish = func() error {
+			_, err := io.WriteString(w, "\n]\n")
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	for page := 1; ; page++ {
+		searchOpts.Paginator = &db.ListOptions{Page: page, PageSize: exportPageSize}
+		issues, err := issues_model.Issues(ctx, searchOpts)
+		if err != nil {
+			return err
+		}
+		if err := issues.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		if err := issues.LoadDiscussComments(ctx); err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			exported, err := toExportedIssue(ctx, issue, withTimes, opts)
+			if err != nil {
+				return err
+			}
+			if err := writeIssue(exported); err != nil {
+				return err
+			}
+		}
+		if len(issues) < exportPageSize {
+			break
+		}
+	}
+	return finish()
+}
+
+func toExportedIssue(ctx context.Context, issue *issues_model.Issue, withTimes bool, opts ExportIssuesOptions) (*api.ExportedIssue, error) {
+	exported := &api.ExportedIssue{
+		Number:       issue.Index,
+		IsPull:       issue.IsPull,
+		Title:        issue.Title,
+		Body:         issue.Content,
+		State:        issue.State(),
+		Poster:       issue.Poster.Name,
+		Assignees:    make([]string, 0, len(issue.Assignees)),
+		Labels:       make([]string, 0, len(issue.Labels)),
+		Created:      issue.CreatedUnix.AsTime(),
+		Updated:      issue.UpdatedUnix.AsTime(),
+		Comments:     make([]*api.ExportedComment, 0, len(issue.Comments)),
+		TrackedTimes: []*api.ExportedTrackedTime{},
+	}
+	if issue.IsHidden {
+		// hidden by a moderator
+		exported.Body = ""
+	}
+	for _, assignee := range issue.Assignees {
+		exported.Assignees = append(exported.Assignees, assignee.Name)
+	}
+	for _, label := range issue.Labels {
+		exported.Labels = append(exported.Labels, label.Name)
+	}
+	if issue.Milestone != nil {
+		exported.Milestone = issue.Milestone.Name
+	}
+	if issue.IsClosed && issue.ClosedUnix > 0 {
+		closed := issue.ClosedUnix.AsTime()
+		exported.Closed = &closed
+	}
+
+	if err := issue.Comments.LoadPosters(ctx); err != nil {
+		return nil, err
+	}
+	for _, comment := range issue.Comments {
+		body := comment.Content
+		if comment.IsHidden {
+			body = ""
+		}
+		exported.Comments = append(exported.Comments, &api.ExportedComment{
+			Poster:  comment.Poster.Name,
+			Body:    body,
+			Created: comment.CreatedUnix.AsTime(),
+		})
+	}
+
+	if withTimes && (opts.AllTrackedTimes || opts.TrackedTimesUserID != 0) {
+		findOpts := &issues_model.FindTrackedTimesOptions{IssueID: issue.ID}
+		if opts.AllTrackedTimes {
+			exported.TimeSpent = issue.TotalTrackedTime
+		} else {
+			findOpts.UserID = opts.TrackedTimesUserID
+		}
+		trackedTimes, err := issues_model.GetTrackedTimes(ctx, findOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range trackedTimes {
+			t.Issue = issue
+		}
+		if err := trackedTimes.LoadAttributes(ctx); err != nil {
+			return nil, err
+		}
+		for _, t := range trackedTimes {
+			exported.TrackedTimes = append(exported.TrackedTimes, &api.ExportedTrackedTime{
+				User:    t.User.Name,
+				Time:    t.Time,
+				Created: t.Created,
+			})
+			if !opts.AllTrackedTimes {
+				exported.TimeSpent += t.Time
+			}
+		}
+	}
+	return exported, nil
+}
+
+// exportedIssueCSVRecord returns the columns of an issue in a CSV export, its comments are joined
+// in a single column
+func exportedIssueCSVRecord(issue *api.ExportedIssue) []string {
+	issueType := "issue"
+	if issue.IsPull {
+		issueType = "pull"
+	}
+	closed := ""
+	if issue.Closed != nil {
+		closed = issue.Closed.UTC().Format(time.RFC3339)
+	}
+	comments := make([]string, 0, len(issue.Comments))
+	for _, comment := range issue.Comments {
+		comments = append(comments, fmt.Sprintf("%s (%s):\n%s", comment.Poster, comment.Created.UTC().Format(time.RFC3339), comment.Body))
+	}
+	return []string{
+		strconv.FormatInt(issue.Number, 10),
+		issueType,
+		issue.Title,
+		string(issue.State),
+		issue.Poster,
+		strings.Join(issue.Assignees, ","),
+		strings.Join(issue.Labels, ","),
+		issue.Milestone,
+		issue.Created.UTC().Format(time.RFC3339),
+		issue.Updated.UTC().Format(time.RFC3339),
+		closed,
+		strconv.FormatInt(issue.TimeSpent, 10),
+		issue.Body,
+		strings.Join(comments, "\n\n---\n\n"),
+	}
+}
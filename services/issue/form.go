@@ -0,0 +1,185 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/issue/template"
+	"forgejo.org/modules/optional"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+)
+
+// IssueForm is a submitted issue form, with the content it renders to and the metadata its values are
+// mapped to
+type IssueForm struct {
+	Content     string
+	LabelIDs    []int64
+	AssigneeIDs []int64
+	MilestoneID int64
+	ProjectID   int64
+	// Attachments are the UUIDs of the attachments of the file fields
+	Attachments []string
+}
+
+// SubmitIssueForm validates the values submitted to an issue form, renders them to the content of the issue and
+// maps them to the labels, assignees, milestone and project of the repository the fields are mapped to. Only the
+// labels declared by the template can be mapped, and the metadata is dropped if the doer can not write issues, as
+// when creating an issue. The errors caused by the values wrap util.ErrInvalidArgument.
+func SubmitIssueForm(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, it *api.IssueTemplate, values url.Values, isPull bool) (*IssueForm, error) {
+	if err := template.ValidateValues(it, values); err != nil {
+		return nil, err
+	}
+	metas := template.MapValues(it, values)
+	form := &IssueForm{}
+
+	fileNames := make(map[string]string, len(metas.Files))
+	if len(metas.Files) > 0 {
+		attachments, err := repo_model.GetAttachmentsByUUIDs(ctx, metas.Files)
+		if err != nil {
+			return nil, err
+		}
+		for _, attachment := range attachments {
+			if attachment.UploaderID != doer.ID || attachment.IssueID != 0 || attachment.ReleaseID != 0 {
+				continue
+			}
+			fileNames[attachment.UUID] = attachment.Name
+		}
+		for _, uuid := range metas.Files {
+			if _, ok := fileNames[uuid]; !ok {
+				return nil, util.NewInvalidArgumentErrorf("the attachment %q does not exist", uuid)
+			}
+			if !slices.Contains(form.Attachments, uuid) {
+				form.Attachments = append(form.Attachments, uuid)
+			}
+		}
+	}
+	form.Content = template.RenderToMarkdownWithFiles(it, values, fileNames)
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanWriteIssuesOrPulls(isPull) {
+		// setting the metadata is not allowed if the doer is not a writer
+		return form, nil
+	}
+
+	if len(metas.Labels) > 0 {
+		declared := template.DeclaredLabels(it)
+		labels, err := getImportLabels(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range metas.Labels {
+			if !slices.ContainsFunc(declared, func(d string) bool { return strings.EqualFold(d, name) }) {
+				return nil, util.NewInvalidArgumentErrorf("the label %q is not declared by the template", name)
+			}
+			label, ok := labels[strings.ToLower(name)]
+			if !ok {
+				return nil, util.NewInvalidArgumentErrorf("the label %q does not exist", name)
+			}
+			if !slices.Contains(form.LabelIDs, label.ID) {
+				form.LabelIDs = append(form.LabelIDs, label.ID)
+			}
+		}
+	}
+
+	for _, name := range metas.Assignees {
+		assignee, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("the user %q does not exist", name)
+			}
+			return nil, err
+		}
+		valid, err := access_model.CanBeAssigned(ctx, assignee, repo, isPull)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, util.NewInvalidArgumentErrorf("the user %q can not be assigned", name)
+		}
+		if !slices.Contains(form.AssigneeIDs, assignee.ID) {
+			form.AssigneeIDs = append(form.AssigneeIDs, assignee.ID)
+		}
+	}
+
+	if metas.Milestone != "" {
+		milestone, err := issues_model.GetMilestoneByRepoIDANDName(ctx, repo.ID, metas.Milestone)
+		if err != nil {
+			if issues_model.IsErrMilestoneNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("the milestone %q does not exist", metas.Milestone)
+			}
+			return nil, err
+		}
+		form.MilestoneID = milestone.ID
+	}
+
+	if metas.Project != "" {
+		project, err := getProjectByTitle(ctx, repo, metas.Project)
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			return nil, util.NewInvalidArgumentErrorf("the project %q does not exist", metas.Project)
+		}
+		form.ProjectID = project.ID
+	}
+	return form, nil
+}
+
+// MergeMetas adds the labels and assignees of the form to those which were chosen, and returns the milestone
+// and project of the form if none was chosen
+func (f *IssueForm) MergeMetas(labelIDs, assigneeIDs []int64, milestoneID, projectID int64) ([]int64, []int64, int64, int64) {
+	for _, id := range f.LabelIDs {
+		if !slices.Contains(labelIDs, id) {
+			labelIDs = append(labelIDs, id)
+		}
+	}
+	for _, id := range f.AssigneeIDs {
+		if !slices.Contains(assigneeIDs, id) {
+			assigneeIDs = append(assigneeIDs, id)
+		}
+	}
+	if milestoneID == 0 {
+		milestoneID = f.MilestoneID
+	}
+	if projectID == 0 {
+		projectID = f.ProjectID
+	}
+	return labelIDs, assigneeIDs, milestoneID, projectID
+}
+
+// getProjectByTitle returns the open project of a repository, or else of its owner, with a title, or nil
+func getProjectByTitle(ctx context.Context, repo *repo_model.Repository, title string) (*project_model.Project, error) {
+	for _, opts := range []project_model.SearchOptions{
+		{RepoID: repo.ID, Type: project_model.TypeRepository},
+		{OwnerID: repo.OwnerID, Type: project_model.TypeOrganization},
+		{OwnerID: repo.OwnerID, Type: project_model.TypeIndividual},
+	} {
+		opts.Title = title
+		opts.IsClosed = optional.Some(false)
+		projects, err := db.Find[project_model.Project](ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			if strings.EqualFold(project.Title, title) {
+				return project, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/issue/template"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitIssueForm(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	it, err := template.Unmarshal("form.yaml", []byte(`
+name: Bug
+about: Report a bug
+body:
+  - type: label
+    id: kind
+    attributes:
+      label: Kind
+      multiple: true
+      map_to: labels
+      options:
+        - label1
+        - label2
+  - type: label
+    id: other
+    attributes:
+      label: Other
+      map_to: labels
+  - type: dropdown
+    id: milestone
+    attributes:
+      label: Milestone
+      map_to: milestone
+      options:
+        - milestone1
+        - unknown
+  - type: user
+    id: owner
+    attributes:
+      label: Owner
+      map_to: assignees
+`))
+	require.NoError(t, err)
+
+	form, err := SubmitIssueForm(db.DefaultContext, repo, doer, it, template.ValuesFromMap(it, map[string]string{
+		"kind":      "LABEL1,label2",
+		"milestone": "0",
+		"owner":     "user2",
+	}), false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, form.LabelIDs)
+	assert.Equal(t, int64(1), form.MilestoneID)
+	assert.Equal(t, []int64{2}, form.AssigneeIDs)
+	assert.Contains(t, form.Content, "@user2")
+
+	labelIDs, assigneeIDs, milestoneID, projectID := form.MergeMetas([]int64{2, 3}, nil, 2, 0)
+	assert.Equal(t, []int64{2, 3, 1}, labelIDs)
+	assert.Equal(t, []int64{2}, assigneeIDs)
+	assert.Equal(t, int64(2), milestoneID)
+	assert.Zero(t, projectID)
+
+	_, err = SubmitIssueForm(db.DefaultContext, repo, doer, it, template.ValuesFromMap(it, map[string]string{
+		"milestone": "1",
+	}), false)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	_, err = SubmitIssueForm(db.DefaultContext, repo, doer, it, template.ValuesFromMap(it, map[string]string{
+		"owner": "user2,user4",
+	}), false)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// the label is not declared by the template
+	_, err = SubmitIssueForm(db.DefaultContext, repo, doer, it, template.ValuesFromMap(it, map[string]string{
+		"other": "label1",
+	}), false)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// user4 can only read the issues
+	reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	form, err = SubmitIssueForm(db.DefaultContext, repo, reader, it, template.ValuesFromMap(it, map[string]string{
+		"kind":      "label1",
+		"milestone": "0",
+		"owner":     "user2",
+	}), false)
+	require.NoError(t, err)
+	assert.Empty(t, form.LabelIDs)
+	assert.Empty(t, form.AssigneeIDs)
+	assert.Zero(t, form.MilestoneID)
+	assert.Contains(t, form.Content, "@user2")
+}
+
+func TestIsTemplatePath(t *testing.T) {
+	assert.True(t, IsTemplatePath(".forgejo/ISSUE_TEMPLATE/bug.yaml", false))
+	assert.True(t, IsTemplatePath("ISSUE_TEMPLATE/bug.md", false))
+	assert.False(t, IsTemplatePath(".forgejo/ISSUE_TEMPLATE/bug.yaml", true))
+	assert.True(t, IsTemplatePath(".forgejo/PULL_REQUEST_TEMPLATE/feature.yaml", true))
+	assert.False(t, IsTemplatePath("docs/form.yaml", false))
+	assert.False(t, IsTemplatePath(".forgejo/ISSUE_TEMPLATE/../../docs/form.yaml", false))
+	assert.False(t, IsTemplatePath(".forgejo/ISSUE_TEMPLATE/README", false))
+}
@@ -0,0 +1,341 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// MaxImportedIssues is the maximum number of issues of an import
+const MaxImportedIssues = 1000
+
+// MaxImportSize is the maximum size of the file of an import
+const MaxImportSize = 32 << 20
+
+// importedLabelColor is the color of the labels created by an import
+const importedLabelColor = "#cccccc"
+
+// ImportIssuesOptions are the options of an import of issues
+type ImportIssuesOptions struct {
+	Format string
+	// CreateMissing creates the labels and the milestones which do not exist, instead of
+	// considering them as errors
+	CreateMissing bool
+	// DryRun only validates the issues
+	DryRun bool
+}
+
+// importedIssue is an issue read from an import
+type importedIssue struct {
+	Line      int64
+	IsPull    bool
+	Title     string
+	Body      string
+	State     string
+	Labels    []string
+	Milestone string
+}
+
+// ImportIssues creates issues in a repository from a CSV or JSON file. The issues are all
+// validated first, with their labels and milestones mapped by name to those of the repository and
+// of its organization, and none is created if one is invalid or in dry-run.
+func ImportIssues(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, r io.Reader, opts ImportIssuesOptions) (*api.IssueImportResult, error) {
+	r = io.LimitReader(r, MaxImportSize)
+	var issues []*importedIssue
+	var err error
+	switch opts.Format {
+	case IssuesFormatCSV:
+		issues, err = readImportedIssuesCSV(r)
+	case IssuesFormatJSON:
+		issues, err = readImportedIssuesJSON(r)
+	default:
+		return nil, util.NewInvalidArgumentErrorf("unknown format %q", opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("there are no issues to import")
+	}
+	if len(issues) > MaxImportedIssues {
+		return nil, util.NewInvalidArgumentErrorf("there are more than %d issues to import", MaxImportedIssues)
+	}
+
+	labels, err := getImportLabels(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	milestones, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{RepoID: repo.ID})
+	if err != nil {
+		return nil, err
+	}
+	milestoneMap := make(map[string]*issues_model.Milestone, len(milestones))
+	for _, milestone := range milestones {
+		milestoneMap[strings.ToLower(milestone.Name)] = milestone
+	}
+
+	result := &api.IssueImportResult{Valid: true, Issues: make([]*api.IssueImportRow, 0, len(issues))}
+	missingLabels := make(map[string]string)
+	missingMilestones := make(map[string]string)
+	for _, issue := range issues {
+		row := &api.IssueImportRow{
+			Line:      issue.Line,
+			Title:     issue.Title,
+			Labels:    issue.Labels,
+			Milestone: issue.Milestone,
+			Closed:    issue.State == string(api.StateClosed),
+		}
+		if issue.IsPull {
+			row.Errors = append(row.Errors, "pull requests cannot be imported")
+		}
+		if issue.Title == "" {
+			row.Errors = append(row.Errors, "the title is empty")
+		} else if len(issue.Title) > 255 {
+			row.Errors = append(row.Errors, "the title is longer than 255 characters")
+		}
+		if issue.State != "" && issue.State != string(api.StateOpen) && issue.State != string(api.StateClosed) {
+			row.Errors = append(row.Errors, fmt.Sprintf("the state %q is neither open nor closed", issue.State))
+		}
+		for _, name := range issue.Labels {
+			if _, ok := labels[strings.ToLower(name)]; ok {
+				continue
+			}
+			if !opts.CreateMissing {
+				row.Errors = append(row.Errors, fmt.Sprintf("the label %q does not exist", name))
+			} else {
+				missingLabels[strings.ToLower(name)] = name
+			}
+		}
+		if issue.Milestone != "" {
+			if _, ok := milestoneMap[strings.ToLower(issue.Milestone)]; !ok {
+				if !opts.CreateMissing {
+					row.Errors = append(row.Errors, fmt.Sprintf("the milestone %q does not exist", issue.Milestone))
+				} else {
+					missingMilestones[strings.ToLower(issue.Milestone)] = issue.Milestone
+				}
+			}
+		}
+		if len(row.Errors) > 0 {
+			result.Valid = false
+		}
+		result.Issues = append(result.Issues, row)
+	}
+	if !result.Valid || opts.DryRun {
+		return result, nil
+	}
+
+	if user_model.IsBlocked(ctx, repo.OwnerID, doer.ID) {
+		return nil, user_model.ErrBlockedByUser
+	}
+
+	// everything is created in a transaction so that a failure does not leave a partial import,
+	// the notifications are only sent once it is committed
+	created := make([]*issues_model.Issue, 0, len(issues))
+	closeComments := make(map[int64]*issues_model.Comment)
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for key, name := range missingLabels {
+			l := &issues_model.Label{RepoID: repo.ID, Name: name, Color: importedLabelColor}
+			if err := issues_model.NewLabel(ctx, l); err != nil {
+				return err
+			}
+			labels[key] = l
+		}
+		for key, name := range missingMilestones {
+			m := &issues_model.Milestone{RepoID: repo.ID, Name: name}
+			if err := issues_model.NewMilestone(ctx, m); err != nil {
+				return err
+			}
+			milestoneMap[key] = m
+		}
+
+		for _, imported := range issues {
+			issue := &issues_model.Issue{
+				RepoID:   repo.ID,
+				Repo:     repo,
+				Title:    imported.Title,
+				Content:  imported.Body,
+				PosterID: doer.ID,
+				Poster:   doer,
+			}
+			if imported.Milestone != "" {
+				issue.MilestoneID = milestoneMap[strings.ToLower(imported.Milestone)].ID
+			}
+			labelIDs := make([]int64, 0, len(imported.Labels))
+			for _, name := range imported.Labels {
+				labelIDs = append(labelIDs, labels[strings.ToLower(name)].ID)
+			}
+			if err := issues_model.NewIssue(ctx, repo, issue, labelIDs, nil); err != nil {
+				return fmt.Errorf("import issue at line %d: %w", imported.Line, err)
+			}
+			if imported.State == string(api.StateClosed) {
+				comment, err := issues_model.ChangeIssueStatus(ctx, issue, doer, true)
+				if err != nil {
+					return fmt.Errorf("close issue at line %d: %w", imported.Line, err)
+				}
+				closeComments[issue.ID] = comment
+			}
+			created = append(created, issue)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i, issue := range created {
+		result.Issues[i].Number = issue.Index
+
+		mentions, err := issues_model.FindAndUpdateIssueMentions(ctx, issue, doer, issue.Content)
+		if err != nil {
+			return nil, err
+		}
+		notify_service.NewIssue(ctx, issue, mentions)
+		if len(issue.Labels) > 0 {
+			notify_service.IssueChangeLabels(ctx, doer, issue, issue.Labels, nil)
+		}
+		if issue.Milestone != nil {
+			notify_service.IssueChangeMilestone(ctx, doer, issue, 0)
+		}
+		if comment, ok := closeComments[issue.ID]; ok {
+			notify_service.IssueChangeStatus(ctx, doer, "", issue, comment, true)
+		}
+	}
+	result.Imported = true
+	return result, nil
+}
+
+// getImportLabels returns the labels of a repository and of its organization by lower case name,
+// the labels of the repository take precedence
+func getImportLabels(ctx context.Context, repo *repo_model.Repository) (map[string]*issues_model.Label, error) {
+	labels := make(map[string]*issues_model.Label)
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, repo.OwnerID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range orgLabels {
+			labels[strings.ToLower(l.Name)] = l
+		}
+	}
+	repoLabels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range repoLabels {
+		labels[strings.ToLower(l.Name)] = l
+	}
+	return labels, nil
+}
+
+// readImportedIssuesCSV reads issues from a CSV file with a header, in which the columns title,
+// body, state, labels and milestone are read, and the others are ignored
+func readImportedIssuesCSV(r io.Reader) ([]*importedIssue, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, util.NewInvalidArgumentErrorf("invalid CSV: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, util.NewInvalidArgumentErrorf("the CSV has no title column")
+	}
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var issues []*importedIssue
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid CSV: %v", err)
+		}
+		if len(issues) >= MaxImportedIssues {
+			return nil, util.NewInvalidArgumentErrorf("there are more than %d issues to import", MaxImportedIssues)
+		}
+		line, _ := cr.FieldPos(0)
+		issues = append(issues, &importedIssue{
+			Line:      int64(line),
+			IsPull:    strings.EqualFold(strings.TrimSpace(column(record, "type")), "pull"),
+			Title:     strings.TrimSpace(column(record, "title")),
+			Body:      column(record, "body"),
+			State:     strings.ToLower(strings.TrimSpace(column(record, "state"))),
+			Labels:    splitImportedNames(column(record, "labels")),
+			Milestone: strings.TrimSpace(column(record, "milestone")),
+		})
+	}
+	return issues, nil
+}
+
+// readImportedIssuesJSON reads issues from a JSON array of objects in the format of the exports
+func readImportedIssuesJSON(r io.Reader) ([]*importedIssue, error) {
+	var exported []*api.ExportedIssue
+	if err := json.NewDecoder(r).Decode(&exported); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid JSON: %v", err)
+	}
+	if len(exported) > MaxImportedIssues {
+		return nil, util.NewInvalidArgumentErrorf("there are more than %d issues to import", MaxImportedIssues)
+	}
+
+	issues := make([]*importedIssue, 0, len(exported))
+	for i, e := range exported {
+		if e == nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid JSON: the issue %d is null", i+1)
+		}
+		labels := make([]string, 0, len(e.Labels))
+		for _, name := range e.Labels {
+			if name = strings.TrimSpace(name); name != "" {
+				labels = append(labels, name)
+			}
+		}
+		issues = append(issues, &importedIssue{
+			Line:      int64(i + 1),
+			IsPull:    e.IsPull,
+			Title:     strings.TrimSpace(e.Title),
+			Body:      e.Body,
+			State:     strings.ToLower(string(e.State)),
+			Labels:    labels,
+			Milestone: strings.TrimSpace(e.Milestone),
+		})
+	}
+	return issues, nil
+}
+
+func splitImportedNames(s string) []string {
+	names := []string{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
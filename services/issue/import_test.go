@@ -0,0 +1,132 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportIssuesValidate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	const content = "title,state,labels,milestone\n" +
+		"first,open,\"LABEL1, label2\",milestone1\n" +
+		"second,closed,unknown,\n" +
+		",pending,,missing\n"
+
+	result, err := ImportIssues(db.DefaultContext, doer, repo, strings.NewReader(content), ImportIssuesOptions{Format: IssuesFormatCSV})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.False(t, result.Imported)
+	require.Len(t, result.Issues, 3)
+	assert.Empty(t, result.Issues[0].Errors)
+	assert.Equal(t, []string{"LABEL1", "label2"}, result.Issues[0].Labels)
+	assert.Equal(t, int64(2), result.Issues[0].Line)
+	assert.True(t, result.Issues[1].Closed)
+	assert.Len(t, result.Issues[1].Errors, 1)
+	assert.Len(t, result.Issues[2].Errors, 3)
+
+	// the missing labels and milestones are not errors, but are not created in dry-run
+	result, err = ImportIssues(db.DefaultContext, doer, repo, strings.NewReader(content), ImportIssuesOptions{Format: IssuesFormatCSV, CreateMissing: true, DryRun: true})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Issues[1].Errors, 0)
+	assert.Len(t, result.Issues[2].Errors, 2)
+	unittest.AssertNotExistsBean(t, &issues_model.Label{RepoID: repo.ID, Name: "unknown"})
+
+	_, err = ImportIssues(db.DefaultContext, doer, repo, strings.NewReader("body\nsomething\n"), ImportIssuesOptions{Format: IssuesFormatCSV})
+	require.Error(t, err)
+	_, err = ImportIssues(db.DefaultContext, doer, repo, strings.NewReader("{}"), ImportIssuesOptions{Format: IssuesFormatJSON})
+	require.Error(t, err)
+}
+
+func TestExportIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	numIssues := unittest.GetCount(t, &issues_model.Issue{RepoID: repo.ID, IsPull: false})
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportIssues(db.DefaultContext, &buf, repo, IssuesFormatJSON, ExportIssuesOptions{IncludeIssues: true}))
+	var exported []*api.ExportedIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+	assert.Len(t, exported, numIssues)
+	for _, issue := range exported {
+		assert.False(t, issue.IsPull)
+	}
+
+	// an export can be read back by an import
+	issues, err := readImportedIssuesJSON(&buf)
+	require.NoError(t, err)
+	assert.Len(t, issues, numIssues)
+
+	buf.Reset()
+	require.NoError(t, ExportIssues(db.DefaultContext, &buf, repo, IssuesFormatCSV, ExportIssuesOptions{IncludeIssues: true}))
+	issues, err = readImportedIssuesCSV(&buf)
+	require.NoError(t, err)
+	assert.Len(t, issues, numIssues)
+}
+
+func TestExportIssuesHiddenAndTrackedTimes(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Service.EnableTimetracking, true)()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	_, err := db.GetEngine(db.DefaultContext).ID(1).Cols("is_hidden").Update(&issues_model.Issue{IsHidden: true})
+	require.NoError(t, err)
+	_, err = db.GetEngine(db.DefaultContext).ID(2).Cols("is_hidden").Update(&issues_model.Comment{IsHidden: true})
+	require.NoError(t, err)
+
+	export := func(opts ExportIssuesOptions) *api.ExportedIssue {
+		var buf bytes.Buffer
+		opts.IncludeIssues = true
+		require.NoError(t, ExportIssues(db.DefaultContext, &buf, repo, IssuesFormatJSON, opts))
+		var exported []*api.ExportedIssue
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+		for _, issue := range exported {
+			if issue.Number == 1 {
+				return issue
+			}
+		}
+		require.FailNow(t, "issue #1 is not exported")
+		return nil
+	}
+
+	issue := export(ExportIssuesOptions{AllTrackedTimes: true})
+	assert.Empty(t, issue.Body)
+	require.NotEmpty(t, issue.Comments)
+	for _, comment := range issue.Comments {
+		if comment.Poster == "user3" {
+			assert.Empty(t, comment.Body)
+		} else {
+			assert.NotEmpty(t, comment.Body)
+		}
+	}
+	require.Len(t, issue.TrackedTimes, 1)
+	assert.Equal(t, "user1", issue.TrackedTimes[0].User)
+
+	// a reader only gets their own tracked times
+	issue = export(ExportIssuesOptions{TrackedTimesUserID: 2})
+	assert.Empty(t, issue.TrackedTimes)
+	assert.Zero(t, issue.TimeSpent)
+	issue = export(ExportIssuesOptions{TrackedTimesUserID: 1})
+	require.Len(t, issue.TrackedTimes, 1)
+	assert.EqualValues(t, 400, issue.TimeSpent)
+}
@@ -171,6 +171,12 @@ func DeleteIssue(ctx context.Context, doer *user_model.User, gitRepo *git.Reposi
 		return err
 	}
 
+	// the sub-issues lose their parent
+	subIssues, err := issues_model.GetSubIssues(ctx, issue.ID)
+	if err != nil {
+		return err
+	}
+
 	// delete entries in database
 	if err := deleteIssue(ctx, issue); err != nil {
 		return err
@@ -191,6 +197,9 @@ func DeleteIssue(ctx context.Context, doer *user_model.User, gitRepo *git.Reposi
 	}
 
 	notify_service.DeleteIssue(ctx, doer, issue)
+	for _, subIssue := range subIssues {
+		notify_service.IssueChangeSubIssue(ctx, doer, issue, subIssue, true)
+	}
 
 	return nil
 }
@@ -289,8 +298,11 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 	if err := db.DeleteBeans(ctx,
 		&issues_model.ContentHistory{IssueID: issue.ID},
 		&issues_model.Comment{IssueID: issue.ID},
+		&issues_model.AppliedSuggestion{IssueID: issue.ID},
 		&issues_model.IssueLabel{IssueID: issue.ID},
 		&issues_model.IssueDependency{IssueID: issue.ID},
+		&issues_model.SubIssue{IssueID: issue.ID},
+		&issues_model.SubIssue{ParentID: issue.ID},
 		&issues_model.IssueAssignees{IssueID: issue.ID},
 		&issues_model.IssueUser{IssueID: issue.ID},
 		&activities_model.Notification{IssueID: issue.ID},
@@ -299,6 +311,7 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&issues_model.Stopwatch{IssueID: issue.ID},
 		&issues_model.TrackedTime{IssueID: issue.ID},
 		&project_model.ProjectIssue{IssueID: issue.ID},
+		&project_model.FieldValue{IssueID: issue.ID},
 		&repo_model.Attachment{IssueID: issue.ID},
 		&issues_model.PullRequest{IssueID: issue.ID},
 		&issues_model.Comment{RefIssueID: issue.ID},
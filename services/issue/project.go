@@ -0,0 +1,123 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"slices"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// AssignOrRemoveProject adds an issue to a project, moves it to another project, or removes it from
+// its project if newProjectID is 0. The issue is placed in the default column if newColumnID is 0.
+func AssignOrRemoveProject(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, newProjectID, newColumnID int64) error {
+	oldProjectID := issue.ProjectID(ctx)
+	oldColumnID := issue.ProjectColumnID(ctx)
+
+	if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, newProjectID, newColumnID); err != nil {
+		return err
+	}
+
+	if oldProjectID > 0 || newProjectID > 0 {
+		notify_service.IssueChangeProject(ctx, doer, issue, oldProjectID, oldColumnID)
+	}
+	return nil
+}
+
+// MoveIssuesOnProjectColumn moves issues of a project to a column and sorts the issues of that column
+func MoveIssuesOnProjectColumn(ctx context.Context, doer *user_model.User, column *project_model.Column, sortedIssueIDs map[int64]int64) error {
+	oldProjectIssues, err := project_model.GetProjectIssuesByIssueIDs(ctx, column.ProjectID, util.ValuesOfMap(sortedIssueIDs))
+	if err != nil {
+		return err
+	}
+
+	if err := project_model.MoveIssuesOnProjectColumn(ctx, column, sortedIssueIDs); err != nil {
+		return err
+	}
+
+	movedIssueIDs := make([]int64, 0, len(sortedIssueIDs))
+	for _, issueID := range sortedIssueIDs {
+		oldProjectIssue, ok := oldProjectIssues[issueID]
+		if !ok || oldProjectIssue.ProjectColumnID == column.ID {
+			continue
+		}
+		// issues which are not placed in a column are shown in the default one
+		if oldProjectIssue.ProjectColumnID == 0 && column.Default {
+			continue
+		}
+		movedIssueIDs = append(movedIssueIDs, issueID)
+	}
+	if len(movedIssueIDs) == 0 {
+		return nil
+	}
+
+	movedIssues, err := issues_model.GetIssuesByIDs(ctx, movedIssueIDs)
+	if err != nil {
+		return err
+	}
+	for _, issue := range movedIssues {
+		notify_service.IssueChangeProject(ctx, doer, issue, column.ProjectID, oldProjectIssues[issue.ID].ProjectColumnID)
+	}
+	return nil
+}
+
+// MoveIssueOnProjectColumn moves an issue of a project to a position of a column, starting at 0,
+// or to the end of the column if the position is negative
+func MoveIssueOnProjectColumn(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, column *project_model.Column, position int) error {
+	projectIssues, err := column.GetIssues(ctx)
+	if err != nil {
+		return err
+	}
+
+	issueIDs := make([]int64, 0, len(projectIssues)+1)
+	for _, projectIssue := range projectIssues {
+		if projectIssue.IssueID != issue.ID {
+			issueIDs = append(issueIDs, projectIssue.IssueID)
+		}
+	}
+	if position < 0 || position > len(issueIDs) {
+		position = len(issueIDs)
+	}
+	issueIDs = slices.Insert(issueIDs, position, issue.ID)
+
+	sortedIssueIDs := make(map[int64]int64, len(issueIDs))
+	for sorting, issueID := range issueIDs {
+		sortedIssueIDs[int64(sorting)] = issueID
+	}
+	return MoveIssuesOnProjectColumn(ctx, doer, column, sortedIssueIDs)
+}
+
+// SetProjectFieldValue sets the value of a custom field of the project of an issue, or removes it if the value is empty
+func SetProjectFieldValue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *project_model.Field, value string) error {
+	if err := issue.LoadProject(ctx); err != nil {
+		return err
+	}
+	if issue.Project == nil {
+		return util.NewInvalidArgumentErrorf("the issue is not in a project")
+	}
+	if has, err := issue.Project.HasField(ctx, field); err != nil {
+		return err
+	} else if !has {
+		return util.NewInvalidArgumentErrorf("the field is not a field of the project of the issue")
+	}
+
+	value, err := field.NormalizeValue(value)
+	if err != nil {
+		return err
+	}
+	oldValue, err := project_model.SetIssueFieldValue(ctx, field, issue.ID, value)
+	if err != nil {
+		return err
+	}
+
+	if oldValue != value {
+		notify_service.IssueChangeProjectField(ctx, doer, issue, field, oldValue)
+	}
+	return nil
+}
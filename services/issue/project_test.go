@@ -0,0 +1,47 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveIssueOnProjectColumn(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	column := unittest.AssertExistsAndLoadBean(t, &project_model.Column{ID: 3})
+
+	// issue 5 is already in the column, issue 1 is moved before it
+	require.NoError(t, MoveIssueOnProjectColumn(db.DefaultContext, doer, issue, column, 0))
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectColumnID: 3, Sorting: 0})
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 5, ProjectColumnID: 3, Sorting: 1})
+
+	// a negative position moves the issue to the end of the column
+	require.NoError(t, MoveIssueOnProjectColumn(db.DefaultContext, doer, issue, column, -1))
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 5, ProjectColumnID: 3, Sorting: 0})
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectColumnID: 3, Sorting: 1})
+}
+
+func TestAssignOrRemoveProject(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+
+	require.NoError(t, AssignOrRemoveProject(db.DefaultContext, issue, doer, 0, 0))
+	unittest.AssertNotExistsBean(t, &project_model.ProjectIssue{IssueID: 1})
+	assert.EqualValues(t, 0, issue.ProjectID(db.DefaultContext))
+
+	require.NoError(t, AssignOrRemoveProject(db.DefaultContext, issue, doer, 1, 2))
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectID: 1, ProjectColumnID: 2})
+}
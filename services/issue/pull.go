@@ -42,9 +42,26 @@ type ReviewRequestNotifier struct {
 	ReviewTeam *org_model.Team
 }
 
-func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
-	files := []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitea/CODEOWNERS"}
+// codeOwnersFiles are the paths of the CODEOWNERS file, by priority
+var codeOwnersFiles = []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitea/CODEOWNERS"}
+
+// GetCodeOwnersRules returns the rules of the CODEOWNERS file of a commit, or none if it has no such file
+func GetCodeOwnersRules(ctx context.Context, commit *git.Commit) []*issues_model.CodeOwnerRule {
+	var data string
+	for _, file := range codeOwnersFiles {
+		if blob, err := commit.GetBlobByPath(file); err == nil {
+			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
+			if err == nil {
+				break
+			}
+		}
+	}
+
+	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
+	return rules
+}
 
+func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
 	if pr.IsWorkInProgress(ctx) {
 		return nil, nil
 	}
@@ -72,17 +89,7 @@ func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue,
 		return nil, err
 	}
 
-	var data string
-	for _, file := range files {
-		if blob, err := commit.GetBlobByPath(file); err == nil {
-			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
-			if err == nil {
-				break
-			}
-		}
-	}
-
-	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
+	rules := GetCodeOwnersRules(ctx, commit)
 
 	// get the mergebase
 	mergeBase, err := getMergeBase(repo, pr, git.BranchPrefix+pr.BaseBranch, pr.GetGitRefName())
@@ -101,7 +108,7 @@ func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue,
 	uniqTeams := make(map[string]*org_model.Team)
 	for _, rule := range rules {
 		for _, f := range changedFiles {
-			if (rule.Rule.MatchString(f) && !rule.Negative) || (!rule.Rule.MatchString(f) && rule.Negative) {
+			if rule.Match(f) {
 				for _, u := range rule.Users {
 					uniqUsers[u.ID] = u
 				}
@@ -137,16 +144,12 @@ func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue,
 		}
 	}
 	for _, t := range uniqTeams {
-		comment, err := issues_model.AddTeamReviewRequest(ctx, issue, t, issue.Poster)
+		teamNotifiers, err := addTeamReviewRequest(ctx, issue, issue.Poster, t)
 		if err != nil {
 			log.Warn("Failed add assignee team: %s to PR review: %s#%d, error: %s", t.Name, pr.BaseRepo.Name, pr.ID, err)
 			return nil, err
 		}
-		notifiers = append(notifiers, &ReviewRequestNotifier{
-			Comment:    comment,
-			IsAdd:      true,
-			ReviewTeam: t,
-		})
+		notifiers = append(notifiers, teamNotifiers...)
 	}
 
 	return notifiers, nil
@@ -0,0 +1,221 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ValidateRecurringIssue checks the title, the schedule, the labels and the assignees of a recurring
+// issue, only the creators who can write issues can set labels and assignees. The errors wrap
+// util.ErrInvalidArgument.
+func ValidateRecurringIssue(ctx context.Context, repo *repo_model.Repository, r *issues_model.RecurringIssue) error {
+	if strings.TrimSpace(r.Title) == "" {
+		return util.NewInvalidArgumentErrorf("the title is empty")
+	}
+	if len(r.Title) > 255 {
+		return util.NewInvalidArgumentErrorf("the title is longer than 255 characters")
+	}
+	if _, err := issues_model.ParseRecurringIssueSchedule(r.Schedule); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid schedule %q: %v", r.Schedule, err)
+	}
+	if len(r.Labels) == 0 && len(r.Assignees) == 0 {
+		return nil
+	}
+	creator, err := user_model.GetUserByID(ctx, r.CreatorID)
+	if err != nil {
+		return err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, creator)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWriteIssuesOrPulls(false) {
+		return util.NewInvalidArgumentErrorf("only the writers of the issues can set the labels and the assignees")
+	}
+	_, _, err = getRecurringIssueMetas(ctx, repo, r)
+	return err
+}
+
+// SaveRecurringIssue validates a recurring issue, schedules its next run and creates or updates it
+func SaveRecurringIssue(ctx context.Context, repo *repo_model.Repository, r *issues_model.RecurringIssue) error {
+	if err := ValidateRecurringIssue(ctx, repo, r); err != nil {
+		return err
+	}
+	var err error
+	if r.NextRunUnix, err = r.NextRun(time.Now()); err != nil {
+		return err
+	}
+	if r.ID > 0 {
+		return issues_model.UpdateRecurringIssue(ctx, r)
+	}
+	return issues_model.CreateRecurringIssue(ctx, r)
+}
+
+// getRecurringIssueMetas returns the IDs of the labels and of the assignees of a recurring issue, the
+// labels are those of the repository and of its organization
+func getRecurringIssueMetas(ctx context.Context, repo *repo_model.Repository, r *issues_model.RecurringIssue) (labelIDs, assigneeIDs []int64, err error) {
+	if len(r.Labels) > 0 {
+		labels, err := getImportLabels(ctx, repo)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range r.Labels {
+			label, ok := labels[strings.ToLower(name)]
+			if !ok {
+				return nil, nil, util.NewInvalidArgumentErrorf("the label %q does not exist", name)
+			}
+			labelIDs = append(labelIDs, label.ID)
+		}
+	}
+	for _, name := range r.Assignees {
+		assignee, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, nil, util.NewInvalidArgumentErrorf("the user %q does not exist", name)
+			}
+			return nil, nil, err
+		}
+		if assignee.IsOrganization() {
+			return nil, nil, util.NewInvalidArgumentErrorf("the organization %q can not be assigned", name)
+		}
+		valid, err := access_model.CanBeAssigned(ctx, assignee, repo, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !valid {
+			return nil, nil, util.NewInvalidArgumentErrorf("the user %q can not be assigned", name)
+		}
+		assigneeIDs = append(assigneeIDs, assignee.ID)
+	}
+	return labelIDs, assigneeIDs, nil
+}
+
+// CreateDueRecurringIssues creates the issues of the recurring issues which are due
+func CreateDueRecurringIssues(ctx context.Context) error {
+	now := time.Now()
+	var afterID int64
+	for {
+		rs, err := issues_model.GetDueRecurringIssues(ctx, timeutil.TimeStamp(now.Unix()), afterID, 50)
+		if err != nil {
+			return err
+		}
+		for _, r := range rs {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err := RunRecurringIssue(ctx, r, now); err != nil {
+				log.Error("RunRecurringIssue [id: %d]: %v", r.ID, err)
+			}
+			afterID = r.ID
+		}
+		if len(rs) < 50 {
+			return nil
+		}
+	}
+}
+
+// RunRecurringIssue creates the issue of a recurring issue on behalf of its creator, unless the last one
+// is still open and it is skipped in that case, records the run and schedules the next one. The reasons
+// why the issue cannot be created are recorded in the history instead of being returned.
+func RunRecurringIssue(ctx context.Context, r *issues_model.RecurringIssue, now time.Time) error {
+	run := &issues_model.RecurringIssueRun{RecurringIssueID: r.ID}
+	issue, err := createRecurringIssue(ctx, r)
+	switch {
+	case errors.Is(err, errRecurringIssueSkipped):
+		run.Skipped = true
+	case errors.Is(err, util.ErrInvalidArgument):
+		run.Error = err.Error()
+	case err != nil:
+		return err
+	default:
+		run.IssueID = issue.ID
+		r.LastIssueID = issue.ID
+	}
+	if err := issues_model.CreateRecurringIssueRun(ctx, run); err != nil {
+		return err
+	}
+
+	r.LastRunUnix = timeutil.TimeStamp(now.Unix())
+	if r.NextRunUnix, err = r.NextRun(now); err != nil {
+		// the schedule was validated when it was saved
+		return err
+	}
+	return issues_model.UpdateRecurringIssueRun(ctx, r)
+}
+
+var errRecurringIssueSkipped = errors.New("the last issue is still open")
+
+func createRecurringIssue(ctx context.Context, r *issues_model.RecurringIssue) (*issues_model.Issue, error) {
+	if r.SkipIfOpen && r.LastIssueID > 0 {
+		last, err := issues_model.GetIssueByID(ctx, r.LastIssueID)
+		if err != nil && !issues_model.IsErrIssueNotExist(err) {
+			return nil, err
+		}
+		if last != nil && !last.IsClosed {
+			return nil, errRecurringIssueSkipped
+		}
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, r.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	if repo.IsArchived {
+		return nil, util.NewInvalidArgumentErrorf("the repository is archived")
+	}
+	if !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		return nil, util.NewInvalidArgumentErrorf("the issues of the repository are disabled")
+	}
+	creator, err := user_model.GetUserByID(ctx, r.CreatorID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil, util.NewInvalidArgumentErrorf("the creator of the recurring issue does not exist")
+		}
+		return nil, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, creator)
+	if err != nil {
+		return nil, err
+	}
+	if !creator.IsActive || creator.ProhibitLogin || !perm.CanRead(unit.TypeIssues) {
+		return nil, util.NewInvalidArgumentErrorf("the creator %q can no longer create issues", creator.Name)
+	}
+	// the labels and the assignees are only set for the creators who can still write issues
+	var labelIDs, assigneeIDs []int64
+	if perm.CanWriteIssuesOrPulls(false) {
+		labelIDs, assigneeIDs, err = getRecurringIssueMetas(ctx, repo, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    r.Title,
+		Content:  r.Content,
+		PosterID: creator.ID,
+		Poster:   creator,
+	}
+	if err := NewIssue(ctx, repo, issue, labelIDs, nil, assigneeIDs); err != nil {
+		return nil, fmt.Errorf("NewIssue: %w", err)
+	}
+	return issue, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveRecurringIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	for _, r := range []*issues_model.RecurringIssue{
+		{Title: "", Schedule: "@weekly"},
+		{Title: "Chores", Schedule: "every monday"},
+		{Title: "Chores", Schedule: "@weekly", Labels: []string{"unknown"}},
+		{Title: "Chores", Schedule: "@weekly", Assignees: []string{"user3"}},
+	} {
+		r.RepoID = repo.ID
+		r.CreatorID = 2
+		assert.ErrorIs(t, SaveRecurringIssue(db.DefaultContext, repo, r), util.ErrInvalidArgument)
+	}
+
+	r := &issues_model.RecurringIssue{
+		RepoID:    repo.ID,
+		Title:     "Chores",
+		Labels:    []string{"Label1"},
+		Assignees: []string{"user2"},
+		Schedule:  "0 9 * * 1",
+		IsActive:  true,
+		CreatorID: 2,
+	}
+	require.NoError(t, SaveRecurringIssue(db.DefaultContext, repo, r))
+	next := r.NextRunUnix.AsTime().UTC()
+	assert.Equal(t, time.Monday, next.Weekday())
+	assert.Equal(t, 9, next.Hour())
+
+	r.IsActive = false
+	require.NoError(t, SaveRecurringIssue(db.DefaultContext, repo, r))
+	assert.Zero(t, r.NextRunUnix)
+
+	// user4 can only read the issues
+	reader := &issues_model.RecurringIssue{
+		RepoID:    repo.ID,
+		Title:     "Chores",
+		Labels:    []string{"Label1"},
+		Schedule:  "@weekly",
+		CreatorID: 4,
+	}
+	require.ErrorIs(t, SaveRecurringIssue(db.DefaultContext, repo, reader), util.ErrInvalidArgument)
+	reader.Labels = nil
+	require.NoError(t, SaveRecurringIssue(db.DefaultContext, repo, reader))
+}
+
+func TestRunRecurringIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	r := &issues_model.RecurringIssue{
+		RepoID:     repo.ID,
+		Title:      "Update the dependencies",
+		Content:    "Check the dependencies",
+		Labels:     []string{"label1"},
+		Assignees:  []string{"user2"},
+		Schedule:   "@daily",
+		SkipIfOpen: true,
+		IsActive:   true,
+		CreatorID:  2,
+	}
+	require.NoError(t, SaveRecurringIssue(db.DefaultContext, repo, r))
+
+	// make it due
+	r.NextRunUnix = timeutil.TimeStamp(time.Now().Add(-time.Minute).Unix())
+	require.NoError(t, issues_model.UpdateRecurringIssueRun(db.DefaultContext, r))
+	require.NoError(t, CreateDueRecurringIssues(db.DefaultContext))
+
+	r = unittest.AssertExistsAndLoadBean(t, &issues_model.RecurringIssue{ID: r.ID})
+	assert.Greater(t, r.NextRunUnix, timeutil.TimeStampNow())
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: r.LastIssueID})
+	assert.Equal(t, "Update the dependencies", issue.Title)
+	assert.EqualValues(t, 2, issue.PosterID)
+	require.NoError(t, issue.LoadLabels(db.DefaultContext))
+	if assert.Len(t, issue.Labels, 1) {
+		assert.EqualValues(t, 1, issue.Labels[0].ID)
+	}
+	unittest.AssertExistsIf(t, true, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+	unittest.AssertExistsIf(t, true, &issues_model.RecurringIssueRun{RecurringIssueID: r.ID, IssueID: issue.ID})
+
+	// the last issue is still open
+	require.NoError(t, RunRecurringIssue(db.DefaultContext, r, time.Now()))
+	unittest.AssertExistsIf(t, true, &issues_model.RecurringIssueRun{RecurringIssueID: r.ID, Skipped: true})
+	assert.Equal(t, issue.ID, r.LastIssueID)
+
+	// the label was deleted
+	r.Labels = []string{"label1", "deleted"}
+	r.SkipIfOpen = false
+	require.NoError(t, RunRecurringIssue(db.DefaultContext, r, time.Now()))
+	runs, err := db.Find[issues_model.RecurringIssueRun](db.DefaultContext, issues_model.FindRecurringIssueRunsOptions{RecurringIssueID: r.ID})
+	require.NoError(t, err)
+	if assert.Len(t, runs, 3) {
+		assert.Zero(t, runs[0].IssueID)
+		assert.False(t, runs[0].Skipped)
+		assert.Contains(t, runs[0].Error, `the label "deleted" does not exist`)
+	}
+
+	// the creator can no longer write issues, the labels and the assignees are dropped
+	r.Labels = []string{"label1"}
+	r.CreatorID = 4
+	require.NoError(t, RunRecurringIssue(db.DefaultContext, r, time.Now()))
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: r.LastIssueID})
+	assert.EqualValues(t, 4, issue.PosterID)
+	require.NoError(t, issue.LoadLabels(db.DefaultContext))
+	assert.Empty(t, issue.Labels)
+	unittest.AssertExistsIf(t, false, &issues_model.IssueAssignees{IssueID: issue.ID})
+
+	require.NoError(t, issues_model.DeleteRecurringIssuesByRepoID(db.DefaultContext, repo.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.RecurringIssue{ID: r.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.RecurringIssueRun{RecurringIssueID: r.ID})
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	activities_model "forgejo.org/models/activities"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/timeutil"
+)
+
+// CanViewSavedSearch returns whether a user can see and run a saved search: its owner or a member
+// of the organization which owns it
+func CanViewSavedSearch(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if doer.ID == search.OwnerID {
+		return true, nil
+	}
+	return organization.IsOrganizationMember(ctx, search.OwnerID, doer.ID)
+}
+
+// CanEditSavedSearch returns whether a user can change or delete a saved search: its owner, the
+// member of the organization who created it or an owner of the organization
+func CanEditSavedSearch(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if doer.ID == search.OwnerID {
+		return true, nil
+	}
+	if doer.ID == search.CreatorID {
+		return organization.IsOrganizationMember(ctx, search.OwnerID, doer.ID)
+	}
+	return organization.IsOrganizationOwner(ctx, search.OwnerID, doer.ID)
+}
+
+// RunSavedSearch returns the issues and pull requests matching a saved search which the doer can
+// read, and their total number
+func RunSavedSearch(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch, listOpts *db.ListOptions, updatedAfter timeutil.TimeStamp) (issues_model.IssueList, int64, error) {
+	if err := search.LoadOwner(ctx); err != nil {
+		return nil, 0, err
+	}
+	filter := search.Filter()
+
+	searchOpt := &issue_indexer.SearchOptions{
+		Paginator: listOpts,
+		Keyword:   filter.Keyword,
+		SortBy:    issue_indexer.ParseSortBy(filter.Sort, issue_indexer.SortByCreatedDesc),
+	}
+	if strings.IndexByte(searchOpt.Keyword, 0) >= 0 {
+		searchOpt.Keyword = ""
+	}
+	switch filter.Type {
+	case "pulls":
+		searchOpt.IsPull = optional.Some(true)
+	case "issues":
+		searchOpt.IsPull = optional.Some(false)
+	}
+	switch filter.State {
+	case "closed":
+		searchOpt.IsClosed = optional.Some(true)
+	case "all":
+	default:
+		searchOpt.IsClosed = optional.Some(false)
+	}
+	if filter.ProjectID > 0 {
+		searchOpt.ProjectID = optional.Some(filter.ProjectID)
+	}
+	if updatedAfter > 0 {
+		searchOpt.UpdatedAfterUnix = optional.Some(int64(updatedAfter))
+	}
+
+	var err error
+	searchOpt.RepoIDs, searchOpt.AllPublic, err = savedSearchRepoIDs(ctx, doer, search.Owner, filter.Repos)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(searchOpt.RepoIDs) == 0 && !searchOpt.AllPublic {
+		return issues_model.IssueList{}, 0, nil
+	}
+
+	if len(filter.Labels) > 0 {
+		if searchOpt.IncludedAnyLabelIDs, err = issues_model.GetLabelIDsByNames(ctx, filter.Labels); err != nil {
+			return nil, 0, err
+		}
+	}
+	if len(filter.Milestones) > 0 {
+		if searchOpt.MilestoneIDs, err = issues_model.GetMilestoneIDsByNames(ctx, filter.Milestones); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// a filter on a user who does not exist matches nothing
+	for _, userFilter := range []struct {
+		name string
+		opt  *optional.Option[int64]
+	}{
+		{filter.Assignee, &searchOpt.AssigneeID},
+		{filter.Poster, &searchOpt.PosterID},
+		{filter.Mentioned, &searchOpt.MentionID},
+	} {
+		name, opt := userFilter.name, userFilter.opt
+		if name == "" {
+			continue
+		}
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return issues_model.IssueList{}, 0, nil
+			}
+			return nil, 0, err
+		}
+		*opt = optional.Some(u.ID)
+	}
+
+	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
+	if err != nil {
+		return nil, 0, err
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return issues, total, nil
+}
+
+// savedSearchRepoIDs returns the repositories a saved search looks into: the listed repositories
+// the doer can read issues of, or the repositories of the owner if it is an organization, or all
+// the repositories the doer can access
+func savedSearchRepoIDs(ctx context.Context, doer, owner *user_model.User, repoNames []string) ([]int64, bool, error) {
+	if len(repoNames) > 0 {
+		repoIDs := make([]int64, 0, len(repoNames))
+		for _, fullName := range repoNames {
+			ownerName, repoName, ok := strings.Cut(fullName, "/")
+			if !ok {
+				continue
+			}
+			repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+			if err != nil {
+				if repo_model.IsErrRepoNotExist(err) {
+					continue
+				}
+				return nil, false, err
+			}
+			perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+			if err != nil {
+				return nil, false, err
+			}
+			if perm.CanRead(unit.TypeIssues) || perm.CanRead(unit.TypePullRequests) {
+				repoIDs = append(repoIDs, repo.ID)
+			}
+		}
+		return repoIDs, false, nil
+	}
+
+	opts := &repo_model.SearchRepoOptions{
+		Actor:       doer,
+		Private:     true,
+		AllLimited:  true,
+		Collaborate: optional.None[bool](),
+		OrderBy:     db.SearchOrderByAlphabetically,
+	}
+	allPublic := true
+	if owner.IsOrganization() {
+		opts.OwnerID = owner.ID
+		opts.AllLimited = false
+		opts.Collaborate = optional.Some(false)
+		allPublic = false
+	}
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	return repoIDs, allPublic, nil
+}
+
+// CheckSavedSearchNotifications notifies the users who follow saved searches about the issues and
+// pull requests which started to match them since the last check
+func CheckSavedSearchNotifications(ctx context.Context) error {
+	subs, err := issues_model.GetNotifySavedSearchSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := checkSavedSearchSubscription(ctx, sub); err != nil {
+			log.Error("checkSavedSearchSubscription [subscription id: %d]: %v", sub.ID, err)
+		}
+	}
+	return nil
+}
+
+func checkSavedSearchSubscription(ctx context.Context, sub *issues_model.SavedSearchSubscription) error {
+	search, err := issues_model.GetSavedSearchByID(ctx, sub.SearchID)
+	if err != nil {
+		return err
+	}
+	user, err := user_model.GetUserByID(ctx, sub.UserID)
+	if err != nil {
+		return err
+	}
+	if canView, err := CanViewSavedSearch(ctx, user, search); err != nil || !canView {
+		return err
+	}
+
+	checkedUnix := timeutil.TimeStampNow()
+	for page := 1; ; page++ {
+		issues, _, err := RunSavedSearch(ctx, user, search, &db.ListOptions{Page: page, PageSize: 50}, sub.LastCheckedUnix)
+		if err != nil {
+			return err
+		}
+		issueIDs := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+		newIDs, err := issues_model.AddSavedSearchMatches(ctx, sub.ID, issueIDs)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if issue.PosterID == user.ID || !slices.Contains(newIDs, issue.ID) {
+				continue
+			}
+			if err := activities_model.CreateOrUpdateIssueNotifications(ctx, issue.ID, 0, issue.PosterID, user.ID); err != nil {
+				return err
+			}
+		}
+		if len(issues) < 50 {
+			break
+		}
+	}
+
+	sub.LastCheckedUnix = checkedUnix
+	return issues_model.UpdateSavedSearchLastChecked(ctx, sub)
+}
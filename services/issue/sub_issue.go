@@ -0,0 +1,57 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// AddSubIssue makes an issue the last child of a parent issue
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if parent.IsPull || issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests cannot have sub-issues or be sub-issues")
+	}
+	if err := issues_model.AddSubIssue(ctx, doer, parent, issue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeSubIssue(ctx, doer, parent, issue, false)
+	return nil
+}
+
+// RemoveSubIssue removes an issue from the children of its parent issue
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := issues_model.RemoveSubIssue(ctx, doer, parent, issue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeSubIssue(ctx, doer, parent, issue, true)
+	return nil
+}
+
+// GetReadableSubIssueTree returns all the levels of sub-issues below an issue, without the sub-issues
+// which the doer cannot read and their own sub-issues
+func GetReadableSubIssueTree(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (issues_model.SubIssueTree, error) {
+	tree, err := issues_model.GetSubIssueTree(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := make(map[int64]access_model.Permission)
+	tree = tree.Filter(func(subIssue *issues_model.Issue) bool {
+		perm, ok := perms[subIssue.RepoID]
+		if !ok && err == nil {
+			perm, err = access_model.GetUserRepoPermission(ctx, subIssue.Repo, doer)
+			perms[subIssue.RepoID] = perm
+		}
+		return err == nil && perm.CanReadIssuesOrPulls(subIssue.IsPull)
+	})
+	return tree, err
+}
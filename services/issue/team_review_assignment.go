@@ -0,0 +1,140 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	access_model "forgejo.org/models/perm/access"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+)
+
+// addTeamReviewRequest requests a review from a team. When the team has a review assignment policy, the
+// review is requested from some of its members instead, and from the team only when none of them can be
+// assigned.
+func addTeamReviewRequest(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, team *organization.Team) ([]*ReviewRequestNotifier, error) {
+	if team.HasReviewAssignment() {
+		reviewers, err := chooseTeamReviewers(ctx, issue, team)
+		if err != nil {
+			return nil, err
+		}
+		if len(reviewers) > 0 {
+			notifiers := make([]*ReviewRequestNotifier, 0, len(reviewers))
+			for _, reviewer := range reviewers {
+				comment, err := issues_model.AddReviewRequest(ctx, issue, reviewer, doer)
+				if err != nil {
+					return nil, err
+				}
+				if comment == nil {
+					continue
+				}
+				notifiers = append(notifiers, &ReviewRequestNotifier{
+					Comment:  comment,
+					IsAdd:    true,
+					Reviewer: reviewer,
+				})
+			}
+			return notifiers, nil
+		}
+	}
+
+	comment, err := issues_model.AddTeamReviewRequest(ctx, issue, team, doer)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, nil
+	}
+	return []*ReviewRequestNotifier{{
+		Comment:    comment,
+		IsAdd:      true,
+		ReviewTeam: team,
+	}}, nil
+}
+
+// chooseTeamReviewers chooses the members of a team to request a review from, following the review
+// assignment policy of the team. The members who already reviewed or are already requested to review count
+// towards the number of reviewers of the policy. The author of the pull request, their managers, the excluded
+// members, the members who cannot sign in or read the pull requests and the members who have too many pending
+// review requests are never chosen.
+func chooseTeamReviewers(ctx context.Context, issue *issues_model.Issue, team *organization.Team) ([]*user_model.User, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: team.ID})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(members, func(a, b *user_model.User) int { return int(a.ID - b.ID) })
+	managerIDs, err := organization.GetTeamReviewManagerIDs(ctx, team.ID, issue.PosterID)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := max(team.ReviewAssignmentCount, 1)
+	candidates := make([]*user_model.User, 0, len(members))
+	for _, member := range members {
+		if member.ID == issue.PosterID || team.IsReviewAssignmentExcluded(member.ID) || slices.Contains(managerIDs, member.ID) ||
+			!member.IsActive || member.ProhibitLogin {
+			continue
+		}
+		review, err := issues_model.GetReviewByIssueIDAndUserID(ctx, issue.ID, member.ID)
+		if err != nil && !issues_model.IsErrReviewNotExist(err) {
+			return nil, err
+		}
+		if review != nil {
+			wanted--
+			continue
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, member)
+		if err != nil {
+			return nil, fmt.Errorf("GetUserRepoPermission: %w", err)
+		}
+		if !perm.CanRead(unit.TypePullRequests) {
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+	if wanted <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	candidateIDs := make([]int64, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateIDs = append(candidateIDs, candidate.ID)
+	}
+	pending, err := issues_model.CountPendingReviewRequests(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	if team.ReviewAssignmentMaxPending > 0 {
+		candidates = slices.DeleteFunc(candidates, func(u *user_model.User) bool {
+			return pending[u.ID] >= team.ReviewAssignmentMaxPending
+		})
+	}
+
+	switch team.ReviewAssignment {
+	case organization.ReviewAssignmentRoundRobin:
+		// start after the member assigned last, the members being ordered by ID
+		next := slices.IndexFunc(candidates, func(u *user_model.User) bool { return u.ID > team.ReviewAssignmentLastUserID })
+		if next > 0 {
+			candidates = slices.Concat(candidates[next:], candidates[:next])
+		}
+	case organization.ReviewAssignmentLoadBalance:
+		slices.SortStableFunc(candidates, func(a, b *user_model.User) int { return pending[a.ID] - pending[b.ID] })
+	}
+	reviewers := candidates[:min(wanted, len(candidates))]
+
+	if team.ReviewAssignment == organization.ReviewAssignmentRoundRobin && len(reviewers) > 0 {
+		if err := organization.UpdateTeamReviewAssignmentLastUser(ctx, team, reviewers[len(reviewers)-1].ID); err != nil {
+			return nil, err
+		}
+	}
+	return reviewers, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChooseTeamReviewers(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// the members of the team are user38 and user39, the pull request is posted by user40
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 22})
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 22})
+
+	reviewerIDs := func(t *testing.T) []int64 {
+		t.Helper()
+		reviewers, err := chooseTeamReviewers(db.DefaultContext, issue, team)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(reviewers))
+		for _, reviewer := range reviewers {
+			ids = append(ids, reviewer.ID)
+		}
+		return ids
+	}
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		team.ReviewAssignment = organization.ReviewAssignmentRoundRobin
+		team.ReviewAssignmentCount = 1
+		assert.Equal(t, []int64{38}, reviewerIDs(t))
+		assert.Equal(t, []int64{39}, reviewerIDs(t))
+		assert.Equal(t, []int64{38}, reviewerIDs(t))
+		unittest.AssertExistsIf(t, true, &organization.Team{ID: 22, ReviewAssignmentLastUserID: 38})
+
+		team.ReviewAssignmentCount = 3
+		assert.Equal(t, []int64{39, 38}, reviewerIDs(t))
+	})
+
+	t.Run("Excluded", func(t *testing.T) {
+		team.ReviewAssignment = organization.ReviewAssignmentLoadBalance
+		team.ReviewAssignmentCount = 2
+		team.ReviewAssignmentExcludedUserIDs = []int64{38}
+		assert.Equal(t, []int64{39}, reviewerIDs(t))
+		team.ReviewAssignmentExcludedUserIDs = nil
+	})
+
+	t.Run("Managers", func(t *testing.T) {
+		team.ReviewAssignment = organization.ReviewAssignmentLoadBalance
+		team.ReviewAssignmentCount = 2
+		// user39 manages the author of the pull request, user38 manages user39
+		require.NoError(t, organization.UpdateTeamReviewAssignment(db.DefaultContext, team, []*organization.TeamReviewManager{
+			{UserID: 40, ManagerID: 39},
+			{UserID: 39, ManagerID: 38},
+		}))
+		assert.Equal(t, []int64{38}, reviewerIDs(t))
+
+		require.NoError(t, organization.UpdateTeamReviewAssignment(db.DefaultContext, team, nil))
+		assert.Equal(t, []int64{38, 39}, reviewerIDs(t))
+	})
+
+	t.Run("ReplacesTeamRequest", func(t *testing.T) {
+		doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 40})
+		team.ReviewAssignment = organization.ReviewAssignmentLoadBalance
+		team.ReviewAssignmentCount = 1
+
+		comments, err := TeamReviewRequest(db.DefaultContext, issue, doer, team, true)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		assert.EqualValues(t, 38, comments[0].AssigneeID)
+		unittest.AssertExistsIf(t, true, &issues_model.Review{IssueID: 22, ReviewerID: 38, Type: issues_model.ReviewTypeRequest})
+		unittest.AssertExistsIf(t, false, &issues_model.Review{IssueID: 22, ReviewerTeamID: 22})
+
+		pending, err := issues_model.CountPendingReviewRequests(db.DefaultContext, []int64{38, 39})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]int{38: 1}, pending)
+
+		// user38 counts towards the number of reviewers
+		team.ReviewAssignmentCount = 2
+		assert.Equal(t, []int64{39}, reviewerIDs(t))
+
+		// user39 is busy
+		team.ReviewAssignmentMaxPending = 1
+		otherIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 20})
+		require.NoError(t, otherIssue.LoadRepo(db.DefaultContext))
+		_, err = issues_model.AddReviewRequest(db.DefaultContext, otherIssue, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 39}), doer)
+		require.NoError(t, err)
+		assert.Empty(t, reviewerIDs(t))
+	})
+}
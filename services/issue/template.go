@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"slices"
 	"strings"
 
 	"forgejo.org/models/repo"
@@ -33,6 +34,18 @@ var templateDirCandidates = []string{
 	".gitlab/issue_template",
 }
 
+// pullRequestTemplateDirCandidates pull request templates directory
+var pullRequestTemplateDirCandidates = []string{
+	"PULL_REQUEST_TEMPLATE",
+	"pull_request_template",
+	".forgejo/PULL_REQUEST_TEMPLATE",
+	".forgejo/pull_request_template",
+	".gitea/PULL_REQUEST_TEMPLATE",
+	".gitea/pull_request_template",
+	".github/PULL_REQUEST_TEMPLATE",
+	".github/pull_request_template",
+}
+
 var templateConfigCandidates = []string{
 	".forgejo/ISSUE_TEMPLATE/config",
 	".forgejo/issue_template/config",
@@ -111,9 +124,49 @@ func IsTemplateConfig(path string) bool {
 	return false
 }
 
+// IsTemplatePath returns if the given path is a file of the issue templates directories, or of the pull request
+// templates directories if isPull is true.
+func IsTemplatePath(name string, isPull bool) bool {
+	dirCandidates := templateDirCandidates
+	if isPull {
+		dirCandidates = pullRequestTemplateDirCandidates
+	}
+	name = path.Clean(name)
+	return template.CouldBe(path.Base(name)) && slices.Contains(dirCandidates, path.Dir(name))
+}
+
 // GetTemplatesFromDefaultBranch checks for issue templates in the repo's default branch,
 // returns valid templates and the errors of invalid template files.
 func GetTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository) ([]*api.IssueTemplate, map[string]error) {
+	return getTemplatesFromDefaultBranch(repo, gitRepo, templateDirCandidates)
+}
+
+// GetPullRequestTemplatesFromDefaultBranch checks for the pull request templates of the templates directory
+// in the repo's default branch, returns valid templates and the errors of invalid template files.
+func GetPullRequestTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository) ([]*api.IssueTemplate, map[string]error) {
+	return getTemplatesFromDefaultBranch(repo, gitRepo, pullRequestTemplateDirCandidates)
+}
+
+// FindPullRequestTemplate returns the pull request template chosen by name, the file name of a template
+// in the templates directory, or else the first template whose branch patterns match the target branch.
+// It returns nil if there is no such template.
+func FindPullRequestTemplate(templates []*api.IssueTemplate, name, targetBranch string) *api.IssueTemplate {
+	if name != "" {
+		for _, it := range templates {
+			if it.FileName == name || path.Base(it.FileName) == name {
+				return it
+			}
+		}
+	}
+	for _, it := range templates {
+		if template.MatchBranch(it, targetBranch) {
+			return it
+		}
+	}
+	return nil
+}
+
+func getTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository, dirCandidates []string) ([]*api.IssueTemplate, map[string]error) {
 	var issueTemplates []*api.IssueTemplate
 
 	if repo.IsEmpty {
@@ -126,7 +179,7 @@ func GetTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repositor
 	}
 
 	invalidFiles := map[string]error{}
-	for _, dirName := range templateDirCandidates {
+	for _, dirName := range dirCandidates {
 		tree, err := commit.SubTree(dirName)
 		if err != nil {
 			log.Debug("get sub tree of %s: %v", dirName, err)
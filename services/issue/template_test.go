@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+
+	api "forgejo.org/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPullRequestTemplate(t *testing.T) {
+	feature := &api.IssueTemplate{Name: "Feature", FileName: ".forgejo/PULL_REQUEST_TEMPLATE/feature.md"}
+	release := &api.IssueTemplate{Name: "Release", FileName: ".forgejo/PULL_REQUEST_TEMPLATE/release.md", Branches: []string{"release/*"}}
+	hotfix := &api.IssueTemplate{Name: "Hotfix", FileName: ".forgejo/PULL_REQUEST_TEMPLATE/hotfix.md", Branches: []string{"release/*", "main"}}
+	templates := []*api.IssueTemplate{feature, release, hotfix}
+
+	for _, testCase := range []struct {
+		name         string
+		templateName string
+		targetBranch string
+		want         *api.IssueTemplate
+	}{
+		{"Path", ".forgejo/PULL_REQUEST_TEMPLATE/feature.md", "main", feature},
+		{"FileName", "feature.md", "main", feature},
+		// the choice wins over the branches of the templates
+		{"ChosenForOtherBranch", "release.md", "main", release},
+		{"UnknownName", "unknown.md", "main", hotfix},
+		{"TemplateName", "Feature", "develop", nil},
+		{"Branch", "", "release/1.0", release},
+		{"OtherBranch", "", "main", hotfix},
+		// the default template is used when no template is chosen for the branch
+		{"NoMatch", "", "develop", nil},
+		{"NoMatchInSubdirectory", "", "release/1.0/fix", nil},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, FindPullRequestTemplate(templates, testCase.templateName, testCase.targetBranch))
+		})
+	}
+
+	assert.Nil(t, FindPullRequestTemplate(nil, "feature.md", "main"))
+}
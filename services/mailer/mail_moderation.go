@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	moderation_model "forgejo.org/models/moderation"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplAbuseReportResolvedMail base.TplName = "notify/abuse_report_resolved"
+)
+
+// MailAbuseReportsResolved lets the reporters know that a moderator resolved their reports
+func MailAbuseReportsResolved(ctx context.Context, reports moderation_model.AbuseReportList) {
+	if setting.MailService == nil {
+		return
+	}
+
+	for _, report := range reports {
+		if report.Reporter == nil || report.Reporter.ID <= 0 || !report.Reporter.IsActive || report.Reporter.ProhibitLogin {
+			continue
+		}
+		if err := mailAbuseReportResolved(report); err != nil {
+			log.Error("mailAbuseReportResolved [report_id: %d]: %v", report.ID, err)
+		}
+	}
+}
+
+func mailAbuseReportResolved(report *moderation_model.AbuseReport) error {
+	locale := translation.NewLocale(report.Reporter.Language)
+
+	subject := locale.TrString("mail.moderation.report_resolved.subject", report.ContentReference)
+	data := map[string]any{
+		"locale":      locale,
+		"DisplayName": report.Reporter.DisplayName(),
+		"Report":      report,
+		"Handled":     report.Status == moderation_model.ReportStatusHandled,
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tplAbuseReportResolvedMail), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(report.Reporter.EmailTo(), subject, content.String())
+	msg.Info = fmt.Sprintf("UID: %d, abuse report %d resolved", report.ReporterID, report.ID)
+
+	SendAsync(msg)
+	return nil
+}
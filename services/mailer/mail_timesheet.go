@@ -0,0 +1,52 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplTimesheetSummaryMail base.TplName = "notify/timesheet_summary"
+)
+
+// MailTimesheetSummary sends to a user the summary of the time they tracked in a period, by repository
+func MailTimesheetSummary(u *user_model.User, from, to time.Time, entries []*api.TimesheetEntry, total int64) error {
+	if setting.MailService == nil {
+		return nil
+	}
+	locale := translation.NewLocale(u.Language)
+
+	fromDate := from.In(setting.DefaultUILocation).Format("2006-01-02")
+	toDate := to.In(setting.DefaultUILocation).Format("2006-01-02")
+	subject := locale.TrString("mail.timesheet.summary.subject", fromDate, toDate)
+	data := map[string]any{
+		"locale":      locale,
+		"DisplayName": u.DisplayName(),
+		"From":        fromDate,
+		"To":          toDate,
+		"Entries":     entries,
+		"Total":       total,
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tplTimesheetSummaryMail), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), subject, content.String())
+	msg.Info = fmt.Sprintf("UID: %d, timesheet summary", u.ID)
+
+	SendAsync(msg)
+	return nil
+}
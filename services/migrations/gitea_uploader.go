@@ -918,7 +918,12 @@ func (g *GiteaLocalUploader) CreateReviews(reviews ...*base.Review) error {
 				_ = writer.Close()
 			}(comment)
 
-			patch, _ = git.CutDiffAroundLine(reader, int64((&issues_model.Comment{Line: int64(line + comment.Position - 1)}).UnsignedLine()), line < 0, setting.UI.CodeCommentLines)
+			var startLine int64
+			if comment.StartLine != 0 && comment.Line != 0 {
+				startLine = int64(comment.StartLine)
+			}
+			rangeComment := &issues_model.Comment{Line: int64(line + comment.Position - 1), StartLine: startLine}
+			patch, _ = git.CutDiffAroundLines(reader, int64(rangeComment.UnsignedStartLine()), int64(rangeComment.UnsignedLine()), line < 0, setting.UI.CodeCommentLines)
 
 			if comment.CreatedAt.IsZero() {
 				comment.CreatedAt = review.CreatedAt
@@ -938,6 +943,7 @@ func (g *GiteaLocalUploader) CreateReviews(reviews ...*base.Review) error {
 				IssueID:     issue.ID,
 				Content:     comment.Content,
 				Line:        int64(line + comment.Position - 1),
+				StartLine:   startLine,
 				TreePath:    comment.TreePath,
 				CommitSHA:   comment.CommitID,
 				Patch:       patch,
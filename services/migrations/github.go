@@ -794,7 +794,7 @@ func (g *GithubDownloaderV3) convertGithubReviewComments(cs []*github.PullReques
 			}
 		}
 
-		rcs = append(rcs, &base.ReviewComment{
+		rc := &base.ReviewComment{
 			ID:        c.GetID(),
 			InReplyTo: c.GetInReplyTo(),
 			Content:   c.GetBody(),
@@ -806,7 +806,15 @@ func (g *GithubDownloaderV3) convertGithubReviewComments(cs []*github.PullReques
 			Reactions: reactions,
 			CreatedAt: c.GetCreatedAt().Time,
 			UpdatedAt: c.GetUpdatedAt().Time,
-		})
+		}
+		// multi-line comments are on a range of lines of the same side, LEFT being the previous lines
+		if c.GetStartLine() != 0 && c.GetLine() != 0 && c.GetStartSide() == c.GetSide() {
+			rc.Line, rc.StartLine = c.GetLine(), c.GetStartLine()
+			if c.GetSide() == "LEFT" {
+				rc.Line, rc.StartLine = -rc.Line, -rc.StartLine
+			}
+		}
+		rcs = append(rcs, rc)
 	}
 	return rcs, nil
 }
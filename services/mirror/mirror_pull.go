@@ -464,6 +464,7 @@ func SyncPullMirror(ctx context.Context, repoID int64) bool {
 	defer finished()
 
 	log.Trace("SyncMirrors [repo: %-v]: Running Sync", m.Repo)
+	startUnix := timeutil.TimeStampNow()
 	results, ok := runSync(ctx, m)
 	if !ok {
 		if err = repo_model.TouchMirror(ctx, m); err != nil {
@@ -581,6 +582,12 @@ func SyncPullMirror(ctx context.Context, repoID int64) bool {
 		}
 	}
 
+	if setting.Replica.Enabled {
+		if err = repo_model.UpdateReplicaRepositorySync(ctx, m.RepoID, startUnix); err != nil {
+			log.Error("SyncMirrors [repo: %-v]: unable to update the replica sync time: %v", m.Repo, err)
+		}
+	}
+
 	log.Trace("SyncMirrors [repo: %-v]: Successfully updated", m.Repo)
 
 	return true
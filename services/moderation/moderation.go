@@ -0,0 +1,239 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	issues_model "forgejo.org/models/issues"
+	moderation_model "forgejo.org/models/moderation"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/mailer"
+	repo_service "forgejo.org/services/repository"
+)
+
+// ReportedContent is the user, repository, issue or comment an abuse report is about
+type ReportedContent struct {
+	Type moderation_model.ContentType
+	ID   int64
+	// Reference is a short human readable name of the content, e.g. owner/repo#12
+	Reference string
+	// Link is the relative link to the content
+	Link string
+	// Poster is the user who is responsible for the content
+	Poster *user_model.User
+
+	User    *user_model.User
+	Repo    *repo_model.Repository
+	Issue   *issues_model.Issue
+	Comment *issues_model.Comment
+}
+
+// GetReportedContent returns the content of a type with an ID, or an error wrapping util.ErrNotExist
+// if it does not exist or the doer cannot see it
+func GetReportedContent(ctx context.Context, doer *user_model.User, contentType moderation_model.ContentType, contentID int64) (*ReportedContent, error) {
+	notExist := util.NewNotExistErrorf("%s %d does not exist", contentType, contentID)
+	content := &ReportedContent{Type: contentType, ID: contentID}
+
+	switch contentType {
+	case moderation_model.ContentTypeUser:
+		u, err := user_model.GetUserByID(ctx, contentID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, notExist
+			}
+			return nil, err
+		}
+		if !user_model.IsUserVisibleToViewer(ctx, u, doer) {
+			return nil, notExist
+		}
+		content.User = u
+		content.Poster = u
+		content.Reference = "@" + u.Name
+		content.Link = u.HomeLink()
+		return content, nil
+
+	case moderation_model.ContentTypeRepository:
+		repo, err := repo_model.GetRepositoryByID(ctx, contentID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, notExist
+			}
+			return nil, err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if !perm.HasAccess() {
+			return nil, notExist
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return nil, err
+		}
+		content.Repo = repo
+		content.Poster = repo.Owner
+		content.Reference = repo.FullName()
+		content.Link = repo.Link()
+		return content, nil
+
+	case moderation_model.ContentTypeIssue, moderation_model.ContentTypeComment:
+		var comment *issues_model.Comment
+		issueID := contentID
+		if contentType == moderation_model.ContentTypeComment {
+			var err error
+			comment, err = issues_model.GetCommentByID(ctx, contentID)
+			if err != nil {
+				if issues_model.IsErrCommentNotExist(err) {
+					return nil, notExist
+				}
+				return nil, err
+			}
+			issueID = comment.IssueID
+		}
+
+		issue, err := issues_model.GetIssueByID(ctx, issueID)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				return nil, notExist
+			}
+			return nil, err
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+			return nil, notExist
+		}
+		content.Repo = issue.Repo
+		content.Issue = issue
+		content.Reference = fmt.Sprintf("%s#%d", issue.Repo.FullName(), issue.Index)
+		content.Link = issue.Link()
+
+		if comment == nil {
+			if err := issue.LoadPoster(ctx); err != nil {
+				return nil, err
+			}
+			content.Poster = issue.Poster
+			return content, nil
+		}
+		if err := comment.LoadPoster(ctx); err != nil {
+			return nil, err
+		}
+		comment.Issue = issue
+		content.Comment = comment
+		content.Poster = comment.Poster
+		content.Link += "#" + comment.HashTag()
+		return content, nil
+	}
+	return nil, notExist
+}
+
+// ReportAbuse saves the report of a user about some content
+func ReportAbuse(ctx context.Context, doer *user_model.User, content *ReportedContent, category moderation_model.Category, remarks string) (*moderation_model.AbuseReport, error) {
+	if category.String() == "" {
+		return nil, util.NewInvalidArgumentErrorf("unknown report category %d", category)
+	}
+	if content.Poster != nil && content.Poster.ID == doer.ID {
+		return nil, util.NewInvalidArgumentErrorf("users cannot report their own content")
+	}
+
+	report := &moderation_model.AbuseReport{
+		ReporterID:       doer.ID,
+		Reporter:         doer,
+		ContentType:      content.Type,
+		ContentID:        content.ID,
+		ContentReference: content.Reference,
+		ContentLink:      content.Link,
+		Category:         category,
+		Remarks:          remarks,
+	}
+	if err := moderation_model.CreateAbuseReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ResolveReport takes the action of a moderator on the content of a report, resolves all the open
+// reports about this content with it and lets the reporters know
+func ResolveReport(ctx context.Context, doer *user_model.User, report *moderation_model.AbuseReport, action moderation_model.Action, reason string) error {
+	if !report.IsOpen() {
+		return moderation_model.ErrAbuseReportResolved{ID: report.ID}
+	}
+	if action != moderation_model.ActionNone && !slices.Contains(report.ContentType.Actions(), action) {
+		return util.NewInvalidArgumentErrorf("action %s cannot be taken on a %s", action, report.ContentType)
+	}
+
+	if action != moderation_model.ActionNone {
+		content, err := GetReportedContent(ctx, doer, report.ContentType, report.ContentID)
+		if err != nil {
+			return err
+		}
+		if err := takeAction(ctx, doer, content, action); err != nil {
+			return err
+		}
+	}
+
+	reports, err := moderation_model.ResolveReports(ctx, moderation_model.ResolveReportsOptions{
+		ContentType: report.ContentType,
+		ContentID:   report.ContentID,
+		Resolver:    doer,
+		Action:      action,
+		Reason:      reason,
+	})
+	if err != nil {
+		return err
+	}
+	if err := reports.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes: %v", err)
+		return nil
+	}
+	mailer.MailAbuseReportsResolved(ctx, reports)
+	return nil
+}
+
+func takeAction(ctx context.Context, doer *user_model.User, content *ReportedContent, action moderation_model.Action) error {
+	switch action {
+	case moderation_model.ActionHideContent:
+		if content.Comment != nil {
+			content.Comment.IsHidden = true
+			if err := issues_model.UpdateCommentHidden(ctx, content.Comment); err != nil {
+				return err
+			}
+		} else {
+			content.Issue.IsHidden = true
+			if err := issues_model.UpdateIssueCols(ctx, content.Issue, "is_hidden"); err != nil {
+				return err
+			}
+		}
+		issue_indexer.UpdateIssueIndexer(ctx, content.Issue.ID)
+		return nil
+
+	case moderation_model.ActionSuspendUser:
+		u := content.Poster
+		if u == nil || u.ID <= 0 || u.IsOrganization() {
+			return util.NewInvalidArgumentErrorf("%s is not a user who can be suspended", content.Reference)
+		}
+		if u.IsAdmin {
+			return util.NewInvalidArgumentErrorf("administrators cannot be suspended")
+		}
+		u.ProhibitLogin = true
+		return user_model.UpdateUserCols(ctx, u, "prohibit_login")
+
+	case moderation_model.ActionDeleteRepository:
+		return repo_service.DeleteRepository(ctx, doer, content.Repo, true)
+	}
+	return nil
+}
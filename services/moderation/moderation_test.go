@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	moderation_model "forgejo.org/models/moderation"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReportedContent(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	content, err := GetReportedContent(db.DefaultContext, user4, moderation_model.ContentTypeIssue, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "user2/repo1#1", content.Reference)
+	assert.EqualValues(t, 1, content.Poster.ID)
+
+	content, err = GetReportedContent(db.DefaultContext, user4, moderation_model.ContentTypeComment, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "user2/repo1#1", content.Reference)
+	assert.Contains(t, content.Link, "#issuecomment-2")
+
+	content, err = GetReportedContent(db.DefaultContext, user4, moderation_model.ContentTypeUser, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "@user2", content.Reference)
+
+	// the issues of a private repository cannot be reported by users who cannot see them
+	_, err = GetReportedContent(db.DefaultContext, user4, moderation_model.ContentTypeIssue, 4)
+	require.ErrorIs(t, err, util.ErrNotExist)
+
+	_, err = GetReportedContent(db.DefaultContext, user4, moderation_model.ContentTypeRepository, 1000)
+	require.ErrorIs(t, err, util.ErrNotExist)
+}
+
+func TestReportAndResolve(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	content, err := GetReportedContent(db.DefaultContext, user4, moderation_model.ContentTypeComment, 2)
+	require.NoError(t, err)
+
+	_, err = ReportAbuse(db.DefaultContext, user4, content, moderation_model.Category(0), "")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ReportAbuse(db.DefaultContext, content.Poster, content, moderation_model.CategorySpam, "")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	report, err := ReportAbuse(db.DefaultContext, user4, content, moderation_model.CategorySpam, "buy now")
+	require.NoError(t, err)
+
+	// a comment cannot be deleted as a repository
+	err = ResolveReport(db.DefaultContext, admin, report, moderation_model.ActionDeleteRepository, "")
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, ResolveReport(db.DefaultContext, admin, report, moderation_model.ActionHideContent, "spam"))
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	assert.True(t, comment.IsHidden)
+
+	report = unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: report.ID})
+	assert.Equal(t, moderation_model.ReportStatusHandled, report.Status)
+	err = ResolveReport(db.DefaultContext, admin, report, moderation_model.ActionNone, "")
+	assert.True(t, moderation_model.IsErrAbuseReportResolved(err))
+}
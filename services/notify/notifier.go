@@ -8,6 +8,7 @@ import (
 
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
@@ -31,6 +32,9 @@ type Notifier interface {
 	IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool)
 	DeleteIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue)
 	IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64)
+	IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID, oldColumnID int64)
+	IssueChangeProjectField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *project_model.Field, oldValue string)
+	IssueChangeSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue, removed bool)
 	IssueChangeAssignee(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, assignee *user_model.User, removed bool, comment *issues_model.Comment)
 	PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment)
 	IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string)
@@ -8,6 +8,7 @@ import (
 
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
@@ -198,6 +199,27 @@ func IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *iss
 	}
 }
 
+// IssueChangeProject notifies the move of an issue to another project or column to notifiers
+func IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID, oldColumnID int64) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeProject(ctx, doer, issue, oldProjectID, oldColumnID)
+	}
+}
+
+// IssueChangeProjectField notifies the change of the value of a custom field of projects for an issue to notifiers
+func IssueChangeProjectField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *project_model.Field, oldValue string) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeProjectField(ctx, doer, issue, field, oldValue)
+	}
+}
+
+// IssueChangeSubIssue notifies the addition or the removal of a sub-issue of a parent issue to notifiers
+func IssueChangeSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue, removed bool) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeSubIssue(ctx, doer, parent, issue, removed)
+	}
+}
+
 // IssueChangeContent notifies change content to notifiers
 func IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
 	for _, notifier := range notifiers {
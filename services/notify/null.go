@@ -8,6 +8,7 @@ import (
 
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
@@ -112,6 +113,18 @@ func (*NullNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, r
 func (*NullNotifier) IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64) {
 }
 
+// IssueChangeProject places a place holder function
+func (*NullNotifier) IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID, oldColumnID int64) {
+}
+
+// IssueChangeProjectField places a place holder function
+func (*NullNotifier) IssueChangeProjectField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *project_model.Field, oldValue string) {
+}
+
+// IssueChangeSubIssue places a place holder function
+func (*NullNotifier) IssueChangeSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue, removed bool) {
+}
+
 // IssueChangeContent places a place holder function
 func (*NullNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
 }
@@ -9,8 +9,11 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
 	org_model "forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/storage"
@@ -48,6 +51,22 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return models.ErrUserOwnPackages{UID: org.ID}
 	}
 
+	if err := git_model.DeleteOrgRulesets(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteOrgRulesets: %w", err)
+	}
+
+	if err := project_model.DeleteFieldsByOwnerID(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteFieldsByOwnerID: %w", err)
+	}
+
+	if err := issues_model.DeleteSavedSearchesByOwner(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteSavedSearchesByOwner: %w", err)
+	}
+
+	if err := issues_model.DeleteTriageRulesByOwnerID(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteTriageRulesByOwnerID: %w", err)
+	}
+
 	if err := org_model.DeleteOrganization(ctx, org); err != nil {
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+)
+
+// ReviewAssignmentOptions is the review assignment policy of a team, the members being given by username
+type ReviewAssignmentOptions struct {
+	Algorithm  org_model.ReviewAssignmentAlgorithm
+	Count      int
+	MaxPending int
+	// Excluded are the members who are never assigned
+	Excluded []string
+	// Managers are the members managing a user by user, they are never assigned to the pull requests of the user
+	Managers map[string][]string
+}
+
+// UpdateTeamReviewAssignment validates and updates the review assignment policy of a team. The excluded users and
+// the managers must be members of the team, the managed users can be any user. The errors caused by the options
+// wrap util.ErrInvalidArgument.
+func UpdateTeamReviewAssignment(ctx context.Context, t *org_model.Team, opts ReviewAssignmentOptions) error {
+	if !opts.Algorithm.IsValid() {
+		return util.NewInvalidArgumentErrorf("unknown review assignment %q", opts.Algorithm)
+	}
+	if opts.Count < 1 || opts.Count > 10 {
+		return util.NewInvalidArgumentErrorf("the number of reviewers must be between 1 and 10")
+	}
+	if opts.MaxPending < 0 || opts.MaxPending > 1000 {
+		return util.NewInvalidArgumentErrorf("the maximum of pending review requests must be between 0 and 1000")
+	}
+
+	members, err := org_model.GetTeamMembers(ctx, &org_model.SearchMembersOptions{TeamID: t.ID})
+	if err != nil {
+		return err
+	}
+	getMember := func(name string) (*user_model.User, error) {
+		for _, member := range members {
+			if strings.EqualFold(member.Name, strings.TrimSpace(name)) {
+				return member, nil
+			}
+		}
+		return nil, util.NewInvalidArgumentErrorf("the user %q is not a member of the team", name)
+	}
+
+	excludedUserIDs := make([]int64, 0, len(opts.Excluded))
+	for _, name := range opts.Excluded {
+		member, err := getMember(name)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(excludedUserIDs, member.ID) {
+			excludedUserIDs = append(excludedUserIDs, member.ID)
+		}
+	}
+
+	managers := make([]*org_model.TeamReviewManager, 0, len(opts.Managers))
+	for name, managerNames := range opts.Managers {
+		user, err := user_model.GetUserByName(ctx, strings.TrimSpace(name))
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return util.NewInvalidArgumentErrorf("the user %q does not exist", name)
+			}
+			return err
+		}
+		for _, managerName := range managerNames {
+			manager, err := getMember(managerName)
+			if err != nil {
+				return err
+			}
+			if manager.ID == user.ID {
+				return util.NewInvalidArgumentErrorf("the user %q can not be their own manager", name)
+			}
+			if slices.ContainsFunc(managers, func(m *org_model.TeamReviewManager) bool {
+				return m.UserID == user.ID && m.ManagerID == manager.ID
+			}) {
+				continue
+			}
+			managers = append(managers, &org_model.TeamReviewManager{UserID: user.ID, ManagerID: manager.ID})
+		}
+	}
+
+	t.ReviewAssignment = opts.Algorithm
+	t.ReviewAssignmentCount = opts.Count
+	t.ReviewAssignmentMaxPending = opts.MaxPending
+	t.ReviewAssignmentExcludedUserIDs = excludedUserIDs
+	return org_model.UpdateTeamReviewAssignment(ctx, t, managers)
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTeamReviewAssignment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 22})
+	opts := ReviewAssignmentOptions{
+		Algorithm: organization.ReviewAssignmentRoundRobin,
+		Count:     1,
+		Excluded:  []string{"User38", "user38"},
+		Managers:  map[string][]string{"user40": {"user39", "user39"}},
+	}
+	require.NoError(t, UpdateTeamReviewAssignment(db.DefaultContext, team, opts))
+	team = unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 22})
+	assert.Equal(t, organization.ReviewAssignmentRoundRobin, team.ReviewAssignment)
+	assert.Equal(t, []int64{38}, team.ReviewAssignmentExcludedUserIDs)
+	managers, err := organization.GetTeamReviewManagers(db.DefaultContext, 22)
+	require.NoError(t, err)
+	require.Len(t, managers, 1)
+	assert.EqualValues(t, 40, managers[0].UserID)
+	assert.EqualValues(t, 39, managers[0].ManagerID)
+
+	for name, opts := range map[string]ReviewAssignmentOptions{
+		"unknown algorithm":    {Algorithm: "random", Count: 1},
+		"count":                {Algorithm: organization.ReviewAssignmentRoundRobin, Count: 11},
+		"excluded non member":  {Algorithm: organization.ReviewAssignmentRoundRobin, Count: 1, Excluded: []string{"user40"}},
+		"manager non member":   {Algorithm: organization.ReviewAssignmentRoundRobin, Count: 1, Managers: map[string][]string{"user39": {"user40"}}},
+		"unknown managed user": {Algorithm: organization.ReviewAssignmentRoundRobin, Count: 1, Managers: map[string][]string{"nobody": {"user39"}}},
+		"own manager":          {Algorithm: organization.ReviewAssignmentRoundRobin, Count: 1, Managers: map[string][]string{"user39": {"user39"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, UpdateTeamReviewAssignment(db.DefaultContext, team, opts), util.ErrInvalidArgument)
+		})
+	}
+
+	// the invalid options change nothing
+	managers, err = organization.GetTeamReviewManagers(db.DefaultContext, 22)
+	require.NoError(t, err)
+	assert.Len(t, managers, 1)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -34,13 +35,14 @@ import (
 var prPatchCheckerQueue *queue.WorkerPoolQueue[string]
 
 var (
-	ErrIsClosed              = errors.New("pull is closed")
-	ErrUserNotAllowedToMerge = models.ErrDisallowedToMerge{}
-	ErrHasMerged             = errors.New("has already been merged")
-	ErrIsWorkInProgress      = errors.New("work in progress PRs cannot be merged")
-	ErrIsChecking            = errors.New("cannot merge while conflict checking is in progress")
-	ErrNotMergeableState     = errors.New("not in mergeable state")
-	ErrDependenciesLeft      = errors.New("is blocked by an open dependency")
+	ErrIsClosed                   = errors.New("pull is closed")
+	ErrUserNotAllowedToMerge      = models.ErrDisallowedToMerge{}
+	ErrHasMerged                  = errors.New("has already been merged")
+	ErrIsWorkInProgress           = errors.New("work in progress PRs cannot be merged")
+	ErrIsChecking                 = errors.New("cannot merge while conflict checking is in progress")
+	ErrNotMergeableState          = errors.New("not in mergeable state")
+	ErrDependenciesLeft           = errors.New("is blocked by an open dependency")
+	ErrReviewChecklistNotComplete = errors.New("no approval ticked every item of the review checklist")
 )
 
 // AddToTaskQueue adds itself to pull request test task queue.
@@ -69,15 +71,8 @@ const (
 // CheckPullMergeable check if the pull mergeable based on all conditions (branch protection, merge options, ...)
 func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *access_model.Permission, pr *issues_model.PullRequest, mergeCheckType MergeCheckType, adminSkipProtectionCheck bool) error {
 	return db.WithTx(stdCtx, func(ctx context.Context) error {
-		if pr.HasMerged {
-			return ErrHasMerged
-		}
-
-		if err := pr.LoadIssue(ctx); err != nil {
-			log.Error("Unable to load issue[%d] for %-v: %v", pr.IssueID, pr, err)
+		if err := checkPullOpen(ctx, pr); err != nil {
 			return err
-		} else if pr.Issue.IsClosed {
-			return ErrIsClosed
 		}
 
 		if allowedMerge, err := IsUserAllowedToMerge(ctx, pr, *perm, doer); err != nil {
@@ -92,64 +87,112 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			return nil
 		}
 
-		if pr.IsWorkInProgress(ctx) {
-			return ErrIsWorkInProgress
-		}
-
-		if !pr.CanAutoMerge() && !pr.IsEmpty() {
-			return ErrNotMergeableState
-		}
-
-		if pr.IsChecking() {
-			return ErrIsChecking
-		}
-
-		if pb, err := CheckPullBranchProtections(ctx, pr, false); err != nil {
-			if !models.IsErrDisallowedToMerge(err) {
-				log.Error("Error whilst checking pull branch protection for %-v: %v", pr, err)
-				return err
-			}
+		// the review checklist of the repository is not part of the branch protection and can not be skipped
+		checkReviewChecklist := mergeCheckType != MergeCheckTypeAuto
+		return checkPullMergeableState(ctx, pr, checkReviewChecklist, func() error {
+			if pb, err := CheckPullBranchProtections(ctx, pr, false); err != nil {
+				if !models.IsErrDisallowedToMerge(err) {
+					log.Error("Error whilst checking pull branch protection for %-v: %v", pr, err)
+					return err
+				}
 
-			// Now the branch protection check failed, check whether the failure could be skipped (skip by setting err = nil)
+				// Now the branch protection check failed, check whether the failure could be skipped (skip by setting err = nil)
 
-			// * when doing Auto Merge (Scheduled Merge After Checks Succeed), skip the branch protection check
-			if mergeCheckType == MergeCheckTypeAuto {
-				err = nil
-			}
+				// * when doing Auto Merge (Scheduled Merge After Checks Succeed), skip the branch protection check
+				if mergeCheckType == MergeCheckTypeAuto {
+					err = nil
+				}
 
-			// * if the doer is admin, they could skip the branch protection check,
-			// if that's allowed by the protected branch rule.
-			if adminSkipProtectionCheck {
-				if doer.IsAdmin {
-					err = nil // instance admin can skip the check, so clear the error
-				} else if !pb.ApplyToAdmins {
-					if isRepoAdmin, errCheckAdmin := access_model.IsUserRepoAdmin(ctx, pr.BaseRepo, doer); errCheckAdmin != nil {
-						log.Error("Unable to check if %-v is a repo admin in %-v: %v", doer, pr.BaseRepo, errCheckAdmin)
-						return errCheckAdmin
-					} else if isRepoAdmin {
-						err = nil // repo admin can skip the check, so clear the error
+				// * if the doer is admin, they could skip the branch protection check,
+				// if that's allowed by the protected branch rule.
+				if adminSkipProtectionCheck {
+					if doer.IsAdmin {
+						err = nil // instance admin can skip the check, so clear the error
+					} else if !pb.ApplyToAdmins {
+						if isRepoAdmin, errCheckAdmin := access_model.IsUserRepoAdmin(ctx, pr.BaseRepo, doer); errCheckAdmin != nil {
+							log.Error("Unable to check if %-v is a repo admin in %-v: %v", doer, pr.BaseRepo, errCheckAdmin)
+							return errCheckAdmin
+						} else if isRepoAdmin {
+							err = nil // repo admin can skip the check, so clear the error
+						}
 					}
 				}
-			}
 
-			// If there is still a branch protection check error, return it
-			if err != nil {
-				return err
+				// If there is still a branch protection check error, return it
+				if err != nil {
+					return err
+				}
 			}
-		}
 
-		if _, err := isSignedIfRequired(ctx, pr, doer); err != nil {
+			_, err := isSignedIfRequired(ctx, pr, doer)
 			return err
-		}
+		})
+	})
+}
 
-		if noDeps, err := issues_model.IssueNoDependenciesLeft(ctx, pr.Issue); err != nil {
+// CheckPullMergeStatus checks whether the pull request is ready to be merged, regardless of who merges it
+// and of the rights they may have to skip the branch protection
+func CheckPullMergeStatus(ctx context.Context, pr *issues_model.PullRequest) error {
+	if err := checkPullOpen(ctx, pr); err != nil {
+		return err
+	}
+	return checkPullMergeableState(ctx, pr, true, func() error {
+		_, err := CheckPullBranchProtections(ctx, pr, false)
+		return err
+	})
+}
+
+// checkPullOpen checks that the pull request is neither merged nor closed
+func checkPullOpen(ctx context.Context, pr *issues_model.PullRequest) error {
+	if pr.HasMerged {
+		return ErrHasMerged
+	}
+
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("Unable to load issue[%d] for %-v: %v", pr.IssueID, pr, err)
+		return err
+	} else if pr.Issue.IsClosed {
+		return ErrIsClosed
+	}
+	return nil
+}
+
+// checkPullMergeableState runs the checks of an open pull request shared by all merges: it must not be a work
+// in progress nor conflict with its base branch, must pass checkProtection, which checks the branch protection
+// and decides which of its failures are skipped, and must not depend on open issues. The review checklist is
+// only checked if checkReviewChecklist is true.
+func checkPullMergeableState(ctx context.Context, pr *issues_model.PullRequest, checkReviewChecklist bool, checkProtection func() error) error {
+	if pr.IsWorkInProgress(ctx) {
+		return ErrIsWorkInProgress
+	}
+
+	if !pr.CanAutoMerge() && !pr.IsEmpty() {
+		return ErrNotMergeableState
+	}
+
+	if pr.IsChecking() {
+		return ErrIsChecking
+	}
+
+	if err := checkProtection(); err != nil {
+		return err
+	}
+
+	if checkReviewChecklist {
+		if complete, err := IsReviewChecklistComplete(ctx, pr); err != nil {
 			return err
-		} else if !noDeps {
-			return ErrDependenciesLeft
+		} else if !complete {
+			return ErrReviewChecklistNotComplete
 		}
+	}
 
-		return nil
-	})
+	if noDeps, err := issues_model.IssueNoDependenciesLeft(ctx, pr.Issue); err != nil {
+		return err
+	} else if !noDeps {
+		return ErrDependenciesLeft
+	}
+
+	return nil
 }
 
 // isSignedIfRequired check if merge will be signed if required
@@ -158,8 +201,15 @@ func isSignedIfRequired(ctx context.Context, pr *issues_model.PullRequest, doer
 	if err != nil {
 		return false, err
 	}
+	rules, err := git_model.GetEnforcedRulesetBranchRules(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return false, err
+	}
+	if pb != nil {
+		rules = append(rules, pb)
+	}
 
-	if pb == nil || !pb.RequireSignedCommits {
+	if !slices.ContainsFunc(rules, func(rule *git_model.ProtectedBranch) bool { return rule.RequireSignedCommits }) {
 		return true, nil
 	}
 
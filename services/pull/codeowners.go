@@ -0,0 +1,170 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	issue_service "forgejo.org/services/issue"
+)
+
+// CodeOwnersApproval is a path changed by a pull request with its code owners, and those of them who
+// approved the pull request
+type CodeOwnersApproval struct {
+	Path  string
+	Users []*user_model.User
+	Teams []*org_model.Team
+	// Owners are the names of the users and of the teams as written in the CODEOWNERS file
+	Owners     []string
+	ApprovedBy []*user_model.User
+}
+
+// IsApproved returns true if a code owner of the path approved the pull request
+func (a *CodeOwnersApproval) IsApproved() bool {
+	return len(a.ApprovedBy) > 0
+}
+
+// GetCodeOwnersApprovals returns the paths changed by a pull request which have code owners according
+// to the CODEOWNERS file of its base branch, with the code owners who approved it. The owners of a path
+// are the users and the teams of all the rules matching it, and a team approves when one of its members
+// does. Like for the required approvals, only the official approvals count, and the stale approvals are
+// ignored if ignoreStale is set.
+func GetCodeOwnersApprovals(ctx context.Context, pr *issues_model.PullRequest, ignoreStale bool) ([]*CodeOwnersApproval, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(pr.BaseBranch)
+	if err != nil {
+		return nil, fmt.Errorf("GetBranchCommit: %w", err)
+	}
+	rules := issue_service.GetCodeOwnersRules(ctx, commit)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	mergeBase := pr.MergeBase
+	if mergeBase == "" {
+		if mergeBase, _, err = gitRepo.GetMergeBase("", git.BranchPrefix+pr.BaseBranch, pr.GetGitRefName()); err != nil {
+			return nil, fmt.Errorf("GetMergeBase: %w", err)
+		}
+	}
+	changedFiles, err := gitRepo.GetFilesChangedBetween(mergeBase, pr.GetGitRefName())
+	if err != nil {
+		return nil, fmt.Errorf("GetFilesChangedBetween: %w", err)
+	}
+
+	reviews, err := issues_model.GetReviewsByIssueID(ctx, pr.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	approvers := make([]*user_model.User, 0, len(reviews))
+	for _, review := range reviews {
+		if review.ReviewerID <= 0 || review.Type != issues_model.ReviewTypeApprove || !review.Official || (ignoreStale && review.Stale) {
+			continue
+		}
+		if err := review.LoadReviewer(ctx); err != nil {
+			return nil, err
+		}
+		approvers = append(approvers, review.Reviewer)
+	}
+
+	isTeamMember := make(map[string]bool)
+	orgNames := make(map[int64]string)
+	approvals := make([]*CodeOwnersApproval, 0, len(changedFiles))
+	for _, path := range changedFiles {
+		approval := &CodeOwnersApproval{Path: path}
+		users := make(map[int64]bool)
+		teams := make(map[int64]bool)
+		for _, rule := range rules {
+			if !rule.Match(path) {
+				continue
+			}
+			for _, u := range rule.Users {
+				if !users[u.ID] {
+					users[u.ID] = true
+					approval.Users = append(approval.Users, u)
+				}
+			}
+			for _, t := range rule.Teams {
+				if !teams[t.ID] {
+					teams[t.ID] = true
+					approval.Teams = append(approval.Teams, t)
+				}
+			}
+		}
+		if len(approval.Users) == 0 && len(approval.Teams) == 0 {
+			continue
+		}
+		for _, u := range approval.Users {
+			approval.Owners = append(approval.Owners, "@"+u.Name)
+		}
+		for _, t := range approval.Teams {
+			orgName, ok := orgNames[t.OrgID]
+			if !ok {
+				org, err := user_model.GetUserByID(ctx, t.OrgID)
+				if err != nil {
+					return nil, err
+				}
+				orgName = org.Name
+				orgNames[t.OrgID] = orgName
+			}
+			approval.Owners = append(approval.Owners, "@"+orgName+"/"+t.Name)
+		}
+
+		for _, approver := range approvers {
+			isOwner := users[approver.ID]
+			for _, t := range approval.Teams {
+				if isOwner {
+					break
+				}
+				key := fmt.Sprintf("%d/%d", t.ID, approver.ID)
+				isMember, ok := isTeamMember[key]
+				if !ok {
+					if isMember, err = org_model.IsTeamMember(ctx, t.OrgID, t.ID, approver.ID); err != nil {
+						return nil, err
+					}
+					isTeamMember[key] = isMember
+				}
+				isOwner = isMember
+			}
+			if isOwner {
+				approval.ApprovedBy = append(approval.ApprovedBy, approver)
+			}
+		}
+		approvals = append(approvals, approval)
+	}
+	return approvals, nil
+}
+
+// GetMissingCodeOwnersApprovals returns the paths changed by a pull request which a branch protection
+// requires a code owner to approve and which are not approved yet
+func GetMissingCodeOwnersApprovals(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) ([]*CodeOwnersApproval, error) {
+	if !pb.RequireCodeOwnerApproval {
+		return nil, nil
+	}
+	approvals, err := GetCodeOwnersApprovals(ctx, pr, pb.IgnoreStaleApprovals)
+	if err != nil {
+		return nil, err
+	}
+	missing := make([]*CodeOwnersApproval, 0, len(approvals))
+	for _, approval := range approvals {
+		if !approval.IsApproved() {
+			missing = append(missing, approval)
+		}
+	}
+	return missing, nil
+}
@@ -77,11 +77,16 @@ func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (
 	if err != nil {
 		return false, fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
 	}
+	return isPullCommitStatusPassForRule(ctx, pr, pb)
+}
+
+// isPullCommitStatusPassForRule returns if all the status checks required by the branch protection rule PASS
+func isPullCommitStatusPassForRule(ctx context.Context, pr *issues_model.PullRequest, pb *git_model.ProtectedBranch) (bool, error) {
 	if pb == nil || !pb.EnableStatusCheck {
 		return true, nil
 	}
 
-	state, err := GetPullRequestCommitStatusState(ctx, pr)
+	state, err := getPullRequestCommitStatusState(ctx, pr, pb)
 	if err != nil {
 		return false, err
 	}
@@ -90,6 +95,39 @@ func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (
 
 // GetPullRequestCommitStatusState returns pull request merged commit status state
 func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullRequest) (structs.CommitStatusState, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return "", fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
+	}
+	return getPullRequestCommitStatusState(ctx, pr, pb)
+}
+
+// GetRequiredCommitStatuses returns the latest status of each context of a commit which can count towards
+// the status checks required by the branch protection rule, ignoring the statuses created by a source the
+// rule does not trust for their context
+func GetRequiredCommitStatuses(ctx context.Context, repoID int64, sha string, pb *git_model.ProtectedBranch) ([]*git_model.CommitStatus, error) {
+	if pb == nil || len(pb.StatusCheckSources) == 0 {
+		commitStatuses, _, err := git_model.GetLatestCommitStatus(ctx, repoID, sha, db.ListOptionsAll)
+		if err != nil {
+			return nil, fmt.Errorf("GetLatestCommitStatus: %w", err)
+		}
+		return commitStatuses, nil
+	}
+
+	// a status from an untrusted source may be more recent than the one of the trusted source
+	commitStatuses, err := db.Find[git_model.CommitStatus](ctx, &git_model.CommitStatusOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      repoID,
+		SHA:         sha,
+		SortType:    "leastindex",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("FindCommitStatuses: %w", err)
+	}
+	return pb.TrustedCommitStatuses(commitStatuses), nil
+}
+
+func getPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullRequest, pb *git_model.ProtectedBranch) (structs.CommitStatusState, error) {
 	// Ensure HeadRepo is loaded
 	if err := pr.LoadHeadRepo(ctx); err != nil {
 		return "", fmt.Errorf("LoadHeadRepo: %w", err)
@@ -123,19 +161,14 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 		return "", fmt.Errorf("LoadBaseRepo: %w", err)
 	}
 
-	commitStatuses, _, err := git_model.GetLatestCommitStatus(ctx, pr.BaseRepo.ID, sha, db.ListOptionsAll)
+	commitStatuses, err := GetRequiredCommitStatuses(ctx, pr.BaseRepo.ID, sha, pb)
 	if err != nil {
-		return "", fmt.Errorf("GetLatestCommitStatus: %w", err)
+		return "", err
 	}
 
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
-	if err != nil {
-		return "", fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
-	}
 	var requiredContexts []string
 	if pb != nil {
 		requiredContexts = pb.StatusCheckContexts
 	}
-
 	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts), nil
 }
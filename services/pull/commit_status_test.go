@@ -7,10 +7,18 @@ package pull
 import (
 	"testing"
 
+	"forgejo.org/models"
+	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
 	"forgejo.org/modules/structs"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMergeRequiredContextsCommitStatus(t *testing.T) {
@@ -63,3 +71,72 @@ func TestMergeRequiredContextsCommitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckPullBranchProtectionStatusCheckSources(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pr.BaseRepoID})
+	writer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	actionsUser := user_model.NewActionsUser()
+	sha := "985f0301dba5e7b34be866819cd15ad3d8f508ee" // head of branch2
+
+	pb := &git_model.ProtectedBranch{
+		RepoID:              repo.ID,
+		RuleName:            pr.BaseBranch,
+		EnableStatusCheck:   true,
+		StatusCheckContexts: []string{"ci/build", "deploy"},
+		StatusCheckSources: []*git_model.StatusCheckSource{
+			{Context: "ci/*", Type: git_model.StatusCheckSourceActions, Workflow: "build.yml"},
+			{Context: "deploy", Type: git_model.StatusCheckSourceOAuth2, ApplicationID: 1},
+		},
+	}
+
+	createStatus := func(creator *user_model.User, status *git_model.CommitStatus) {
+		if status.State == "" {
+			status.State = structs.CommitStatusSuccess
+		}
+		require.NoError(t, git_model.NewCommitStatus(db.DefaultContext, git_model.NewCommitStatusOptions{
+			Repo:         repo,
+			Creator:      creator,
+			SHA:          git.MustIDFromString(sha),
+			CommitStatus: status,
+		}))
+	}
+	requiredContexts := func() []string {
+		statuses, err := GetRequiredCommitStatuses(db.DefaultContext, repo.ID, sha, pb)
+		require.NoError(t, err)
+		contexts := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			contexts = append(contexts, status.Context)
+		}
+		return contexts
+	}
+	assertMergeBlocked := func() {
+		err := CheckPullBranchProtection(db.DefaultContext, pr, pb, true)
+		require.Error(t, err)
+		assert.True(t, models.IsErrDisallowedToMerge(err))
+	}
+
+	// faked by a writer with their own token, through the workflow of the head branch of the pull request and
+	// through another workflow
+	createStatus(writer, &git_model.CommitStatus{Context: "ci/build"})
+	createStatus(writer, &git_model.CommitStatus{Context: "deploy"})
+	createStatus(actionsUser, &git_model.CommitStatus{Context: "ci/build", ActionsWorkflowID: "build.yml", ActionsWorkflowRef: "refs/heads/branch2"})
+	createStatus(actionsUser, &git_model.CommitStatus{Context: "ci/build", ActionsWorkflowID: "other.yml", ActionsWorkflowRef: "refs/heads/master"})
+	assert.Empty(t, requiredContexts())
+	assertMergeBlocked()
+
+	// pull_request_target workflow read from the base branch
+	createStatus(actionsUser, &git_model.CommitStatus{Context: "ci/build", ActionsWorkflowID: "build.yml", ActionsWorkflowRef: "refs/heads/master"})
+	assert.Equal(t, []string{"ci/build"}, requiredContexts())
+	assertMergeBlocked()
+
+	createStatus(writer, &git_model.CommitStatus{Context: "deploy", OAuth2ApplicationID: 1})
+	assert.ElementsMatch(t, []string{"ci/build", "deploy"}, requiredContexts())
+	require.NoError(t, CheckPullBranchProtection(db.DefaultContext, pr, pb, true))
+
+	// a more recent fake status does not hide the trusted one
+	createStatus(writer, &git_model.CommitStatus{Context: "deploy", State: structs.CommitStatusFailure})
+	require.NoError(t, CheckPullBranchProtection(db.DefaultContext, pr, pb, true))
+}
@@ -430,13 +430,23 @@ func IsUserAllowedToMerge(ctx context.Context, pr *issues_model.PullRequest, p a
 	}
 
 	if (p.CanWrite(unit.TypeCode) && pb == nil) || (pb != nil && git_model.IsUserMergeWhitelisted(ctx, pb, user.ID, p)) {
+		rules, err := git_model.GetEnforcedRulesetBranchRules(ctx, pr.BaseRepoID, pr.BaseBranch)
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range rules {
+			if !git_model.IsUserMergeWhitelisted(ctx, rule, user.ID, p) {
+				return false, nil
+			}
+		}
 		return true, nil
 	}
 
 	return false, nil
 }
 
-// CheckPullBranchProtections checks whether the PR is ready to be merged (reviews and status checks).
+// CheckPullBranchProtections checks whether the PR is ready to be merged (reviews and status checks)
+// according to the protected branch rule and the organization rulesets of its base branch.
 // Returns the protected branch rule when `ErrDisallowedToMerge` is returned as error.
 func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullRequest, skipProtectedFilesCheck bool) (protectedBranchRule *git_model.ProtectedBranch, err error) {
 	if err = pr.LoadBaseRepo(ctx); err != nil {
@@ -447,53 +457,92 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 	if err != nil {
 		return nil, fmt.Errorf("LoadProtectedBranch: %v", err)
 	}
-	if pb == nil {
-		return nil, nil
+	rules, err := git_model.GetEnforcedRulesetBranchRules(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return nil, fmt.Errorf("GetEnforcedRulesetBranchRules: %w", err)
+	}
+	if pb != nil {
+		rules = append(git_model.ProtectedBranchRules{pb}, rules...)
+	}
+
+	for _, rule := range rules {
+		if err := CheckPullBranchProtection(ctx, pr, rule, skipProtectedFilesCheck); err != nil {
+			if models.IsErrDisallowedToMerge(err) {
+				return rule, err
+			}
+			return nil, err
+		}
 	}
+	return nil, nil
+}
 
-	isPass, err := IsPullCommitStatusPass(ctx, pr)
+// CheckPullBranchProtection checks whether the PR is ready to be merged according to a single branch protection rule
+func CheckPullBranchProtection(ctx context.Context, pr *issues_model.PullRequest, pb *git_model.ProtectedBranch, skipProtectedFilesCheck bool) error {
+	isPass, err := isPullCommitStatusPassForRule(ctx, pr, pb)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if !isPass {
-		return pb, models.ErrDisallowedToMerge{
+		return models.ErrDisallowedToMerge{
 			Reason: "Not all required status checks successful",
 		}
 	}
 
 	if !issues_model.HasEnoughApprovals(ctx, pb, pr) {
-		return pb, models.ErrDisallowedToMerge{
+		return models.ErrDisallowedToMerge{
 			Reason: "Does not have enough approvals",
 		}
 	}
 	if issues_model.MergeBlockedByRejectedReview(ctx, pb, pr) {
-		return pb, models.ErrDisallowedToMerge{
+		return models.ErrDisallowedToMerge{
 			Reason: "There are requested changes",
 		}
 	}
 	if issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pr) {
-		return pb, models.ErrDisallowedToMerge{
+		return models.ErrDisallowedToMerge{
 			Reason: "There are official review requests",
 		}
 	}
 
 	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
-		return pb, models.ErrDisallowedToMerge{
+		return models.ErrDisallowedToMerge{
 			Reason: "The head branch is behind the base branch",
 		}
 	}
 
+	if pb.RequireResolvedConversations {
+		unresolved, err := issues_model.CountUnresolvedCodeConversations(ctx, pr.IssueID)
+		if err != nil {
+			return fmt.Errorf("CountUnresolvedCodeConversations: %w", err)
+		}
+		if unresolved > 0 {
+			return models.ErrDisallowedToMerge{
+				Reason: "There are unresolved conversations",
+			}
+		}
+	}
+
+	missingApprovals, err := GetMissingCodeOwnersApprovals(ctx, pb, pr)
+	if err != nil {
+		return fmt.Errorf("GetMissingCodeOwnersApprovals: %w", err)
+	}
+	if len(missingApprovals) > 0 {
+		return models.ErrDisallowedToMerge{
+			Reason: "Not all the changed paths are approved by a code owner",
+		}
+	}
+
 	if skipProtectedFilesCheck {
-		return nil, nil
+		return nil
 	}
 
 	if pb.MergeBlockedByProtectedFiles(pr.ChangedProtectedFiles) {
-		return pb, models.ErrDisallowedToMerge{
+		return models.ErrDisallowedToMerge{
 			Reason: "Changed protected files",
 		}
 	}
 
-	return nil, nil
+	return nil
 }
 
 // MergedManually mark pr as merged manually
@@ -0,0 +1,48 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/util"
+)
+
+// GetRangeDiff compares the commits of two revisions of a pull request, given by their head commits, like
+// git range-diff. The commits of each revision are the ones since its merge base with the base branch, so
+// that the commits of the base branch are left out when the pull request was rebased in between.
+func GetRangeDiff(ctx context.Context, baseGitRepo *git.Repository, pr *issues_model.PullRequest, oldHead, newHead string) ([]*git.RangeDiffEntry, error) {
+	revisions, err := issues_model.GetPullRequestRevisions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	for _, head := range []string{oldHead, newHead} {
+		if issues_model.GetPullRequestRevision(revisions, head) == nil {
+			return nil, util.NewNotExistErrorf("%s is not a revision of pull request #%d", head, pr.Index)
+		}
+	}
+
+	base := git.BranchPrefix + pr.BaseBranch
+	if pr.HasMerged {
+		// the base branch moved on with the merge, or may be gone
+		base = pr.MergeBase
+	}
+	oldBase, _, err := baseGitRepo.GetMergeBase("", base, oldHead)
+	if err != nil {
+		return nil, util.NewNotExistErrorf("the commits of revision %s are not available anymore", oldHead)
+	}
+	newBase, _, err := baseGitRepo.GetMergeBase("", base, newHead)
+	if err != nil {
+		return nil, util.NewNotExistErrorf("the commits of revision %s are not available anymore", newHead)
+	}
+
+	entries, err := baseGitRepo.RangeDiff(oldBase, oldHead, newBase, newHead)
+	if err != nil {
+		return nil, fmt.Errorf("RangeDiff: %w", err)
+	}
+	return entries, nil
+}
@@ -59,9 +59,50 @@ func checkInvalidation(ctx context.Context, c *issues_model.Comment, repo *git.R
 		c.Invalidated = true
 		return issues_model.UpdateCommentInvalidate(ctx, c)
 	}
+	// the blame of the last line of a range does not tell if the lines above it changed
+	if c.StartLine > 0 {
+		changed, err := codeCommentRangeChanged(c, repo, branch)
+		if err != nil {
+			return err
+		}
+		if changed {
+			c.Invalidated = true
+			return issues_model.UpdateCommentInvalidate(ctx, c)
+		}
+	}
 	return nil
 }
 
+// codeCommentRangeChanged tells if the lines of a code comment on a range of proposed lines are not the ones
+// of its patch anymore
+func codeCommentRangeChanged(c *issues_model.Comment, repo *git.Repository, branch string) (bool, error) {
+	commented := c.CommentedLines()
+	if commented == nil {
+		return false, nil
+	}
+	commit, err := repo.GetCommit(branch)
+	if err != nil {
+		return false, err
+	}
+	content, err := commit.GetFileContent(c.TreePath, 0)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	lines := strings.Split(content, "\n")
+	if int(c.Line) > len(lines) {
+		return true, nil
+	}
+	for i, line := range commented {
+		if strings.TrimSuffix(lines[int(c.StartLine)-1+i], "\r") != strings.TrimSuffix(line, "\r") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // InvalidateCodeComments will lookup the prs for code comments which got invalidated by change
 func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestList, doer *user_model.User, repo *git.Repository, branch string) error {
 	if len(prs) == 0 {
@@ -86,8 +127,8 @@ func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestLis
 	return nil
 }
 
-// CreateCodeComment creates a comment on the code line
-func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, line int64, content, treePath string, pendingReview bool, replyReviewID int64, latestCommitID string, attachments []string) (*issues_model.Comment, error) {
+// CreateCodeComment creates a comment on the code line, or on the lines from startLine to line if startLine is not 0
+func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, line, startLine int64, content, treePath string, pendingReview bool, replyReviewID int64, latestCommitID string, attachments []string) (*issues_model.Comment, error) {
 	var (
 		existsReview bool
 		err          error
@@ -119,6 +160,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 			content,
 			treePath,
 			line,
+			startLine,
 			replyReviewID,
 			attachments,
 		)
@@ -160,6 +202,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 		content,
 		treePath,
 		line,
+		startLine,
 		review.ID,
 		attachments,
 	)
@@ -169,7 +212,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 
 	if !pendingReview && !existsReview {
 		// Submit the review we've just created so the comment shows up in the issue view
-		if _, _, err = SubmitReview(ctx, doer, gitRepo, issue, issues_model.ReviewTypeComment, "", latestCommitID, nil); err != nil {
+		if _, _, err = SubmitReview(ctx, doer, gitRepo, issue, issues_model.ReviewTypeComment, "", latestCommitID, nil, nil); err != nil {
 			return nil, err
 		}
 	}
@@ -179,8 +222,15 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 	return comment, nil
 }
 
-// CreateCodeCommentKnownReviewID creates a plain code comment at the specified line / path
-func CreateCodeCommentKnownReviewID(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content, treePath string, line, reviewID int64, attachments []string) (*issues_model.Comment, error) {
+// CreateCodeCommentKnownReviewID creates a plain code comment at the specified line / path, or on the lines from
+// startLine to line if startLine is not 0
+func CreateCodeCommentKnownReviewID(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content, treePath string, line, startLine, reviewID int64, attachments []string) (*issues_model.Comment, error) {
+	if startLine == line {
+		startLine = 0
+	} else if startLine != 0 && ((startLine < 0) != (line < 0) || (startLine > 0 && startLine > line) || (startLine < 0 && startLine < line)) {
+		return nil, util.NewInvalidArgumentErrorf("the lines %d to %d are not a range of lines on the same side", startLine, line)
+	}
+
 	var commitID, patch string
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		return nil, fmt.Errorf("LoadPullRequest: %w", err)
@@ -213,6 +263,7 @@ func CreateCodeCommentKnownReviewID(ctx context.Context, doer *user_model.User,
 				commitID = first[0].CommitSHA
 				invalidated = first[0].Invalidated
 				patch = first[0].Patch
+				startLine = first[0].StartLine
 			} else if err != nil && !issues_model.IsErrCommentNotExist(err) {
 				return nil, fmt.Errorf("Find first comment for %d line %d path %s. Error: %w", reviewID, line, treePath, err)
 			} else {
@@ -260,33 +311,47 @@ func CreateCodeCommentKnownReviewID(ctx context.Context, doer *user_model.User,
 			_ = writer.Close()
 		}()
 
-		patch, err = git.CutDiffAroundLine(reader, int64((&issues_model.Comment{Line: line}).UnsignedLine()), line < 0, setting.UI.CodeCommentLines)
+		rangeComment := &issues_model.Comment{Line: line, StartLine: startLine}
+		patch, err = git.CutDiffAroundLines(reader, int64(rangeComment.UnsignedStartLine()), int64(rangeComment.UnsignedLine()), line < 0, setting.UI.CodeCommentLines)
 		if err != nil {
 			log.Error("Error whilst generating patch: %v", err)
 			return nil, err
 		}
 	}
 	return issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
-		Type:        issues_model.CommentTypeCode,
-		Doer:        doer,
-		Repo:        repo,
-		Issue:       issue,
-		Content:     content,
-		LineNum:     line,
-		TreePath:    treePath,
-		CommitSHA:   commitID,
-		ReviewID:    reviewID,
-		Patch:       patch,
-		Invalidated: invalidated,
-		Attachments: attachments,
+		Type:         issues_model.CommentTypeCode,
+		Doer:         doer,
+		Repo:         repo,
+		Issue:        issue,
+		Content:      content,
+		LineNum:      line,
+		StartLineNum: startLine,
+		TreePath:     treePath,
+		CommitSHA:    commitID,
+		ReviewID:     reviewID,
+		Patch:        patch,
+		Invalidated:  invalidated,
+		Attachments:  attachments,
 	})
 }
 
 // SubmitReview creates a review out of the existing pending review or creates a new one if no pending review exist
-func SubmitReview(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, reviewType issues_model.ReviewType, content, commitID string, attachmentUUIDs []string) (*issues_model.Review, *issues_model.Comment, error) {
+func SubmitReview(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, reviewType issues_model.ReviewType, content, commitID string, checklist, attachmentUUIDs []string) (*issues_model.Review, *issues_model.Comment, error) {
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		return nil, nil, err
 	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	repoChecklist, err := GetReviewChecklist(ctx, issue.Repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	checklist, err = checkReviewChecklist(repoChecklist, checklist, reviewType)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	pr := issue.PullRequest
 	var stale bool
@@ -308,7 +373,7 @@ func SubmitReview(ctx context.Context, doer *user_model.User, gitRepo *git.Repos
 		}
 	}
 
-	review, comm, err := issues_model.SubmitReview(ctx, doer, issue, reviewType, content, commitID, stale, attachmentUUIDs)
+	review, comm, err := issues_model.SubmitReview(ctx, doer, issue, reviewType, content, commitID, stale, checklist, attachmentUUIDs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/util"
+)
+
+// ErrReviewChecklistIncomplete represents an error when an approval does not tick every item of the review checklist
+type ErrReviewChecklistIncomplete struct {
+	Missing []string
+}
+
+// IsErrReviewChecklistIncomplete checks if an error is an ErrReviewChecklistIncomplete.
+func IsErrReviewChecklistIncomplete(err error) bool {
+	_, ok := err.(ErrReviewChecklistIncomplete)
+	return ok
+}
+
+func (err ErrReviewChecklistIncomplete) Error() string {
+	return fmt.Sprintf("review checklist is not complete, missing: %v", err.Missing)
+}
+
+func (err ErrReviewChecklistIncomplete) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// GetReviewChecklist returns the items the reviewers of the pull requests of a repository have to tick
+func GetReviewChecklist(ctx context.Context, repo *repo_model.Repository) ([]string, error) {
+	prUnit, err := repo.GetUnit(ctx, unit.TypePullRequests)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return prUnit.PullRequestsConfig().ReviewChecklist, nil
+}
+
+// missingChecklistItems returns the items of the checklist which have not been ticked
+func missingChecklistItems(checklist, ticked []string) []string {
+	var missing []string
+	for _, item := range checklist {
+		if !slices.Contains(ticked, item) {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// checkReviewChecklist returns the items of the checklist ticked by a review, dropping the unknown ones.
+// An approval has to tick every item.
+func checkReviewChecklist(checklist, ticked []string, reviewType issues_model.ReviewType) ([]string, error) {
+	if len(checklist) == 0 {
+		return nil, nil
+	}
+	ticked = slices.DeleteFunc(slices.Clone(ticked), func(item string) bool {
+		return !slices.Contains(checklist, item)
+	})
+	if reviewType == issues_model.ReviewTypeApprove {
+		if missing := missingChecklistItems(checklist, ticked); len(missing) > 0 {
+			return nil, ErrReviewChecklistIncomplete{Missing: missing}
+		}
+	}
+	return ticked, nil
+}
+
+// IsReviewChecklistComplete returns true if the repository has no review checklist, or if the latest
+// review of an official reviewer is an approval which ticked all its items. The stale approvals do not
+// count if the branch protection of the base branch ignores them.
+func IsReviewChecklistComplete(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	checklist, err := GetReviewChecklist(ctx, pr.BaseRepo)
+	if err != nil {
+		return false, err
+	}
+	if len(checklist) == 0 {
+		return true, nil
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return false, err
+	}
+	ignoreStale := pb != nil && pb.IgnoreStaleApprovals
+
+	// the latest non dismissed review of each reviewer
+	reviews, err := issues_model.GetReviewsByIssueID(ctx, pr.IssueID)
+	if err != nil {
+		return false, err
+	}
+	for _, review := range reviews {
+		if review.Type != issues_model.ReviewTypeApprove || !review.Official || (ignoreStale && review.Stale) {
+			continue
+		}
+		if len(missingChecklistItems(checklist, review.Checklist)) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
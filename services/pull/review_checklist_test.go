@@ -0,0 +1,139 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pull
+
+import (
+	"testing"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReviewChecklist(t *testing.T) {
+	checklist := []string{"tests", "docs"}
+
+	ticked, err := checkReviewChecklist(nil, []string{"tests"}, issues_model.ReviewTypeApprove)
+	require.NoError(t, err)
+	assert.Empty(t, ticked)
+
+	ticked, err = checkReviewChecklist(checklist, []string{"docs", "unknown"}, issues_model.ReviewTypeComment)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs"}, ticked)
+
+	_, err = checkReviewChecklist(checklist, []string{"docs", "unknown"}, issues_model.ReviewTypeApprove)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	var incomplete ErrReviewChecklistIncomplete
+	require.ErrorAs(t, err, &incomplete)
+	assert.Equal(t, []string{"tests"}, incomplete.Missing)
+
+	ticked, err = checkReviewChecklist(checklist, []string{"docs", "tests"}, issues_model.ReviewTypeApprove)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "tests"}, ticked)
+}
+
+func TestIsReviewChecklistComplete(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1})
+	complete, err := IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.True(t, complete)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pr.BaseRepoID})
+	prUnit, err := repo.GetUnit(db.DefaultContext, unit.TypePullRequests)
+	require.NoError(t, err)
+	prUnit.PullRequestsConfig().ReviewChecklist = []string{"tests", "docs"}
+	require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, prUnit))
+
+	pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1})
+	require.NoError(t, pr.LoadIssue(db.DefaultContext))
+	complete, err = IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.False(t, complete)
+
+	reviewer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	_, err = issues_model.CreateReview(db.DefaultContext, issues_model.CreateReviewOptions{
+		Type:      issues_model.ReviewTypeApprove,
+		Issue:     pr.Issue,
+		Reviewer:  reviewer,
+		Official:  true,
+		Checklist: []string{"tests"},
+	})
+	require.NoError(t, err)
+	complete, err = IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.False(t, complete)
+
+	approve := func() {
+		_, err := issues_model.CreateReview(db.DefaultContext, issues_model.CreateReviewOptions{
+			Type:      issues_model.ReviewTypeApprove,
+			Issue:     pr.Issue,
+			Reviewer:  reviewer,
+			Official:  true,
+			Checklist: []string{"docs", "tests"},
+		})
+		require.NoError(t, err)
+	}
+	approve()
+	complete, err = IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.True(t, complete)
+
+	// the reviewer changed their mind
+	_, err = issues_model.CreateReview(db.DefaultContext, issues_model.CreateReviewOptions{
+		Type:     issues_model.ReviewTypeReject,
+		Issue:    pr.Issue,
+		Reviewer: reviewer,
+		Official: true,
+	})
+	require.NoError(t, err)
+	complete, err = IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.False(t, complete)
+
+	// the approval is stale after a push
+	approve()
+	require.NoError(t, issues_model.MarkReviewsAsStale(db.DefaultContext, pr.IssueID))
+	complete, err = IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.True(t, complete)
+
+	require.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, &git_model.ProtectedBranch{
+		RepoID:               repo.ID,
+		RuleName:             pr.BaseBranch,
+		IgnoreStaleApprovals: true,
+	}, git_model.WhitelistOptions{}))
+	complete, err = IsReviewChecklistComplete(db.DefaultContext, pr)
+	require.NoError(t, err)
+	assert.False(t, complete)
+}
+
+func TestCheckPullBranchProtectionResolvedConversations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1})
+	pb := &git_model.ProtectedBranch{RepoID: pr.BaseRepoID, RuleName: pr.BaseBranch}
+	require.NoError(t, CheckPullBranchProtection(db.DefaultContext, pr, pb, true))
+
+	// the comments 5 and 6 are a conversation which is not resolved
+	pb.RequireResolvedConversations = true
+	err := CheckPullBranchProtection(db.DefaultContext, pr, pb, true)
+	require.Error(t, err)
+	assert.True(t, models.IsErrDisallowedToMerge(err))
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 5})
+	require.NoError(t, issues_model.MarkConversation(db.DefaultContext, comment, doer, true))
+	require.NoError(t, CheckPullBranchProtection(db.DefaultContext, pr, pb, true))
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+)
+
+// splitPullRequestStack returns the stack of a pull request, split in the pull requests up to it, itself
+// included, and the ones above it
+func splitPullRequestStack(ctx context.Context, pr *issues_model.PullRequest) (below, above issues_model.PullRequestList, err error) {
+	stack, err := issues_model.GetPullRequestStack(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos := slices.IndexFunc(stack, func(p *issues_model.PullRequest) bool { return p.ID == pr.ID })
+	return stack[:pos+1], stack[pos+1:], nil
+}
+
+// MergeStack merges in order the pull requests of the stack of a pull request, from the bottom of the stack
+// up to the pull request itself. Each pull request is retargeted to the base branch of the one merged before
+// it, then checked like any merge. The merge stops at the first pull request which cannot be merged, and the
+// number of merged pull requests is returned with the error.
+func MergeStack(ctx context.Context, doer *user_model.User, baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (int, error) {
+	if pr.Flow != issues_model.PullRequestFlowGithub {
+		return 0, util.NewInvalidArgumentErrorf("pull request #%d cannot be part of a stack", pr.Index)
+	}
+	if mergeStyle == repo_model.MergeStyleManuallyMerged || mergeStyle == repo_model.MergeStyleRebaseUpdate {
+		return 0, util.NewInvalidArgumentErrorf("a stack cannot be merged with the %q merge style", mergeStyle)
+	}
+	stack, _, err := splitPullRequestStack(ctx, pr)
+	if err != nil {
+		return 0, err
+	}
+
+	merged := 0
+	var previous *issues_model.PullRequest
+	for _, p := range stack {
+		if previous != nil {
+			retargeted, err := prepareStackedMerge(ctx, doer, p, previous.BaseBranch)
+			if err != nil {
+				return merged, fmt.Errorf("#%d: %w", p.Index, err)
+			}
+			p = retargeted
+		}
+		if err := p.LoadBaseRepo(ctx); err != nil {
+			return merged, err
+		}
+		if err := p.LoadHeadRepo(ctx); err != nil {
+			return merged, err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, p.BaseRepo, doer)
+		if err != nil {
+			return merged, err
+		}
+		if err := CheckPullMergeable(ctx, doer, &perm, p, MergeCheckTypeGeneral, false); err != nil {
+			return merged, fmt.Errorf("#%d: %w", p.Index, err)
+		}
+
+		message, body, err := GetDefaultMergeMessage(ctx, baseGitRepo, p, mergeStyle)
+		if err != nil {
+			return merged, err
+		}
+		if body != "" {
+			message += "\n\n" + body
+		}
+		if err := Merge(ctx, p, doer, baseGitRepo, mergeStyle, "", message, false); err != nil {
+			return merged, fmt.Errorf("#%d: %w", p.Index, err)
+		}
+		merged++
+		previous = p
+
+		// the pull requests stacked on the merged one now target the branch it was merged into, as if its
+		// branch was deleted
+		if err := RetargetChildrenOnMerge(ctx, doer, p); err != nil {
+			log.Error("RetargetChildrenOnMerge %-v: %v", p, err)
+		}
+	}
+	return merged, nil
+}
+
+// prepareStackedMerge reloads a pull request of a stack whose parent was just merged, makes sure it targets
+// the branch the parent was merged into and tests it against that branch. The test is done now rather than
+// by the checks queue, which may not have run yet.
+func prepareStackedMerge(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, targetBranch string) (*issues_model.PullRequest, error) {
+	pr, err := issues_model.GetPullRequestByID(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if err := pr.Issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	if pr.BaseBranch != targetBranch {
+		return pr, ChangeTargetBranch(ctx, pr, doer, targetBranch)
+	}
+	if err := TestPatch(pr); err != nil {
+		return pr, err
+	}
+	if pr.Status == issues_model.PullRequestStatusChecking {
+		pr.Status = issues_model.PullRequestStatusMergeable
+	}
+	return pr, nil
+}
+
+// RebaseStack rebases the pull requests stacked on a pull request on their base branches, from the bottom of
+// the stack to its top, and returns how many were rebased. The pull requests which are not behind their base
+// branches are left as they are.
+func RebaseStack(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) (int, error) {
+	_, stack, err := splitPullRequestStack(ctx, pr)
+	if err != nil {
+		return 0, err
+	}
+
+	rebased := 0
+	for _, p := range stack {
+		if err := p.LoadBaseRepo(ctx); err != nil {
+			return rebased, err
+		}
+		if err := p.LoadHeadRepo(ctx); err != nil {
+			return rebased, err
+		}
+		if p.HeadRepo == nil {
+			return rebased, util.NewInvalidArgumentErrorf("the head repository of pull request #%d does not exist", p.Index)
+		}
+		// rebaseAllowed only tells whether the head branch can be force pushed, not whether the doer can push it
+		updateAllowed, rebaseAllowed, err := IsUserAllowedToUpdate(ctx, p, doer)
+		if err != nil {
+			return rebased, err
+		}
+		if !updateAllowed || !rebaseAllowed {
+			return rebased, util.NewPermissionDeniedErrorf("pull request #%d cannot be rebased", p.Index)
+		}
+
+		divergence, err := GetDiverging(ctx, p)
+		if err != nil {
+			return rebased, err
+		}
+		if divergence.Behind == 0 {
+			continue
+		}
+		if err := Update(ctx, p, doer, "", true); err != nil {
+			return rebased, fmt.Errorf("#%d: %w", p.Index, err)
+		}
+		rebased++
+	}
+	return rebased, nil
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package replica
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/auth/password"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	base "forgejo.org/modules/migration"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/migrations"
+	mirror_service "forgejo.org/services/mirror"
+	repo_service "forgejo.org/services/repository"
+)
+
+// followQueue holds the IDs of the repositories of the primary which are not followed yet
+var followQueue *queue.WorkerPoolQueue[int64]
+
+var primaryClient = &http.Client{
+	Timeout:   time.Minute,
+	Transport: &http.Transport{Proxy: proxy.Proxy()},
+}
+
+// Init starts the queue following the new repositories of the primary
+func Init() error {
+	if !setting.Replica.Enabled {
+		return nil
+	}
+	followQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "replica", handler)
+	if followQueue == nil {
+		return fmt.Errorf("unable to create replica queue")
+	}
+	go graceful.GetManager().RunWithCancel(followQueue)
+	return nil
+}
+
+func handler(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, primaryRepoID := range items {
+		r := new(api.Repository)
+		if err := getFromPrimary(ctx, fmt.Sprintf("repositories/%d", primaryRepoID), r); err != nil {
+			log.Error("Replica: unable to get the repository %d of the primary: %v", primaryRepoID, err)
+			continue
+		}
+		if err := followRepository(ctx, r); err != nil {
+			log.Error("Replica: unable to follow %s: %v", r.FullName, err)
+		}
+	}
+	return nil
+}
+
+// VerifySignature returns true if the payload of a webhook was signed with the secret shared with the primary
+func VerifySignature(payload []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(setting.Replica.WebhookSecret))
+	_, _ = mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// HandleEvent handles a webhook event sent by the primary
+func HandleEvent(ctx context.Context, event string, payload []byte) error {
+	var p struct {
+		Action     string          `json:"action"`
+		Repository *api.Repository `json:"repository"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+	if p.Repository == nil {
+		return nil
+	}
+
+	switch event {
+	case "push", "create", "delete":
+		return syncRepository(ctx, p.Repository)
+	case "repository":
+		if p.Action == string(api.HookRepoDeleted) {
+			return unfollowPrimaryRepository(ctx, p.Repository.ID)
+		}
+		return syncRepository(ctx, p.Repository)
+	}
+	return nil
+}
+
+// syncRepository syncs the replica of a repository of the primary, or follows it if it is not followed yet
+func syncRepository(ctx context.Context, r *api.Repository) error {
+	replica, has, err := repo_model.GetReplicaRepositoryByPrimaryRepoID(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	if !has {
+		if isReplicable(r) {
+			return followQueue.Push(r.ID)
+		}
+		return nil
+	}
+	if !isReplicable(r) {
+		return unfollowRepository(ctx, replica)
+	}
+
+	if err := repo_model.UpdateReplicaRepositoryEvent(ctx, replica.RepoID); err != nil {
+		return err
+	}
+	mirror_service.AddPullMirrorToQueue(replica.RepoID)
+	return nil
+}
+
+// isReplicable returns true if a repository of the primary can be served by the replica, which
+// only serves the public repositories since it does not know the users of the primary
+func isReplicable(r *api.Repository) bool {
+	return !r.Private && !r.Internal && r.Owner != nil && r.Owner.Visibility == api.VisibleTypePublic.String()
+}
+
+// followRepository creates a pull mirror of a repository of the primary
+func followRepository(ctx context.Context, r *api.Repository) error {
+	if !isReplicable(r) {
+		return nil
+	}
+	if _, has, err := repo_model.GetReplicaRepositoryByPrimaryRepoID(ctx, r.ID); err != nil || has {
+		return err
+	}
+
+	doer, err := user_model.GetAdminUser(ctx)
+	if err != nil {
+		return err
+	}
+	owner, err := getOrCreateOwner(ctx, doer, r.Owner)
+	if err != nil {
+		return fmt.Errorf("the owner %s: %w", r.Owner.UserName, err)
+	}
+	if exist, err := repo_model.IsRepositoryModelExist(ctx, owner, r.Name); err != nil {
+		return err
+	} else if exist {
+		log.Warn("Replica: %s already exists and does not follow the primary", r.FullName)
+		return nil
+	}
+
+	cloneURL, err := url.Parse(r.CloneURL)
+	if err != nil {
+		return err
+	}
+	if setting.Replica.PrimaryToken != "" {
+		cloneURL.User = url.UserPassword(setting.Replica.PrimaryToken, "x-oauth-basic")
+	}
+
+	log.Trace("Replica: following %s", r.FullName)
+	startUnix := timeutil.TimeStampNow()
+	repo, err := migrations.MigrateRepository(ctx, doer, owner.Name, base.MigrateOptions{
+		CloneAddr:      cloneURL.String(),
+		RepoName:       r.Name,
+		Description:    r.Description,
+		OriginalURL:    r.HTMLURL,
+		GitServiceType: api.PlainGitService,
+		Mirror:         true,
+		MirrorInterval: setting.Replica.MirrorInterval.String(),
+		Wiki:           r.HasWiki,
+		LFS:            setting.LFS.StartServer,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return repo_model.InsertReplicaRepository(ctx, &repo_model.ReplicaRepository{
+		RepoID:        repo.ID,
+		PrimaryRepoID: r.ID,
+		LastSyncUnix:  startUnix,
+	})
+}
+
+// getOrCreateOwner returns the user or the organization owning a repository of the primary, and creates it
+// if it does not exist on the replica yet. The users are created without the right to log in, since the
+// replica does not know their credentials.
+func getOrCreateOwner(ctx context.Context, doer *user_model.User, u *api.User) (*user_model.User, error) {
+	owner, err := user_model.GetUserByName(ctx, u.UserName)
+	if err == nil || !user_model.IsErrUserNotExist(err) {
+		return owner, err
+	}
+
+	org := new(api.Organization)
+	err = getFromPrimary(ctx, "orgs/"+url.PathEscape(u.UserName), org)
+	if err == nil {
+		log.Trace("Replica: creating the organization %s", u.UserName)
+		o := &organization.Organization{
+			Name:        u.UserName,
+			FullName:    org.FullName,
+			Description: org.Description,
+			Website:     org.Website,
+			Location:    org.Location,
+			Visibility:  api.VisibleTypePublic,
+		}
+		if err := organization.CreateOrganization(ctx, o, doer); err != nil {
+			return nil, err
+		}
+		return o.AsUser(), nil
+	} else if !errors.Is(err, util.ErrNotExist) {
+		return nil, err
+	}
+
+	log.Trace("Replica: creating the user %s", u.UserName)
+	passwd, err := password.Generate(32)
+	if err != nil {
+		return nil, err
+	}
+	owner = &user_model.User{
+		Name:          u.UserName,
+		FullName:      u.FullName,
+		Email:         fmt.Sprintf("%s@%s", strings.ToLower(u.UserName), setting.Service.NoReplyAddress),
+		Passwd:        passwd,
+		Description:   u.Description,
+		Website:       u.Website,
+		Location:      u.Location,
+		ProhibitLogin: true,
+	}
+	if err := user_model.CreateUser(ctx, owner, &user_model.CreateUserOverwriteOptions{
+		KeepEmailPrivate: optional.Some(true),
+		IsActive:         optional.Some(false),
+	}); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+func unfollowPrimaryRepository(ctx context.Context, primaryRepoID int64) error {
+	replica, has, err := repo_model.GetReplicaRepositoryByPrimaryRepoID(ctx, primaryRepoID)
+	if err != nil || !has {
+		return err
+	}
+	return unfollowRepository(ctx, replica)
+}
+
+// unfollowRepository deletes the replica of a repository which was deleted or made private on the primary
+func unfollowRepository(ctx context.Context, replica *repo_model.ReplicaRepository) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, replica.RepoID)
+	if err != nil {
+		return err
+	}
+	doer, err := user_model.GetAdminUser(ctx)
+	if err != nil {
+		return err
+	}
+	log.Trace("Replica: unfollowing %-v", repo)
+	return repo_service.DeleteRepository(ctx, doer, repo, false)
+}
+
+// SyncRepositories follows the public repositories of the primary which are not followed yet,
+// and deletes the replicas of the repositories which are no longer public on the primary
+func SyncRepositories(ctx context.Context) error {
+	if !setting.Replica.Enabled {
+		return nil
+	}
+
+	primaryRepoIDs := make(container.Set[int64])
+	for page := 1; ; page++ {
+		var results api.SearchResults
+		if err := getFromPrimary(ctx, fmt.Sprintf("repos/search?limit=%d&page=%d", setting.API.MaxResponseItems, page), &results); err != nil {
+			return err
+		}
+		if len(results.Data) == 0 {
+			break
+		}
+		for _, r := range results.Data {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before following %s", r.FullName)
+			default:
+			}
+			if !isReplicable(r) {
+				continue
+			}
+			primaryRepoIDs.Add(r.ID)
+			if err := followRepository(ctx, r); err != nil {
+				log.Error("Replica: unable to follow %s: %v", r.FullName, err)
+			}
+		}
+	}
+
+	// an empty list is more likely a misconfiguration of the token than the deletion of every repository
+	if len(primaryRepoIDs) == 0 {
+		log.Warn("Replica: the primary has no public repository, not unfollowing any repository")
+		return nil
+	}
+	return db.Iterate(ctx, nil, func(ctx context.Context, replica *repo_model.ReplicaRepository) error {
+		if primaryRepoIDs.Contains(replica.PrimaryRepoID) {
+			return nil
+		}
+		if err := unfollowRepository(ctx, replica); err != nil {
+			log.Error("Replica: unable to unfollow the repository %d: %v", replica.RepoID, err)
+		}
+		return nil
+	})
+}
+
+// getFromPrimary decodes the response of the API of the primary
+func getFromPrimary(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, setting.Replica.PrimaryURL+"api/v1/"+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if setting.Replica.PrimaryToken != "" {
+		req.Header.Set("Authorization", "token "+setting.Replica.PrimaryToken)
+	}
+
+	resp, err := primaryClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return util.NewNotExistErrorf("GET %s: not found on the primary", path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
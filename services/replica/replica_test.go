@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package replica
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrCreateOwner(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/orgs/primary-org":
+			_ = json.NewEncoder(w).Encode(&api.Organization{Name: "primary-org", FullName: "Primary organization"})
+		case "/api/v1/orgs/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer primary.Close()
+	defer test.MockVariableValue(&setting.Replica.PrimaryURL, primary.URL+"/")()
+	defer test.MockVariableValue(&setting.Service.NoReplyAddress, "noreply.example.org")()
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	// the owner exists on the replica
+	owner, err := getOrCreateOwner(db.DefaultContext, doer, &api.User{UserName: "user2"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, owner.ID)
+
+	// the organization does not exist on the replica yet
+	owner, err = getOrCreateOwner(db.DefaultContext, doer, &api.User{UserName: "primary-org"})
+	require.NoError(t, err)
+	org := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: owner.ID})
+	assert.True(t, owner.IsOrganization())
+	assert.Equal(t, "Primary organization", org.FullName)
+	assert.Equal(t, api.VisibleTypePublic, org.Visibility)
+
+	// the user does not exist on the replica yet
+	owner, err = getOrCreateOwner(db.DefaultContext, doer, &api.User{UserName: "primary-user", FullName: "Primary user"})
+	require.NoError(t, err)
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: owner.ID})
+	assert.True(t, user.IsIndividual())
+	assert.Equal(t, "Primary user", user.FullName)
+	assert.True(t, user.ProhibitLogin)
+	assert.False(t, user.IsActive)
+
+	// the repository is not followed if its owner can not be created
+	err = followRepository(db.DefaultContext, &api.Repository{
+		ID:       1000,
+		Name:     "repo",
+		FullName: "broken/repo",
+		Owner:    &api.User{UserName: "broken", Visibility: api.VisibleTypePublic.String()},
+	})
+	require.ErrorContains(t, err, "the owner broken")
+	unittest.AssertNotExistsBean(t, &user_model.User{LowerName: "broken"})
+}
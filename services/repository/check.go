@@ -113,6 +113,32 @@ func GitGcRepo(ctx context.Context, repo *repo_model.Repository, timeout time.Du
 	return nil
 }
 
+// GitGcObjectPools maintains the object pools shared by fork networks and calls 'git gc' on them
+func GitGcObjectPools(ctx context.Context, timeout time.Duration, args git.TrustedCmdArgs) error {
+	log.Trace("Doing: GitGcObjectPools")
+
+	poolIDs, err := repo_module.ListObjectPools()
+	if err != nil {
+		return err
+	}
+	for _, poolID := range poolIDs {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("before GC of object pool %d", poolID)
+		default:
+		}
+		if err := repo_module.GarbageCollectObjectPool(ctx, poolID, timeout, args); err != nil {
+			log.Error("Object pool garbage collection failed for pool %d: %v", poolID, err)
+			if err := system_model.CreateRepositoryNotice("Object pool garbage collection failed for %s: %v", repo_model.ObjectPoolPath(poolID), err); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+		}
+	}
+
+	log.Trace("Finished: GitGcObjectPools")
+	return nil
+}
+
 func gatherMissingRepoRecords(ctx context.Context) (repo_model.RepositoryList, error) {
 	repos := make([]*repo_model.Repository, 0, 10)
 	if err := db.Iterate(
@@ -166,6 +166,8 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&repo_model.PushMirror{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
+		&repo_model.RepoMaintenance{RepoID: repoID},
+		&repo_model.ReplicaRepository{RepoID: repoID},
 		&repo_model.Redirect{RedirectRepoID: repoID},
 		&repo_model.RepoUnit{RepoID: repoID},
 		&repo_model.Star{RepoID: repoID},
@@ -192,6 +194,14 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return err
 	}
 
+	if err := issues_model.DeleteTriageRulesByRepoID(ctx, repoID); err != nil {
+		return err
+	}
+
+	if err := issues_model.DeleteRecurringIssuesByRepoID(ctx, repoID); err != nil {
+		return err
+	}
+
 	// Delete Pulls and related objects
 	if err := issues_model.DeletePullsByBaseRepoID(ctx, repoID); err != nil {
 		return err
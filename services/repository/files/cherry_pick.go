@@ -80,14 +80,18 @@ func CherryPick(ctx context.Context, repo *repo_model.Repository, doer *user_mod
 	}
 
 	description := fmt.Sprintf("CherryPick %s onto %s", right, opts.OldBranch)
-	conflict, _, err := pull.AttemptThreeWayMerge(ctx,
+	conflict, conflictedFiles, err := pull.AttemptThreeWayMerge(ctx,
 		t.basePath, t.gitRepo, base, opts.LastCommitID, right, description)
 	if err != nil {
 		return nil, fmt.Errorf("failed to three-way merge %s onto %s: %w", right, opts.OldBranch, err)
 	}
 
 	if conflict {
-		return nil, fmt.Errorf("failed to merge due to conflicts")
+		return nil, models.ErrCherryPickConflict{
+			CommitID: commit.ID.String(),
+			Branch:   opts.OldBranch,
+			Files:    conflictedFiles,
+		}
 	}
 
 	treeHash, err := t.WriteTree()
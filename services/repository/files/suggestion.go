@@ -0,0 +1,295 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package files
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/util"
+)
+
+// SuggestionRef identifies a suggestion of a code comment
+type SuggestionRef struct {
+	CommentID int64
+	Index     int
+}
+
+// CanApplySuggestions returns true if a user can commit the suggestions of code comments to the head
+// branch of an open pull request, which needs the same permissions as editing its files
+func CanApplySuggestions(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) (bool, error) {
+	if doer == nil || pr.HasMerged {
+		return false, nil
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return false, err
+	}
+	if pr.Issue.IsClosed {
+		return false, nil
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return false, err
+	}
+	if pr.HeadRepo == nil || !pr.HeadRepo.CanEnableEditor() {
+		return false, nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, pr.HeadRepo, doer)
+	if err != nil {
+		return false, err
+	}
+	return issues_model.CanMaintainerWriteToBranch(ctx, perm, pr.HeadBranch, doer), nil
+}
+
+type pendingSuggestion struct {
+	comment    *issues_model.Comment
+	suggestion *issues_model.CodeSuggestion
+	// start and end are the first and the last commented lines, old are their content
+	start, end int
+	old        []string
+}
+
+// ApplySuggestions commits suggestions of code comments to the head branch of a pull request in a single
+// commit made by doer, with the authors of the suggestions as co-authors, and records who applied them.
+// The suggestions which cannot be applied, because the commented lines were deleted or changed since or
+// because they overlap, are errors wrapping util.ErrInvalidArgument.
+func ApplySuggestions(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, refs []SuggestionRef, message string) (string, error) {
+	if len(refs) == 0 {
+		return "", util.NewInvalidArgumentErrorf("no suggestion to apply")
+	}
+	canApply, err := CanApplySuggestions(ctx, doer, pr)
+	if err != nil {
+		return "", err
+	}
+	if !canApply {
+		return "", util.NewPermissionDeniedErrorf("the suggestions cannot be applied to the head branch")
+	}
+
+	byPath := make(map[string][]*pendingSuggestion)
+	comments := make(map[int64]*issues_model.Comment)
+	seen := make(container.Set[SuggestionRef], len(refs))
+	for _, ref := range refs {
+		if !seen.Add(ref) {
+			continue
+		}
+		comment, ok := comments[ref.CommentID]
+		if !ok {
+			if comment, err = getSuggestionComment(ctx, pr, ref.CommentID); err != nil {
+				return "", err
+			}
+			comments[comment.ID] = comment
+		}
+		if ref.Index < 0 || ref.Index >= len(comment.CodeSuggestions) {
+			return "", util.NewInvalidArgumentErrorf("the suggestion %d of the comment %d does not exist", ref.Index, comment.ID)
+		}
+		suggestion := comment.CodeSuggestions[ref.Index]
+		if suggestion.Applied != nil {
+			return "", util.NewInvalidArgumentErrorf("the suggestion %d of the comment %d is already applied", ref.Index, comment.ID)
+		}
+		old := comment.CommentedLines()
+		if len(old) == 0 {
+			return "", util.NewInvalidArgumentErrorf("the lines commented by the comment %d are unknown", comment.ID)
+		}
+		end := int(comment.Line)
+		byPath[comment.TreePath] = append(byPath[comment.TreePath], &pendingSuggestion{
+			comment:    comment,
+			suggestion: suggestion,
+			start:      end - len(old) + 1,
+			end:        end,
+			old:        old,
+		})
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.HeadRepo)
+	if err != nil {
+		return "", err
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetBranchCommit(pr.HeadBranch)
+	if err != nil {
+		return "", fmt.Errorf("GetBranchCommit: %w", err)
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	files := make([]*ChangeRepoFile, 0, len(paths))
+	for _, path := range paths {
+		entry, err := commit.GetTreeEntryByPath(path)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				return "", util.NewInvalidArgumentErrorf("the file %q does not exist anymore", path)
+			}
+			return "", err
+		}
+		content, err := readBlob(entry.Blob())
+		if err != nil {
+			return "", err
+		}
+		content, err = applySuggestionsToContent(path, content, byPath[path])
+		if err != nil {
+			return "", err
+		}
+		files = append(files, &ChangeRepoFile{
+			Operation:     "update",
+			TreePath:      path,
+			ContentReader: strings.NewReader(content),
+			SHA:           entry.ID.String(),
+		})
+	}
+
+	if strings.TrimSpace(message) == "" {
+		if len(seen) == 1 {
+			message = "Apply suggestion from code review"
+		} else {
+			message = "Apply suggestions from code review"
+		}
+	}
+	message += suggestionCoAuthors(doer, comments)
+
+	resp, err := ChangeRepoFiles(ctx, pr.HeadRepo, doer, &ChangeRepoFilesOptions{
+		LastCommitID: commit.ID.String(),
+		OldBranch:    pr.HeadBranch,
+		NewBranch:    pr.HeadBranch,
+		Message:      message,
+		Files:        files,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	applied := make([]*issues_model.AppliedSuggestion, 0, len(seen))
+	for _, path := range paths {
+		for _, p := range byPath[path] {
+			applied = append(applied, &issues_model.AppliedSuggestion{
+				IssueID:   pr.IssueID,
+				CommentID: p.comment.ID,
+				Index:     p.suggestion.Index,
+				DoerID:    doer.ID,
+				CommitSHA: resp.Commit.SHA,
+			})
+		}
+	}
+	if err := issues_model.CreateAppliedSuggestions(ctx, applied); err != nil {
+		return "", err
+	}
+	return resp.Commit.SHA, nil
+}
+
+// getSuggestionComment returns a code comment of a pull request with its suggestions, as long as they
+// are visible and were made on lines which did not change since
+func getSuggestionComment(ctx context.Context, pr *issues_model.PullRequest, id int64) (*issues_model.Comment, error) {
+	comment, err := issues_model.GetCommentByID(ctx, id)
+	if err != nil {
+		if issues_model.IsErrCommentNotExist(err) {
+			return nil, util.NewInvalidArgumentErrorf("the comment %d does not exist", id)
+		}
+		return nil, err
+	}
+	if comment.IssueID != pr.IssueID || comment.Type != issues_model.CommentTypeCode {
+		return nil, util.NewInvalidArgumentErrorf("the comment %d does not exist", id)
+	}
+	if err := comment.LoadReview(ctx); err != nil {
+		return nil, err
+	}
+	if comment.Review != nil && comment.Review.Type == issues_model.ReviewTypePending {
+		return nil, util.NewInvalidArgumentErrorf("the review of the comment %d is pending", id)
+	}
+	if comment.Invalidated {
+		return nil, util.NewInvalidArgumentErrorf("the comment %d is outdated", id)
+	}
+	if comment.Line < 0 {
+		// the commented lines were deleted by the pull request, there is nothing left to replace in its head branch
+		return nil, util.NewInvalidArgumentErrorf("the comment %d is on deleted lines, its suggestions cannot be applied", id)
+	}
+	if err := comment.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+	if err := (issues_model.CommentList{comment}).LoadCodeSuggestions(ctx); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func readBlob(blob *git.Blob) (string, error) {
+	reader, err := blob.DataAsync()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	return string(content), err
+}
+
+// applySuggestionsToContent replaces the commented lines of a file by the suggested ones, from the last
+// lines to the first ones so that the line numbers of the next suggestions do not change
+func applySuggestionsToContent(path, content string, suggestions []*pendingSuggestion) (string, error) {
+	lines := strings.Split(content, "\n")
+	count := len(lines)
+	if strings.HasSuffix(content, "\n") {
+		count--
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].start > suggestions[j].start
+	})
+	for i, s := range suggestions {
+		if i > 0 && s.end >= suggestions[i-1].start {
+			return "", util.NewInvalidArgumentErrorf("the suggestions on the lines %d and %d of %q overlap", s.end, suggestions[i-1].start, path)
+		}
+		if s.start < 1 || s.end > count {
+			return "", util.NewInvalidArgumentErrorf("the lines %d to %d of %q changed since the suggestion", s.start, s.end, path)
+		}
+		for j, old := range s.old {
+			if strings.TrimSuffix(lines[s.start-1+j], "\r") != old {
+				return "", util.NewInvalidArgumentErrorf("the lines %d to %d of %q changed since the suggestion", s.start, s.end, path)
+			}
+		}
+
+		suffix := ""
+		if strings.HasSuffix(lines[s.end-1], "\r") {
+			suffix = "\r"
+		}
+		suggested := make([]string, 0, len(s.suggestion.Lines))
+		for _, line := range s.suggestion.Lines {
+			suggested = append(suggested, line+suffix)
+		}
+		lines = slices.Concat(lines[:s.start-1], suggested, lines[s.end:])
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// suggestionCoAuthors returns the Co-authored-by trailers of the authors of suggestions applied by doer
+func suggestionCoAuthors(doer *user_model.User, comments map[int64]*issues_model.Comment) string {
+	ids := make([]int64, 0, len(comments))
+	for id := range comments {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var trailers strings.Builder
+	seen := make(container.Set[int64])
+	for _, id := range ids {
+		poster := comments[id].Poster
+		if poster == nil || poster.ID == doer.ID || poster.IsGhost() || !seen.Add(poster.ID) {
+			continue
+		}
+		if trailers.Len() == 0 {
+			trailers.WriteString("\n")
+		}
+		fmt.Fprintf(&trailers, "\nCo-authored-by: %s", poster.NewGitSig().String())
+	}
+	return trailers.String()
+}
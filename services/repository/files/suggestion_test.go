@@ -0,0 +1,82 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package files
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySuggestionsToContent(t *testing.T) {
+	suggestion := func(line int, old string, lines ...string) *pendingSuggestion {
+		return &pendingSuggestion{
+			suggestion: &issues_model.CodeSuggestion{Lines: lines},
+			start:      line,
+			end:        line,
+			old:        []string{old},
+		}
+	}
+
+	t.Run("Batch", func(t *testing.T) {
+		content, err := applySuggestionsToContent("main.go", "package main\n\nvar a = 1\nvar b = 2\n", []*pendingSuggestion{
+			suggestion(3, "var a = 1", "var a = 10"),
+			suggestion(4, "var b = 2", "var b = 20", "var c = 30"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n\nvar a = 10\nvar b = 20\nvar c = 30\n", content)
+	})
+
+	t.Run("Removal", func(t *testing.T) {
+		content, err := applySuggestionsToContent("main.go", "package main\n\nvar a = 1", []*pendingSuggestion{
+			suggestion(3, "var a = 1"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", content)
+	})
+
+	t.Run("CRLF", func(t *testing.T) {
+		content, err := applySuggestionsToContent("main.go", "package main\r\nvar a = 1\r\n", []*pendingSuggestion{
+			suggestion(2, "var a = 1", "var a = 10"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "package main\r\nvar a = 10\r\n", content)
+	})
+
+	t.Run("Outdated", func(t *testing.T) {
+		_, err := applySuggestionsToContent("main.go", "package main\nvar a = 2\n", []*pendingSuggestion{
+			suggestion(2, "var a = 1", "var a = 10"),
+		})
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		_, err = applySuggestionsToContent("main.go", "package main\n", []*pendingSuggestion{
+			suggestion(2, "var a = 1", "var a = 10"),
+		})
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Overlap", func(t *testing.T) {
+		_, err := applySuggestionsToContent("main.go", "package main\nvar a = 1\n", []*pendingSuggestion{
+			suggestion(2, "var a = 1", "var a = 10"),
+			suggestion(2, "var a = 1", "var a = 20"),
+		})
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+}
+
+func TestGetSuggestionCommentOnDeletedLines(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{IssueID: 2})
+
+	// the comment 5 is on the old side of the diff
+	_, err := getSuggestionComment(db.DefaultContext, pr, 5)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "deleted lines")
+}
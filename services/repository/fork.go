@@ -17,6 +17,7 @@ import (
 	"forgejo.org/modules/gitrepo"
 	"forgejo.org/modules/log"
 	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
 	"forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
 	notify_service "forgejo.org/services/notify"
@@ -93,6 +94,21 @@ func ForkRepositoryIfNotExists(ctx context.Context, doer, owner *user_model.User
 
 	oldRepoPath := opts.BaseRepo.RepoPath()
 
+	// public forks of public repositories borrow the objects of their fork network from a shared pool. The pool is
+	// created and the fork joins it in the background, the clone only borrows from a pool which already exists.
+	joinObjectPool := false
+	if setting.Repository.EnableForkObjectPools && !opts.BaseRepo.IsEmpty && !repo.IsPrivate && owner.Visibility.IsPublic() {
+		canShare, err := opts.BaseRepo.CanShareObjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		joinObjectPool = canShare
+	}
+	useObjectPool := joinObjectPool && opts.BaseRepo.ObjectPoolID != 0
+	if useObjectPool {
+		repo.ObjectPoolID = opts.BaseRepo.ObjectPoolID
+	}
+
 	needsRollback := false
 	rollbackFn := func() {
 		if !needsRollback {
@@ -145,6 +161,9 @@ func ForkRepositoryIfNotExists(ctx context.Context, doer, owner *user_model.User
 		if opts.SingleBranch != "" {
 			cloneCmd.AddArguments("--single-branch", "--branch").AddDynamicArguments(opts.SingleBranch)
 		}
+		if useObjectPool {
+			cloneCmd.AddArguments("--no-local", "--reference").AddDynamicArguments(repo.ObjectPoolPath())
+		}
 		repoPath := repo_model.RepoPath(owner.Name, repo.Name)
 		if stdout, _, err := cloneCmd.AddDynamicArguments(oldRepoPath, repoPath).
 			SetDescription(fmt.Sprintf("ForkRepositoryIfNotExists(git clone): %s to %s", opts.BaseRepo.FullName(), repo.FullName())).
@@ -153,6 +172,13 @@ func ForkRepositoryIfNotExists(ctx context.Context, doer, owner *user_model.User
 			return fmt.Errorf("git clone: %w", err)
 		}
 
+		if useObjectPool {
+			// git clone records an absolute path, which would break if the repository root is moved
+			if err := repo_module.WriteAlternates(repoPath, repo.ObjectPoolPath()); err != nil {
+				return fmt.Errorf("WriteAlternates: %w", err)
+			}
+		}
+
 		if err := repo_module.CheckDaemonExportOK(txCtx, repo); err != nil {
 			return fmt.Errorf("checkDaemonExportOK: %w", err)
 		}
@@ -183,6 +209,10 @@ func ForkRepositoryIfNotExists(ctx context.Context, doer, owner *user_model.User
 		return nil, err
 	}
 
+	if joinObjectPool {
+		addForkToObjectPoolQueue(repo.ID)
+	}
+
 	return repo, nil
 }
 
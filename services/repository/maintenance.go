@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	system_model "forgejo.org/models/system"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// MaintenanceOptions control which repositories are maintained, and how
+type MaintenanceOptions struct {
+	Timeout time.Duration
+	// Tasks are run on every maintained repository, except MaintenancePackBitmap which is
+	// only run on repositories of at least BitmapMinRepoSize bytes
+	Tasks []git.MaintenanceTask
+	// Interval is the minimum time between two maintenances of a repository which received pushes
+	Interval time.Duration
+	// PushThreshold is the number of pushes after which a repository is maintained without waiting for Interval
+	PushThreshold int64
+	// MinRepoSize is the size in bytes below which repositories are left to git gc
+	MinRepoSize       int64
+	BitmapMinRepoSize int64
+}
+
+// isMaintenanceDue returns true if a repository received enough pushes since its last maintenance
+func isMaintenanceDue(m *repo_model.RepoMaintenance, opts *MaintenanceOptions, now time.Time) bool {
+	if !m.HasRun() {
+		return true
+	}
+	if m.PushesSinceRun == 0 {
+		return false
+	}
+	if opts.PushThreshold > 0 && m.PushesSinceRun >= opts.PushThreshold {
+		return true
+	}
+	return now.Sub(m.LastRunUnix.AsTime()) >= opts.Interval
+}
+
+// MaintainRepositories runs incremental git maintenance on the repositories which are due for it
+func MaintainRepositories(ctx context.Context, opts *MaintenanceOptions) error {
+	log.Trace("Doing: MaintainRepositories")
+
+	if err := db.Iterate(
+		ctx,
+		builder.Eq{"is_empty": false}.And(builder.Gte{"git_size": opts.MinRepoSize}),
+		func(ctx context.Context, repo *repo_model.Repository) error {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before maintenance of %s", repo.FullName())
+			default:
+			}
+
+			m, err := repo_model.GetRepoMaintenance(ctx, repo.ID)
+			if err != nil {
+				return err
+			}
+			if !isMaintenanceDue(m, opts, time.Now()) {
+				return nil
+			}
+			// the error is recorded in the maintenance status and as a notice
+			_ = MaintainRepository(ctx, repo, m, opts)
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	log.Trace("Finished: MaintainRepositories")
+	return nil
+}
+
+// MaintainRepository runs incremental git maintenance on a repository and records its outcome
+func MaintainRepository(ctx context.Context, repo *repo_model.Repository, m *repo_model.RepoMaintenance, opts *MaintenanceOptions) error {
+	tasks := make([]git.MaintenanceTask, 0, len(opts.Tasks))
+	names := make([]string, 0, len(opts.Tasks))
+	for _, task := range opts.Tasks {
+		if task == git.MaintenancePackBitmap && repo.GitSize < opts.BitmapMinRepoSize {
+			continue
+		}
+		tasks = append(tasks, task)
+		names = append(names, string(task))
+	}
+
+	log.Trace("Running git maintenance (%s) on %-v", strings.Join(names, ","), repo)
+	pushesSeen := m.PushesSinceRun
+	start := time.Now()
+	err := git.RunMaintenance(ctx, repo.RepoPath(), tasks, opts.Timeout)
+
+	m.LastRunUnix = timeutil.TimeStampNow()
+	m.LastDuration = time.Since(start).Milliseconds()
+	m.LastTasks = strings.Join(names, ",")
+	m.LastError = ""
+	if err != nil {
+		log.Error("Repository maintenance failed for %-v: %v", repo, err)
+		m.LastError = err.Error()
+		desc := fmt.Sprintf("Repository maintenance failed for %s. Error: %v", repo.RepoPath(), err)
+		if err := system_model.CreateRepositoryNotice(desc); err != nil {
+			log.Error("CreateRepositoryNotice: %v", err)
+		}
+	}
+	if err := repo_model.UpdateRepoMaintenanceRun(ctx, m, pushesSeen); err != nil {
+		log.Error("UpdateRepoMaintenanceRun for %-v: %v", repo, err)
+	}
+	return err
+}
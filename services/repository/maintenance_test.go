@@ -0,0 +1,29 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMaintenanceDue(t *testing.T) {
+	now := time.Now()
+	opts := &MaintenanceOptions{Interval: 24 * time.Hour, PushThreshold: 10}
+	lastRun := timeutil.TimeStamp(now.Add(-time.Hour).Unix())
+
+	assert.True(t, isMaintenanceDue(&repo_model.RepoMaintenance{}, opts, now))
+	assert.False(t, isMaintenanceDue(&repo_model.RepoMaintenance{LastRunUnix: lastRun}, opts, now))
+	assert.False(t, isMaintenanceDue(&repo_model.RepoMaintenance{LastRunUnix: lastRun, PushesSinceRun: 3}, opts, now))
+	assert.True(t, isMaintenanceDue(&repo_model.RepoMaintenance{LastRunUnix: lastRun, PushesSinceRun: 10}, opts, now))
+
+	lastRun = timeutil.TimeStamp(now.Add(-25 * time.Hour).Unix())
+	assert.True(t, isMaintenanceDue(&repo_model.RepoMaintenance{LastRunUnix: lastRun, PushesSinceRun: 1}, opts, now))
+	assert.False(t, isMaintenanceDue(&repo_model.RepoMaintenance{LastRunUnix: lastRun}, opts, now))
+}
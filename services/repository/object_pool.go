@@ -0,0 +1,86 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+)
+
+// objectPoolQueue holds the IDs of the forks to add to the object pool of their fork network. Creating a pool
+// repacks the parent repository, which is too slow to be done while the fork is requested.
+var objectPoolQueue *queue.WorkerPoolQueue[int64]
+
+func handlerObjectPool(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, forkID := range items {
+		if err := joinForkNetworkObjectPool(ctx, forkID); err != nil {
+			log.Error("Unable to add repository %d to the object pool of its fork network: %v", forkID, err)
+		}
+	}
+	return nil
+}
+
+func initObjectPoolQueue(ctx context.Context) error {
+	objectPoolQueue = queue.CreateUniqueQueue(ctx, "repo_object_pool", handlerObjectPool)
+	if objectPoolQueue == nil {
+		return errors.New("unable to create repo_object_pool queue")
+	}
+	go graceful.GetManager().RunWithCancel(objectPoolQueue)
+
+	return nil
+}
+
+func addForkToObjectPoolQueue(forkID int64) {
+	if err := objectPoolQueue.Push(forkID); err != nil {
+		log.Error("Unable to push repository %d to the repo_object_pool queue: %v", forkID, err)
+	}
+}
+
+// joinForkNetworkObjectPool makes a fork borrow the objects of its parent from the pool of their fork network,
+// which is created from the parent if needed. Nothing is done if one of them cannot share its objects anymore.
+func joinForkNetworkObjectPool(ctx context.Context, forkID int64) error {
+	if !setting.Repository.EnableForkObjectPools {
+		return nil
+	}
+	fork, err := repo_model.GetRepositoryByID(ctx, forkID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !fork.IsFork {
+		return nil
+	}
+	parent, err := repo_model.GetRepositoryByID(ctx, fork.ForkID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if parent.IsEmpty {
+		return nil
+	}
+	for _, repo := range []*repo_model.Repository{parent, fork} {
+		if canShare, err := repo.CanShareObjects(ctx); err != nil || !canShare {
+			return err
+		}
+	}
+
+	if parent.ObjectPoolID == 0 {
+		if err := repo_module.CreateObjectPool(ctx, parent); err != nil {
+			return err
+		}
+	}
+	return repo_module.JoinObjectPool(ctx, fork, parent.ObjectPoolID)
+}
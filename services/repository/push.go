@@ -96,6 +96,9 @@ func pushUpdates(optsList []*repo_module.PushUpdateOptions) error {
 	if err = repo_module.UpdateRepoSize(ctx, repo); err != nil {
 		return fmt.Errorf("Failed to update size for repository: %v", err)
 	}
+	if err := repo_model.IncreaseRepoMaintenancePushes(ctx, repo.ID); err != nil {
+		log.Error("Unable to record the push for the maintenance of %-v: %v", repo, err)
+	}
 
 	addTags := make([]string, 0, len(optsList))
 	delTags := make([]string, 0, len(optsList))
@@ -100,7 +100,10 @@ func Init(ctx context.Context) error {
 	if err := initPushQueue(); err != nil {
 		return err
 	}
-	return initBranchSyncQueue(graceful.GetManager().ShutdownContext())
+	if err := initBranchSyncQueue(graceful.GetManager().ShutdownContext()); err != nil {
+		return err
+	}
+	return initObjectPoolQueue(graceful.GetManager().ShutdownContext())
 }
 
 // UpdateRepository updates a repository
@@ -115,7 +118,17 @@ func UpdateRepository(ctx context.Context, repo *repo_model.Repository, visibili
 		return fmt.Errorf("updateRepository: %w", err)
 	}
 
-	return committer.Commit()
+	if err = committer.Commit(); err != nil {
+		return err
+	}
+
+	// the repository and its forks must not share their objects anymore if they were made private
+	if visibilityChanged && repo.IsPrivate && repo.ObjectPoolID != 0 {
+		if err := repo_module.DetachPrivateObjectPoolMembers(ctx, repo.ObjectPoolID); err != nil {
+			log.Error("Unable to detach private repositories from object pool %d: %v", repo.ObjectPoolID, err)
+		}
+	}
+	return nil
 }
 
 // LinkedRepository returns the linked repo if any
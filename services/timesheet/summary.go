@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package timesheet
+
+import (
+	"context"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/services/mailer"
+)
+
+// SendWeeklySummaries mails to the users who tracked time during the last week the summary of their tracked
+// time by repository
+func SendWeeklySummaries(ctx context.Context) error {
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+	userIDs, err := issues_model.GetTrackedTimeUserIDs(ctx, from.Unix())
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		u, err := user_model.GetUserByID(ctx, userID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if !u.IsActive || u.ProhibitLogin || u.IsOrganization() {
+			continue
+		}
+		rows, err := issues_model.GetTimesheet(ctx, &issues_model.TimesheetOptions{
+			AccessCond:        readableTimesCond(u),
+			UserID:            u.ID,
+			CreatedAfterUnix:  from.Unix(),
+			CreatedBeforeUnix: to.Unix(),
+		}, issues_model.TimesheetGroupRepo)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		entries, err := toTimesheetEntries(ctx, rows, issues_model.TimesheetGroupRepo)
+		if err != nil {
+			return err
+		}
+		if err := mailer.MailTimesheetSummary(u, from, to, entries, TotalTime(entries)); err != nil {
+			log.Error("MailTimesheetSummary [user_id: %d]: %v", u.ID, err)
+		}
+	}
+	return nil
+}
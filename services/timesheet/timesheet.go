@@ -0,0 +1,175 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package timesheet
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// dateLayout is the format of the dates of the filters of a timesheet
+const dateLayout = "2006-01-02"
+
+// Options are the filters of a timesheet, in which the users and repositories are given by name
+type Options struct {
+	Group     issues_model.TimesheetGroup
+	User      string
+	Repo      string
+	Milestone string
+	Label     string
+	// From and To are the first and the last days of the timesheet, in the format YYYY-MM-DD
+	From string
+	To   string
+}
+
+// GetTimesheet returns the tracked times in the repositories of an owner which the doer can read, aggregated
+// by the group of the options. The errors caused by the options wrap util.ErrInvalidArgument.
+func GetTimesheet(ctx context.Context, doer, owner *user_model.User, opts Options) ([]*api.TimesheetEntry, error) {
+	if !opts.Group.IsValid() {
+		return nil, util.NewInvalidArgumentErrorf("unknown group %q", opts.Group)
+	}
+	findOpts := &issues_model.TimesheetOptions{
+		OwnerID:    owner.ID,
+		AccessCond: readableTimesCond(doer),
+		Milestone:  opts.Milestone,
+		Label:      opts.Label,
+	}
+
+	if opts.User != "" {
+		u, err := user_model.GetUserByName(ctx, opts.User)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("the user %q does not exist", opts.User)
+			}
+			return nil, err
+		}
+		findOpts.UserID = u.ID
+	}
+	if opts.Repo != "" {
+		repo, err := repo_model.GetRepositoryByName(ctx, owner.ID, opts.Repo)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("the repository %q does not exist", opts.Repo)
+			}
+			return nil, err
+		}
+		findOpts.RepoID = repo.ID
+	}
+	if opts.From != "" {
+		from, err := time.ParseInLocation(dateLayout, opts.From, setting.DefaultUILocation)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("the date %q is not in the format YYYY-MM-DD", opts.From)
+		}
+		findOpts.CreatedAfterUnix = from.Unix()
+	}
+	if opts.To != "" {
+		to, err := time.ParseInLocation(dateLayout, opts.To, setting.DefaultUILocation)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("the date %q is not in the format YYYY-MM-DD", opts.To)
+		}
+		findOpts.CreatedBeforeUnix = to.AddDate(0, 0, 1).Unix() - 1
+	}
+
+	rows, err := issues_model.GetTimesheet(ctx, findOpts, opts.Group)
+	if err != nil {
+		return nil, err
+	}
+	return toTimesheetEntries(ctx, rows, opts.Group)
+}
+
+// readableTimesCond returns the condition of the tracked times the doer can read, or nil if the doer can read
+// them all. Like in the tracked times of a repository, the users who are not writers of its issues can only
+// read their own times.
+func readableTimesCond(doer *user_model.User) builder.Cond {
+	if doer.IsAdmin {
+		return nil
+	}
+	return builder.And(
+		builder.Or(
+			repo_model.AccessibleRepositoryCondition(doer, unit.TypeIssues),
+			repo_model.AccessibleRepositoryCondition(doer, unit.TypePullRequests),
+		),
+		builder.Or(
+			repo_model.UnitWriterRepositoryCondition(doer, unit.TypeIssues),
+			builder.Eq{"tracked_time.user_id": doer.ID},
+		),
+	)
+}
+
+// toTimesheetEntries names the rows of a timesheet
+func toTimesheetEntries(ctx context.Context, rows []*issues_model.TimesheetRow, group issues_model.TimesheetGroup) ([]*api.TimesheetEntry, error) {
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	names := make(map[int64]string, len(rows))
+	switch group {
+	case issues_model.TimesheetGroupUser:
+		users, err := user_model.GetUsersByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			names[u.ID] = u.Name
+		}
+	case issues_model.TimesheetGroupRepo:
+		repos, err := repo_model.GetRepositoriesMapByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for id, repo := range repos {
+			names[id] = repo.FullName()
+		}
+	}
+
+	entries := make([]*api.TimesheetEntry, 0, len(rows))
+	for _, row := range rows {
+		name := row.Name
+		if group == issues_model.TimesheetGroupUser || group == issues_model.TimesheetGroupRepo {
+			var ok bool
+			if name, ok = names[row.ID]; !ok {
+				name = user_model.NewGhostUser().Name
+			}
+		}
+		entries = append(entries, &api.TimesheetEntry{Name: name, Time: row.Seconds})
+	}
+	return entries, nil
+}
+
+// WriteCSV writes the entries of a timesheet in CSV, with their time in seconds and formatted
+func WriteCSV(w io.Writer, group issues_model.TimesheetGroup, entries []*api.TimesheetEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{string(group), "seconds", "time"}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := cw.Write([]string{entry.Name, strconv.FormatInt(entry.Time, 10), util.SecToTime(entry.Time)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// TotalTime returns the total time of the entries of a timesheet, in seconds
+func TotalTime(entries []*api.TimesheetEntry) int64 {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Time
+	}
+	return total
+}
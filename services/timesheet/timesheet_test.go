@@ -0,0 +1,50 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package timesheet
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTimesheetReadOnlyMember(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	member := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	// user4 only reads the issues of repo3 through team1
+	_, err := db.GetEngine(db.DefaultContext).Where("team_id = ? AND type = ?", 2, unit.TypeIssues).
+		Cols("access_mode").Update(&organization.TeamUnit{AccessMode: perm.AccessModeRead})
+	require.NoError(t, err)
+
+	_, err = issues_model.AddTime(db.DefaultContext, owner, issue, 200, time.Now())
+	require.NoError(t, err)
+	_, err = issues_model.AddTime(db.DefaultContext, member, issue, 100, time.Now())
+	require.NoError(t, err)
+
+	opts := Options{Group: issues_model.TimesheetGroupUser, Repo: "repo3"}
+	entries, err := GetTimesheet(db.DefaultContext, owner, org, opts)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, err = GetTimesheet(db.DefaultContext, member, org, opts)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "user4", entries[0].Name)
+	assert.EqualValues(t, 100, entries[0].Time)
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package triage
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	notify_service "forgejo.org/services/notify"
+)
+
+type triageNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &triageNotifier{}
+
+// NewNotifier create a new triageNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &triageNotifier{}
+}
+
+// push queues an event, unless it results from the actions of a rule
+func push(ctx context.Context, e *triageEvent) {
+	if isTriage(ctx) {
+		return
+	}
+	if err := triageQueue.Push(e); err != nil {
+		log.Error("push issue %d to the triage queue: %v", e.IssueID, err)
+	}
+}
+
+func (*triageNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, _ []*user_model.User) {
+	push(ctx, &triageEvent{Event: issues_model.TriageEventOpened, IssueID: issue.ID})
+}
+
+func (*triageNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, _ []*user_model.User) {
+	push(ctx, &triageEvent{Event: issues_model.TriageEventOpened, IssueID: pr.IssueID})
+}
+
+func (*triageNotifier) IssueChangeLabels(ctx context.Context, _ *user_model.User, issue *issues_model.Issue, addedLabels, _ []*issues_model.Label) {
+	if len(addedLabels) == 0 {
+		return
+	}
+	labelIDs := make([]int64, 0, len(addedLabels))
+	for _, label := range addedLabels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+	push(ctx, &triageEvent{Event: issues_model.TriageEventLabeled, IssueID: issue.ID, LabelIDs: labelIDs})
+}
+
+func (*triageNotifier) CreateIssueComment(ctx context.Context, _ *user_model.User, _ *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment, _ []*user_model.User) {
+	if comment == nil {
+		return
+	}
+	push(ctx, &triageEvent{Event: issues_model.TriageEventCommented, IssueID: issue.ID, CommentID: comment.ID})
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package triage
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierSkipsTriageActions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	events := make(chan *triageEvent, 10)
+	cfg, err := setting.GetQueueSettings(setting.CfgProvider, "issue_triage")
+	require.NoError(t, err)
+	triageQueue, err = queue.NewWorkerPoolQueueWithContext(t.Context(), "issue_triage", cfg, func(items ...*triageEvent) []*triageEvent {
+		for _, e := range items {
+			events <- e
+		}
+		return nil
+	}, false)
+	require.NoError(t, err)
+	go triageQueue.Run()
+	defer func() {
+		triageQueue.ShutdownWait(5 * time.Second)
+		triageQueue = nil
+	}()
+
+	assert.False(t, isTriage(db.DefaultContext))
+	assert.True(t, isTriage(withTriage(db.DefaultContext)))
+
+	// the issues opened by the actions of a rule do not make the rules run again
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	notifier := NewNotifier()
+	notifier.NewIssue(withTriage(db.DefaultContext), issue, nil)
+	notifier.NewIssue(db.DefaultContext, issue, nil)
+
+	select {
+	case e := <-events:
+		assert.Equal(t, issues_model.TriageEventOpened, e.Event)
+		assert.EqualValues(t, 1, e.IssueID)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "Timeout: nothing was added to the triage queue")
+	}
+	select {
+	case e := <-events:
+		assert.Failf(t, "Unexpected triage event", "%v on issue %d", e.Event, e.IssueID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
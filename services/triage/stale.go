@@ -0,0 +1,54 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package triage
+
+import (
+	"context"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+)
+
+// CheckStaleIssues runs the rules for the stale event on the open issues and pull requests which
+// were not updated for the number of days of the rules
+func CheckStaleIssues(ctx context.Context) error {
+	rules, err := issues_model.GetActiveStaleTriageRules(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = withTriage(ctx)
+	for _, rule := range rules {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := checkStaleIssues(ctx, rule); err != nil {
+			log.Error("checkStaleIssues [rule id: %d]: %v", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+func checkStaleIssues(ctx context.Context, rule *issues_model.TriageRule) error {
+	updatedBefore := timeutil.TimeStamp(time.Now().AddDate(0, 0, -rule.StaleDays).Unix())
+	var afterID int64
+	for {
+		issues, err := issues_model.GetTriageStaleIssues(ctx, rule, updatedBefore, afterID, 50)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if err := RunRule(ctx, rule, issue, &EventData{Event: issues_model.TriageEventStale, Body: issue.Content}); err != nil {
+				return err
+			}
+			afterID = issue.ID
+		}
+		if len(issues) < 50 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,516 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package triage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/util"
+	issue_service "forgejo.org/services/issue"
+	notify_service "forgejo.org/services/notify"
+)
+
+// triageEvent is an event on an issue or a pull request for which the triage rules run
+type triageEvent struct {
+	Event     issues_model.TriageEvent
+	IssueID   int64
+	CommentID int64
+	// LabelIDs are the labels added for the labeled event
+	LabelIDs []int64
+}
+
+var triageQueue *queue.WorkerPoolQueue[*triageEvent]
+
+// Init registers the notifier which runs the triage rules
+func Init() error {
+	triageQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "issue_triage", handler)
+	if triageQueue == nil {
+		return fmt.Errorf("unable to create issue_triage queue")
+	}
+	go graceful.GetManager().RunWithCancel(triageQueue)
+
+	notify_service.RegisterNotifier(NewNotifier())
+	return nil
+}
+
+func handler(items ...*triageEvent) []*triageEvent {
+	ctx := withTriage(graceful.GetManager().ShutdownContext())
+	for _, e := range items {
+		if err := handleEvent(ctx, e); err != nil {
+			log.Error("triage of issue %d on %s: %v", e.IssueID, e.Event, err)
+		}
+	}
+	return nil
+}
+
+type triageContextKey struct{}
+
+// withTriage marks a context as the one of the actions of the triage rules, so that they do not
+// make rules run again
+func withTriage(ctx context.Context) context.Context {
+	return context.WithValue(ctx, triageContextKey{}, true)
+}
+
+func isTriage(ctx context.Context) bool {
+	v, _ := ctx.Value(triageContextKey{}).(bool)
+	return v
+}
+
+func handleEvent(ctx context.Context, e *triageEvent) error {
+	issue, err := issues_model.GetIssueByID(ctx, e.IssueID)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	rules, err := issues_model.GetActiveTriageRules(ctx, issue.Repo, e.Event)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	data := &EventData{Event: e.Event, Body: issue.Content}
+	switch e.Event {
+	case issues_model.TriageEventLabeled:
+		labels, err := issues_model.GetLabelsByIDs(ctx, e.LabelIDs, "id", "name")
+		if err != nil {
+			return err
+		}
+		for _, label := range labels {
+			data.AddedLabels = append(data.AddedLabels, label.Name)
+		}
+	case issues_model.TriageEventCommented:
+		comment, err := issues_model.GetCommentByID(ctx, e.CommentID)
+		if err != nil {
+			if issues_model.IsErrCommentNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		data.Body = comment.Content
+	}
+
+	for _, rule := range rules {
+		if err := RunRule(ctx, rule, issue, data); err != nil {
+			log.Error("triage rule %d on issue %d: %v", rule.ID, issue.ID, err)
+		}
+	}
+	return nil
+}
+
+// EventData are the data of an event which the conditions of the rules are checked against
+type EventData struct {
+	Event issues_model.TriageEvent
+	// Body is the one of the issue, or of the comment for the commented event
+	Body string
+	// AddedLabels are the names of the labels added for the labeled event
+	AddedLabels []string
+}
+
+// RunRule takes the actions of a rule on an issue if it meets the conditions of the rule, or only
+// logs them if the rule is in dry-run
+func RunRule(ctx context.Context, rule *issues_model.TriageRule, issue *issues_model.Issue, data *EventData) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if matched, err := matchConditions(ctx, rule, issue, data); err != nil || !matched {
+		return err
+	}
+
+	entry := &issues_model.TriageLog{
+		RuleID:  rule.ID,
+		IssueID: issue.ID,
+		Event:   data.Event,
+		DryRun:  rule.DryRun,
+	}
+	var actions []string
+	var err error
+	if rule.DryRun {
+		actions = describeActions(rule)
+	} else {
+		actions, err = takeActions(ctx, rule, issue)
+		if err != nil {
+			entry.Error = err.Error()
+		}
+	}
+	entry.Actions = strings.Join(actions, "\n")
+	if err := issues_model.CreateTriageLog(ctx, entry); err != nil {
+		return err
+	}
+	return err
+}
+
+func matchConditions(ctx context.Context, rule *issues_model.TriageRule, issue *issues_model.Issue, data *EventData) (bool, error) {
+	cond := &rule.Conditions
+	switch cond.Type {
+	case "issues":
+		if issue.IsPull {
+			return false, nil
+		}
+	case "pulls":
+		if !issue.IsPull {
+			return false, nil
+		}
+	}
+
+	if len(cond.Authors) > 0 {
+		if err := issue.LoadPoster(ctx); err != nil {
+			return false, err
+		}
+		if !slices.ContainsFunc(cond.Authors, func(name string) bool { return strings.EqualFold(name, issue.Poster.Name) }) {
+			return false, nil
+		}
+	}
+
+	if cond.TitleRegex != "" {
+		re, err := regexp.Compile(cond.TitleRegex)
+		if err != nil {
+			return false, err
+		}
+		if !re.MatchString(issue.Title) {
+			return false, nil
+		}
+	}
+	if cond.BodyRegex != "" {
+		re, err := regexp.Compile(cond.BodyRegex)
+		if err != nil {
+			return false, err
+		}
+		if !re.MatchString(data.Body) {
+			return false, nil
+		}
+	}
+
+	if len(cond.Labels) > 0 || len(cond.ExcludedLabels) > 0 {
+		issue.Labels = nil
+		if err := issue.LoadLabels(ctx); err != nil {
+			return false, err
+		}
+		hasLabel := func(name string) bool {
+			return slices.ContainsFunc(issue.Labels, func(l *issues_model.Label) bool { return strings.EqualFold(l.Name, name) })
+		}
+		for _, name := range cond.Labels {
+			if !hasLabel(name) {
+				return false, nil
+			}
+		}
+		if slices.ContainsFunc(cond.ExcludedLabels, hasLabel) {
+			return false, nil
+		}
+		// a rule for the labeled event runs when one of the labels of its conditions is added
+		if data.Event == issues_model.TriageEventLabeled && len(cond.Labels) > 0 &&
+			!slices.ContainsFunc(data.AddedLabels, func(name string) bool {
+				return slices.ContainsFunc(cond.Labels, func(n string) bool { return strings.EqualFold(n, name) })
+			}) {
+			return false, nil
+		}
+	}
+
+	if len(cond.Fields) > 0 {
+		if err := issue.LoadProject(ctx); err != nil {
+			return false, err
+		}
+		if issue.Project == nil {
+			return false, nil
+		}
+		fields, err := issue.Project.GetFields(ctx)
+		if err != nil {
+			return false, err
+		}
+		values, err := project_model.GetIssueFieldValues(ctx, issue.ID)
+		if err != nil {
+			return false, err
+		}
+		for name, value := range cond.Fields {
+			idx := slices.IndexFunc(fields, func(f *project_model.Field) bool { return strings.EqualFold(f.Name, name) })
+			if idx < 0 || !strings.EqualFold(values[fields[idx].ID], value) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// describeActions describes the actions of a rule for its log
+func describeActions(rule *issues_model.TriageRule) []string {
+	a := &rule.Actions
+	actions := make([]string, 0, 6)
+	if len(a.AddLabels) > 0 {
+		actions = append(actions, "add labels: "+strings.Join(a.AddLabels, ", "))
+	}
+	if len(a.RemoveLabels) > 0 {
+		actions = append(actions, "remove labels: "+strings.Join(a.RemoveLabels, ", "))
+	}
+	if a.AssignTeam != "" {
+		actions = append(actions, "assign a member of team "+a.AssignTeam)
+	}
+	if a.Comment != "" {
+		actions = append(actions, "comment")
+	}
+	if a.ProjectColumnID > 0 {
+		actions = append(actions, fmt.Sprintf("move to project column %d", a.ProjectColumnID))
+	}
+	if a.Close {
+		actions = append(actions, "close")
+	}
+	return actions
+}
+
+// takeActions takes the actions of a rule on an issue as the creator of the rule, and returns
+// what was done
+func takeActions(ctx context.Context, rule *issues_model.TriageRule, issue *issues_model.Issue) ([]string, error) {
+	doer, err := user_model.GetUserByID(ctx, rule.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanWriteIssuesOrPulls(issue.IsPull) {
+		return nil, fmt.Errorf("the creator of the rule %s cannot change the issues of %s", doer.Name, issue.Repo.FullName())
+	}
+
+	a := &rule.Actions
+	actions := make([]string, 0, 6)
+	if len(a.AddLabels) > 0 {
+		labels, err := getLabelsByNames(ctx, issue.Repo, a.AddLabels)
+		if err != nil {
+			return actions, err
+		}
+		if len(labels) > 0 {
+			if err := issue_service.AddLabels(ctx, issue, doer, labels); err != nil {
+				return actions, err
+			}
+			actions = append(actions, "add labels: "+labelNames(labels))
+		}
+	}
+	if len(a.RemoveLabels) > 0 {
+		labels, err := getLabelsByNames(ctx, issue.Repo, a.RemoveLabels)
+		if err != nil {
+			return actions, err
+		}
+		removed := make([]*issues_model.Label, 0, len(labels))
+		for _, label := range labels {
+			if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+				continue
+			}
+			if err := issue_service.RemoveLabel(ctx, issue, doer, label); err != nil {
+				return actions, err
+			}
+			removed = append(removed, label)
+		}
+		if len(removed) > 0 {
+			actions = append(actions, "remove labels: "+labelNames(removed))
+		}
+	}
+	if a.AssignTeam != "" {
+		assignee, err := assignTeamMember(ctx, rule, issue, doer)
+		if err != nil {
+			return actions, err
+		}
+		if assignee != nil {
+			actions = append(actions, "assign "+assignee.Name)
+		}
+	}
+	if a.Comment != "" {
+		content, err := renderComment(ctx, rule, issue)
+		if err != nil {
+			return actions, err
+		}
+		if _, err := issue_service.CreateIssueComment(ctx, doer, issue.Repo, issue, content, nil); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "comment")
+	}
+	if a.ProjectColumnID > 0 {
+		column, err := project_model.GetColumn(ctx, a.ProjectColumnID)
+		if err != nil {
+			return actions, err
+		}
+		if issue.ProjectID(ctx) == column.ProjectID {
+			err = issue_service.MoveIssueOnProjectColumn(ctx, doer, issue, column, -1)
+		} else {
+			err = issue_service.AssignOrRemoveProject(ctx, issue, doer, column.ProjectID, column.ID)
+		}
+		if err != nil {
+			return actions, err
+		}
+		actions = append(actions, "move to project column "+column.Title)
+	}
+	if a.Close && !issue.IsClosed {
+		if err := issue_service.ChangeStatus(ctx, issue, doer, "", true); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "close")
+	}
+	return actions, nil
+}
+
+// getLabelsByNames returns the labels of a repository and of its organization with some names
+func getLabelsByNames(ctx context.Context, repo *repo_model.Repository, names []string) ([]*issues_model.Label, error) {
+	labelIDs, err := issues_model.GetLabelIDsInRepoByNames(ctx, repo.ID, names)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if repo.Owner.IsOrganization() {
+		orgLabelIDs, err := issues_model.GetLabelIDsInOrgByNames(ctx, repo.OwnerID, names)
+		if err != nil {
+			return nil, err
+		}
+		labelIDs = append(labelIDs, orgLabelIDs...)
+	}
+	return issues_model.GetLabelsByIDs(ctx, labelIDs)
+}
+
+func labelNames(labels []*issues_model.Label) string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// assignTeamMember assigns the next member of the team of a rule who can be assigned to the issue,
+// unless a member of the team is already assigned
+func assignTeamMember(ctx context.Context, rule *issues_model.TriageRule, issue *issues_model.Issue, doer *user_model.User) (*user_model.User, error) {
+	team, err := organization.GetTeam(ctx, issue.Repo.OwnerID, rule.Actions.AssignTeam)
+	if err != nil {
+		return nil, err
+	}
+	if err := team.LoadMembers(ctx); err != nil {
+		return nil, err
+	}
+	for _, member := range team.Members {
+		if assigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, member); err != nil {
+			return nil, err
+		} else if assigned {
+			return nil, nil
+		}
+	}
+
+	for i := range team.Members {
+		member := team.Members[(rule.NextAssignee+i)%len(team.Members)]
+		if canBeAssigned, err := access_model.CanBeAssigned(ctx, member, issue.Repo, issue.IsPull); err != nil {
+			return nil, err
+		} else if !canBeAssigned {
+			continue
+		}
+		if _, _, err := issue_service.ToggleAssigneeWithNotify(ctx, issue, doer, member.ID); err != nil {
+			return nil, err
+		}
+		rule.NextAssignee = (rule.NextAssignee + i + 1) % len(team.Members)
+		return member, issues_model.UpdateTriageRuleNextAssignee(ctx, rule)
+	}
+	return nil, nil
+}
+
+// CommentData are the data of the template of the comment of a rule
+type CommentData struct {
+	Title  string
+	Index  int64
+	URL    string
+	Poster string
+	Repo   string
+	Rule   string
+}
+
+func renderComment(ctx context.Context, rule *issues_model.TriageRule, issue *issues_model.Issue) (string, error) {
+	tmpl, err := template.New("comment").Parse(rule.Actions.Comment)
+	if err != nil {
+		return "", err
+	}
+	if err := issue.LoadPoster(ctx); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, &CommentData{
+		Title:  issue.Title,
+		Index:  issue.Index,
+		URL:    issue.HTMLURL(),
+		Poster: issue.Poster.Name,
+		Repo:   issue.Repo.FullName(),
+		Rule:   rule.Name,
+	}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// ValidateRule checks the conditions and the actions of a rule
+func ValidateRule(ctx context.Context, rule *issues_model.TriageRule) error {
+	if rule.Event == issues_model.TriageEventStale && rule.StaleDays <= 0 {
+		return util.NewInvalidArgumentErrorf("the number of days of a rule for stale issues must be positive")
+	}
+	if _, err := regexp.Compile(rule.Conditions.TitleRegex); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid title regular expression: %v", err)
+	}
+	if _, err := regexp.Compile(rule.Conditions.BodyRegex); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid body regular expression: %v", err)
+	}
+	if rule.Actions.IsEmpty() {
+		return util.NewInvalidArgumentErrorf("a rule must take at least one action")
+	}
+	if _, err := template.New("comment").Parse(rule.Actions.Comment); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid comment template: %v", err)
+	}
+
+	ownerID := rule.OwnerID
+	if rule.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, rule.RepoID)
+		if err != nil {
+			return err
+		}
+		ownerID = repo.OwnerID
+	}
+	if rule.Actions.AssignTeam != "" {
+		if _, err := organization.GetTeam(ctx, ownerID, rule.Actions.AssignTeam); err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				return util.NewInvalidArgumentErrorf("the team %s does not exist", rule.Actions.AssignTeam)
+			}
+			return err
+		}
+	}
+	if rule.Actions.ProjectColumnID > 0 {
+		column, err := project_model.GetColumn(ctx, rule.Actions.ProjectColumnID)
+		if err != nil {
+			if project_model.IsErrProjectColumnNotExist(err) {
+				return util.NewInvalidArgumentErrorf("the project column does not exist")
+			}
+			return err
+		}
+		project, err := project_model.GetProjectByID(ctx, column.ProjectID)
+		if err != nil {
+			return err
+		}
+		if (rule.RepoID > 0 && project.RepoID != rule.RepoID && project.OwnerID != ownerID) ||
+			(rule.RepoID == 0 && project.OwnerID != ownerID) {
+			return util.NewInvalidArgumentErrorf("the project column is not in a project of the repository or of its owner")
+		}
+	}
+	return nil
+}
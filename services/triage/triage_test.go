@@ -0,0 +1,180 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package triage
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	issue_service "forgejo.org/services/issue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchConditions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// the issue 1 is titled issue1, is posted by user1 and has the label label1
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	opened := &EventData{Event: issues_model.TriageEventOpened, Body: issue.Content}
+
+	for _, testCase := range []struct {
+		name       string
+		conditions issues_model.TriageConditions
+		data       *EventData
+		matched    bool
+	}{
+		{"None", issues_model.TriageConditions{}, opened, true},
+		{"Issues", issues_model.TriageConditions{Type: "issues"}, opened, true},
+		{"Pulls", issues_model.TriageConditions{Type: "pulls"}, opened, false},
+		{"Author", issues_model.TriageConditions{Authors: []string{"user2", "USER1"}}, opened, true},
+		{"OtherAuthor", issues_model.TriageConditions{Authors: []string{"user2"}}, opened, false},
+		{"Title", issues_model.TriageConditions{TitleRegex: "^issue[0-9]$"}, opened, true},
+		{"OtherTitle", issues_model.TriageConditions{TitleRegex: "^bug"}, opened, false},
+		{"Body", issues_model.TriageConditions{BodyRegex: "first"}, opened, true},
+		{"CommentBody", issues_model.TriageConditions{BodyRegex: "first"}, &EventData{Event: issues_model.TriageEventCommented, Body: "a comment"}, false},
+		{"Label", issues_model.TriageConditions{Labels: []string{"Label1"}}, opened, true},
+		{"MissingLabel", issues_model.TriageConditions{Labels: []string{"label1", "label2"}}, opened, false},
+		{"ExcludedLabel", issues_model.TriageConditions{ExcludedLabels: []string{"label1"}}, opened, false},
+		{"AddedLabel", issues_model.TriageConditions{Labels: []string{"label1"}}, &EventData{Event: issues_model.TriageEventLabeled, AddedLabels: []string{"label1"}}, true},
+		{"OtherAddedLabel", issues_model.TriageConditions{Labels: []string{"label1"}}, &EventData{Event: issues_model.TriageEventLabeled, AddedLabels: []string{"label2"}}, false},
+		{"NoProject", issues_model.TriageConditions{Fields: map[string]string{"Priority": "High"}}, opened, false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			matched, err := matchConditions(db.DefaultContext, &issues_model.TriageRule{Conditions: testCase.conditions}, issue, testCase.data)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.matched, matched)
+		})
+	}
+
+	_, err := matchConditions(db.DefaultContext, &issues_model.TriageRule{Conditions: issues_model.TriageConditions{TitleRegex: "("}}, issue, opened)
+	require.Error(t, err)
+}
+
+func TestTakeActions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issue.LoadRepo(db.DefaultContext))
+	rule := &issues_model.TriageRule{
+		RepoID:    issue.RepoID,
+		Name:      "triage",
+		Event:     issues_model.TriageEventOpened,
+		CreatorID: 2,
+		Actions: issues_model.TriageActions{
+			AddLabels:    []string{"label2", "unknown"},
+			RemoveLabels: []string{"label1"},
+			Comment:      "{{.Title}} was triaged by {{.Rule}}",
+			Close:        true,
+		},
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, rule))
+
+	t.Run("Permission", func(t *testing.T) {
+		// user5 cannot write the issues of the repository
+		rule := *rule
+		rule.CreatorID = 5
+		_, err := takeActions(db.DefaultContext, &rule, issue)
+		require.Error(t, err)
+		assert.True(t, issues_model.HasIssueLabel(db.DefaultContext, issue.ID, 1))
+	})
+
+	actions, err := takeActions(db.DefaultContext, rule, issue)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add labels: label2", "remove labels: label1", "comment", "close"}, actions)
+
+	assert.True(t, issues_model.HasIssueLabel(db.DefaultContext, issue.ID, 2))
+	assert.False(t, issues_model.HasIssueLabel(db.DefaultContext, issue.ID, 1))
+	unittest.AssertExistsIf(t, true, &issues_model.Comment{IssueID: issue.ID, PosterID: 2, Type: issues_model.CommentTypeComment, Content: "issue1 was triaged by triage"})
+	unittest.AssertExistsIf(t, true, &issues_model.Issue{ID: issue.ID, IsClosed: true})
+
+	// the labels which were already removed and the state which is already set are not changed again
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issue.LoadRepo(db.DefaultContext))
+	rule.Actions.Comment = ""
+	actions, err = takeActions(db.DefaultContext, rule, issue)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add labels: label2"}, actions)
+}
+
+func TestAssignTeamMember(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// the members of Team1 are user38 and user39, who can both be assigned to the pull request
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 22})
+	require.NoError(t, issue.LoadRepo(db.DefaultContext))
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 40})
+	rule := &issues_model.TriageRule{
+		RepoID:    issue.RepoID,
+		Name:      "assign",
+		Event:     issues_model.TriageEventOpened,
+		CreatorID: doer.ID,
+		Actions:   issues_model.TriageActions{AssignTeam: "Team1"},
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, rule))
+
+	assign := func(t *testing.T) int64 {
+		t.Helper()
+		assignee, err := assignTeamMember(db.DefaultContext, rule, issue, doer)
+		require.NoError(t, err)
+		if assignee == nil {
+			return 0
+		}
+		return assignee.ID
+	}
+	unassign := func(t *testing.T, id int64) {
+		t.Helper()
+		removed, _, err := issue_service.ToggleAssigneeWithNotify(db.DefaultContext, issue, doer, id)
+		require.NoError(t, err)
+		require.True(t, removed)
+	}
+
+	assert.EqualValues(t, 38, assign(t))
+	unittest.AssertExistsIf(t, true, &issues_model.TriageRule{ID: rule.ID, NextAssignee: 1})
+
+	// nobody else is assigned while a member of the team is
+	assert.Zero(t, assign(t))
+
+	// the members are assigned in turn
+	unassign(t, 38)
+	assert.EqualValues(t, 39, assign(t))
+	assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &issues_model.TriageRule{ID: rule.ID}).NextAssignee)
+	unassign(t, 39)
+	assert.EqualValues(t, 38, assign(t))
+}
+
+func TestRunRuleDryRun(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	rule := &issues_model.TriageRule{
+		RepoID:     issue.RepoID,
+		Name:       "dry-run",
+		Event:      issues_model.TriageEventOpened,
+		CreatorID:  2,
+		DryRun:     true,
+		Conditions: issues_model.TriageConditions{Labels: []string{"label1"}},
+		Actions: issues_model.TriageActions{
+			AddLabels: []string{"label2"},
+			Close:     true,
+		},
+	}
+	require.NoError(t, issues_model.CreateTriageRule(db.DefaultContext, rule))
+
+	require.NoError(t, RunRule(db.DefaultContext, rule, issue, &EventData{Event: issues_model.TriageEventOpened, Body: issue.Content}))
+
+	// the actions are only logged
+	unittest.AssertExistsIf(t, true, &issues_model.TriageLog{RuleID: rule.ID, IssueID: issue.ID, DryRun: true, Actions: "add labels: label2\nclose"})
+	assert.False(t, issues_model.HasIssueLabel(db.DefaultContext, issue.ID, 2))
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID}).IsClosed)
+
+	// the issues which do not meet the conditions are not logged
+	rule.Conditions.Labels = []string{"label2"}
+	require.NoError(t, RunRule(db.DefaultContext, rule, issue, &EventData{Event: issues_model.TriageEventOpened, Body: issue.Content}))
+	unittest.AssertCount(t, &issues_model.TriageLog{RuleID: rule.ID}, 1)
+}
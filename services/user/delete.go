@@ -105,6 +105,13 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		return err
 	}
 
+	if err := issues_model.DeleteSavedSearchesByOwner(ctx, u.ID); err != nil {
+		return fmt.Errorf("DeleteSavedSearchesByOwner: %w", err)
+	}
+	if err := issues_model.DeleteSavedSearchSubscriptionsByUser(ctx, u.ID); err != nil {
+		return fmt.Errorf("DeleteSavedSearchSubscriptionsByUser: %w", err)
+	}
+
 	if purge || (setting.Service.UserDeleteWithCommentsMaxTime != 0 &&
 		u.CreatedUnix.AsTime().Add(setting.Service.UserDeleteWithCommentsMaxTime).After(time.Now())) {
 		// Delete Comments
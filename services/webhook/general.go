@@ -127,6 +127,12 @@ func getIssuesPayloadInfo(p *api.IssuePayload, linkFormatter linkFormatter, with
 		text = fmt.Sprintf("[%s] Issue milestoned to %s: %s", p.Repository.FullName, p.Issue.Milestone.Title, titleLink)
 	case api.HookIssueDemilestoned:
 		text = fmt.Sprintf("[%s] Issue milestone cleared: %s", p.Repository.FullName, titleLink)
+	case api.HookIssueProjected:
+		text = fmt.Sprintf("[%s] Issue added to project %s: %s", p.Repository.FullName, p.ProjectCard.Project.Title, titleLink)
+	case api.HookIssueDeprojected:
+		text = fmt.Sprintf("[%s] Issue removed from its project: %s", p.Repository.FullName, titleLink)
+	case api.HookIssueProjectColumnChanged:
+		text = fmt.Sprintf("[%s] Issue moved to column %s of project %s: %s", p.Repository.FullName, p.ProjectCard.Column.Title, p.ProjectCard.Project.Title, titleLink)
 	}
 	if withSender {
 		text += fmt.Sprintf(" by %s", p.Sender.UserName)
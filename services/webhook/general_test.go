@@ -456,8 +456,33 @@ func TestGetIssuesPayloadInfo(t *testing.T) {
 			"",
 			yellowColor,
 		},
+		{
+			api.HookIssueProjected,
+			"[test/repo] Issue added to project Roadmap: #2 crash by user1",
+			"#2 crash",
+			"",
+			yellowColor,
+		},
+		{
+			api.HookIssueDeprojected,
+			"[test/repo] Issue removed from its project: #2 crash by user1",
+			"#2 crash",
+			"",
+			yellowColor,
+		},
+		{
+			api.HookIssueProjectColumnChanged,
+			"[test/repo] Issue moved to column Done of project Roadmap: #2 crash by user1",
+			"#2 crash",
+			"",
+			yellowColor,
+		},
 	}
 
+	p.ProjectCard = &api.ProjectCardPayload{
+		Project: &api.Project{ID: 1, Title: "Roadmap"},
+		Column:  &api.ProjectColumn{ID: 2, Title: "Done"},
+	}
 	for i, c := range cases {
 		p.Action = c.action
 		text, issueTitle, attachmentText, color := getIssuesPayloadInfo(p, noneLinkFormatter, true)
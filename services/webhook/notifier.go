@@ -10,6 +10,7 @@ import (
 	packages_model "forgejo.org/models/packages"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
@@ -598,6 +599,51 @@ func (m *webhookNotifier) IssueChangeMilestone(ctx context.Context, doer *user_m
 	}
 }
 
+func (m *webhookNotifier) IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID, oldColumnID int64) {
+	if err := issue.LoadAttributes(ctx); err != nil {
+		log.Error("issue.LoadAttributes failed: %v", err)
+		return
+	}
+
+	card := &api.ProjectCardPayload{
+		OldProjectID: oldProjectID,
+		OldColumnID:  oldColumnID,
+	}
+	hookAction := api.HookIssueProjectColumnChanged
+	projectID := issue.ProjectID(ctx)
+	if projectID == 0 {
+		hookAction = api.HookIssueDeprojected
+	} else {
+		if projectID != oldProjectID {
+			hookAction = api.HookIssueProjected
+		}
+		project, err := project_model.GetProjectByID(ctx, projectID)
+		if err != nil {
+			log.Error("GetProjectByID: %v", err)
+			return
+		}
+		column, err := project_model.GetColumn(ctx, issue.ProjectColumnID(ctx))
+		if err != nil {
+			log.Error("GetColumn: %v", err)
+			return
+		}
+		card.Project = convert.ToAPIProject(ctx, project, doer)
+		card.Column = convert.ToAPIProjectColumn(column)
+	}
+
+	permission, _ := access_model.GetUserRepoPermission(ctx, issue.Repo, doer)
+	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueProject, &api.IssuePayload{
+		Action:      hookAction,
+		Index:       issue.Index,
+		Issue:       convert.ToAPIIssue(ctx, doer, issue),
+		Repository:  convert.ToRepo(ctx, issue.Repo, permission),
+		Sender:      convert.ToUser(ctx, doer, nil),
+		ProjectCard: card,
+	}); err != nil {
+		log.Error("PrepareWebhooks [is_pull: %v]: %v", issue.IsPull, err)
+	}
+}
+
 func (m *webhookNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
 	if len(commits.Commits) > setting.Webhook.PayloadCommitLimit {
 		commits.Commits = commits.Commits[:setting.Webhook.PayloadCommitLimit]
@@ -55,7 +55,7 @@ func NewPayload[T any](rc PayloadConvertor[T], data []byte, event webhook_module
 		return convertUnmarshalledJSON(rc.Delete, data)
 	case webhook_module.HookEventFork:
 		return convertUnmarshalledJSON(rc.Fork, data)
-	case webhook_module.HookEventIssues, webhook_module.HookEventIssueAssign, webhook_module.HookEventIssueLabel, webhook_module.HookEventIssueMilestone:
+	case webhook_module.HookEventIssues, webhook_module.HookEventIssueAssign, webhook_module.HookEventIssueLabel, webhook_module.HookEventIssueMilestone, webhook_module.HookEventIssueProject:
 		return convertUnmarshalledJSON(rc.Issue, data)
 	case webhook_module.HookEventIssueComment, webhook_module.HookEventPullRequestComment:
 		// previous code sometimes sent s.PullRequest(p.(*api.PullRequestPayload))
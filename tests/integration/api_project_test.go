@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIEditProject(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+
+	// an invalid state does not edit the project
+	title := "Renamed project"
+	state := "unknown"
+	req := NewRequestWithJSON(t, "PATCH", "/api/v1/projects/1", &api.EditProjectOption{Title: &title, State: &state}).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	p := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	assert.Equal(t, "First project", p.Title)
+	assert.False(t, p.IsClosed)
+
+	state = "closed"
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/projects/1", &api.EditProjectOption{Title: &title, State: &state}).
+		AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var apiProject api.Project
+	DecodeJSON(t, resp, &apiProject)
+	assert.Equal(t, title, apiProject.Title)
+	p = unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	assert.Equal(t, title, p.Title)
+	assert.True(t, p.IsClosed)
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/gitrepo"
+	repo_module "forgejo.org/modules/repository"
+	issue_service "forgejo.org/services/issue"
+	pull_service "forgejo.org/services/pull"
+	repo_service "forgejo.org/services/repository"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullBackport(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", nil, nil, []*files_service.ChangeRepoFile{
+			{
+				Operation:     "create",
+				TreePath:      "a.txt",
+				ContentReader: strings.NewReader("a\n"),
+			},
+		})
+		defer f()
+		// the pull requests are backported to release, and a.txt is changed differently on conflict
+		commitToBranch(t, user2, repo, "main", "release", "create", "release.txt", "release")
+		commitToBranch(t, user2, repo, "main", "conflict", "update", "a.txt", "conflict\n")
+
+		// openPullRequest opens a pull request of two commits, the first one adds a file and the second one
+		// changes treePath
+		openPullRequest := func(t *testing.T, branch, operation, treePath string) *issues_model.PullRequest {
+			t.Helper()
+			commitToBranch(t, user2, repo, "main", branch, "create", branch+".txt", branch)
+			commitToBranch(t, user2, repo, branch, branch, operation, treePath, branch+"\n")
+			pr := createPullRequestFromBranch(t, user2, repo, branch, "main")
+			require.NoError(t, pr.LoadIssue(db.DefaultContext))
+			return pr
+		}
+		addLabel := func(t *testing.T, pr *issues_model.PullRequest, name string) {
+			t.Helper()
+			label := &issues_model.Label{RepoID: repo.ID, Name: name, Color: "#ffffff"}
+			require.NoError(t, issues_model.NewLabel(db.DefaultContext, label))
+			require.NoError(t, issue_service.AddLabel(db.DefaultContext, pr.Issue, user2, label))
+		}
+		merge := func(t *testing.T, pr *issues_model.PullRequest) {
+			t.Helper()
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			require.NoError(t, pull_service.Merge(db.DefaultContext, pr, user2, gitRepo, repo_model.MergeStyleMerge, "", "merge", false))
+		}
+		comment := func(t *testing.T, doer *user_model.User, pr *issues_model.PullRequest, content string) {
+			t.Helper()
+			_, err := issue_service.CreateIssueComment(db.DefaultContext, doer, repo, pr.Issue, content, nil)
+			require.NoError(t, err)
+		}
+		lastComment := func(t *testing.T, pr *issues_model.PullRequest) string {
+			t.Helper()
+			comments, err := issues_model.FindComments(db.DefaultContext, &issues_model.FindCommentsOptions{
+				IssueID: pr.IssueID,
+				Type:    issues_model.CommentTypeComment,
+			})
+			require.NoError(t, err)
+			if len(comments) == 0 {
+				return ""
+			}
+			return comments[len(comments)-1].Content
+		}
+		// waitForBackport waits for the worker to record the result of the backport and to report it
+		waitForBackport := func(t *testing.T, pr *issues_model.PullRequest, targetBranch string) *issues_model.PullBackport {
+			t.Helper()
+			var backport *issues_model.PullBackport
+			require.Eventually(t, func() bool {
+				backport = unittest.AssertExistsAndLoadBean(t, &issues_model.PullBackport{PullID: pr.ID, TargetBranch: targetBranch})
+				return backport.Status != issues_model.PullBackportPending &&
+					strings.HasPrefix(lastComment(t, pr), "Backport")
+			}, 10*time.Second, 100*time.Millisecond)
+			return backport
+		}
+		assertBackported := func(t *testing.T, pr *issues_model.PullRequest, backport *issues_model.PullBackport, files ...string) {
+			t.Helper()
+			require.Equal(t, issues_model.PullBackportDone, backport.Status, backport.Error)
+			backportPR := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: backport.BackportPullID})
+			assert.Equal(t, fmt.Sprintf("backport-%d-%s", pr.Index, backport.TargetBranch), backportPR.HeadBranch)
+			assert.Equal(t, backport.TargetBranch, backportPR.BaseBranch)
+			unittest.AssertExistsIf(t, true, &issues_model.Comment{
+				IssueID: pr.IssueID,
+				Type:    issues_model.CommentTypeComment,
+				Content: fmt.Sprintf("Backported to `%s` in #%d.", backport.TargetBranch, backportPR.Index),
+			})
+
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			commit, err := gitRepo.GetBranchCommit(backportPR.HeadBranch)
+			require.NoError(t, err)
+			for _, file := range files {
+				_, err := commit.GetTreeEntryByPath(file)
+				require.NoError(t, err, file)
+			}
+		}
+
+		t.Run("Label", func(t *testing.T) {
+			pr := openPullRequest(t, "label", "create", "label-2.txt")
+			addLabel(t, pr, "backport/release")
+			merge(t, pr)
+
+			backport := waitForBackport(t, pr, "release")
+			assert.Equal(t, user2.ID, backport.DoerID)
+			assertBackported(t, pr, backport, "release.txt", "label.txt", "label-2.txt")
+		})
+
+		t.Run("Comment", func(t *testing.T) {
+			pr := openPullRequest(t, "comment", "create", "comment-2.txt")
+			merge(t, pr)
+			comment(t, user2, pr, "LGTM\n/backport release")
+
+			assertBackported(t, pr, waitForBackport(t, pr, "release"), "release.txt", "comment.txt", "comment-2.txt")
+
+			// the requests of the users who cannot write to the repository are ignored
+			comment(t, user4, pr, "/backport conflict")
+			unittest.AssertNotExistsBean(t, &issues_model.PullBackport{PullID: pr.ID, TargetBranch: "conflict"})
+		})
+
+		t.Run("Permission", func(t *testing.T) {
+			// user4 requests the backport before the merge, and cannot write to the repository anymore once
+			// the pull request is merged
+			pr := openPullRequest(t, "permission", "create", "permission-2.txt")
+			require.NoError(t, repo_module.AddCollaborator(db.DefaultContext, repo, user4))
+			comment(t, user4, pr, "/backport release")
+			backport := unittest.AssertExistsAndLoadBean(t, &issues_model.PullBackport{PullID: pr.ID, TargetBranch: "release"})
+			assert.Equal(t, issues_model.PullBackportPending, backport.Status)
+			require.NoError(t, repo_service.DeleteCollaboration(db.DefaultContext, repo, user4.ID))
+			merge(t, pr)
+
+			backport = waitForBackport(t, pr, "release")
+			assert.Equal(t, issues_model.PullBackportFailed, backport.Status)
+			assert.Equal(t, "@user4 cannot write to the repository", backport.Error)
+			assert.Zero(t, backport.BackportPullID)
+			unittest.AssertExistsIf(t, true, &issues_model.Comment{
+				IssueID: pr.IssueID,
+				Type:    issues_model.CommentTypeComment,
+				Content: "Backporting to `release` failed: @user4 cannot write to the repository.",
+			})
+		})
+
+		t.Run("Conflict", func(t *testing.T) {
+			// the first commit is cherry-picked, the second one changing a.txt conflicts
+			pr := openPullRequest(t, "conflicting", "update", "a.txt")
+			addLabel(t, pr, "backport/conflict")
+			merge(t, pr)
+
+			backport := waitForBackport(t, pr, "conflict")
+			assert.Equal(t, issues_model.PullBackportFailed, backport.Status)
+			assert.Zero(t, backport.BackportPullID)
+			report := lastComment(t, pr)
+			assert.True(t, strings.HasPrefix(report, "Backporting to `conflict` failed: commit "), report)
+			assert.True(t, strings.HasSuffix(report, "conflicts with the branch in:\n\n* `a.txt`"), report)
+
+			// the branch holding the commits picked before the conflict is deleted
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			assert.False(t, gitRepo.IsBranchExist(fmt.Sprintf("backport-%d-conflict", pr.Index)))
+		})
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	repo_module "forgejo.org/modules/repository"
+	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullCodeOwnersApprovals(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		// user4 is a member of org3/team1
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+		// user8 is a code owner who can only read the repository
+		user8 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", nil, nil, []*files_service.ChangeRepoFile{
+			{
+				Operation:     "create",
+				TreePath:      "CODEOWNERS",
+				ContentReader: strings.NewReader("docs/.* @user5 @user8\nsrc/.* @org3/team1\n"),
+			},
+		})
+		defer f()
+		require.NoError(t, repo_module.AddCollaborator(db.DefaultContext, repo, user4))
+		require.NoError(t, repo_module.AddCollaborator(db.DefaultContext, repo, user5))
+		commitToBranch(t, user2, repo, "main", "feature", "create", "docs/index.md", "docs")
+		commitToBranch(t, user2, repo, "feature", "feature", "create", "src/main.go", "package main")
+		commitToBranch(t, user2, repo, "feature", "feature", "create", "README.txt", "not owned")
+		pr := createPullRequestFromBranch(t, user2, repo, "feature", "main")
+		require.NoError(t, pr.LoadIssue(db.DefaultContext))
+
+		pb := &git_model.ProtectedBranch{RepoID: repo.ID, RuleName: "main", RequireCodeOwnerApproval: true}
+		missingPaths := func(t *testing.T) []string {
+			t.Helper()
+			missing, err := pull_service.GetMissingCodeOwnersApprovals(db.DefaultContext, pb, pr)
+			require.NoError(t, err)
+			paths := make([]string, 0, len(missing))
+			for _, approval := range missing {
+				paths = append(paths, approval.Path)
+			}
+			return paths
+		}
+		assertMergeBlocked := func(t *testing.T, blocked bool) {
+			t.Helper()
+			err := pull_service.CheckPullBranchProtection(db.DefaultContext, pr, pb, true)
+			if blocked {
+				require.Error(t, err)
+				assert.True(t, models.IsErrDisallowedToMerge(err))
+			} else {
+				require.NoError(t, err)
+			}
+		}
+		approve := func(t *testing.T, reviewer *user_model.User, stale bool) *issues_model.Review {
+			t.Helper()
+			official, err := issues_model.IsOfficialReviewer(db.DefaultContext, pr.Issue, reviewer)
+			require.NoError(t, err)
+			review, err := issues_model.CreateReview(db.DefaultContext, issues_model.CreateReviewOptions{
+				Type:     issues_model.ReviewTypeApprove,
+				Issue:    pr.Issue,
+				Reviewer: reviewer,
+				Official: official,
+				Stale:    stale,
+			})
+			require.NoError(t, err)
+			return review
+		}
+
+		// the paths without code owners are left out
+		approvals, err := pull_service.GetCodeOwnersApprovals(db.DefaultContext, pr, false)
+		require.NoError(t, err)
+		require.Len(t, approvals, 2)
+		assert.Equal(t, "docs/index.md", approvals[0].Path)
+		assert.Equal(t, []string{"@user5", "@user8"}, approvals[0].Owners)
+		assert.Equal(t, "src/main.go", approvals[1].Path)
+		assert.Equal(t, []string{"@org3/team1"}, approvals[1].Owners)
+		assert.False(t, approvals[0].IsApproved())
+		assert.False(t, approvals[1].IsApproved())
+
+		pb.RequireCodeOwnerApproval = false
+		assert.Empty(t, missingPaths(t))
+		assertMergeBlocked(t, false)
+		pb.RequireCodeOwnerApproval = true
+		assert.Equal(t, []string{"docs/index.md", "src/main.go"}, missingPaths(t))
+		assertMergeBlocked(t, true)
+
+		// the approvals of the code owners who cannot write to the repository are not official and do not count
+		approve(t, user8, false)
+		assert.Equal(t, []string{"docs/index.md", "src/main.go"}, missingPaths(t))
+		assertMergeBlocked(t, true)
+
+		// a member of a team approves for the team
+		teamApproval := approve(t, user4, false)
+		assert.Equal(t, []string{"docs/index.md"}, missingPaths(t))
+		assertMergeBlocked(t, true)
+
+		// the stale approvals only count if the protection does not ignore them
+		approve(t, user5, true)
+		pb.IgnoreStaleApprovals = true
+		assert.Equal(t, []string{"docs/index.md"}, missingPaths(t))
+		assertMergeBlocked(t, true)
+		pb.IgnoreStaleApprovals = false
+		assert.Empty(t, missingPaths(t))
+		assertMergeBlocked(t, false)
+
+		approvals, err = pull_service.GetCodeOwnersApprovals(db.DefaultContext, pr, false)
+		require.NoError(t, err)
+		require.Len(t, approvals, 2)
+		require.Len(t, approvals[0].ApprovedBy, 1)
+		assert.Equal(t, user5.ID, approvals[0].ApprovedBy[0].ID)
+		require.Len(t, approvals[1].ApprovedBy, 1)
+		assert.Equal(t, user4.ID, approvals[1].ApprovedBy[0].ID)
+
+		// the dismissed approvals do not count
+		require.NoError(t, issues_model.DismissReview(db.DefaultContext, teamApproval, true))
+		assert.Equal(t, []string{"src/main.go"}, missingPaths(t))
+		assertMergeBlocked(t, true)
+	})
+}
@@ -26,6 +26,7 @@ import (
 	files_service "forgejo.org/services/repository/files"
 	"forgejo.org/tests"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -220,6 +221,63 @@ func TestPullCreateWithPullTemplate(t *testing.T) {
 			// templates, only the highest priority one is used.
 			testPullPreview(t, session, forkUser.Name, forkedRepo.Name, message+" .forgejo/PULL_REQUEST_TEMPLATE.md")
 		})
+
+		t.Run("templates directory", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			// Create the base repository, with a default template and the templates to choose from
+			baseRepo, _, deferrer := tests.CreateDeclarativeRepo(t, baseUser, "", nil, nil, []*files_service.ChangeRepoFile{
+				{
+					Operation:     "create",
+					TreePath:      ".forgejo/PULL_REQUEST_TEMPLATE.md",
+					ContentReader: strings.NewReader("Default template"),
+				},
+				{
+					Operation:     "create",
+					TreePath:      ".forgejo/PULL_REQUEST_TEMPLATE/feature.md",
+					ContentReader: strings.NewReader("---\nname: Feature\nabout: Add a feature\n---\nFeature template"),
+				},
+				{
+					Operation:     "create",
+					TreePath:      ".forgejo/PULL_REQUEST_TEMPLATE/release.md",
+					ContentReader: strings.NewReader("---\nname: Release\nabout: Prepare a release\nbranches:\n  - release/*\n---\nRelease template"),
+				},
+			})
+			defer deferrer()
+			commitToBranch(t, baseUser, baseRepo, "main", "release/1.0", "create", "release.txt", "release")
+			commitToBranch(t, baseUser, baseRepo, "main", "feature", "create", "feature.txt", "feature")
+
+			session := loginUser(t, baseUser.Name)
+			// loadCompare loads the compare page and returns its message and the templates of its dropdown
+			loadCompare := func(t *testing.T, link string) (string, *goquery.Selection) {
+				t.Helper()
+				resp := session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+				htmlDoc := NewHTMLParser(t, resp.Body)
+				return htmlDoc.doc.Find("textarea[placeholder*='comment']").Text(), htmlDoc.doc.Find(".ui.jump.dropdown .menu a.item")
+			}
+
+			// no template is chosen for main, the default template is used
+			message, items := loadCompare(t, path.Join(baseUser.Name, baseRepo.Name, "compare", "main...feature"))
+			assert.Contains(t, message, "Default template")
+			require.Equal(t, 2, items.Length())
+			assert.Equal(t, "Feature", items.Eq(0).Find("strong").Text())
+			assert.Equal(t, "Release", items.Eq(1).Find("strong").Text())
+			assert.Zero(t, items.Filter(".active").Length())
+
+			// the template chosen in the dropdown is used
+			link, exists := items.Eq(0).Attr("href")
+			require.True(t, exists)
+			message, items = loadCompare(t, link)
+			assert.Contains(t, message, "Feature template")
+			assert.NotContains(t, message, "Default template")
+			assert.True(t, items.Eq(0).HasClass("active"))
+			assert.False(t, items.Eq(1).HasClass("active"))
+
+			// the template restricted to the release branches is used for them
+			message, items = loadCompare(t, path.Join(baseUser.Name, baseRepo.Name, "compare", "release/1.0...feature"))
+			assert.Contains(t, message, "Release template")
+			assert.True(t, items.Eq(1).HasClass("active"))
+		})
 	})
 }
 
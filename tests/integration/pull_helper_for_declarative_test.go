@@ -0,0 +1,83 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitToBranch commits a file to a branch, created from another branch if they differ
+func commitToBranch(t *testing.T, user *user_model.User, repo *repo_model.Repository, oldBranch, newBranch, operation, treePath, content string) {
+	t.Helper()
+
+	_, err := files_service.ChangeRepoFiles(git.DefaultContext, repo, user, &files_service.ChangeRepoFilesOptions{
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     operation,
+				TreePath:      treePath,
+				ContentReader: strings.NewReader(content),
+			},
+		},
+		Message:   "Change " + treePath + " on " + newBranch,
+		OldBranch: oldBranch,
+		NewBranch: newBranch,
+		Author: &files_service.IdentityOptions{
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		Committer: &files_service.IdentityOptions{
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		Dates: &files_service.CommitDateOptions{
+			Author:    time.Now(),
+			Committer: time.Now(),
+		},
+	})
+	require.NoError(t, err)
+}
+
+// createPullRequestFromBranch opens a pull request from a branch of the repository to another one, and waits for
+// its check to be done
+func createPullRequestFromBranch(t *testing.T, user *user_model.User, repo *repo_model.Repository, headBranch, baseBranch string) *issues_model.PullRequest {
+	t.Helper()
+
+	pullIssue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Title:    "Pull request from " + headBranch,
+		PosterID: user.ID,
+		Poster:   user,
+		IsPull:   true,
+	}
+	pr := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: headBranch,
+		BaseBranch: baseBranch,
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		Type:       issues_model.PullRequestGitea,
+	}
+	require.NoError(t, pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pr, nil))
+
+	// the pushes made before the pull request may still be checked
+	assert.Eventually(t, func() bool {
+		pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+		return !pr.IsChecking()
+	}, 10*time.Second, 100*time.Millisecond)
+	return pr
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/url"
+	"testing"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/gitrepo"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	pull_service "forgejo.org/services/pull"
+	commitstatus_service "forgejo.org/services/repository/commitstatus"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createPullRequestStack creates three pull requests stacked on top of each other on the main branch, and calls
+// beforeOpen before they are opened
+func createPullRequestStack(t *testing.T, user *user_model.User, beforeOpen func(repo *repo_model.Repository)) (*repo_model.Repository, []*issues_model.PullRequest) {
+	t.Helper()
+
+	repo, _, _ := tests.CreateDeclarativeRepo(t, user, "", nil, nil, nil)
+	commitToBranch(t, user, repo, "main", "stack-1", "create", "one.txt", "one")
+	commitToBranch(t, user, repo, "stack-1", "stack-2", "create", "two.txt", "two")
+	commitToBranch(t, user, repo, "stack-2", "stack-3", "create", "three.txt", "three")
+	if beforeOpen != nil {
+		beforeOpen(repo)
+	}
+
+	return repo, []*issues_model.PullRequest{
+		createPullRequestFromBranch(t, user, repo, "stack-1", "main"),
+		createPullRequestFromBranch(t, user, repo, "stack-2", "stack-1"),
+		createPullRequestFromBranch(t, user, repo, "stack-3", "stack-2"),
+	}
+}
+
+func TestPullMergeStack(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+		mergeStack := func(t *testing.T, doer *user_model.User, repo *repo_model.Repository, pr *issues_model.PullRequest) (int, error) {
+			t.Helper()
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			return pull_service.MergeStack(db.DefaultContext, doer, gitRepo, pr, repo_model.MergeStyleMerge)
+		}
+		assertMerged := func(t *testing.T, expected ...bool) func(prs ...*issues_model.PullRequest) {
+			return func(prs ...*issues_model.PullRequest) {
+				t.Helper()
+				for i, pr := range prs {
+					pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+					assert.Equal(t, expected[i], pr.HasMerged, "#%d", pr.Index)
+				}
+			}
+		}
+
+		t.Run("Ordering", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, nil)
+
+			merged, err := mergeStack(t, user2, repo, prs[1])
+			require.NoError(t, err)
+			assert.Equal(t, 2, merged)
+			assertMerged(t, true, true, false)(prs...)
+
+			// the second pull request was retargeted before being merged, after the first one
+			first := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[0].ID})
+			second := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[1].ID})
+			assert.Equal(t, "main", second.BaseBranch)
+			assert.LessOrEqual(t, first.MergedUnix, second.MergedUnix)
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			isAncestor, err := gitRepo.IsCommitInBranch(first.MergedCommitID, "main")
+			require.NoError(t, err)
+			assert.True(t, isAncestor)
+			mainCommitID, err := gitRepo.GetBranchCommitID("main")
+			require.NoError(t, err)
+			assert.Equal(t, second.MergedCommitID, mainCommitID)
+
+			// the rest of the stack targets the branch the stack was merged into
+			third := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[2].ID})
+			assert.Equal(t, "main", third.BaseBranch)
+		})
+
+		t.Run("ConflictMidway", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, func(repo *repo_model.Repository) {
+				// conflicts with the second pull request only
+				commitToBranch(t, user2, repo, "stack-2", "stack-2", "update", "README.md", "from the stack")
+				commitToBranch(t, user2, repo, "main", "main", "update", "README.md", "from main")
+			})
+
+			merged, err := mergeStack(t, user2, repo, prs[2])
+			require.ErrorIs(t, err, pull_service.ErrNotMergeableState)
+			assert.Equal(t, 1, merged)
+			assertMerged(t, true, false, false)(prs...)
+		})
+
+		t.Run("Permission", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, nil)
+			reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+			merged, err := mergeStack(t, reader, repo, prs[2])
+			var disallowed models.ErrDisallowedToMerge
+			require.ErrorAs(t, err, &disallowed)
+			assert.Equal(t, 0, merged)
+			assertMerged(t, false, false, false)(prs...)
+		})
+
+		t.Run("Protection", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, nil)
+			require.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, &git_model.ProtectedBranch{
+				RepoID:              repo.ID,
+				RuleName:            "main",
+				CanPush:             true,
+				EnableStatusCheck:   true,
+				StatusCheckContexts: []string{"ci"},
+			}, git_model.WhitelistOptions{}))
+
+			// only the first pull request passes the required status check
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			sha, err := gitRepo.GetBranchCommitID("stack-1")
+			require.NoError(t, err)
+			require.NoError(t, commitstatus_service.CreateCommitStatus(db.DefaultContext, repo, user2, sha, &git_model.CommitStatus{
+				State:   api.CommitStatusSuccess,
+				Context: "ci",
+			}))
+
+			merged, err := mergeStack(t, user2, repo, prs[2])
+			var disallowed models.ErrDisallowedToMerge
+			require.ErrorAs(t, err, &disallowed)
+			assert.Equal(t, "Not all required status checks successful", disallowed.Reason)
+			assert.Equal(t, 1, merged)
+			assertMerged(t, true, false, false)(prs...)
+		})
+	})
+}
+
+func TestPullRebaseStack(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+		behind := func(t *testing.T, pr *issues_model.PullRequest) int {
+			t.Helper()
+			pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+			divergence, err := pull_service.GetDiverging(db.DefaultContext, pr)
+			require.NoError(t, err)
+			return divergence.Behind
+		}
+
+		t.Run("Ordering", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, nil)
+			commitToBranch(t, user2, repo, "stack-1", "stack-1", "create", "one-more.txt", "one more")
+			assert.Positive(t, behind(t, prs[1]))
+
+			// the second pull request is rebased first, the third one is then behind its rebased base branch
+			rebased, err := pull_service.RebaseStack(db.DefaultContext, user2, prs[0])
+			require.NoError(t, err)
+			assert.Equal(t, 2, rebased)
+			assert.Zero(t, behind(t, prs[1]))
+			assert.Zero(t, behind(t, prs[2]))
+
+			// nothing left to rebase
+			rebased, err = pull_service.RebaseStack(db.DefaultContext, user2, prs[0])
+			require.NoError(t, err)
+			assert.Zero(t, rebased)
+		})
+
+		t.Run("ConflictMidway", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, func(repo *repo_model.Repository) {
+				commitToBranch(t, user2, repo, "stack-3", "stack-3", "update", "README.md", "from the top of the stack")
+			})
+			// conflicts with the third pull request only
+			commitToBranch(t, user2, repo, "stack-1", "stack-1", "update", "README.md", "from the bottom of the stack")
+
+			rebased, err := pull_service.RebaseStack(db.DefaultContext, user2, prs[0])
+			require.Error(t, err)
+			assert.Equal(t, 1, rebased)
+			assert.Zero(t, behind(t, prs[1]))
+			assert.Positive(t, behind(t, prs[2]))
+		})
+
+		t.Run("Permission", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, nil)
+			commitToBranch(t, user2, repo, "stack-1", "stack-1", "create", "one-more.txt", "one more")
+			reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+			rebased, err := pull_service.RebaseStack(db.DefaultContext, reader, prs[0])
+			require.ErrorIs(t, err, util.ErrPermissionDenied)
+			assert.Zero(t, rebased)
+			assert.Positive(t, behind(t, prs[1]))
+		})
+
+		t.Run("Protection", func(t *testing.T) {
+			repo, prs := createPullRequestStack(t, user2, nil)
+			commitToBranch(t, user2, repo, "stack-1", "stack-1", "create", "one-more.txt", "one more")
+			// the head branch of the third pull request cannot be force pushed
+			require.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, &git_model.ProtectedBranch{
+				RepoID:   repo.ID,
+				RuleName: "stack-3",
+				CanPush:  true,
+			}, git_model.WhitelistOptions{}))
+
+			rebased, err := pull_service.RebaseStack(db.DefaultContext, user2, prs[0])
+			require.ErrorIs(t, err, util.ErrPermissionDenied)
+			assert.Equal(t, 1, rebased)
+			assert.Zero(t, behind(t, prs[1]))
+			assert.Positive(t, behind(t, prs[2]))
+		})
+	})
+}
@@ -228,7 +228,8 @@ export function initDropzone(el) {
     init() {
       this.on('success', (file, data) => {
         file.uuid = data.uuid;
-        const $input = $(`<input id="${data.uuid}" name="files" type="hidden">`).val(data.uuid);
+        // the dropzones of the file fields of issue forms submit their files with the name of the field
+        const $input = $(`<input id="${data.uuid}" type="hidden">`).attr('name', $dropzone.data('field-name') || 'files').val(data.uuid);
         $dropzone.find('.files').append($input);
         // Create a "Copy Link" element, to conveniently copy the image
         // or file link as Markdown to the clipboard
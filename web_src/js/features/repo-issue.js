@@ -537,6 +537,8 @@ export function initRepoPullRequestReview() {
     });
   }
 
+  // the line clicked last, a shift-click on a line below it on the same side comments the lines in between
+  let lastCodeCommentLine = null;
   $(document).on('click', '.add-code-comment', async function (e) {
     if (e.target.classList.contains('btn-add-single')) return; // https://github.com/go-gitea/gitea/issues/4745
     e.preventDefault();
@@ -545,6 +547,13 @@ export function initRepoPullRequestReview() {
     const side = this.getAttribute('data-side');
     const idx = this.getAttribute('data-idx');
     const path = this.closest('[data-path]')?.getAttribute('data-path');
+    let startIdx = '';
+    if (e.shiftKey && lastCodeCommentLine?.path === path && lastCodeCommentLine.side === side &&
+      Number(lastCodeCommentLine.idx) < Number(idx)) {
+      startIdx = lastCodeCommentLine.idx;
+    } else {
+      lastCodeCommentLine = {path, side, idx};
+    }
     const tr = this.closest('tr');
     const lineType = tr.getAttribute('data-line-type');
 
@@ -571,6 +580,7 @@ export function initRepoPullRequestReview() {
         const html = await response.text();
         $td.html(html);
         $td.find("input[name='line']").val(idx);
+        $td.find("input[name='start_line']").val(startIdx);
         $td.find("input[name='side']").val(side === 'left' ? 'previous' : 'proposed');
         $td.find("input[name='path']").val(path);
 